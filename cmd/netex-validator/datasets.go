@@ -0,0 +1,197 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+
+	"github.com/theoremus-urban-solutions/netex-validator/validator"
+)
+
+// datasetResult is the outcome of validating one of several --input datasets
+type datasetResult struct {
+	path   string
+	result *validator.ValidationResult
+	err    error
+}
+
+// datasetName names a dataset by its file name without extension, e.g. north for
+// feeds/north.zip
+func datasetName(path string) string {
+	base := filepath.Base(filepath.Clean(path))
+	return strings.TrimSuffix(base, filepath.Ext(base))
+}
+
+// datasetOptions returns a copy of options for one of concurrency datasets validated
+// at once, sharing the file and ID worker budget between them so that the total
+// stays within --concurrent and --id-workers, or the number of CPUs when unset
+func datasetOptions(options *validator.ValidationOptions, concurrency int) *validator.ValidationOptions {
+	shared := *options
+	if concurrency <= 1 {
+		return &shared
+	}
+	share := func(budget int) int {
+		if budget <= 0 {
+			budget = runtime.GOMAXPROCS(0)
+		}
+		if budget < concurrency {
+			return 1
+		}
+		return budget / concurrency
+	}
+	shared.ConcurrentFiles = share(options.ConcurrentFiles)
+	shared.IdValidationWorkers = share(options.IdValidationWorkers)
+	return &shared
+}
+
+// validateDatasets validates every path as a separate dataset, up to concurrency at
+// a time, each with its own validator as no state is shared between datasets. The
+// results are returned in the order of the paths.
+func validateDatasets(options *validator.ValidationOptions, paths []string, concurrency int) []datasetResult {
+	if concurrency > len(paths) {
+		concurrency = len(paths)
+	}
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	opts := datasetOptions(options, concurrency)
+
+	results := make([]datasetResult, len(paths))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				results[i] = validateDataset(opts, paths[i])
+			}
+		}()
+	}
+	for i := range paths {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+	return results
+}
+
+// validateDataset validates one dataset with a validator of its own
+func validateDataset(options *validator.ValidationOptions, path string) datasetResult {
+	v, err := validator.NewWithOptions(options)
+	if err != nil {
+		return datasetResult{path: path, err: err}
+	}
+	result, err := validatePath(v, path)
+	return datasetResult{path: path, result: result, err: err}
+}
+
+// datasetSinks returns the sinks for one of several datasets: file sinks write to
+// a file named after the dataset, e.g. report-north.json for report.json, JSON on
+// stdout is written as one line per dataset (NDJSON), and the other sinks receive
+// each dataset's result in turn
+func datasetSinks(sinks []Sink, name string) []Sink {
+	named := make([]Sink, len(sinks))
+	for i, sink := range sinks {
+		switch s := sink.(type) {
+		case *fileSink:
+			ext := filepath.Ext(s.path)
+			named[i] = &fileSink{format: s.format, path: strings.TrimSuffix(s.path, ext) + "-" + name + ext}
+		case *stdoutSink:
+			if s.format != "json" {
+				named[i] = sink
+				break
+			}
+			named[i] = &ndjsonSink{out: s.out}
+		default:
+			named[i] = sink
+		}
+	}
+	return named
+}
+
+// ndjsonSink prints the JSON result on a single line, so that the results of
+// several datasets on stdout form newline-delimited JSON whatever --pretty says
+type ndjsonSink struct {
+	out io.Writer
+}
+
+func (s *ndjsonSink) Write(result *validator.ValidationResult) error {
+	var rendered bytes.Buffer
+	if err := (&stdoutSink{format: "json", out: &rendered}).Write(result); err != nil {
+		return err
+	}
+	var line bytes.Buffer
+	if err := json.Compact(&line, rendered.Bytes()); err != nil {
+		return err
+	}
+	line.WriteByte('\n')
+	_, err := s.out.Write(line.Bytes())
+	return err
+}
+
+// validateDatasetsCommand validates several --input datasets and writes each result
+// to the sinks, and its result line, naming the dataset, to stderr. A failing sink
+// does not stop the other datasets from being written. The exit code is the worst
+// of the datasets: a configuration error of any dataset before an input error, and
+// an input error, including a failed sink, before validation findings.
+func validateDatasetsCommand(options *validator.ValidationOptions, paths []string, concurrency int, sinks []Sink, stderr io.Writer) error {
+	names := make(map[string]string, len(paths))
+	for _, path := range paths {
+		name := datasetName(path)
+		if other, ok := names[name]; ok {
+			return configError(fmt.Errorf("inputs %s and %s would write to the same output, rename one of them", other, path))
+		}
+		names[name] = path
+	}
+
+	results := validateDatasets(options, paths, concurrency)
+	defer func() {
+		for _, dataset := range results {
+			if dataset.result != nil {
+				_ = dataset.result.Close()
+			}
+		}
+	}()
+
+	var inputErrs, configErrs []error
+	invalid := false
+	for _, dataset := range results {
+		if dataset.err != nil {
+			inputErrs = append(inputErrs, fmt.Errorf("validation of %s failed: %w", dataset.path, dataset.err))
+			continue
+		}
+		result := dataset.result
+		if verbose {
+			fmt.Printf("%s: %s\n", dataset.path, resultSummary(result))
+		}
+		if err := outputResult(result, datasetSinks(sinks, datasetName(dataset.path))); err != nil {
+			inputErrs = append(inputErrs, fmt.Errorf("failed to output results of %s: %w", dataset.path, err))
+		}
+		fmt.Fprintf(stderr, "%s dataset=%s\n", resultLine(result), datasetName(dataset.path))
+		switch {
+		case result.Error != nil && result.Error.Code == validator.ErrorCodeConfigError:
+			configErrs = append(configErrs, fmt.Errorf("validation of %s failed: %w", dataset.path, result.Error))
+		case result.Error != nil:
+			inputErrs = append(inputErrs, fmt.Errorf("validation of %s failed: %w", dataset.path, result.Error))
+		case !result.IsValid():
+			invalid = true
+		}
+	}
+
+	switch {
+	case len(configErrs) > 0:
+		return configError(errors.Join(append(configErrs, inputErrs...)...))
+	case len(inputErrs) > 0:
+		return inputError(errors.Join(inputErrs...))
+	case invalid:
+		return validationFailedError()
+	}
+	return nil
+}
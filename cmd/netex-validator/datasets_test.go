@@ -0,0 +1,155 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/theoremus-urban-solutions/netex-validator/validator"
+)
+
+// copyTestData copies a file of the test data directory into dir under name
+func copyTestData(t *testing.T, dir, source, name string) string {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("../../testdata", source))
+	if err != nil {
+		t.Fatalf("failed to read %s: %v", source, err)
+	}
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatalf("failed to write %s: %v", path, err)
+	}
+	return path
+}
+
+func TestValidateDatasets(t *testing.T) {
+	dir := t.TempDir()
+	paths := []string{
+		copyTestData(t, dir, "valid_minimal.xml", "north.xml"),
+		copyTestData(t, dir, "empty.xml", "clean.xml"),
+		copyTestData(t, dir, "valid_minimal.xml", "south.xml"),
+	}
+	options := validator.DefaultValidationOptions().WithCodespace("TEST").WithSkipSchema(true)
+
+	results := validateDatasets(options, paths, 3)
+	if len(results) != len(paths) {
+		t.Fatalf("Expected %d results, got %d", len(paths), len(results))
+	}
+	for i, dataset := range results {
+		if dataset.err != nil {
+			t.Fatalf("Validation of %s failed: %v", dataset.path, dataset.err)
+		}
+		if dataset.path != paths[i] {
+			t.Errorf("Expected result %d for %s, got %s", i, paths[i], dataset.path)
+		}
+		if dataset.result.FilesProcessed != 1 {
+			t.Errorf("Expected %s to be validated on its own, got %d files processed", dataset.path, dataset.result.FilesProcessed)
+		}
+	}
+
+	north, clean, south := results[0].result, results[1].result, results[2].result
+	if len(north.ValidationReportEntries) == 0 {
+		t.Fatal("Expected findings for valid_minimal.xml")
+	}
+	if len(south.ValidationReportEntries) != len(north.ValidationReportEntries) {
+		t.Errorf("Expected identical datasets to have the same findings, got %d and %d",
+			len(north.ValidationReportEntries), len(south.ValidationReportEntries))
+	}
+	if len(clean.ValidationReportEntries) != 0 || !clean.IsValid() {
+		t.Errorf("Expected no findings for empty.xml, got %d", len(clean.ValidationReportEntries))
+	}
+}
+
+func TestDatasetOptions(t *testing.T) {
+	options := validator.DefaultValidationOptions().WithConcurrentFiles(8).WithIdValidationWorkers(3)
+
+	shared := datasetOptions(options, 2)
+	if shared.ConcurrentFiles != 4 || shared.IdValidationWorkers != 1 {
+		t.Errorf("Expected 4 files and 1 ID worker per dataset, got %d and %d", shared.ConcurrentFiles, shared.IdValidationWorkers)
+	}
+	if options.ConcurrentFiles != 8 {
+		t.Errorf("Expected the shared options to be a copy, got %d concurrent files", options.ConcurrentFiles)
+	}
+	if single := datasetOptions(options, 1); single.ConcurrentFiles != 8 || single.IdValidationWorkers != 3 {
+		t.Errorf("Expected a single dataset to keep the budget, got %d and %d", single.ConcurrentFiles, single.IdValidationWorkers)
+	}
+}
+
+func TestRun_MultipleDatasets(t *testing.T) {
+	dir := t.TempDir()
+	north := copyTestData(t, dir, "valid_minimal.xml", "north.xml")
+	clean := copyTestData(t, dir, "empty.xml", "clean.xml")
+	summaryPath := filepath.Join(dir, "summary.txt")
+
+	code := run([]string{"-i", north, "-i", clean, "-c", "TEST", "--skip-schema", "--dataset-concurrency", "2",
+		"--sink", "format=summary,dest=file:" + summaryPath})
+	if code != exitValidationFailed {
+		t.Fatalf("Expected exit code %d, got %d", exitValidationFailed, code)
+	}
+
+	northSummary, err := os.ReadFile(filepath.Join(dir, "summary-north.txt"))
+	if err != nil {
+		t.Fatalf("Expected a summary for north.xml: %v", err)
+	}
+	cleanSummary, err := os.ReadFile(filepath.Join(dir, "summary-clean.txt"))
+	if err != nil {
+		t.Fatalf("Expected a summary for clean.xml: %v", err)
+	}
+	if strings.HasPrefix(string(northSummary), "0 issues") {
+		t.Errorf("Expected findings for north.xml, got %q", northSummary)
+	}
+	if !strings.HasPrefix(string(cleanSummary), "0 issues") || !strings.Contains(string(cleanSummary), "in 1 files") {
+		t.Errorf("Expected no findings for clean.xml alone, got %q", cleanSummary)
+	}
+
+	// Inputs named alike would overwrite each other's output
+	other := filepath.Join(dir, "other")
+	if err := os.Mkdir(other, 0o750); err != nil {
+		t.Fatal(err)
+	}
+	copyTestData(t, other, "empty.xml", "clean.xml")
+	code = run([]string{"-i", clean, "-i", filepath.Join(other, "clean.xml"), "-c", "TEST", "--skip-schema",
+		"-o", filepath.Join(dir, "report.json")})
+	if code != exitConfigError {
+		t.Errorf("Expected exit code %d for inputs with the same name, got %d", exitConfigError, code)
+	}
+}
+
+func TestValidateDatasetsCommand_Sinks(t *testing.T) {
+	dir := t.TempDir()
+	north := copyTestData(t, dir, "valid_minimal.xml", "north.xml")
+	clean := copyTestData(t, dir, "empty.xml", "clean.xml")
+	options := validator.DefaultValidationOptions().WithCodespace("TEST").WithSkipSchema(true)
+
+	var stdout, stderr bytes.Buffer
+	sinks := []Sink{
+		// Fails for every dataset, as the directory does not exist
+		&fileSink{format: "json", path: filepath.Join(dir, "missing", "report.json")},
+		&stdoutSink{format: "json", out: &stdout},
+	}
+	err := validateDatasetsCommand(options, []string{north, clean}, 1, sinks, &stderr)
+	if got := exitCode(err); got != exitInputError {
+		t.Errorf("Expected exit code %d for failing sinks, got %d (%v)", exitInputError, got, err)
+	}
+	if err == nil || !strings.Contains(err.Error(), "north.xml") || !strings.Contains(err.Error(), "clean.xml") {
+		t.Errorf("Expected the sink failures of both datasets, got %v", err)
+	}
+
+	// The other sinks and the result lines still cover every dataset
+	lines := strings.Split(strings.TrimSuffix(stdout.String(), "\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected one JSON line per dataset on stdout, got %q", stdout.String())
+	}
+	for i, line := range lines {
+		var result map[string]any
+		if err := json.Unmarshal([]byte(line), &result); err != nil {
+			t.Errorf("Expected line %d to be a JSON document: %v", i+1, err)
+		}
+	}
+	if got := strings.Count(stderr.String(), resultLinePrefix); got != 2 {
+		t.Errorf("Expected 2 result lines on stderr, got %d: %q", got, stderr.String())
+	}
+}
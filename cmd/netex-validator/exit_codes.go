@@ -0,0 +1,63 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Exit codes returned by the CLI. They let scripts tell a dataset that failed
+// validation apart from a run that could not validate anything, e.g. so CI can
+// retry on an unreadable input but not on legitimate findings.
+const (
+	// exitOK means validation completed without findings at or above ERROR
+	exitOK = 0
+	// exitValidationFailed means validation completed and reported ERROR or CRITICAL findings
+	exitValidationFailed = 1
+	// exitInputError means the input or output could not be read or written
+	exitInputError = 2
+	// exitConfigError means the command line or configuration file is invalid
+	exitConfigError = 3
+)
+
+// exitError associates an error with the exit code the process should return
+type exitError struct {
+	code int
+	err  error
+}
+
+func (e *exitError) Error() string {
+	return e.err.Error()
+}
+
+func (e *exitError) Unwrap() error {
+	return e.err
+}
+
+// validationFailedError reports that the dataset has findings at or above ERROR
+func validationFailedError() error {
+	return &exitError{code: exitValidationFailed, err: fmt.Errorf("validation found errors")}
+}
+
+// inputError marks err as an input/IO failure
+func inputError(err error) error {
+	return &exitError{code: exitInputError, err: err}
+}
+
+// configError marks err as a configuration failure
+func configError(err error) error {
+	return &exitError{code: exitConfigError, err: err}
+}
+
+// exitCode maps an error returned by the command to a process exit code.
+// Errors that were not classified by the command come from cobra's flag and
+// argument parsing and are reported as configuration errors.
+func exitCode(err error) int {
+	if err == nil {
+		return exitOK
+	}
+	var exitErr *exitError
+	if errors.As(err, &exitErr) {
+		return exitErr.code
+	}
+	return exitConfigError
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/theoremus-urban-solutions/netex-validator/config"
+	"github.com/theoremus-urban-solutions/netex-validator/rules"
+)
+
+// newExplainCommand builds the explain subcommand
+func newExplainCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "explain CODE",
+		Short: "Print the details of a rule",
+		Long: `Print the name, severity, category, description and XPath of a built-in XPath
+rule, with an example violation and its fix where documented.
+
+Rules checked by business validators, such as ROUTE_SINGLE_DIRECTION, are described
+in VALIDATION_CAPABILITIES.md instead.`,
+		Example: "  netex-validator explain LINE_4",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+			code := strings.ToUpper(strings.TrimSpace(args[0]))
+			rule, ok := rules.NewRuleRegistry(config.DefaultConfig()).GetRuleByCode(code)
+			if !ok {
+				return configError(fmt.Errorf("unknown rule code %s", code))
+			}
+			writeRuleExplanation(cmd.OutOrStdout(), rule)
+			return nil
+		},
+	}
+}
+
+// writeRuleExplanation prints a rule from its registry metadata and prose docs
+func writeRuleExplanation(out io.Writer, rule rules.Rule) {
+	description := rule.Description
+	if description == "" {
+		description = rule.Message
+	}
+
+	fmt.Fprintf(out, "%s: %s\n", rule.Code, rule.Name)
+	fmt.Fprintf(out, "Severity:    %s\n", rule.Severity)
+	fmt.Fprintf(out, "Category:    %s\n", rule.Category)
+	fmt.Fprintf(out, "Description: %s\n", description)
+	if rule.XPath != "" {
+		fmt.Fprintf(out, "XPath:       %s\n", rule.XPath)
+	}
+
+	doc, ok := rules.GetRuleDoc(rule.Code)
+	if !ok {
+		fmt.Fprintf(out, "\nNo example documented for this rule.\n")
+		return
+	}
+	fmt.Fprintf(out, "\nExample violation:\n")
+	for _, line := range strings.Split(doc.Example, "\n") {
+		fmt.Fprintf(out, "  %s\n", line)
+	}
+	fmt.Fprintf(out, "\nFix: %s\n", doc.Fix)
+}
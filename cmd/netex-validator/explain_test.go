@@ -0,0 +1,46 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func explainRule(t *testing.T, code string) string {
+	t.Helper()
+	var out bytes.Buffer
+	cmd := newExplainCommand()
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{code})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("explain %s: %v", code, err)
+	}
+	return out.String()
+}
+
+func TestExplainCommand(t *testing.T) {
+	out := explainRule(t, "line_4")
+	for _, want := range []string{
+		"LINE_4: Line missing TransportMode",
+		"Severity:    ERROR",
+		"Category:    line",
+		"XPath:       //*[local-name()='lines']",
+		"Example violation:",
+		"<PublicCode>42</PublicCode>",
+		"Fix: Add <TransportMode>",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected explain LINE_4 to contain %q, got:\n%s", want, out)
+		}
+	}
+
+	// Rules without prose docs are described from the registry
+	out = explainRule(t, "ROUTE_5")
+	if !strings.Contains(out, "ROUTE_5: Route illegal DirectionRef") || !strings.Contains(out, "No example documented") {
+		t.Errorf("Expected registry metadata for ROUTE_5, got:\n%s", out)
+	}
+
+	if got := run([]string{"explain", "NO_SUCH_RULE"}); got != exitConfigError {
+		t.Errorf("run(explain NO_SUCH_RULE) = %d, want %d", got, exitConfigError)
+	}
+}
@@ -1,7 +1,10 @@
 package main
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"runtime/pprof"
@@ -12,8 +15,15 @@ import (
 	"github.com/theoremus-urban-solutions/netex-validator/validator"
 )
 
+// anonymizationKeyEnv names the environment variable holding the key of
+// --anonymize-ids; without it each report uses a random key
+const anonymizationKeyEnv = "NETEX_ANONYMIZATION_KEY"
+
 var (
 	inputFile       string
+	inputFiles      []string
+	datasetWorkers  int
+	inputURL        string
 	outputFile      string
 	outputFormat    string
 	codespace       string
@@ -21,17 +31,56 @@ var (
 	skipValidators  bool
 	verbose         bool
 	maxSchemaErrors int
+	maxDepth        int
 	configFile      string
 	generateConfig  bool
 	profile         string
 	maxFindings     int
+	maxPerRule      int
 	allowSchemaNet  bool
 	schemaCacheDir  string
 	schemaTimeout   int
+	schemaRetries   int
+	schemaParallel  int
 	useLibxml2XSD   bool
 	concurrentFiles int
+	idWorkers       int
+	threads         int
+	deterministic   bool
 	cpuProfile      string
 	memProfile      string
+	changedFiles    string
+	baselineDataset string
+	explain         bool
+	includeXPath    bool
+	prettyJSON      bool
+	compactJSON     bool
+	anonymizeIds    bool
+	enforcePrefix   bool
+	fingerprintLine bool
+	strictDeadRuns  bool
+	codespaceRegex  string
+	flagUnknown     bool
+	flagOutliers    bool
+	versionReport   bool
+	reportSkipped   bool
+	strictCalendar  bool
+	streaming       bool
+	reportTitle     string
+	htmlTemplate    string
+	reportMetadata  map[string]string
+	plugins         []string
+	strictRouteDirs bool
+	ambiguousNames  int
+	ruleDocsBase    string
+	topRules        int
+	watch           bool
+	flagExpired     bool
+	sinkSpecs       []string
+	checkPartRef    bool
+	spillDir        string
+	spillThreshold  int
+	expectedCounts  string
 	// Performance optimization flags
 	enableCache      bool
 	cacheMaxEntries  int
@@ -39,7 +88,23 @@ var (
 	cacheTTLHours    int
 )
 
+// optionalCommands holds the subcommands compiled in through build tags, such as
+// the tui command
+var optionalCommands []func() *cobra.Command
+
 func main() {
+	os.Exit(run(os.Args[1:]))
+}
+
+// run executes the CLI with the given arguments and returns the process exit code
+func run(args []string) int {
+	rootCmd := newRootCommand()
+	rootCmd.SetArgs(args)
+	return exitCode(rootCmd.Execute())
+}
+
+// newRootCommand builds the root command and binds its flags
+func newRootCommand() *cobra.Command {
 	var rootCmd = &cobra.Command{
 		Use:   "netex-validator",
 		Short: "NetEX validator for EU NeTEx Profile",
@@ -50,34 +115,90 @@ func main() {
 - YAML configuration for rule customization
 - JSON and HTML output formats
 
+Exit codes:
+  0  validation completed without errors
+  1  validation found ERROR or CRITICAL issues
+  2  input or output could not be read or written
+  3  invalid flags or configuration
+
 Examples:
   netex-validator -i data.xml -c "MyCodespace"
   netex-validator -i dataset.zip -c "MyCodespace" --format json
-  netex-validator -i data.xml -c "MyCodespace" --config custom-rules.yaml`,
+  netex-validator --url https://example.com/netex/latest.zip -c "MyCodespace"
+  netex-validator -i data.xml -c "MyCodespace" --config custom-rules.yaml
+  netex-validator -i data.xml -c "MyCodespace" --watch
+  netex-validator -i dataset.zip -c "MyCodespace" --sink format=summary,dest=stdout --sink format=json,dest=file:report.json
+  netex-validator -i north.zip -i south.zip -c "MyCodespace" --dataset-concurrency 2 -o report.json
+  netex-validator -i dataset.zip -c "MyCodespace" --changed-files changed.txt
+  netex-validator -i delta.zip -c "MyCodespace" --baseline-dataset full.zip
+  netex-validator serve --addr :8080`,
 		RunE: validateCommand,
 	}
 
 	// Add flags
-	rootCmd.Flags().StringVarP(&inputFile, "input", "i", "", "Input NetEX file or ZIP dataset (required)")
+	rootCmd.Flags().StringArrayVarP(&inputFiles, "input", "i", nil, "Input NetEX file, ZIP dataset or directory of XML files; may be repeated to validate several datasets (required unless --url is given)")
+	rootCmd.Flags().IntVar(&datasetWorkers, "dataset-concurrency", 1, "Number of datasets given with --input to validate in parallel, sharing the --concurrent and --id-workers budget")
+	rootCmd.Flags().StringVar(&inputURL, "url", "", "Download the NetEX file or ZIP dataset to validate from this http(s) URL instead of --input")
 	rootCmd.Flags().StringVarP(&outputFile, "output", "o", "", "Output file (default: stdout)")
-	rootCmd.Flags().StringVar(&outputFormat, "format", "", "Output format: json or html (default: json)")
-	rootCmd.Flags().StringVarP(&codespace, "codespace", "c", "", "Validation codespace (required)")
+	rootCmd.Flags().StringVar(&outputFormat, "format", "", "Output format: json, html or sqlite (default: json; sqlite requires --output)")
+	rootCmd.Flags().BoolVar(&prettyJSON, "pretty", false, "Write indented JSON (default when writing to stdout)")
+	rootCmd.Flags().BoolVar(&compactJSON, "compact", false, "Write single-line JSON (default when writing to --output)")
+	rootCmd.MarkFlagsMutuallyExclusive("pretty", "compact")
+	rootCmd.Flags().StringArrayVar(&sinkSpecs, "sink", nil, "Write the result to a destination in a format, e.g. format=summary,dest=stdout or format=json,dest=file:report.json or format=json,dest=https://dashboard.example.org/results; may be repeated and replaces --output and --format (formats: json, html, sqlite, summary)")
+	rootCmd.MarkFlagsMutuallyExclusive("sink", "output")
+	rootCmd.MarkFlagsMutuallyExclusive("sink", "format")
+	rootCmd.Flags().StringVarP(&codespace, "codespace", "c", "", "Validation codespace (required unless the configuration file sets validator.defaultCodespace)")
 	rootCmd.Flags().BoolVar(&skipSchema, "skip-schema", false, "Skip XML Schema validation")
 	rootCmd.Flags().BoolVar(&skipValidators, "skip-validators", false, "Skip XPath business rule validation")
 	rootCmd.Flags().BoolVarP(&verbose, "verbose", "v", false, "Enable verbose output")
 	rootCmd.Flags().IntVar(&maxSchemaErrors, "max-schema-errors", 0, "Maximum schema errors to report (0 = use config default)")
+	rootCmd.Flags().IntVar(&maxDepth, "max-depth", validator.DefaultMaxXMLDepth, "Reject documents nesting elements deeper than this (0 = no limit)")
 	rootCmd.Flags().StringVar(&configFile, "config", "", "Configuration file path")
 	rootCmd.Flags().BoolVar(&generateConfig, "generate-config", false, "Generate default configuration file")
-	// Profile flag retained for compatibility but ignored (EU is default)
-	rootCmd.Flags().StringVar(&profile, "profile", "", "(Deprecated) Validation profile – ignored; EU is default")
+	rootCmd.Flags().StringVar(&profile, "profile", "", "NeTEx profile of the data (eu, nordic, fr) instead of the detected one; national profiles use the EU rules without the EU-only ones")
 	rootCmd.Flags().IntVar(&maxFindings, "max-findings", 0, "Maximum number of findings to report (0 = unlimited)")
+	rootCmd.Flags().IntVar(&maxPerRule, "max-findings-per-rule", 0, "Maximum number of findings to report per rule, summarizing the rest (0 = unlimited)")
 	rootCmd.Flags().BoolVar(&allowSchemaNet, "allow-schema-network", true, "Allow downloading NetEX schemas from the network")
 	rootCmd.Flags().StringVar(&schemaCacheDir, "schema-cache-dir", "", "Directory to cache downloaded schemas")
 	rootCmd.Flags().IntVar(&schemaTimeout, "schema-timeout", 30, "Schema download timeout in seconds")
+	rootCmd.Flags().IntVar(&schemaRetries, "schema-download-retries", 0, "Number of times a failed schema or --url download is retried with backoff")
+	rootCmd.Flags().IntVar(&schemaParallel, "schema-download-concurrency", 0, "Maximum number of concurrent schema downloads (0 = no limit)")
 	rootCmd.Flags().BoolVar(&useLibxml2XSD, "use-libxml2-xsd", false, "Use libxml2-backed XSD validation (experimental)")
 	rootCmd.Flags().IntVar(&concurrentFiles, "concurrent", 0, "Number of files to validate in parallel for ZIP datasets (0 = default)")
+	rootCmd.Flags().IntVar(&idWorkers, "id-workers", 0, "Number of goroutines for cross-file ID validation (0 = number of CPUs)")
+	rootCmd.Flags().IntVar(&threads, "threads", 0, "Upper bound for both --concurrent and --id-workers (0 = no limit)")
+	rootCmd.Flags().BoolVar(&deterministic, "deterministic", false, "Validate single-threaded and sort findings so identical input gives byte-identical output (slower)")
 	rootCmd.Flags().StringVar(&cpuProfile, "cpuprofile", "", "Write CPU profile to file")
 	rootCmd.Flags().StringVar(&memProfile, "memprofile", "", "Write memory profile to file")
+	rootCmd.Flags().StringVar(&changedFiles, "changed-files", "", "File listing the ZIP entries to validate (one path per line); other entries are only loaded for cross-file ID validation")
+	rootCmd.Flags().StringVar(&baselineDataset, "baseline-dataset", "", "Full dataset ZIP to resolve references of a delta ZIP dataset against")
+	rootCmd.Flags().BoolVar(&explain, "explain", false, "Include a snippet of each matched element in XPath rule findings")
+	rootCmd.Flags().BoolVar(&includeXPath, "include-xpath", false, "Include the XPath of the matching rule in XPath rule findings")
+	rootCmd.Flags().BoolVar(&anonymizeIds, "anonymize-ids", false, "Replace element ids in findings with keyed hash-based pseudonyms; set "+anonymizationKeyEnv+" to keep them stable across reports")
+	rootCmd.Flags().BoolVar(&enforcePrefix, "enforce-codespace-prefix", false, "Warn about element ids that do not start with the codespace or a declared Codespace (ZIP datasets)")
+	rootCmd.Flags().BoolVar(&fingerprintLine, "fingerprint-line-numbers", false, "Include line numbers in finding fingerprints")
+	rootCmd.Flags().BoolVar(&strictDeadRuns, "strict-dead-runs", false, "Warn about DeadRuns that use a Route of passenger ServiceJourneys (ZIP datasets)")
+	rootCmd.Flags().BoolVar(&strictRouteDirs, "strict-route-directions", false, "Warn about Lines whose Routes all have the same DirectionType (ZIP datasets)")
+	rootCmd.Flags().StringVar(&expectedCounts, "expected-counts", "", "JSON manifest of expected element counts, e.g. {\"Line\": 42, \"ScheduledStopPoint\": {\"min\": 1100, \"max\": 1300}} (ZIP datasets)")
+	rootCmd.Flags().IntVar(&ambiguousNames, "ambiguous-name-threshold", 0, "Report ScheduledStopPoint Names shared by more than this many stop points (0 = not checked)")
+	rootCmd.Flags().IntVar(&topRules, "top-rules", validator.DefaultTopRules, "Number of rules with the most findings listed in verbose output and the HTML report (0 = not listed)")
+	rootCmd.Flags().StringVar(&ruleDocsBase, "rule-docs-base-url", "", "Link each finding to this URL followed by the rule code, e.g. 'https://docs.example.org/rules/'")
+	rootCmd.Flags().StringVar(&codespaceRegex, "codespace-pattern", "", "Regular expression the codespace of every id must match, e.g. '[A-Z]{2}'")
+	rootCmd.Flags().BoolVar(&flagUnknown, "flag-unknown-modes", false, "Warn about Lines and ServiceJourneys with TransportMode 'unknown'")
+	rootCmd.Flags().BoolVar(&flagOutliers, "flag-mode-outliers", false, "Report Lines whose TransportMode differs from the mode of nearly all Lines of their Network as INFO (ZIP datasets)")
+	rootCmd.Flags().BoolVar(&flagExpired, "flag-expired-data", false, "Report AvailabilityConditions and ServiceCalendars whose ToDate is before the PublicationTimestamp")
+	rootCmd.Flags().BoolVar(&checkPartRef, "check-participant-ref", false, "Warn about files whose ParticipantRef matches neither the codespace nor a declared Codespace")
+	rootCmd.Flags().StringVar(&spillDir, "spill-dir", "", "Spill the findings of ZIP datasets to NDJSON files in this directory to bound memory; output must be json, written as flat JSON, sqlite or summary")
+	rootCmd.Flags().IntVar(&spillThreshold, "spill-threshold", validator.DefaultResultSpillThreshold, "Number of findings held in memory before they are spilled to --spill-dir")
+	rootCmd.Flags().BoolVar(&reportSkipped, "report-skipped-rules", false, "Add an INFO finding for every XPath rule that could not be evaluated")
+	rootCmd.Flags().StringVar(&reportTitle, "report-title", "", "Title of the HTML report, also written as reportTitle in JSON (default \"NetEX Validation Report\")")
+	rootCmd.Flags().StringVar(&htmlTemplate, "html-template", "", "Go html/template file replacing the built-in HTML report template")
+	rootCmd.Flags().StringToStringVar(&reportMetadata, "report-metadata", nil, "Metadata shown in the HTML report header and written to JSON, e.g. dataset=regional,portal=north")
+	rootCmd.Flags().StringArrayVar(&plugins, "plugin", nil, "Load validators from a Go plugin (.so) built against this version; may be repeated (needs a binary built with cgo and -tags plugins)")
+	rootCmd.Flags().BoolVar(&watch, "watch", false, "Revalidate whenever the input file or an XML file directly in the input directory changes, checking every 500ms, printing a summary of each run")
+	rootCmd.Flags().BoolVar(&streaming, "streaming", false, "Validate XML files token by token with the element-local rules only, for files too large to load into memory")
+	rootCmd.Flags().BoolVar(&strictCalendar, "strict-calendar", false, "Warn about OperatingDays not covered by any OperatingPeriod (ZIP datasets)")
+	rootCmd.Flags().BoolVar(&versionReport, "version-report", false, "List every id declared or referenced with conflicting versions in one finding (ZIP datasets)")
 
 	// Performance optimization flags
 	rootCmd.Flags().BoolVar(&enableCache, "enable-cache", false, "Enable validation result caching by file hash")
@@ -86,12 +207,9 @@ Examples:
 	rootCmd.Flags().IntVar(&cacheTTLHours, "cache-ttl", 24, "Cache time-to-live in hours")
 
 	// Mark required flags
-	if err := rootCmd.MarkFlagRequired("input"); err != nil {
-		fmt.Fprintf(os.Stderr, "failed to mark --input as required: %v\n", err)
-	}
-	if err := rootCmd.MarkFlagRequired("codespace"); err != nil {
-		fmt.Fprintf(os.Stderr, "failed to mark --codespace as required: %v\n", err)
-	}
+	rootCmd.MarkFlagsOneRequired("input", "url")
+	rootCmd.MarkFlagsMutuallyExclusive("input", "url")
+	rootCmd.MarkFlagsMutuallyExclusive("watch", "url")
 
 	// Add generate-config command
 	var generateConfigCmd = &cobra.Command{
@@ -104,40 +222,86 @@ Examples:
 			if len(args) > 0 {
 				configPath = args[0]
 			}
-			return generateDefaultConfig(configPath)
+			if err := generateDefaultConfig(configPath); err != nil {
+				return inputError(err)
+			}
+			return nil
 		},
 	}
 	rootCmd.AddCommand(generateConfigCmd)
 
-	if err := rootCmd.Execute(); err != nil {
-		os.Exit(1)
+	// Add output-schema command
+	var outputSchemaCmd = &cobra.Command{
+		Use:   "output-schema [file]",
+		Short: "Print the JSON Schema of validation results",
+		Long:  "Print the JSON Schema describing a validation result serialized as flat JSON (ValidationResult.ToFlatJSON), or write it to a file",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			schema := validator.ValidationResultJSONSchema()
+			if len(args) == 0 {
+				_, err := cmd.OutOrStdout().Write(schema)
+				return err
+			}
+			if err := os.WriteFile(args[0], schema, 0o600); err != nil {
+				return inputError(fmt.Errorf("failed to write schema: %w", err))
+			}
+			return nil
+		},
+	}
+	rootCmd.AddCommand(outputSchemaCmd)
+	rootCmd.AddCommand(newExplainCommand())
+	rootCmd.AddCommand(newServeCommand())
+	for _, newCommand := range optionalCommands {
+		rootCmd.AddCommand(newCommand())
 	}
+
+	return rootCmd
 }
 
 func validateCommand(cmd *cobra.Command, args []string) error {
+	// Flags were parsed successfully, so usage output would only hide the error
+	cmd.SilenceUsage = true
+
 	// Handle generate-config flag
 	if generateConfig {
-		return generateDefaultConfig("netex-validator.yaml")
+		if err := generateDefaultConfig("netex-validator.yaml"); err != nil {
+			return inputError(err)
+		}
+		return nil
 	}
 
-	// Validate input file exists
-	if _, err := os.Stat(inputFile); os.IsNotExist(err) {
-		return fmt.Errorf("input file does not exist: %s", inputFile)
+	// Validate input files exist
+	if inputURL == "" {
+		for _, input := range inputFiles {
+			if _, err := os.Stat(input); os.IsNotExist(err) {
+				return inputError(fmt.Errorf("input file does not exist: %s", input))
+			}
+		}
+		inputFile = inputFiles[0]
+	}
+	if datasetWorkers < 1 {
+		return configError(fmt.Errorf("--dataset-concurrency must be at least 1"))
+	}
+	if watch && len(inputFiles) > 1 {
+		return configError(fmt.Errorf("--watch accepts a single --input"))
+	}
+	if len(plugins) > 0 && !validator.PluginsSupported {
+		return configError(fmt.Errorf("--plugin requires a netex-validator built with cgo and -tags plugins"))
 	}
 
 	// Start CPU profiling if requested
 	if cpuProfile != "" {
 		// Validate file path to prevent path traversal
 		if !filepath.IsAbs(cpuProfile) && strings.Contains(cpuProfile, "..") {
-			return fmt.Errorf("invalid CPU profile path: %s", cpuProfile)
+			return configError(fmt.Errorf("invalid CPU profile path: %s", cpuProfile))
 		}
 		f, err := os.Create(cpuProfile) //nolint:gosec // Path is validated above
 		if err != nil {
-			return fmt.Errorf("could not create CPU profile: %w", err)
+			return inputError(fmt.Errorf("could not create CPU profile: %w", err))
 		}
 		if err := pprof.StartCPUProfile(f); err != nil {
 			_ = f.Close()
-			return fmt.Errorf("could not start CPU profile: %w", err)
+			return inputError(fmt.Errorf("could not start CPU profile: %w", err))
 		}
 		defer pprof.StopCPUProfile()
 		defer func() { _ = f.Close() }()
@@ -145,8 +309,14 @@ func validateCommand(cmd *cobra.Command, args []string) error {
 
 	if verbose {
 		fmt.Printf("NetEX Validator - Starting validation\n")
-		fmt.Printf("Input: %s\n", inputFile)
-		fmt.Printf("Codespace: %s\n", codespace)
+		if inputURL != "" {
+			fmt.Printf("Input: %s\n", inputURL)
+		} else {
+			fmt.Printf("Input: %s\n", strings.Join(inputFiles, ", "))
+		}
+		if codespace != "" {
+			fmt.Printf("Codespace: %s\n", codespace)
+		}
 		if configFile != "" {
 			fmt.Printf("Config: %s\n", configFile)
 		}
@@ -164,6 +334,9 @@ func validateCommand(cmd *cobra.Command, args []string) error {
 	if maxFindings > 0 {
 		options = options.WithMaxFindings(maxFindings)
 	}
+	if maxPerRule > 0 {
+		options = options.WithMaxFindingsPerRule(maxPerRule)
+	}
 	options = options.WithAllowSchemaNetwork(allowSchemaNet)
 	if schemaCacheDir != "" {
 		options = options.WithSchemaCacheDir(schemaCacheDir)
@@ -171,12 +344,145 @@ func validateCommand(cmd *cobra.Command, args []string) error {
 	if schemaTimeout > 0 {
 		options = options.WithSchemaTimeoutSeconds(schemaTimeout)
 	}
+	options = options.WithSchemaDownloadRetries(schemaRetries)
+	if schemaParallel > 0 {
+		options = options.WithSchemaDownloadConcurrency(schemaParallel)
+	}
 	if useLibxml2XSD {
 		options = options.WithUseLibxml2XSD(true)
 	}
 	if concurrentFiles > 0 {
 		options = options.WithConcurrentFiles(concurrentFiles)
 	}
+	if idWorkers > 0 {
+		options = options.WithIdValidationWorkers(idWorkers)
+	}
+	if threads > 0 {
+		if options.ConcurrentFiles == 0 || options.ConcurrentFiles > threads {
+			options = options.WithConcurrentFiles(threads)
+		}
+		if options.IdValidationWorkers == 0 || options.IdValidationWorkers > threads {
+			options = options.WithIdValidationWorkers(threads)
+		}
+	}
+	if deterministic {
+		options = options.WithDeterministic(true)
+	}
+	// Indented JSON for people reading stdout, compact JSON for files read by tools
+	compact := outputFile != ""
+	if prettyJSON || compactJSON {
+		compact = compactJSON
+	}
+	options = options.WithCompactJSON(compact)
+	if changedFiles != "" {
+		paths, err := readChangedFiles(changedFiles)
+		if err != nil {
+			return inputError(err)
+		}
+		options = options.WithChangedFiles(paths)
+	}
+	if explain {
+		options = options.WithExplain(true)
+	}
+	if includeXPath {
+		options = options.WithIncludeRuleXPath(true)
+	}
+	if anonymizeIds {
+		options = options.WithAnonymizeIds(true)
+		// Read from the environment so the key stays out of the process list
+		if key := os.Getenv(anonymizationKeyEnv); key != "" {
+			options = options.WithAnonymizationKey([]byte(key))
+		}
+	}
+	if enforcePrefix {
+		options = options.WithEnforceCodespacePrefix(true)
+	}
+	if fingerprintLine {
+		options = options.WithFingerprintLineNumbers(true)
+	}
+	if strictDeadRuns {
+		options = options.WithStrictDeadRuns(true)
+	}
+	if strictRouteDirs {
+		options = options.WithStrictRouteDirections(true)
+	}
+	if ambiguousNames > 0 {
+		options = options.WithAmbiguousNameThreshold(ambiguousNames)
+	}
+	if expectedCounts != "" {
+		data, err := os.ReadFile(expectedCounts) //nolint:gosec // Path is provided by the user on the command line
+		if err != nil {
+			return inputError(fmt.Errorf("failed to read expected counts manifest: %w", err))
+		}
+		var manifest map[string]types.ExpectedCount
+		if err := json.Unmarshal(data, &manifest); err != nil {
+			return configError(fmt.Errorf("invalid expected counts manifest %s: %w", expectedCounts, err))
+		}
+		options = options.WithExpectedCounts(manifest)
+	}
+	if ruleDocsBase != "" {
+		options = options.WithRuleDocsBaseURL(ruleDocsBase)
+	}
+	if topRules > 0 {
+		options = options.WithTopRules(topRules)
+	} else {
+		options = options.WithTopRules(-1)
+	}
+	if codespaceRegex != "" {
+		options = options.WithCodespacePattern(codespaceRegex)
+	}
+	if flagUnknown {
+		options = options.WithFlagUnknownModes(true)
+	}
+	if flagOutliers {
+		options = options.WithFlagModeOutliers(true)
+	}
+	if flagExpired {
+		options = options.WithFlagExpiredData(true)
+	}
+	if checkPartRef {
+		options = options.WithCheckParticipantRef(true)
+	}
+	if spillDir != "" {
+		options = options.WithResultSpillDir(spillDir).WithResultSpillThreshold(spillThreshold)
+	}
+	if reportSkipped {
+		options = options.WithReportSkippedRules(true)
+	}
+	if versionReport {
+		options = options.WithVersionReport(true)
+	}
+	if strictCalendar {
+		options = options.WithStrictCalendar(true)
+	}
+	if streaming {
+		options = options.WithStreamingMode(true)
+	}
+	if reportTitle != "" {
+		options = options.WithReportTitle(reportTitle)
+	}
+	if len(reportMetadata) > 0 {
+		options = options.WithReportMetadata(reportMetadata)
+	}
+	if htmlTemplate != "" {
+		data, err := os.ReadFile(htmlTemplate) //nolint:gosec // Path is provided by the user on the command line
+		if err != nil {
+			return inputError(fmt.Errorf("failed to read HTML template: %w", err))
+		}
+		if _, err := validator.NewHTMLReporter().WithCustomHTMLTemplate(string(data)); err != nil {
+			return configError(fmt.Errorf("invalid HTML template %s: %w", htmlTemplate, err))
+		}
+		options = options.WithHTMLTemplate(string(data))
+	}
+	if len(plugins) > 0 {
+		options = options.WithPlugins(plugins...)
+	}
+	if baselineDataset != "" {
+		if _, err := os.Stat(baselineDataset); err != nil {
+			return inputError(fmt.Errorf("baseline dataset not found: %s", baselineDataset))
+		}
+		options = options.WithBaselineDataset(baselineDataset)
+	}
 
 	// Performance optimization options
 	if enableCache {
@@ -186,50 +492,68 @@ func validateCommand(cmd *cobra.Command, args []string) error {
 	if maxSchemaErrors > 0 {
 		options.MaxSchemaErrors = maxSchemaErrors
 	}
+	options = options.WithMaxXMLDepth(maxDepth)
 
 	// Determine output format
 	format := "json"
 	if outputFormat != "" {
 		format = outputFormat
 	}
+	if format != "json" && format != "html" && format != "sqlite" {
+		return configError(fmt.Errorf("unsupported output format: %s (supported: json, html, sqlite)", format))
+	}
+	if format == "sqlite" && outputFile == "" {
+		return configError(fmt.Errorf("--format sqlite requires --output"))
+	}
 	options.OutputFormat = format
+	sinks, err := outputSinks(format, cmd.OutOrStdout())
+	if err != nil {
+		return configError(err)
+	}
 
-	// Perform validation
-	var result *validator.ValidationResult
-	var err error
+	// Configuration problems surface while building the validator
+	v, err := validator.NewWithOptions(options)
+	if err != nil {
+		return configError(err)
+	}
+	if v.Codespace() == "" {
+		return configError(fmt.Errorf("no codespace given: pass --codespace or set validator.defaultCodespace in the configuration file"))
+	}
 
-	isZip := strings.ToLower(filepath.Ext(inputFile)) == ".zip"
-	if isZip {
-		if verbose {
-			fmt.Printf("Processing ZIP dataset...\n")
-		}
-		result, err = validator.ValidateZip(inputFile, options)
-	} else {
-		if verbose {
-			fmt.Printf("Processing single XML file...\n")
+	if len(inputFiles) > 1 {
+		return validateDatasetsCommand(options, inputFiles, datasetWorkers, sinks, cmd.ErrOrStderr())
+	}
+
+	if watch {
+		// Reprinting a full report on every change would bury the summaries
+		if outputFile == "" && len(sinkSpecs) == 0 {
+			sinks = nil
 		}
-		result, err = validator.ValidateFile(inputFile, options)
+		return watchCommand(cmd.Context(), v, sinks, cmd.OutOrStdout())
 	}
 
+	// Perform validation
+	result, err := validateInput(v)
 	if err != nil {
-		return fmt.Errorf("validation failed: %w", err)
+		return inputError(fmt.Errorf("validation failed: %w", err))
 	}
+	defer func() { _ = result.Close() }()
 
 	// Write memory profile if requested
 	if memProfile != "" {
 		// Validate file path to prevent path traversal
 		if !filepath.IsAbs(memProfile) && strings.Contains(memProfile, "..") {
-			return fmt.Errorf("invalid memory profile path: %s", memProfile)
+			return configError(fmt.Errorf("invalid memory profile path: %s", memProfile))
 		}
 		f, err := os.Create(memProfile) //nolint:gosec // Path is validated above
 		if err == nil {
 			if err := pprof.WriteHeapProfile(f); err != nil {
 				_ = f.Close()
-				return fmt.Errorf("could not write memory profile: %w", err)
+				return inputError(fmt.Errorf("could not write memory profile: %w", err))
 			}
 			_ = f.Close()
 		} else {
-			return fmt.Errorf("could not create mem profile: %w", err)
+			return inputError(fmt.Errorf("could not create mem profile: %w", err))
 		}
 	}
 
@@ -245,11 +569,27 @@ func validateCommand(cmd *cobra.Command, args []string) error {
 			}
 			fmt.Printf("\n")
 		}
+
+		if top := result.TopRules(topRules); topRules > 0 && len(top) > 0 {
+			fmt.Printf("Top %d rules by findings:\n", len(top))
+			for i, rule := range top {
+				fmt.Printf("  %2d. %s (%d)\n", i+1, rule.Name, rule.Count)
+			}
+		}
 	}
 
 	// Output results
-	if err := outputResult(result, format); err != nil {
-		return fmt.Errorf("failed to output results: %w", err)
+	if err := outputResult(result, sinks); err != nil {
+		return inputError(fmt.Errorf("failed to output results: %w", err))
+	}
+	fmt.Fprintln(cmd.ErrOrStderr(), resultLine(result))
+
+	// The input was found but could not be read or unpacked, or an option was unusable
+	if result.Error != nil {
+		if result.Error.Code == validator.ErrorCodeConfigError {
+			return configError(fmt.Errorf("validation failed: %w", result.Error))
+		}
+		return inputError(fmt.Errorf("validation failed: %w", result.Error))
 	}
 
 	// Exit with error code if validation found errors
@@ -257,7 +597,7 @@ func validateCommand(cmd *cobra.Command, args []string) error {
 		if verbose {
 			fmt.Printf("Validation completed with errors\n")
 		}
-		return fmt.Errorf("validation found errors")
+		return validationFailedError()
 	}
 
 	if verbose {
@@ -267,30 +607,90 @@ func validateCommand(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
-func outputResult(result *validator.ValidationResult, format string) error {
-	var output []byte
-	var err error
+// validateInput validates the --url, ZIP dataset, directory or XML file given on
+// the command line
+func validateInput(v *validator.NetexValidator) (*validator.ValidationResult, error) {
+	switch {
+	case inputURL != "":
+		if verbose {
+			fmt.Printf("Downloading and validating %s...\n", inputURL)
+		}
+		return v.ValidateURL(inputURL)
+	default:
+		return validatePath(v, inputFile)
+	}
+}
 
-	switch format {
-	case "json":
-		output, err = result.ToJSON()
-	case "html":
-		output, err = result.ToHTML()
+// validatePath validates a ZIP dataset, directory or XML file
+func validatePath(v *validator.NetexValidator, path string) (*validator.ValidationResult, error) {
+	switch {
+	case strings.ToLower(filepath.Ext(path)) == ".zip":
+		if verbose {
+			fmt.Printf("Processing ZIP dataset...\n")
+		}
+		return v.ValidateZip(path)
+	case isDirectory(path):
+		if verbose {
+			fmt.Printf("Processing XML files of directory...\n")
+		}
+		return validateDirectory(v, path)
 	default:
-		return fmt.Errorf("unsupported output format: %s (supported: json, html)", format)
+		if verbose {
+			fmt.Printf("Processing single XML file...\n")
+		}
+		return v.ValidateFile(path)
 	}
+}
 
+// readChangedFiles reads a changed-files list such as the output of
+// `git diff --name-only`. Blank lines and lines starting with # are ignored.
+func readChangedFiles(path string) ([]string, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // Path is provided by the user on the command line
 	if err != nil {
-		return err
+		return nil, fmt.Errorf("failed to read changed files list: %w", err)
 	}
 
-	// Write to file or stdout
-	if outputFile != "" {
-		return os.WriteFile(outputFile, output, 0o600)
-	} else {
-		fmt.Print(string(output))
-		return nil
+	var paths []string
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		paths = append(paths, line)
+	}
+	return paths, nil
+}
+
+// outputSinks returns the sinks given with --sink, or the one --output and --format
+// describe: the file named by --output, or stdout
+func outputSinks(format string, out io.Writer) ([]Sink, error) {
+	if len(sinkSpecs) == 0 {
+		if outputFile != "" {
+			return []Sink{&fileSink{format: format, path: outputFile}}, nil
+		}
+		return []Sink{&stdoutSink{format: format, out: out}}, nil
+	}
+
+	sinks := make([]Sink, 0, len(sinkSpecs))
+	for _, spec := range sinkSpecs {
+		sink, err := parseSink(spec, out)
+		if err != nil {
+			return nil, err
+		}
+		sinks = append(sinks, sink)
+	}
+	return sinks, nil
+}
+
+// outputResult writes the result to every sink, even when an earlier one fails
+func outputResult(result *validator.ValidationResult, sinks []Sink) error {
+	var errs []error
+	for _, sink := range sinks {
+		if err := sink.Write(result); err != nil {
+			errs = append(errs, err)
+		}
 	}
+	return errors.Join(errs...)
 }
 
 func generateDefaultConfig(configPath string) error {
@@ -299,6 +699,7 @@ func generateDefaultConfig(configPath string) error {
 	defaultConfig := `# NetEX Validator Configuration
 validator:
   profile: "eu"
+  # defaultCodespace: "NO"  # used when --codespace is not given
   maxFileSize: 104857600  # 100MB
   maxSchemaErrors: 100
   concurrentFiles: 4
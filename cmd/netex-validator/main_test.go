@@ -0,0 +1,220 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/theoremus-urban-solutions/netex-validator/validator"
+)
+
+func TestRun_ExitCodes(t *testing.T) {
+	tempDir := t.TempDir()
+	output := filepath.Join(tempDir, "report.json")
+
+	codespaceConfig := filepath.Join(tempDir, "codespace.yaml")
+	if err := os.WriteFile(codespaceConfig, []byte("validator:\n  defaultCodespace: TEST\n"), 0o600); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	invalidConfig := filepath.Join(tempDir, "invalid.yaml")
+	if err := os.WriteFile(invalidConfig, []byte("validator: [unclosed"), 0o600); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	htmlTemplate := filepath.Join(tempDir, "report.tmpl")
+	if err := os.WriteFile(htmlTemplate, []byte("<h1>{{.Result.Codespace}}</h1>"), 0o600); err != nil {
+		t.Fatalf("failed to write template: %v", err)
+	}
+
+	invalidTemplate := filepath.Join(tempDir, "invalid.tmpl")
+	if err := os.WriteFile(invalidTemplate, []byte("<h1>{{.Result.Codespace</h1>"), 0o600); err != nil {
+		t.Fatalf("failed to write template: %v", err)
+	}
+
+	// Serves the test data for --url
+	feed := httptest.NewServer(http.FileServer(http.Dir("../../testdata")))
+	t.Cleanup(feed.Close)
+
+	tests := []struct {
+		name string
+		args []string
+		want int
+	}{
+		{
+			name: "clean file",
+			args: []string{"-i", "../../testdata/empty.xml", "-c", "TEST", "--skip-schema", "-o", output},
+			want: exitOK,
+		},
+		{
+			name: "validation findings",
+			args: []string{"-i", "../../testdata/valid_minimal.xml", "-c", "TEST", "--skip-schema", "-o", output},
+			want: exitValidationFailed,
+		},
+		{
+			name: "missing input file",
+			args: []string{"-i", filepath.Join(tempDir, "missing.xml"), "-c", "TEST", "-o", output},
+			want: exitInputError,
+		},
+		{
+			name: "missing changed files list",
+			args: []string{"-i", "../../testdata/empty.xml", "-c", "TEST", "--changed-files", filepath.Join(tempDir, "missing.txt"), "-o", output},
+			want: exitInputError,
+		},
+		{
+			name: "unwritable output",
+			args: []string{"-i", "../../testdata/empty.xml", "-c", "TEST", "--skip-schema", "-o", filepath.Join(tempDir, "missing", "report.json")},
+			want: exitInputError,
+		},
+		{
+			name: "dataset url",
+			args: []string{"--url", feed.URL + "/empty.xml", "-c", "TEST", "--skip-schema", "-o", output},
+			want: exitOK,
+		},
+		{
+			name: "dataset url not found",
+			args: []string{"--url", feed.URL + "/missing.xml", "-c", "TEST", "-o", output},
+			want: exitInputError,
+		},
+		{
+			name: "input and url together",
+			args: []string{"-i", "../../testdata/empty.xml", "--url", feed.URL + "/empty.xml", "-c", "TEST", "-o", output},
+			want: exitConfigError,
+		},
+		{
+			name: "neither input nor url",
+			args: []string{"-c", "TEST", "-o", output},
+			want: exitConfigError,
+		},
+		{
+			name: "invalid sink",
+			args: []string{"-i", "../../testdata/empty.xml", "-c", "TEST", "--sink", "format=json,dest=ftp://example.org"},
+			want: exitConfigError,
+		},
+		{
+			name: "invalid expected counts manifest",
+			args: []string{"-i", "../../testdata/empty.xml", "-c", "TEST", "--expected-counts", invalidConfig, "-o", output},
+			want: exitConfigError,
+		},
+		{
+			name: "dataset concurrency below one",
+			args: []string{"-i", "../../testdata/empty.xml", "-c", "TEST", "--dataset-concurrency", "0", "-o", output},
+			want: exitConfigError,
+		},
+		{
+			name: "watch with several inputs",
+			args: []string{"-i", "../../testdata/empty.xml", "-i", "../../testdata/valid_minimal.xml", "-c", "TEST", "--watch"},
+			want: exitConfigError,
+		},
+		{
+			name: "unavailable plugin",
+			args: []string{"-i", "../../testdata/empty.xml", "-c", "TEST", "--plugin", filepath.Join(tempDir, "missing.so"), "-o", output},
+			want: exitConfigError,
+		},
+		{
+			name: "html template",
+			args: []string{"-i", "../../testdata/empty.xml", "-c", "TEST", "--skip-schema", "--format", "html", "--html-template", htmlTemplate, "-o", output},
+			want: exitOK,
+		},
+		{
+			name: "missing html template",
+			args: []string{"-i", "../../testdata/empty.xml", "-c", "TEST", "--html-template", filepath.Join(tempDir, "missing.tmpl"), "-o", output},
+			want: exitInputError,
+		},
+		{
+			name: "invalid html template",
+			args: []string{"-i", "../../testdata/empty.xml", "-c", "TEST", "--html-template", invalidTemplate, "-o", output},
+			want: exitConfigError,
+		},
+		{
+			name: "missing config file",
+			args: []string{"-i", "../../testdata/empty.xml", "-c", "TEST", "--config", filepath.Join(tempDir, "missing.yaml"), "-o", output},
+			want: exitConfigError,
+		},
+		{
+			name: "invalid config file",
+			args: []string{"-i", "../../testdata/empty.xml", "-c", "TEST", "--config", invalidConfig, "-o", output},
+			want: exitConfigError,
+		},
+		{
+			name: "unsupported output format",
+			args: []string{"-i", "../../testdata/empty.xml", "-c", "TEST", "--format", "csv", "-o", output},
+			want: exitConfigError,
+		},
+		{
+			name: "pretty and compact together",
+			args: []string{"-i", "../../testdata/empty.xml", "-c", "TEST", "--pretty", "--compact", "-o", output},
+			want: exitConfigError,
+		},
+		{
+			name: "sqlite output without file",
+			args: []string{"-i", "../../testdata/empty.xml", "-c", "TEST", "--format", "sqlite"},
+			want: exitConfigError,
+		},
+		{
+			name: "missing codespace",
+			args: []string{"-i", "../../testdata/empty.xml"},
+			want: exitConfigError,
+		},
+		{
+			name: "codespace from configuration",
+			args: []string{"-i", "../../testdata/empty.xml", "--config", codespaceConfig, "--skip-schema", "-o", output},
+			want: exitOK,
+		},
+		{
+			name: "unknown flag",
+			args: []string{"-i", "../../testdata/empty.xml", "-c", "TEST", "--no-such-flag"},
+			want: exitConfigError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := run(tt.args); got != tt.want {
+				t.Errorf("run(%v) = %d, want %d", tt.args, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExitCode(t *testing.T) {
+	cause := errors.New("boom")
+
+	if got := exitCode(nil); got != exitOK {
+		t.Errorf("exitCode(nil) = %d, want %d", got, exitOK)
+	}
+	if got := exitCode(validationFailedError()); got != exitValidationFailed {
+		t.Errorf("exitCode(validationFailedError()) = %d, want %d", got, exitValidationFailed)
+	}
+	if got := exitCode(inputError(cause)); got != exitInputError {
+		t.Errorf("exitCode(inputError) = %d, want %d", got, exitInputError)
+	}
+	if got := exitCode(configError(cause)); got != exitConfigError {
+		t.Errorf("exitCode(configError) = %d, want %d", got, exitConfigError)
+	}
+	if !errors.Is(inputError(cause), cause) {
+		t.Error("Expected classified errors to wrap their cause")
+	}
+}
+
+func TestOutputSchemaCommand(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "result.schema.json")
+	if got := run([]string{"output-schema", path}); got != exitOK {
+		t.Fatalf("run(output-schema) = %d, want %d", got, exitOK)
+	}
+	written, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read schema: %v", err)
+	}
+	if !bytes.Equal(written, validator.ValidationResultJSONSchema()) {
+		t.Error("Expected the written schema to be the embedded one")
+	}
+
+	if got := run([]string{"output-schema", filepath.Join(t.TempDir(), "missing", "schema.json")}); got != exitInputError {
+		t.Errorf("run(output-schema) to an unwritable path = %d, want %d", got, exitInputError)
+	}
+}
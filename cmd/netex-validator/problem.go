@@ -0,0 +1,82 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/theoremus-urban-solutions/netex-validator/validator"
+)
+
+// problemTypePrefix prefixes the error code in the type of a problem, e.g.
+// urn:netex-validator:problem:PARSE_ERROR
+const problemTypePrefix = "urn:netex-validator:problem:"
+
+// Error codes of requests the server rejects before validating, alongside the
+// validator.ResultErrorCode values of failed validations
+const (
+	problemBadRequest       validator.ResultErrorCode = "BAD_REQUEST"
+	problemBadCodespace     validator.ResultErrorCode = "BAD_CODESPACE"
+	problemUploadTooLarge   validator.ResultErrorCode = "UPLOAD_TOO_LARGE"
+	problemMethodNotAllowed validator.ResultErrorCode = "METHOD_NOT_ALLOWED"
+	problemNotFound         validator.ResultErrorCode = "NOT_FOUND"
+	problemInternalError    validator.ResultErrorCode = "INTERNAL_ERROR"
+	problemServerBusy       validator.ResultErrorCode = "SERVER_BUSY"
+)
+
+// problemTitles are the titles of the problem types; they describe the type, while
+// the detail of a problem describes the occurrence
+var problemTitles = map[validator.ResultErrorCode]string{
+	problemBadRequest:                  "Invalid validation request",
+	problemBadCodespace:                "Missing or invalid codespace",
+	problemUploadTooLarge:              "Upload too large",
+	problemMethodNotAllowed:            "Method not allowed",
+	problemNotFound:                    "Not found",
+	problemInternalError:               "Internal error",
+	problemServerBusy:                  "Server busy",
+	validator.ErrorCodeReadError:       "Upload could not be read",
+	validator.ErrorCodeParseError:      "Upload is not well-formed XML or a ZIP archive",
+	validator.ErrorCodeConfigError:     "Invalid validation options",
+	validator.ErrorCodeValidationError: "Validation failed",
+}
+
+// problem is an RFC 7807 problem details object, written as application/problem+json
+type problem struct {
+	Type   string `json:"type"`
+	Title  string `json:"title"`
+	Status int    `json:"status"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// newProblem creates the problem of an error code with the given HTTP status
+func newProblem(status int, code validator.ResultErrorCode, detail string) problem {
+	title, ok := problemTitles[code]
+	if !ok {
+		title = http.StatusText(status)
+	}
+	return problem{Type: problemTypePrefix + string(code), Title: title, Status: status, Detail: detail}
+}
+
+// validationProblem describes an upload that could not be validated. Unreadable
+// and malformed uploads are the client's to fix; anything else is a server failure.
+func validationProblem(err error) problem {
+	var resultErr *validator.ResultError
+	if !errors.As(err, &resultErr) {
+		return newProblem(http.StatusInternalServerError, problemInternalError, err.Error())
+	}
+	switch resultErr.Code {
+	case "":
+		return newProblem(http.StatusInternalServerError, problemInternalError, resultErr.Message)
+	case validator.ErrorCodeReadError, validator.ErrorCodeParseError:
+		return newProblem(http.StatusUnprocessableEntity, resultErr.Code, resultErr.Message)
+	default:
+		return newProblem(http.StatusInternalServerError, resultErr.Code, resultErr.Message)
+	}
+}
+
+// writeProblem writes a problem details response
+func writeProblem(w http.ResponseWriter, p problem) {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(p.Status)
+	_ = json.NewEncoder(w).Encode(p)
+}
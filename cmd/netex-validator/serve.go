@@ -0,0 +1,516 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/theoremus-urban-solutions/netex-validator/validator"
+)
+
+const (
+	// maxUploadBytes bounds the size of a dataset posted to /validate
+	maxUploadBytes = 512 << 20
+	// callbackTimeout bounds the POST of a finished job's result to its callback URL
+	callbackTimeout = 30 * time.Second
+	// defaultJobTTL is how long a finished job stays available from GET /jobs/{id}
+	defaultJobTTL = time.Hour
+	// busyRetryAfter is the Retry-After, in seconds, of a request rejected because
+	// all validation slots are taken
+	busyRetryAfter = "10"
+	// serverReadTimeout bounds reading a request, including an upload of maxUploadBytes
+	serverReadTimeout = 5 * time.Minute
+	// serverWriteTimeout bounds a request from the end of its headers to the end of
+	// the response, which includes a synchronous validation
+	serverWriteTimeout = 30 * time.Minute
+	// serverIdleTimeout bounds how long an idle keep-alive connection stays open
+	serverIdleTimeout = 2 * time.Minute
+)
+
+// Job states reported by GET /jobs/{id}
+const (
+	jobPending   = "pending"
+	jobCompleted = "completed"
+	jobFailed    = "failed"
+)
+
+// job tracks an asynchronous validation started with a callback_url
+type job struct {
+	ID            string          `json:"id"`
+	Status        string          `json:"status"`
+	CallbackURL   string          `json:"callbackUrl"`
+	Error         string          `json:"error,omitempty"`
+	CallbackError string          `json:"callbackError,omitempty"`
+	Result        json.RawMessage `json:"result,omitempty"`
+
+	finished time.Time // when the validation ended, zero while pending
+}
+
+// errMissingCodespace rejects a /validate request without a codespace
+var errMissingCodespace = errors.New("missing codespace")
+
+// errPrivateCallback rejects a callback URL that resolves to a loopback, private or
+// link-local address, so the server cannot be used to reach internal services
+var errPrivateCallback = errors.New("callback_url must not resolve to a loopback, private or link-local address")
+
+// server validates uploaded NetEX files over HTTP
+type server struct {
+	client    *http.Client
+	maxUpload int64         // Largest request body accepted by /validate, in bytes
+	jobTTL    time.Duration // How long finished jobs are kept
+	slots     chan struct{} // One token per running validation
+	// allowPrivateCallbacks lets callbacks reach loopback, private and link-local
+	// addresses, for servers deployed next to the services they notify
+	allowPrivateCallbacks bool
+	now                   func() time.Time
+
+	mu   sync.Mutex
+	jobs map[string]*job
+	wg   sync.WaitGroup // running asynchronous jobs
+}
+
+// newServer creates a server running at most maxConcurrent validations at once
+func newServer(maxConcurrent int) *server {
+	s := &server{
+		maxUpload: maxUploadBytes,
+		jobTTL:    defaultJobTTL,
+		slots:     make(chan struct{}, maxConcurrent),
+		now:       time.Now,
+		jobs:      make(map[string]*job),
+	}
+	// Callback addresses are checked once resolved, so DNS cannot redirect a
+	// callback, or one of its redirects, to an internal address
+	dialer := &net.Dialer{Timeout: callbackTimeout, Control: s.checkCallbackDial}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	s.client = &http.Client{Timeout: callbackTimeout, Transport: transport}
+	return s
+}
+
+// newServeCommand builds the serve subcommand
+func newServeCommand() *cobra.Command {
+	var (
+		addr                  string
+		maxConcurrent         int
+		jobTTL                time.Duration
+		allowPrivateCallbacks bool
+	)
+
+	serveCmd := &cobra.Command{
+		Use:   "serve",
+		Short: "Serve validation over HTTP",
+		Long: `Start an HTTP server exposing validation to other services.
+
+POST /validate takes a multipart form with the NetEX XML or ZIP in "file", the
+"codespace" and optionally "skip_schema=true". The JSON report is returned in the
+response, unless "callback_url" is set: the validation then runs in the
+background, the response carries the job id, and the JSON report is POSTed to
+the callback URL when done. GET /jobs/{id} returns the state of such a job
+until --job-ttl after it finished. Callback URLs resolving to loopback, private
+or link-local addresses are rejected unless --allow-private-callbacks is set.
+
+At most --max-concurrent validations run at once, counting uploads still being
+read; further requests are answered with 503 Service Unavailable and a
+Retry-After header.
+
+Errors are RFC 7807 problem details (application/problem+json) whose type ends in
+an error code, e.g. urn:netex-validator:problem:UPLOAD_TOO_LARGE.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+			if maxConcurrent < 1 {
+				return configError(fmt.Errorf("--max-concurrent must be at least 1, got %d", maxConcurrent))
+			}
+			s := newServer(maxConcurrent)
+			s.jobTTL = jobTTL
+			s.allowPrivateCallbacks = allowPrivateCallbacks
+			srv := &http.Server{
+				Addr:              addr,
+				Handler:           s.routes(),
+				ReadHeaderTimeout: 10 * time.Second,
+				ReadTimeout:       serverReadTimeout,
+				WriteTimeout:      serverWriteTimeout,
+				IdleTimeout:       serverIdleTimeout,
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Listening on %s\n", addr)
+			if err := srv.ListenAndServe(); err != nil {
+				return configError(err)
+			}
+			return nil
+		},
+	}
+	serveCmd.Flags().StringVar(&addr, "addr", ":8080", "Address to listen on")
+	serveCmd.Flags().IntVar(&maxConcurrent, "max-concurrent", runtime.NumCPU(), "Maximum number of validations running at once")
+	serveCmd.Flags().DurationVar(&jobTTL, "job-ttl", defaultJobTTL, "How long finished jobs stay available from GET /jobs/{id}")
+	serveCmd.Flags().BoolVar(&allowPrivateCallbacks, "allow-private-callbacks", false, "Allow callback URLs resolving to loopback, private or link-local addresses")
+	return serveCmd
+}
+
+// routes returns the server's HTTP handler
+func (s *server) routes() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/validate", s.handleValidate)
+	mux.HandleFunc("/jobs/", s.handleJob)
+	return mux
+}
+
+// validationRequest is a parsed /validate form
+type validationRequest struct {
+	fileName    string
+	content     []byte
+	codespace   string
+	skipSchema  bool
+	callbackURL string
+}
+
+func (s *server) handleValidate(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		writeProblem(w, newProblem(http.StatusMethodNotAllowed, problemMethodNotAllowed, "use POST"))
+		return
+	}
+
+	// The slot is taken before the upload is read, so --max-concurrent also bounds
+	// the memory held by uploads
+	if !s.acquireSlot() {
+		w.Header().Set("Retry-After", busyRetryAfter)
+		writeProblem(w, newProblem(http.StatusServiceUnavailable, problemServerBusy,
+			fmt.Sprintf("all %d validation slots are in use", cap(s.slots))))
+		return
+	}
+	handedOff := false
+	defer func() {
+		if !handedOff {
+			s.releaseSlot()
+		}
+	}()
+
+	req, err := parseValidationRequest(w, r, s.maxUpload)
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		switch {
+		case errors.As(err, &tooLarge):
+			writeProblem(w, newProblem(http.StatusRequestEntityTooLarge, problemUploadTooLarge,
+				fmt.Sprintf("upload exceeds the limit of %d bytes", tooLarge.Limit)))
+		case errors.Is(err, errMissingCodespace):
+			writeProblem(w, newProblem(http.StatusBadRequest, problemBadCodespace, err.Error()))
+		default:
+			writeProblem(w, newProblem(http.StatusBadRequest, problemBadRequest, err.Error()))
+		}
+		return
+	}
+
+	if req.callbackURL != "" {
+		if err := s.checkCallbackHost(r.Context(), req.callbackURL); err != nil {
+			writeProblem(w, newProblem(http.StatusBadRequest, problemBadRequest, err.Error()))
+			return
+		}
+	}
+
+	if req.callbackURL == "" {
+		report, err := validateUpload(req)
+		if err != nil {
+			writeProblem(w, validationProblem(err))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(report)
+		return
+	}
+
+	j, err := s.startJob(req)
+	if err != nil {
+		writeProblem(w, newProblem(http.StatusInternalServerError, problemInternalError, err.Error()))
+		return
+	}
+	// The job releases the slot when its validation ends
+	handedOff = true
+	w.Header().Set("Location", "/jobs/"+j.ID)
+	writeJSON(w, http.StatusAccepted, map[string]string{"jobId": j.ID, "status": jobPending})
+}
+
+func (s *server) handleJob(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		writeProblem(w, newProblem(http.StatusMethodNotAllowed, problemMethodNotAllowed, "use GET"))
+		return
+	}
+
+	id := strings.TrimPrefix(r.URL.Path, "/jobs/")
+	s.mu.Lock()
+	s.evictJobs()
+	j, ok := s.jobs[id]
+	var snapshot job
+	if ok {
+		snapshot = *j
+	}
+	s.mu.Unlock()
+
+	if !ok {
+		writeProblem(w, newProblem(http.StatusNotFound, problemNotFound, fmt.Sprintf("unknown job %q", id)))
+		return
+	}
+	writeJSON(w, http.StatusOK, snapshot)
+}
+
+// parseValidationRequest reads the multipart form of a /validate request of at most
+// maxBytes bytes
+func parseValidationRequest(w http.ResponseWriter, r *http.Request, maxBytes int64) (*validationRequest, error) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxBytes)
+	if err := r.ParseMultipartForm(32 << 20); err != nil {
+		return nil, fmt.Errorf("invalid multipart form: %w", err)
+	}
+
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		return nil, fmt.Errorf("missing file: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+	content, err := io.ReadAll(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+
+	req := &validationRequest{
+		fileName:    filepath.Base(header.Filename),
+		content:     content,
+		codespace:   r.FormValue("codespace"),
+		callbackURL: r.FormValue("callback_url"),
+	}
+	if req.codespace == "" {
+		return nil, errMissingCodespace
+	}
+	if v := r.FormValue("skip_schema"); v != "" {
+		if req.skipSchema, err = strconv.ParseBool(v); err != nil {
+			return nil, fmt.Errorf("invalid skip_schema: %w", err)
+		}
+	}
+	if req.callbackURL != "" {
+		u, err := url.Parse(req.callbackURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return nil, fmt.Errorf("invalid callback_url: %s", req.callbackURL)
+		}
+	}
+	return req, nil
+}
+
+// validateUpload validates an uploaded XML file or ZIP dataset and returns the JSON report
+func validateUpload(req *validationRequest) ([]byte, error) {
+	options := validator.DefaultValidationOptions().
+		WithCodespace(req.codespace).
+		WithSkipSchema(req.skipSchema)
+	v, err := validator.NewWithOptions(options)
+	if err != nil {
+		return nil, err
+	}
+
+	var result *validator.ValidationResult
+	if strings.ToLower(filepath.Ext(req.fileName)) == ".zip" {
+		// ZIP datasets are validated from disk
+		tmp, err := os.CreateTemp("", "netex-upload-*.zip")
+		if err != nil {
+			return nil, fmt.Errorf("failed to store upload: %w", err)
+		}
+		defer func() { _ = os.Remove(tmp.Name()) }()
+		_, err = tmp.Write(req.content)
+		if closeErr := tmp.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to store upload: %w", err)
+		}
+		result, err = v.ValidateZip(tmp.Name())
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		result, err = v.ValidateContent(req.content, req.fileName)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return result.ToJSON()
+}
+
+// acquireSlot takes a validation slot, reporting false when all are in use
+func (s *server) acquireSlot() bool {
+	select {
+	case s.slots <- struct{}{}:
+		return true
+	default:
+		return false
+	}
+}
+
+// releaseSlot returns a slot taken by acquireSlot
+func (s *server) releaseSlot() {
+	<-s.slots
+}
+
+// checkCallbackHost rejects a callback URL whose host resolves to an address
+// callbacks may not reach. The address is checked again when connecting.
+func (s *server) checkCallbackHost(ctx context.Context, callbackURL string) error {
+	if s.allowPrivateCallbacks {
+		return nil
+	}
+	u, err := url.Parse(callbackURL)
+	if err != nil {
+		return fmt.Errorf("invalid callback_url: %s", callbackURL)
+	}
+	addrs, err := net.DefaultResolver.LookupIPAddr(ctx, u.Hostname())
+	if err != nil {
+		return fmt.Errorf("failed to resolve callback_url host %s: %w", u.Hostname(), err)
+	}
+	for _, addr := range addrs {
+		if !publicAddress(addr.IP) {
+			return errPrivateCallback
+		}
+	}
+	return nil
+}
+
+// checkCallbackDial is the net.Dialer Control of callback connections, refusing
+// addresses callbacks may not reach
+func (s *server) checkCallbackDial(_, address string, _ syscall.RawConn) error {
+	if s.allowPrivateCallbacks {
+		return nil
+	}
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	if ip := net.ParseIP(host); ip == nil || !publicAddress(ip) {
+		return errPrivateCallback
+	}
+	return nil
+}
+
+// publicAddress reports whether ip is neither loopback, private, link-local,
+// multicast nor unspecified
+func publicAddress(ip net.IP) bool {
+	return !ip.IsLoopback() && !ip.IsPrivate() && !ip.IsLinkLocalUnicast() &&
+		!ip.IsLinkLocalMulticast() && !ip.IsInterfaceLocalMulticast() &&
+		!ip.IsMulticast() && !ip.IsUnspecified()
+}
+
+// evictJobs removes jobs that finished more than the TTL ago. The caller holds s.mu.
+func (s *server) evictJobs() {
+	cutoff := s.now().Add(-s.jobTTL)
+	for id, j := range s.jobs {
+		if !j.finished.IsZero() && j.finished.Before(cutoff) {
+			delete(s.jobs, id)
+		}
+	}
+}
+
+// startJob registers a job and validates it in the background, holding a
+// validation slot taken by the caller until the validation ends
+func (s *server) startJob(req *validationRequest) (*job, error) {
+	id, err := newJobID()
+	if err != nil {
+		return nil, err
+	}
+	j := &job{ID: id, Status: jobPending, CallbackURL: req.callbackURL}
+
+	s.mu.Lock()
+	s.evictJobs()
+	s.jobs[id] = j
+	s.mu.Unlock()
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		s.runJob(j, req)
+	}()
+	return j, nil
+}
+
+// runJob validates the upload, records the outcome and notifies the callback URL
+func (s *server) runJob(j *job, req *validationRequest) {
+	report, err := validateUpload(req)
+	s.releaseSlot()
+
+	s.mu.Lock()
+	j.finished = s.now()
+	if err != nil {
+		j.Status = jobFailed
+		j.Error = err.Error()
+	} else {
+		j.Status = jobCompleted
+		j.Result = report
+	}
+	payload, marshalErr := json.Marshal(j)
+	s.mu.Unlock()
+
+	callbackErr := marshalErr
+	if callbackErr == nil {
+		callbackErr = s.postCallback(j.CallbackURL, j.ID, payload)
+	}
+	if callbackErr != nil {
+		s.mu.Lock()
+		j.CallbackError = callbackErr.Error()
+		s.mu.Unlock()
+	}
+}
+
+// postCallback POSTs a finished job to its callback URL
+func (s *server) postCallback(callbackURL, jobID string, payload []byte) error {
+	ctx, cancel := context.WithTimeout(context.Background(), callbackTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, callbackURL, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to create callback request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Job-Id", jobID)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("callback failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("callback returned HTTP %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// newJobID returns a random job identifier
+func newJobID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate job id: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,354 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime/multipart"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/theoremus-urban-solutions/netex-validator/validator"
+)
+
+// newValidateRequest builds a multipart /validate request for a test data file
+func newValidateRequest(t *testing.T, path string, fields map[string]string) *http.Request {
+	t.Helper()
+
+	content, err := os.ReadFile(path) //nolint:gosec // test data path
+	if err != nil {
+		t.Fatalf("failed to read %s: %v", path, err)
+	}
+	return newUploadRequest(t, "data.xml", content, fields)
+}
+
+// newUploadRequest builds a multipart /validate request uploading content as fileName
+func newUploadRequest(t *testing.T, fileName string, content []byte, fields map[string]string) *http.Request {
+	t.Helper()
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	part, err := writer.CreateFormFile("file", fileName)
+	if err != nil {
+		t.Fatalf("CreateFormFile() error = %v", err)
+	}
+	if _, err := part.Write(content); err != nil {
+		t.Fatalf("failed to write form file: %v", err)
+	}
+	for name, value := range fields {
+		if err := writer.WriteField(name, value); err != nil {
+			t.Fatalf("WriteField() error = %v", err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("failed to close multipart writer: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/validate", &body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	return req
+}
+
+func TestServe_ValidateWithCallback(t *testing.T) {
+	type delivery struct {
+		jobID string
+		body  job
+	}
+	deliveries := make(chan delivery, 1)
+	callback := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		var j job
+		if err := json.Unmarshal(data, &j); err != nil {
+			t.Errorf("callback body is not a job: %v", err)
+		}
+		deliveries <- delivery{jobID: r.Header.Get("X-Job-Id"), body: j}
+	}))
+	defer callback.Close()
+
+	s := newServer(2)
+	s.allowPrivateCallbacks = true // the test callback listens on loopback
+	handler := s.routes()
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, newValidateRequest(t, "../../testdata/valid_minimal.xml", map[string]string{
+		"codespace":    "TEST",
+		"skip_schema":  "true",
+		"callback_url": callback.URL,
+	}))
+	if rec.Code != http.StatusAccepted {
+		t.Fatalf("Expected 202 Accepted, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var accepted map[string]string
+	if err := json.Unmarshal(rec.Body.Bytes(), &accepted); err != nil {
+		t.Fatalf("invalid response: %v", err)
+	}
+	jobID := accepted["jobId"]
+	if jobID == "" || accepted["status"] != jobPending {
+		t.Fatalf("Expected a pending job id, got %v", accepted)
+	}
+
+	select {
+	case d := <-deliveries:
+		if d.jobID != jobID || d.body.ID != jobID {
+			t.Errorf("Expected callback for job %s, got header %s body %s", jobID, d.jobID, d.body.ID)
+		}
+		if d.body.Status != jobCompleted || len(d.body.Result) == 0 {
+			t.Errorf("Expected completed job with a result, got %+v", d.body)
+		}
+	case <-time.After(30 * time.Second):
+		t.Fatal("Timed out waiting for the callback")
+	}
+	s.wg.Wait()
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/jobs/"+jobID, nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200 for job status, got %d", rec.Code)
+	}
+	var status job
+	if err := json.Unmarshal(rec.Body.Bytes(), &status); err != nil {
+		t.Fatalf("invalid job status: %v", err)
+	}
+	if status.Status != jobCompleted || status.CallbackError != "" {
+		t.Errorf("Expected completed job with delivered callback, got %+v", status)
+	}
+}
+
+func TestServe_CallbackFailureIsRecorded(t *testing.T) {
+	callback := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer callback.Close()
+
+	s := newServer(2)
+	s.allowPrivateCallbacks = true // the test callback listens on loopback
+	handler := s.routes()
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, newValidateRequest(t, "../../testdata/empty.xml", map[string]string{
+		"codespace":    "TEST",
+		"skip_schema":  "true",
+		"callback_url": callback.URL,
+	}))
+	if rec.Code != http.StatusAccepted {
+		t.Fatalf("Expected 202 Accepted, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var accepted map[string]string
+	_ = json.Unmarshal(rec.Body.Bytes(), &accepted)
+	s.wg.Wait()
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/jobs/"+accepted["jobId"], nil))
+	var status job
+	if err := json.Unmarshal(rec.Body.Bytes(), &status); err != nil {
+		t.Fatalf("invalid job status: %v", err)
+	}
+	if status.Status != jobCompleted || status.CallbackError == "" {
+		t.Errorf("Expected completed job with a recorded callback error, got %+v", status)
+	}
+}
+
+func TestServe_ValidateSynchronously(t *testing.T) {
+	rec := httptest.NewRecorder()
+	newServer(2).routes().ServeHTTP(rec, newValidateRequest(t, "../../testdata/empty.xml", map[string]string{
+		"codespace":   "TEST",
+		"skip_schema": "true",
+	}))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200 OK, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var report map[string]interface{}
+	if err := json.Unmarshal(rec.Body.Bytes(), &report); err != nil {
+		t.Fatalf("Expected a JSON report, got %v", err)
+	}
+}
+
+func TestServe_RequestErrors(t *testing.T) {
+	s := newServer(2)
+	handler := s.routes()
+	limited := newServer(2)
+	limited.maxUpload = 256
+	busy := newServer(1)
+	busy.slots <- struct{}{}
+
+	tests := []struct {
+		name    string
+		handler http.Handler
+		req     *http.Request
+		want    int
+		code    string
+	}{
+		{"missing codespace", handler, newValidateRequest(t, "../../testdata/empty.xml", nil),
+			http.StatusBadRequest, "BAD_CODESPACE"},
+		{"invalid callback url", handler, newValidateRequest(t, "../../testdata/empty.xml", map[string]string{
+			"codespace": "TEST", "callback_url": "ftp://example.com/hook",
+		}), http.StatusBadRequest, "BAD_REQUEST"},
+		{"loopback callback url", handler, newValidateRequest(t, "../../testdata/empty.xml", map[string]string{
+			"codespace": "TEST", "callback_url": "http://127.0.0.1:8080/hook",
+		}), http.StatusBadRequest, "BAD_REQUEST"},
+		{"all slots in use", busy.routes(), newValidateRequest(t, "../../testdata/empty.xml", map[string]string{
+			"codespace": "TEST",
+		}), http.StatusServiceUnavailable, "SERVER_BUSY"},
+		{"upload too large", limited.routes(), newUploadRequest(t, "data.xml", bytes.Repeat([]byte("x"), 1024), map[string]string{
+			"codespace": "TEST",
+		}), http.StatusRequestEntityTooLarge, "UPLOAD_TOO_LARGE"},
+		{"not a zip archive", handler, newUploadRequest(t, "data.zip", []byte("<PublicationDelivery/>"), map[string]string{
+			"codespace": "TEST", "skip_schema": "true",
+		}), http.StatusUnprocessableEntity, "PARSE_ERROR"},
+		{"unknown job", handler, httptest.NewRequest(http.MethodGet, "/jobs/unknown", nil), http.StatusNotFound, "NOT_FOUND"},
+		{"wrong method", handler, httptest.NewRequest(http.MethodGet, "/validate", nil), http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			tt.handler.ServeHTTP(rec, tt.req)
+			if rec.Code != tt.want {
+				t.Errorf("Expected %d, got %d: %s", tt.want, rec.Code, rec.Body.String())
+			}
+			if contentType := rec.Header().Get("Content-Type"); contentType != "application/problem+json" {
+				t.Errorf("Expected application/problem+json, got %s", contentType)
+			}
+			var p problem
+			if err := json.Unmarshal(rec.Body.Bytes(), &p); err != nil {
+				t.Fatalf("Expected problem details, got %q: %v", rec.Body.String(), err)
+			}
+			if p.Type != problemTypePrefix+tt.code || p.Status != tt.want || p.Title == "" || p.Detail == "" {
+				t.Errorf("Expected a %s problem with status %d, title and detail, got %+v", tt.code, tt.want, p)
+			}
+		})
+	}
+}
+
+func TestServe_BusyResponseCarriesRetryAfter(t *testing.T) {
+	s := newServer(1)
+	s.slots <- struct{}{}
+
+	rec := httptest.NewRecorder()
+	s.routes().ServeHTTP(rec, newValidateRequest(t, "../../testdata/empty.xml", map[string]string{"codespace": "TEST"}))
+	if rec.Code != http.StatusServiceUnavailable || rec.Header().Get("Retry-After") == "" {
+		t.Errorf("Expected 503 with Retry-After, got %d with %q", rec.Code, rec.Header().Get("Retry-After"))
+	}
+
+	<-s.slots
+	rec = httptest.NewRecorder()
+	s.routes().ServeHTTP(rec, newValidateRequest(t, "../../testdata/empty.xml", map[string]string{"codespace": "TEST", "skip_schema": "true"}))
+	if rec.Code != http.StatusOK {
+		t.Errorf("Expected the freed slot to be used, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if len(s.slots) != 0 {
+		t.Errorf("Expected the slot to be released after validating, %d in use", len(s.slots))
+	}
+}
+
+// countingReader counts the bytes read from the wrapped reader
+type countingReader struct {
+	r io.Reader
+	n int
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += n
+	return n, err
+}
+
+func TestServe_SlotTakenBeforeUpload(t *testing.T) {
+	s := newServer(1)
+	s.slots <- struct{}{}
+
+	req := newValidateRequest(t, "../../testdata/empty.xml", map[string]string{"codespace": "TEST"})
+	body := &countingReader{r: req.Body}
+	req.Body = io.NopCloser(body)
+	rec := httptest.NewRecorder()
+	s.routes().ServeHTTP(rec, req)
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("Expected 503, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if body.n != 0 {
+		t.Errorf("Expected a busy server not to read the upload, read %d bytes", body.n)
+	}
+
+	// Rejected requests give their slot back
+	<-s.slots
+	rec = httptest.NewRecorder()
+	s.routes().ServeHTTP(rec, newValidateRequest(t, "../../testdata/empty.xml", nil))
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("Expected 400, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if len(s.slots) != 0 {
+		t.Errorf("Expected the slot of a rejected request to be released, %d in use", len(s.slots))
+	}
+}
+
+func TestServe_FinishedJobsExpire(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	s := newServer(1)
+	s.now = func() time.Time { return now }
+	s.jobs["old"] = &job{ID: "old", Status: jobCompleted, finished: now.Add(-2 * defaultJobTTL)}
+	s.jobs["recent"] = &job{ID: "recent", Status: jobCompleted, finished: now.Add(-time.Minute)}
+	s.jobs["pending"] = &job{ID: "pending", Status: jobPending}
+
+	handler := s.routes()
+	for id, want := range map[string]int{"old": http.StatusNotFound, "recent": http.StatusOK, "pending": http.StatusOK} {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/jobs/"+id, nil))
+		if rec.Code != want {
+			t.Errorf("Expected %d for job %s, got %d", want, id, rec.Code)
+		}
+	}
+}
+
+func TestPublicAddress(t *testing.T) {
+	for _, tt := range []struct {
+		ip   string
+		want bool
+	}{
+		{"93.184.216.34", true},
+		{"2606:2800:220:1::1", true},
+		{"127.0.0.1", false},
+		{"::1", false},
+		{"10.1.2.3", false},
+		{"172.16.0.1", false},
+		{"192.168.1.1", false},
+		{"169.254.169.254", false},
+		{"fe80::1", false},
+		{"fd00::1", false},
+		{"0.0.0.0", false},
+	} {
+		if got := publicAddress(net.ParseIP(tt.ip)); got != tt.want {
+			t.Errorf("publicAddress(%s) = %v, want %v", tt.ip, got, tt.want)
+		}
+	}
+
+	if err := newServer(1).checkCallbackDial("tcp", "169.254.169.254:80", nil); !errors.Is(err, errPrivateCallback) {
+		t.Errorf("Expected a dial to a link-local address to be refused, got %v", err)
+	}
+}
+
+func TestValidationProblem(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		status int
+		code   string
+	}{
+		{"parse error", &validator.ResultError{Code: validator.ErrorCodeParseError, Message: "not a ZIP archive"},
+			http.StatusUnprocessableEntity, "PARSE_ERROR"},
+		{"validation error", &validator.ResultError{Code: validator.ErrorCodeValidationError, Message: "worker panic"},
+			http.StatusInternalServerError, "VALIDATION_ERROR"},
+		{"internal error", errors.New("failed to store upload"), http.StatusInternalServerError, "INTERNAL_ERROR"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := validationProblem(tt.err)
+			if p.Status != tt.status || p.Type != problemTypePrefix+tt.code || p.Detail != tt.err.Error() {
+				t.Errorf("Expected a %s problem with status %d, got %+v", tt.code, tt.status, p)
+			}
+		})
+	}
+}
@@ -0,0 +1,230 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/theoremus-urban-solutions/netex-validator/types"
+	"github.com/theoremus-urban-solutions/netex-validator/validator"
+)
+
+// sinkTimeout bounds the POST of a result to an HTTP sink
+const sinkTimeout = 30 * time.Second
+
+// sinkFormats are the formats a sink can write; sqlite needs a file destination
+var sinkFormats = []string{"json", "html", "sqlite", "summary"}
+
+// Sink receives the result of a validation run in its own format
+type Sink interface {
+	Write(result *validator.ValidationResult) error
+}
+
+// stdoutSink prints the result
+type stdoutSink struct {
+	format string
+	out    io.Writer
+}
+
+func (s *stdoutSink) Write(result *validator.ValidationResult) error {
+	if s.format == "json" && result.SpilledEntryCount() > 0 {
+		return result.WriteFlatJSON(s.out)
+	}
+	output, _, err := renderResult(result, s.format)
+	if err != nil {
+		return err
+	}
+	_, err = s.out.Write(output)
+	return err
+}
+
+// fileSink writes the result to a file, replacing its content
+type fileSink struct {
+	format string
+	path   string
+}
+
+func (s *fileSink) Write(result *validator.ValidationResult) error {
+	if s.format == "sqlite" {
+		return result.ToSQLite(s.path)
+	}
+	if s.format == "json" && result.SpilledEntryCount() > 0 {
+		return writeFlatJSONFile(result, s.path)
+	}
+	output, _, err := renderResult(result, s.format)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(s.path, output, 0o600)
+}
+
+// writeFlatJSONFile streams the findings of a spilled result to a file
+func writeFlatJSONFile(result *validator.ValidationResult, path string) error {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600) //nolint:gosec // Path is provided by the user on the command line
+	if err != nil {
+		return err
+	}
+	if err := result.WriteFlatJSON(f); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// httpSink POSTs the result to an http(s) endpoint, such as a dashboard
+type httpSink struct {
+	format string
+	url    string
+	client *http.Client
+}
+
+func (s *httpSink) Write(result *validator.ValidationResult) error {
+	output, contentType, err := renderResult(result, s.format)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), sinkTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(output))
+	if err != nil {
+		return fmt.Errorf("failed to create request for %s: %w", s.url, err)
+	}
+	req.Header.Set("Content-Type", contentType)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post result to %s: %w", s.url, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("%s returned HTTP %d", s.url, resp.StatusCode)
+	}
+	return nil
+}
+
+// parseSink parses a --sink value of comma-separated key=value pairs:
+//
+//	format=json,dest=stdout
+//	format=html,dest=file:report.html
+//	format=json,dest=https://dashboard.example.org/results
+//
+// format defaults to json. Results printed to stdout are written to out.
+func parseSink(spec string, out io.Writer) (Sink, error) {
+	format, dest := "json", ""
+	for _, pair := range strings.Split(spec, ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid sink %q: expected key=value pairs", spec)
+		}
+		switch key {
+		case "format":
+			format = value
+		case "dest":
+			dest = value
+		default:
+			return nil, fmt.Errorf("invalid sink %q: unknown key %s (supported: format, dest)", spec, key)
+		}
+	}
+	if !isSinkFormat(format) {
+		return nil, fmt.Errorf("invalid sink %q: unsupported format %s (supported: %s)", spec, format, strings.Join(sinkFormats, ", "))
+	}
+
+	switch {
+	case dest == "stdout":
+		if format == "sqlite" {
+			return nil, fmt.Errorf("invalid sink %q: sqlite requires a file destination", spec)
+		}
+		return &stdoutSink{format: format, out: out}, nil
+	case strings.HasPrefix(dest, "file:") && len(dest) > len("file:"):
+		return &fileSink{format: format, path: strings.TrimPrefix(dest, "file:")}, nil
+	case strings.HasPrefix(dest, "http://") || strings.HasPrefix(dest, "https://"):
+		if format == "sqlite" {
+			return nil, fmt.Errorf("invalid sink %q: sqlite requires a file destination", spec)
+		}
+		if u, err := url.Parse(dest); err != nil || u.Host == "" {
+			return nil, fmt.Errorf("invalid sink %q: invalid URL %s", spec, dest)
+		}
+		return &httpSink{format: format, url: dest, client: &http.Client{Timeout: sinkTimeout}}, nil
+	default:
+		return nil, fmt.Errorf("invalid sink %q: dest must be stdout, file:<path> or an http(s) URL", spec)
+	}
+}
+
+func isSinkFormat(format string) bool {
+	for _, supported := range sinkFormats {
+		if format == supported {
+			return true
+		}
+	}
+	return false
+}
+
+// renderResult serializes a result in one of the sink formats other than sqlite
+// and returns it with its content type
+func renderResult(result *validator.ValidationResult, format string) ([]byte, string, error) {
+	switch format {
+	case "json":
+		// Spilled results are written flat, as grouping needs every finding in memory
+		if result.SpilledEntryCount() > 0 {
+			output, err := result.ToFlatJSON()
+			return output, "application/json", err
+		}
+		output, err := result.ToJSON()
+		return output, "application/json", err
+	case "html":
+		output, err := result.ToHTML()
+		return output, "text/html; charset=utf-8", err
+	case "summary":
+		return []byte(resultSummary(result) + "\n"), "text/plain; charset=utf-8", nil
+	default:
+		return nil, "", fmt.Errorf("unsupported output format: %s (supported: json, html, summary)", format)
+	}
+}
+
+// resultLinePrefix starts the result line written to stderr at the end of every
+// run. Log-based monitoring greps for it, so it and the keys after it must not change.
+const resultLinePrefix = "NETEX_RESULT"
+
+// resultLine describes a result as space-separated key=value pairs for log scanning,
+// e.g. "NETEX_RESULT status=fail critical=0 error=12 warning=340 info=5 files=88".
+// The status is pass, fail when ERROR or CRITICAL findings were made, or error when
+// the input could not be validated.
+func resultLine(result *validator.ValidationResult) string {
+	summary := result.Summary()
+	status := "pass"
+	switch {
+	case result.Error != nil:
+		status = "error"
+	case !result.IsValid():
+		status = "fail"
+	}
+	return fmt.Sprintf("%s status=%s critical=%d error=%d warning=%d info=%d files=%d",
+		resultLinePrefix, status,
+		summary.IssuesBySeverity[types.CRITICAL],
+		summary.IssuesBySeverity[types.ERROR],
+		summary.IssuesBySeverity[types.WARNING],
+		summary.IssuesBySeverity[types.INFO],
+		summary.FilesProcessed)
+}
+
+// resultSummary describes a result in one line
+func resultSummary(result *validator.ValidationResult) string {
+	if result.Error != nil {
+		return fmt.Sprintf("validation failed: %v", result.Error)
+	}
+	summary := result.Summary()
+	return fmt.Sprintf("%d issues (%d errors, %d warnings) in %d files",
+		summary.TotalIssues,
+		summary.IssuesBySeverity[types.ERROR]+summary.IssuesBySeverity[types.CRITICAL],
+		summary.IssuesBySeverity[types.WARNING],
+		summary.FilesProcessed)
+}
@@ -0,0 +1,226 @@
+package main
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/theoremus-urban-solutions/netex-validator/types"
+	"github.com/theoremus-urban-solutions/netex-validator/validator"
+)
+
+func TestRun_MultipleSinks(t *testing.T) {
+	var posted []byte
+	var contentType string
+	dashboard := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		contentType = r.Header.Get("Content-Type")
+		posted, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	t.Cleanup(dashboard.Close)
+
+	summaryPath := filepath.Join(t.TempDir(), "summary.txt")
+	code := run([]string{"-i", "../../testdata/valid_minimal.xml", "-c", "TEST", "--skip-schema",
+		"--sink", "format=summary,dest=file:" + summaryPath,
+		"--sink", "format=json,dest=" + dashboard.URL})
+	if code != exitValidationFailed {
+		t.Fatalf("Expected exit code %d, got %d", exitValidationFailed, code)
+	}
+
+	summary, err := os.ReadFile(summaryPath)
+	if err != nil {
+		t.Fatalf("Expected the summary sink to write its file: %v", err)
+	}
+	if !strings.Contains(string(summary), "issues (") || !strings.Contains(string(summary), "in 1 files") {
+		t.Errorf("Expected a one-line summary, got %q", summary)
+	}
+
+	var result map[string]interface{}
+	if err := json.Unmarshal(posted, &result); err != nil {
+		t.Fatalf("Expected the HTTP sink to post JSON, got %q: %v", posted, err)
+	}
+	if contentType != "application/json" || result["codespace"] != "TEST" {
+		t.Errorf("Expected the JSON result with content type application/json, got %s and %v", contentType, result["codespace"])
+	}
+}
+
+func TestParseSink(t *testing.T) {
+	var out bytes.Buffer
+	tests := []struct {
+		spec    string
+		want    Sink
+		wantErr string
+	}{
+		{spec: "dest=stdout", want: &stdoutSink{format: "json", out: &out}},
+		{spec: "format=html,dest=file:report.html", want: &fileSink{format: "html", path: "report.html"}},
+		{spec: "format=sqlite,dest=file:reports.db", want: &fileSink{format: "sqlite", path: "reports.db"}},
+		{spec: "format=summary, dest=https://dashboard.example.org/results"},
+		{spec: "format=xml,dest=stdout", wantErr: "unsupported format"},
+		{spec: "format=sqlite,dest=stdout", wantErr: "requires a file"},
+		{spec: "format=json", wantErr: "dest must be"},
+		{spec: "format=json,dest=file:", wantErr: "dest must be"},
+		{spec: "json", wantErr: "key=value"},
+		{spec: "format=json,target=stdout", wantErr: "unknown key"},
+	}
+
+	for _, tt := range tests {
+		sink, err := parseSink(tt.spec, &out)
+		if tt.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("parseSink(%q) error = %v, want %q", tt.spec, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parseSink(%q) error = %v", tt.spec, err)
+			continue
+		}
+		switch want := tt.want.(type) {
+		case *stdoutSink:
+			if got, ok := sink.(*stdoutSink); !ok || got.format != want.format || got.out != want.out {
+				t.Errorf("parseSink(%q) = %+v, want %+v", tt.spec, sink, want)
+			}
+		case *fileSink:
+			if got, ok := sink.(*fileSink); !ok || *got != *want {
+				t.Errorf("parseSink(%q) = %+v, want %+v", tt.spec, sink, want)
+			}
+		default:
+			if got, ok := sink.(*httpSink); !ok || got.format != "summary" || got.url != "https://dashboard.example.org/results" {
+				t.Errorf("parseSink(%q) = %+v, want an HTTP summary sink", tt.spec, sink)
+			}
+		}
+	}
+}
+
+func TestRun_SpillDir(t *testing.T) {
+	content, err := os.ReadFile("../../testdata/invalid_missing_elements.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	zipPath := filepath.Join(dir, "dataset.zip")
+	f, err := os.Create(zipPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	zw := zip.NewWriter(f)
+	for _, name := range []string{"a.xml", "b.xml"} {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write(content); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	spillDir := t.TempDir()
+	outPath := filepath.Join(dir, "result.json")
+	code := run([]string{"-i", zipPath, "-c", "TEST", "--skip-schema", "-o", outPath,
+		"--spill-dir", spillDir, "--spill-threshold", "1"})
+	if code != exitValidationFailed {
+		t.Fatalf("Expected exit code %d, got %d", exitValidationFailed, code)
+	}
+
+	data, err := os.ReadFile(outPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var result struct {
+		ValidationReportEntries          []map[string]interface{} `json:"validationReportEntries"`
+		NumberOfValidationEntriesPerRule map[string]int           `json:"numberOfValidationEntriesPerRule"`
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		t.Fatalf("Expected flat JSON, got %v", err)
+	}
+	total := 0
+	for _, count := range result.NumberOfValidationEntriesPerRule {
+		total += count
+	}
+	if total < 2 || len(result.ValidationReportEntries) != total {
+		t.Errorf("Expected all %d findings in the output, got %d", total, len(result.ValidationReportEntries))
+	}
+	if left, _ := os.ReadDir(spillDir); len(left) != 0 {
+		t.Errorf("Expected the spill files to be removed, found %d", len(left))
+	}
+}
+
+func TestResultLine(t *testing.T) {
+	entries := func(severity types.Severity, n int) []validator.ValidationReportEntry {
+		found := make([]validator.ValidationReportEntry, n)
+		for i := range found {
+			found[i].Severity = severity
+		}
+		return found
+	}
+	var findings []validator.ValidationReportEntry
+	findings = append(findings, entries(types.ERROR, 12)...)
+	findings = append(findings, entries(types.WARNING, 340)...)
+	findings = append(findings, entries(types.INFO, 5)...)
+
+	tests := []struct {
+		name   string
+		result *validator.ValidationResult
+		want   string
+	}{
+		{
+			name:   "findings",
+			result: &validator.ValidationResult{ValidationReportEntries: findings, FilesProcessed: 88},
+			want:   "NETEX_RESULT status=fail critical=0 error=12 warning=340 info=5 files=88",
+		},
+		{
+			name:   "clean",
+			result: &validator.ValidationResult{ValidationReportEntries: entries(types.WARNING, 1), FilesProcessed: 1},
+			want:   "NETEX_RESULT status=pass critical=0 error=0 warning=1 info=0 files=1",
+		},
+		{
+			name:   "unreadable input",
+			result: &validator.ValidationResult{Error: &validator.ResultError{Code: validator.ErrorCodeReadError, Message: "unreadable"}},
+			want:   "NETEX_RESULT status=error critical=0 error=0 warning=0 info=0 files=0",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := resultLine(tt.result); got != tt.want {
+				t.Errorf("resultLine() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRun_WritesResultLine(t *testing.T) {
+	var stderr bytes.Buffer
+	rootCmd := newRootCommand()
+	rootCmd.SetErr(&stderr)
+	rootCmd.SetArgs([]string{"-i", "../../testdata/valid_minimal.xml", "-c", "TEST", "--skip-schema",
+		"-o", filepath.Join(t.TempDir(), "report.html"), "--format", "html"})
+	if code := exitCode(rootCmd.Execute()); code != exitValidationFailed {
+		t.Fatalf("Expected exit code %d, got %d", exitValidationFailed, code)
+	}
+
+	var lines []string
+	for _, line := range strings.Split(stderr.String(), "\n") {
+		if strings.HasPrefix(line, resultLinePrefix+" ") {
+			lines = append(lines, line)
+		}
+	}
+	if len(lines) != 1 {
+		t.Fatalf("Expected one result line on stderr, got %q", stderr.String())
+	}
+	if !strings.HasPrefix(lines[0], "NETEX_RESULT status=fail critical=") || !strings.HasSuffix(lines[0], " files=1") {
+		t.Errorf("Unexpected result line %q", lines[0])
+	}
+}
@@ -0,0 +1,282 @@
+//go:build tui
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"github.com/spf13/cobra"
+	"github.com/theoremus-urban-solutions/netex-validator/types"
+	"github.com/theoremus-urban-solutions/netex-validator/validator"
+)
+
+const tuiHelp = "[yellow]Enter[-] open/jump  [yellow]/[-] filter  [yellow]g[-] group by  [yellow]q[-] quit"
+
+func init() {
+	optionalCommands = append(optionalCommands, newTUICommand)
+}
+
+// newTUICommand builds the tui subcommand
+func newTUICommand() *cobra.Command {
+	var (
+		input      string
+		codespace  string
+		skipSchema bool
+		configFile string
+	)
+
+	tuiCmd := &cobra.Command{
+		Use:   "tui",
+		Short: "Browse validation findings in a terminal UI",
+		Long: `Validate a NetEX file or ZIP dataset and browse the findings in a terminal UI.
+
+Findings are grouped by severity, rule or file; press g to switch grouping and /
+to filter on rule, message, file or element id. Enter on a finding of an XML
+file opens it at the finding's line in $VISUAL or $EDITOR.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+			if _, err := os.Stat(input); os.IsNotExist(err) {
+				return inputError(fmt.Errorf("input file does not exist: %s", input))
+			}
+
+			// Explain mode keeps the matched XML for the details pane
+			options := validator.DefaultValidationOptions().
+				WithCodespace(codespace).
+				WithSkipSchema(skipSchema).
+				WithConfigFile(configFile).
+				WithExplain(true)
+			v, err := validator.NewWithOptions(options)
+			if err != nil {
+				return configError(err)
+			}
+
+			var result *validator.ValidationResult
+			if strings.ToLower(filepath.Ext(input)) == ".zip" {
+				result, err = v.ValidateZip(input)
+			} else {
+				result, err = v.ValidateFile(input)
+			}
+			if err != nil {
+				return inputError(fmt.Errorf("validation failed: %w", err))
+			}
+			if result.Error != nil {
+				return inputError(fmt.Errorf("validation failed: %w", result.Error))
+			}
+
+			if err := newFindingsBrowser(input, result).run(); err != nil {
+				return inputError(fmt.Errorf("terminal UI failed: %w", err))
+			}
+			return nil
+		},
+	}
+
+	tuiCmd.Flags().StringVarP(&input, "input", "i", "", "Input file path (XML or ZIP)")
+	tuiCmd.Flags().StringVarP(&codespace, "codespace", "c", "", "NetEX codespace")
+	tuiCmd.Flags().BoolVarP(&skipSchema, "skip-schema", "s", false, "Skip XML schema validation")
+	tuiCmd.Flags().StringVar(&configFile, "config", "", "Configuration file path (YAML)")
+	_ = tuiCmd.MarkFlagRequired("input")
+	_ = tuiCmd.MarkFlagRequired("codespace")
+	return tuiCmd
+}
+
+// findingsBrowser is the terminal UI of the tui command
+type findingsBrowser struct {
+	input   string
+	entries []validator.ValidationReportEntry
+	mode    groupMode
+
+	app     *tview.Application
+	tree    *tview.TreeView
+	details *tview.TextView
+	filter  *tview.InputField
+	status  *tview.TextView
+}
+
+func newFindingsBrowser(input string, result *validator.ValidationResult) *findingsBrowser {
+	b := &findingsBrowser{
+		input:   input,
+		entries: result.ValidationReportEntries,
+		app:     tview.NewApplication(),
+		tree:    tview.NewTreeView(),
+		details: tview.NewTextView(),
+		filter:  tview.NewInputField(),
+		status:  tview.NewTextView(),
+	}
+
+	b.tree.SetBorder(true)
+	b.tree.SetChangedFunc(b.showDetails)
+	b.tree.SetSelectedFunc(b.selectNode)
+	b.tree.SetInputCapture(b.handleKey)
+
+	b.details.SetDynamicColors(true).SetWrap(true).SetBorder(true).SetTitle(" Details ")
+
+	b.filter.SetLabel("Filter: ").
+		SetChangedFunc(func(string) { b.rebuild() }).
+		SetDoneFunc(func(tcell.Key) { b.app.SetFocus(b.tree) })
+
+	b.status.SetDynamicColors(true)
+	b.setStatus("")
+
+	b.rebuild()
+	return b
+}
+
+// run shows the UI until the user quits
+func (b *findingsBrowser) run() error {
+	panes := tview.NewFlex().
+		AddItem(b.tree, 0, 1, true).
+		AddItem(b.details, 0, 1, false)
+	root := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(panes, 0, 1, true).
+		AddItem(b.filter, 1, 0, false).
+		AddItem(b.status, 1, 0, false)
+	return b.app.SetRoot(root, true).SetFocus(b.tree).Run()
+}
+
+// rebuild regroups the findings matching the current filter
+func (b *findingsBrowser) rebuild() {
+	filter := strings.TrimSpace(b.filter.GetText())
+	groups := groupFindings(b.entries, b.mode, filter)
+
+	shown := 0
+	root := tview.NewTreeNode(filepath.Base(b.input))
+	for i := range groups {
+		group := &groups[i]
+		shown += len(group.entries)
+		// Filtered results are small enough to show expanded
+		node := tview.NewTreeNode(fmt.Sprintf("%s (%d)", group.title, len(group.entries))).
+			SetReference(group).
+			SetColor(severityColor(group.entries[0].Severity, b.mode)).
+			SetExpanded(filter != "" || i == 0)
+		for j := range group.entries {
+			entry := &group.entries[j]
+			node.AddChild(tview.NewTreeNode(b.entryLabel(entry)).SetReference(entry))
+		}
+		root.AddChild(node)
+	}
+	if len(groups) == 0 {
+		root.AddChild(tview.NewTreeNode("No findings").SetSelectable(false))
+	}
+
+	b.tree.SetRoot(root).SetTopLevel(1)
+	if children := root.GetChildren(); len(children) > 0 {
+		b.tree.SetCurrentNode(children[0])
+		b.showDetails(children[0])
+	}
+	b.tree.SetTitle(fmt.Sprintf(" %d of %d findings by %s ", shown, len(b.entries), b.mode))
+}
+
+// entryLabel names a finding by what its group does not already show
+func (b *findingsBrowser) entryLabel(entry *validator.ValidationReportEntry) string {
+	location := fmt.Sprintf("%s:%d", entry.FileName, entry.Location.LineNumber)
+	switch b.mode {
+	case groupByRule:
+		return fmt.Sprintf("%s %s", location, entry.Location.ElementID)
+	case groupByFile:
+		return fmt.Sprintf("line %d %s", entry.Location.LineNumber, entry.Name)
+	default:
+		return fmt.Sprintf("%s  %s", entry.Name, location)
+	}
+}
+
+// showDetails fills the details pane for the highlighted node
+func (b *findingsBrowser) showDetails(node *tview.TreeNode) {
+	entry, ok := node.GetReference().(*validator.ValidationReportEntry)
+	if !ok {
+		b.details.SetText("")
+		return
+	}
+
+	var sb strings.Builder
+	field := func(label, value string) {
+		if value != "" {
+			fmt.Fprintf(&sb, "[yellow]%s:[-] %s\n", label, tview.Escape(value))
+		}
+	}
+	field("Rule", entry.Name)
+	field("Severity", entry.Severity.String())
+	field("File", entry.FileName)
+	if entry.Location.LineNumber > 0 {
+		field("Line", fmt.Sprint(entry.Location.LineNumber))
+	}
+	field("Element", entry.Location.ElementID)
+	field("XPath", entry.Location.XPath)
+	field("Rule XPath", entry.RuleXPath)
+	sb.WriteString("\n")
+	sb.WriteString(tview.Escape(entry.Message))
+	if entry.MatchedSnippet != "" {
+		sb.WriteString("\n\n[yellow]Matched XML:[-]\n")
+		sb.WriteString(tview.Escape(entry.MatchedSnippet))
+	}
+	b.details.SetText(sb.String()).ScrollToBeginning()
+}
+
+// selectNode toggles a group or jumps to the file and line of a finding
+func (b *findingsBrowser) selectNode(node *tview.TreeNode) {
+	entry, ok := node.GetReference().(*validator.ValidationReportEntry)
+	if !ok {
+		node.SetExpanded(!node.IsExpanded())
+		return
+	}
+
+	path, ok := findingPath(b.input)
+	if !ok {
+		b.setStatus("[red]Files inside a ZIP dataset cannot be opened[-]")
+		return
+	}
+	var err error
+	b.app.Suspend(func() {
+		err = editorCommand(path, entry.Location.LineNumber).Run()
+	})
+	if err != nil {
+		b.setStatus(fmt.Sprintf("[red]Editor failed: %s[-]", tview.Escape(err.Error())))
+		return
+	}
+	b.setStatus("")
+}
+
+// handleKey implements the tree's keyboard shortcuts
+func (b *findingsBrowser) handleKey(event *tcell.EventKey) *tcell.EventKey {
+	switch event.Rune() {
+	case 'q':
+		b.app.Stop()
+		return nil
+	case '/':
+		b.app.SetFocus(b.filter)
+		return nil
+	case 'g':
+		b.mode = b.mode.next()
+		b.rebuild()
+		return nil
+	}
+	return event
+}
+
+// setStatus shows message in front of the key help
+func (b *findingsBrowser) setStatus(message string) {
+	if message != "" {
+		message += "  "
+	}
+	b.status.SetText(message + tuiHelp)
+}
+
+// severityColor colors severity groups; other groupings keep the default color
+func severityColor(severity types.Severity, mode groupMode) tcell.Color {
+	if mode != groupBySeverity {
+		return tview.Styles.PrimaryTextColor
+	}
+	switch severity {
+	case types.CRITICAL, types.ERROR:
+		return tcell.ColorRed
+	case types.WARNING:
+		return tcell.ColorYellow
+	default:
+		return tcell.ColorGreen
+	}
+}
@@ -0,0 +1,130 @@
+//go:build tui
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/theoremus-urban-solutions/netex-validator/validator"
+)
+
+// groupMode selects how the tui command groups findings
+type groupMode int
+
+const (
+	groupBySeverity groupMode = iota
+	groupByRule
+	groupByFile
+)
+
+func (m groupMode) String() string {
+	switch m {
+	case groupByRule:
+		return "rule"
+	case groupByFile:
+		return "file"
+	default:
+		return "severity"
+	}
+}
+
+// next returns the grouping that follows m when cycling through them
+func (m groupMode) next() groupMode {
+	return (m + 1) % 3
+}
+
+// findingGroup is one collapsible node of the findings tree
+type findingGroup struct {
+	title   string
+	entries []validator.ValidationReportEntry
+}
+
+// matchesFilter reports whether filter occurs, ignoring case, in the rule name,
+// message, file name or element id of entry. An empty filter matches everything.
+func matchesFilter(entry validator.ValidationReportEntry, filter string) bool {
+	if filter == "" {
+		return true
+	}
+	filter = strings.ToLower(filter)
+	for _, field := range []string{entry.Name, entry.Message, entry.FileName, entry.Location.ElementID} {
+		if strings.Contains(strings.ToLower(field), filter) {
+			return true
+		}
+	}
+	return false
+}
+
+// groupFindings groups the entries matching filter by mode. Severity groups are
+// ordered from most to least severe, rule and file groups by name; entries keep
+// their report order within a group.
+func groupFindings(entries []validator.ValidationReportEntry, mode groupMode, filter string) []findingGroup {
+	index := make(map[string]int)
+	var groups []findingGroup
+	for _, entry := range entries {
+		if !matchesFilter(entry, filter) {
+			continue
+		}
+
+		var title string
+		switch mode {
+		case groupByRule:
+			title = entry.Name
+		case groupByFile:
+			title = entry.FileName
+		default:
+			title = entry.Severity.String()
+		}
+
+		i, ok := index[title]
+		if !ok {
+			i = len(groups)
+			index[title] = i
+			groups = append(groups, findingGroup{title: title})
+		}
+		groups[i].entries = append(groups[i].entries, entry)
+	}
+
+	sort.SliceStable(groups, func(i, j int) bool {
+		if mode == groupBySeverity {
+			return groups[i].entries[0].Severity > groups[j].entries[0].Severity
+		}
+		return groups[i].title < groups[j].title
+	})
+	return groups
+}
+
+// findingPath returns the file on disk holding a finding. Files of a ZIP
+// dataset only exist inside the archive, so there is nothing to jump to.
+func findingPath(input string) (string, bool) {
+	if strings.ToLower(filepath.Ext(input)) == ".zip" {
+		return "", false
+	}
+	return input, true
+}
+
+// editorCommand builds the command opening path at line in $VISUAL or $EDITOR,
+// falling back to vi. The "+line" argument is understood by vi, vim, nano and emacs.
+func editorCommand(path string, line int) *exec.Cmd {
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if editor == "" {
+		editor = "vi"
+	}
+
+	// $EDITOR may carry arguments, e.g. "emacs -nw"
+	args := strings.Fields(editor)
+	if line > 0 {
+		args = append(args, fmt.Sprintf("+%d", line))
+	}
+	args = append(args, path)
+	cmd := exec.Command(args[0], args[1:]...) //nolint:gosec // The editor is chosen by the user running the command
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	return cmd
+}
@@ -0,0 +1,94 @@
+//go:build tui
+
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/theoremus-urban-solutions/netex-validator/types"
+	"github.com/theoremus-urban-solutions/netex-validator/validator"
+)
+
+func TestGroupFindings(t *testing.T) {
+	entries := []validator.ValidationReportEntry{
+		{Name: "Line missing Name", Message: "Line is missing Name", Severity: types.WARNING, FileName: "b.xml"},
+		{Name: "Route missing LineRef", Message: "Route is missing LineRef", Severity: types.ERROR, FileName: "a.xml"},
+		{Name: "Line missing Name", Message: "Line is missing Name", Severity: types.WARNING, FileName: "a.xml",
+			Location: validator.ValidationReportLocation{ElementID: "TEST:Line:2"}},
+	}
+
+	titles := func(groups []findingGroup) []string {
+		var got []string
+		for _, group := range groups {
+			got = append(got, group.title)
+		}
+		return got
+	}
+
+	tests := []struct {
+		name   string
+		mode   groupMode
+		filter string
+		want   []string
+		counts []int
+	}{
+		{name: "by severity", mode: groupBySeverity, want: []string{"ERROR", "WARNING"}, counts: []int{1, 2}},
+		{name: "by rule", mode: groupByRule, want: []string{"Line missing Name", "Route missing LineRef"}, counts: []int{2, 1}},
+		{name: "by file", mode: groupByFile, want: []string{"a.xml", "b.xml"}, counts: []int{2, 1}},
+		{name: "filter on message", mode: groupByFile, filter: "LINEREF", want: []string{"a.xml"}, counts: []int{1}},
+		{name: "filter on element id", mode: groupBySeverity, filter: "Line:2", want: []string{"WARNING"}, counts: []int{1}},
+		{name: "filter without match", mode: groupByRule, filter: "StopPlace", want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			groups := groupFindings(entries, tt.mode, tt.filter)
+			if got := titles(groups); !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("groupFindings() titles = %v, want %v", got, tt.want)
+			}
+			for i, group := range groups {
+				if len(group.entries) != tt.counts[i] {
+					t.Errorf("group %q has %d entries, want %d", group.title, len(group.entries), tt.counts[i])
+				}
+			}
+		})
+	}
+}
+
+func TestGroupModeNext(t *testing.T) {
+	mode := groupBySeverity
+	var seen []string
+	for i := 0; i < 4; i++ {
+		seen = append(seen, mode.String())
+		mode = mode.next()
+	}
+	if want := []string{"severity", "rule", "file", "severity"}; !reflect.DeepEqual(seen, want) {
+		t.Errorf("group modes = %v, want %v", seen, want)
+	}
+}
+
+func TestEditorCommand(t *testing.T) {
+	t.Setenv("VISUAL", "")
+	t.Setenv("EDITOR", "emacs -nw")
+
+	cmd := editorCommand("line.xml", 42)
+	if want := []string{"emacs", "-nw", "+42", "line.xml"}; !reflect.DeepEqual(cmd.Args, want) {
+		t.Errorf("editorCommand() args = %v, want %v", cmd.Args, want)
+	}
+
+	t.Setenv("EDITOR", "")
+	cmd = editorCommand("line.xml", 0)
+	if want := []string{"vi", "line.xml"}; !reflect.DeepEqual(cmd.Args, want) {
+		t.Errorf("editorCommand() args = %v, want %v", cmd.Args, want)
+	}
+}
+
+func TestFindingPath(t *testing.T) {
+	if path, ok := findingPath("data/line.xml"); !ok || path != "data/line.xml" {
+		t.Errorf("findingPath(xml) = %q, %v", path, ok)
+	}
+	if _, ok := findingPath("data/dataset.ZIP"); ok {
+		t.Error("findingPath(zip) should not return a path")
+	}
+}
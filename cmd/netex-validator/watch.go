@@ -0,0 +1,156 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/theoremus-urban-solutions/netex-validator/validator"
+)
+
+// How often --watch looks for changes, and how long the input must stay unchanged
+// before it is revalidated, so an editor saving in several writes triggers one run.
+// Watching polls the modification times and sizes of the validated files rather
+// than subscribing to file system events, which works the same on every platform
+// and on network drives at the cost of noticing a change up to watchInterval late.
+var (
+	watchInterval = 500 * time.Millisecond
+	watchDebounce = 300 * time.Millisecond
+)
+
+// fileStamp identifies a version of a watched file
+type fileStamp struct {
+	modTime time.Time
+	size    int64
+}
+
+// snapshotInput stamps the input file, or the files of an input directory that
+// validateDirectory validates. Files that cannot be read are left out, so deleting
+// a file counts as a change.
+func snapshotInput(path string) map[string]fileStamp {
+	names := []string{path}
+	if isDirectory(path) {
+		names, _ = directoryFiles(path)
+	}
+	stamps := make(map[string]fileStamp, len(names))
+	for _, name := range names {
+		if info, err := os.Stat(name); err == nil {
+			stamps[name] = fileStamp{modTime: info.ModTime(), size: info.Size()}
+		}
+	}
+	return stamps
+}
+
+// sameSnapshot reports whether two snapshots stamp the same files identically
+func sameSnapshot(a, b map[string]fileStamp) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for name, stamp := range a {
+		other, ok := b[name]
+		if !ok || !stamp.modTime.Equal(other.modTime) || stamp.size != other.size {
+			return false
+		}
+	}
+	return true
+}
+
+// watchInput calls revalidate whenever the file or directory at path changes, once
+// the changes have settled for watchDebounce, until ctx is done
+func watchInput(ctx context.Context, path string, revalidate func()) {
+	ticker := time.NewTicker(watchInterval)
+	defer ticker.Stop()
+
+	last := snapshotInput(path)
+	var changedAt time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			current := snapshotInput(path)
+			if !sameSnapshot(current, last) {
+				last = current
+				changedAt = now
+				continue
+			}
+			if !changedAt.IsZero() && now.Sub(changedAt) >= watchDebounce {
+				changedAt = time.Time{}
+				revalidate()
+			}
+		}
+	}
+}
+
+// watchCommand validates the input, then revalidates it with the same validator on
+// every change, printing a summary of each run and writing the result to sinks.
+// It only returns once ctx is done.
+func watchCommand(ctx context.Context, v *validator.NetexValidator, sinks []Sink, out io.Writer) error {
+	run := func() {
+		v.Reset()
+		result, err := validateInput(v)
+		if err != nil {
+			fmt.Fprintf(out, "[%s] validation failed: %v\n", time.Now().Format("15:04:05"), err)
+			return
+		}
+		defer func() { _ = result.Close() }()
+		fmt.Fprintf(out, "[%s] %s\n", time.Now().Format("15:04:05"), resultSummary(result))
+		if err := outputResult(result, sinks); err != nil {
+			fmt.Fprintf(out, "failed to output results: %v\n", err)
+		}
+	}
+
+	run()
+	fmt.Fprintf(out, "Watching %s for changes, press Ctrl+C to stop\n", inputFile)
+	watchInput(ctx, inputFile, run)
+	return nil
+}
+
+// directoryFiles returns the XML files directly inside dir, those validateDirectory
+// validates and --watch watches
+func directoryFiles(dir string) ([]string, error) {
+	return filepath.Glob(filepath.Join(dir, "*.xml"))
+}
+
+// validateDirectory validates the XML files directly inside dir as one dataset
+func validateDirectory(v *validator.NetexValidator, dir string) (*validator.ValidationResult, error) {
+	paths, err := directoryFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("no XML files in %s", dir)
+	}
+
+	v.BeginDataset()
+	results := make([]*validator.ValidationResult, 0, len(paths)+1)
+	for _, path := range paths {
+		result, err := v.ValidateFile(path)
+		if err != nil {
+			_, _ = v.EndDataset()
+			return nil, err
+		}
+		results = append(results, result)
+	}
+	dataset, err := v.EndDataset()
+	if err != nil {
+		return nil, err
+	}
+	// Merging loads the findings of a spilled dataset result into memory
+	defer func() { _ = dataset.Close() }()
+	// The files were already counted by their own results
+	dataset.FilesProcessed = 0
+	if dataset.QualityScore != nil {
+		dataset.QualityScore.Files = 0
+	}
+	return dataset.Merge(results...), nil
+}
+
+// isDirectory reports whether path names a directory
+func isDirectory(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
@@ -0,0 +1,150 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/theoremus-urban-solutions/netex-validator/validator"
+)
+
+// syncBuffer is a bytes.Buffer safe to read while the watcher writes to it
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestWatchCommand_RevalidatesOnChange(t *testing.T) {
+	interval, debounce := watchInterval, watchDebounce
+	watchInterval, watchDebounce = 10*time.Millisecond, 30*time.Millisecond
+	savedInput := inputFile
+	t.Cleanup(func() {
+		watchInterval, watchDebounce = interval, debounce
+		inputFile = savedInput
+	})
+
+	clean, err := os.ReadFile("../../testdata/empty.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	invalid, err := os.ReadFile("../../testdata/valid_minimal.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	inputFile = filepath.Join(t.TempDir(), "data.xml")
+	if err := os.WriteFile(inputFile, clean, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	v, err := validator.NewWithOptions(validator.DefaultValidationOptions().WithCodespace("TEST").WithSkipSchema(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	out := &syncBuffer{}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_ = watchCommand(ctx, v, nil, out)
+	}()
+	defer func() {
+		cancel()
+		<-done
+	}()
+
+	waitFor := func(runs int) string {
+		t.Helper()
+		deadline := time.Now().Add(5 * time.Second)
+		for time.Now().Before(deadline) {
+			if output := out.String(); strings.Count(output, " issues (") >= runs {
+				return output
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+		t.Fatalf("Expected %d validation runs, got output:\n%s", runs, out.String())
+		return ""
+	}
+
+	if output := waitFor(1); !strings.Contains(output, "0 issues") || !strings.Contains(output, "Watching") {
+		t.Fatalf("Expected the clean file to be validated before watching, got:\n%s", output)
+	}
+
+	// Several quick writes are debounced into one run over the final content
+	for _, content := range [][]byte{clean, invalid[:len(invalid)/2], invalid} {
+		if err := os.WriteFile(inputFile, content, 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	output := waitFor(2)
+	runs := strings.Split(strings.TrimSpace(output), "\n")
+	if last := runs[len(runs)-1]; strings.Contains(last, " 0 issues") || strings.Contains(last, "failed") {
+		t.Errorf("Expected the changed file to be revalidated with findings, got %q", last)
+	}
+
+	time.Sleep(100 * time.Millisecond)
+	if count := strings.Count(out.String(), " issues ("); count != 2 {
+		t.Errorf("Expected the writes to trigger one revalidation, got %d runs:\n%s", count, out.String())
+	}
+}
+
+func TestSnapshotInput_Directory(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"a.xml", "notes.txt", filepath.Join("sub", "b.xml")} {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte("<a/>"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Only the files validateDirectory validates are watched
+	before := snapshotInput(dir)
+	if len(before) != 1 {
+		t.Fatalf("Expected only a.xml to be stamped, got %v", before)
+	}
+	if _, ok := before[filepath.Join(dir, "a.xml")]; !ok {
+		t.Fatalf("Expected a.xml to be stamped, got %v", before)
+	}
+
+	for _, name := range []string{"notes.txt", filepath.Join("sub", "b.xml")} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("<changed/>"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if !sameSnapshot(before, snapshotInput(dir)) {
+		t.Error("Expected changes to files that are not validated to be ignored")
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "a.xml"), []byte("<changed/>"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if sameSnapshot(before, snapshotInput(dir)) {
+		t.Error("Expected a change to a.xml to change the snapshot")
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "c.xml"), []byte("<a/>"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if len(snapshotInput(dir)) != 2 {
+		t.Error("Expected a new XML file to be stamped")
+	}
+}
@@ -15,16 +15,18 @@ type ValidatorConfig struct {
 	Validator ValidatorSettings `yaml:"validator"`
 	Rules     RulesConfig       `yaml:"rules"`
 	Output    OutputConfig      `yaml:"output"`
+	Scoring   ScoringConfig     `yaml:"scoring"`
 }
 
 // ValidatorSettings contains general validator settings
 type ValidatorSettings struct {
-	Profile         string `yaml:"profile"`         // e.g., "eu", "custom"
-	MaxFileSize     int64  `yaml:"maxFileSize"`     // Maximum file size in bytes
-	MaxSchemaErrors int    `yaml:"maxSchemaErrors"` // Maximum schema errors to report
-	ConcurrentFiles int    `yaml:"concurrentFiles"` // Number of files to process concurrently
-	EnableCache     bool   `yaml:"enableCache"`     // Enable validation result caching
-	CacheTimeout    int    `yaml:"cacheTimeout"`    // Cache timeout in minutes
+	Profile          string `yaml:"profile"`          // e.g., "eu", "custom"
+	DefaultCodespace string `yaml:"defaultCodespace"` // Codespace used when none is given
+	MaxFileSize      int64  `yaml:"maxFileSize"`      // Maximum file size in bytes
+	MaxSchemaErrors  int    `yaml:"maxSchemaErrors"`  // Maximum schema errors to report
+	ConcurrentFiles  int    `yaml:"concurrentFiles"`  // Number of files to process concurrently
+	EnableCache      bool   `yaml:"enableCache"`      // Enable validation result caching
+	CacheTimeout     int    `yaml:"cacheTimeout"`     // Cache timeout in minutes
 }
 
 // RulesConfig contains rule-specific configuration
@@ -56,6 +58,7 @@ type CustomRuleConfig struct {
 	Severity types.Severity `yaml:"severity"`
 	XPath    string         `yaml:"xpath"`
 	Enabled  bool           `yaml:"enabled"`
+	DocURL   string         `yaml:"docUrl,omitempty"`
 }
 
 // OutputConfig configures output settings
@@ -66,6 +69,24 @@ type OutputConfig struct {
 	MaxEntries      int    `yaml:"maxEntries"`      // Maximum entries to output (0 = unlimited)
 }
 
+// ScoringConfig weights findings for the dataset quality score. A finding's penalty
+// is the weight of its severity times the weight of its rule.
+type ScoringConfig struct {
+	SeverityWeights map[string]float64 `yaml:"severityWeights"`       // INFO, WARNING, ERROR, CRITICAL -> penalty per finding
+	RuleWeights     map[string]float64 `yaml:"ruleWeights,omitempty"` // Rule code -> importance multiplier (default 1)
+}
+
+// DefaultSeverityWeights returns the penalty per finding of each severity used
+// unless configured otherwise
+func DefaultSeverityWeights() map[string]float64 {
+	return map[string]float64{
+		"INFO":     0.5,
+		"WARNING":  2,
+		"ERROR":    10,
+		"CRITICAL": 25,
+	}
+}
+
 // DefaultConfig returns a default configuration
 func DefaultConfig() *ValidatorConfig {
 	return &ValidatorConfig{
@@ -107,6 +128,10 @@ func DefaultConfig() *ValidatorConfig {
 					Enabled:         true,
 					DefaultSeverity: nil,
 				},
+				"stop_place": {
+					Enabled:         true,
+					DefaultSeverity: nil,
+				},
 				"version": {
 					Enabled:         true,
 					DefaultSeverity: nil,
@@ -184,6 +209,9 @@ func DefaultConfig() *ValidatorConfig {
 			GroupBySeverity: true,
 			MaxEntries:      0, // Unlimited
 		},
+		Scoring: ScoringConfig{
+			SeverityWeights: DefaultSeverityWeights(),
+		},
 	}
 }
 
@@ -269,6 +297,21 @@ func (c *ValidatorConfig) Validate() error {
 		return fmt.Errorf("invalid output format: %s (valid: json, text, html)", c.Output.Format)
 	}
 
+	// Validate scoring weights
+	for severity, weight := range c.Scoring.SeverityWeights {
+		if _, known := DefaultSeverityWeights()[severity]; !known {
+			return fmt.Errorf("scoring: invalid severity %s (valid: INFO, WARNING, ERROR, CRITICAL)", severity)
+		}
+		if weight < 0 {
+			return fmt.Errorf("scoring: weight of %s cannot be negative", severity)
+		}
+	}
+	for code, weight := range c.Scoring.RuleWeights {
+		if weight < 0 {
+			return fmt.Errorf("scoring: weight of rule %s cannot be negative", code)
+		}
+	}
+
 	// Validate custom rules
 	for i, rule := range c.Rules.Custom {
 		if rule.Code == "" {
@@ -292,7 +335,7 @@ func (c *ValidatorConfig) IsRuleEnabled(ruleCode string) bool {
 
 	// Check if category is enabled
 	if catConfig, exists := c.Rules.Categories[category]; exists {
-		if !catConfig.Enabled {
+		if !c.IsCategoryEnabled(category) {
 			return false
 		}
 
@@ -316,6 +359,15 @@ func (c *ValidatorConfig) IsRuleEnabled(ruleCode string) bool {
 	return true
 }
 
+// IsCategoryEnabled reports whether a category of rules is enabled. Categories
+// missing from the configuration are enabled.
+func (c *ValidatorConfig) IsCategoryEnabled(category string) bool {
+	if catConfig, exists := c.Rules.Categories[category]; exists {
+		return catConfig.Enabled
+	}
+	return true
+}
+
 // GetRuleSeverity gets the effective severity for a rule
 func (c *ValidatorConfig) GetRuleSeverity(ruleCode string, defaultSeverity types.Severity) types.Severity {
 	// Determine category from rule code
@@ -358,6 +410,13 @@ func (c *ValidatorConfig) GetCustomRules() []CustomRuleConfig {
 	return enabled
 }
 
+// RuleCategory returns the category of a rule code, as used by the categories
+// section of the configuration. It is the one mapping of rules to categories: the
+// rule registry, the rule toggles and the quality score all categorize through it.
+func RuleCategory(ruleCode string) string {
+	return getRuleCategoryFromCode(ruleCode)
+}
+
 // getRuleCategoryFromCode determines the rule category from rule code
 func getRuleCategoryFromCode(ruleCode string) string {
 	if len(ruleCode) == 0 {
@@ -376,6 +435,7 @@ func getRuleCategoryFromCode(ruleCode string) string {
 		"JOURNEY_PATTERN_":       "journey_pattern",
 		"STOP_POINT_":            "stop_point",
 		"SCHEDULED_STOP_":        "stop_point",
+		"STOP_PLACE_":            "stop_place",
 		"VERSION_":               "version",
 		"TRANSPORT_MODE_":        "transport_mode",
 		"TRANSPORT_SUB_MODE_":    "transport_mode",
@@ -394,6 +454,10 @@ func getRuleCategoryFromCode(ruleCode string) string {
 		"RESOURCE_FRAME_":        "frame",
 		"SITE_FRAME_":            "frame",
 		"INFRASTRUCTURE_FRAME_":  "frame",
+		"FRAME_":                 "frame_structure",
+		"SCHEMA_":                "schema",
+		"NETEX_ID_":              "id",
+		"SELF_REFERENCE":         "id",
 		"FLEXIBLE_SERVICE_":      "flexible_service",
 		"FLEXIBLE_STOP_":         "flexible_service",
 		"FLEXIBLE_AREA_":         "flexible_service",
@@ -406,13 +470,15 @@ func getRuleCategoryFromCode(ruleCode string) string {
 		"FARE_":                  "group",
 	}
 
-	for prefix, category := range categoryMap {
-		if len(ruleCode) >= len(prefix) && ruleCode[:len(prefix)] == prefix {
-			return category
+	// The longest matching prefix wins, so the result does not depend on map order
+	category, matched := "custom", 0
+	for prefix, prefixCategory := range categoryMap {
+		if len(prefix) > matched && strings.HasPrefix(ruleCode, prefix) {
+			category, matched = prefixCategory, len(prefix)
 		}
 	}
 
-	return "custom"
+	return category
 }
 
 // GenerateDefaultConfigFile creates a default configuration file
@@ -155,9 +155,14 @@ func ExampleErrorHandling() {
 		return
 	}
 
-	if result.Error != "" {
-		// Handle validation runtime errors
-		fmt.Printf("Validation runtime error: %s\n", result.Error)
+	if result.Error != nil {
+		// Handle validation runtime errors by category
+		switch result.Error.Code {
+		case validator.ErrorCodeFileNotFound:
+			fmt.Printf("Input not found: %s\n", result.Error)
+		default:
+			fmt.Printf("Validation runtime error (%s): %s\n", result.Error.Code, result.Error)
+		}
 	}
 
 	// Check for specific validation issues
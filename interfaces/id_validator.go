@@ -1,6 +1,7 @@
 package interfaces
 
 import (
+	"github.com/antchfx/xmlquery"
 	"github.com/theoremus-urban-solutions/netex-validator/types"
 )
 
@@ -19,11 +20,28 @@ type IdValidator interface {
 	GetRepository() IdRepository
 }
 
+// DocumentIdValidator is an IdValidator that can also register IDs and references
+// from an already parsed document. The runner uses it when available to avoid
+// parsing each file again; other IdValidator implementations keep working through
+// ExtractIds and ExtractReferences.
+type DocumentIdValidator interface {
+	IdValidator
+
+	// ExtractIdsFromDocument extracts IDs from an already parsed document
+	ExtractIdsFromDocument(fileName string, doc *xmlquery.Node) error
+
+	// ExtractReferencesFromDocument extracts references from an already parsed document
+	ExtractReferencesFromDocument(fileName string, doc *xmlquery.Node) error
+}
+
 // IdRepository manages NetEX ID storage and validation
 type IdRepository interface {
 	// AddId registers a NetEX ID
 	AddId(id, version, fileName string) error
 
+	// AddExternalId registers an ID declared outside the validated dataset
+	AddExternalId(id, version, fileName string)
+
 	// AddReference registers a reference to a NetEX ID
 	AddReference(refId, version, fileName string)
 
@@ -45,6 +63,9 @@ type IdRepository interface {
 	// GetAllIds returns all registered IDs
 	GetAllIds() map[string]types.IdVersion
 
+	// ElementCounts returns the number of distinct IDs declared per element type
+	ElementCounts() map[string]int
+
 	// Clear resets the repository
 	Clear()
 }
@@ -57,3 +78,14 @@ type IdExtractor interface {
 	// ExtractReferences extracts all NetEX ID references from XML content
 	ExtractReferences(fileName string, content []byte) ([]types.IdVersion, error)
 }
+
+// DocumentIdExtractor is an IdExtractor that can also work on an already parsed document
+type DocumentIdExtractor interface {
+	IdExtractor
+
+	// ExtractIdsFromDocument extracts all NetEX IDs from an already parsed document
+	ExtractIdsFromDocument(fileName string, doc *xmlquery.Node) ([]types.IdVersion, error)
+
+	// ExtractReferencesFromDocument extracts all NetEX ID references from an already parsed document
+	ExtractReferencesFromDocument(fileName string, doc *xmlquery.Node) ([]types.IdVersion, error)
+}
@@ -29,9 +29,17 @@ type JAXBValidator interface {
 	GetRules() []types.ValidationRule
 }
 
-// DatasetValidator represents a validator that operates on entire datasets
+// DatasetValidator represents a validator that operates on entire datasets.
+// Collect is called with the parsed document of every file as it is validated;
+// Validate runs once all files have been collected and may consult the shared
+// ID repository to resolve references declared in other files. Dataset validators
+// only run for ZIP datasets and for files validated between BeginDataset and
+// EndDataset; a file validated on its own is not checked by them.
 type DatasetValidator interface {
-	Validate(report *types.ValidationReport) error
+	Collect(context context.XPathValidationContext) error
+	Validate(repository IdRepository) ([]types.ValidationIssue, error)
+	GetRules() []types.ValidationRule
+	Reset()
 }
 
 // ValidationReportEntryFactory creates validation report entries from validation issues
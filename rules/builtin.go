@@ -22,6 +22,7 @@ func (r *RuleRegistry) loadBuiltinRules() {
 
 	r.addRule("LINE_7", "Line missing Network or GroupOfLines", "Line is missing reference to Network or GroupOfLines", types.WARNING,
 		"//lines/*[self::Line or self::FlexibleLine][not(RepresentedByGroupRef)]")
+	// LINE_GROUP_REF_UNRESOLVED resolves RepresentedByGroupRefs across files, see business.TypedReferenceValidator
 
 	r.addRule("LINE_8", "Line missing OperatorRef", "Line is missing OperatorRef", types.WARNING,
 		"//lines/*[self::Line or self::FlexibleLine][not(OperatorRef)]")
@@ -29,6 +30,9 @@ func (r *RuleRegistry) loadBuiltinRules() {
 	r.addRule("LINE_9", "Line missing AuthorityRef", "Line is missing AuthorityRef", types.WARNING,
 		"//lines/*[self::Line or self::FlexibleLine][not(AuthorityRef)]")
 
+	r.addRule("LINE_10", "Line missing OperatorRef and AuthorityRef", "Line has neither OperatorRef nor AuthorityRef", types.ERROR,
+		"//lines/*[self::Line or self::FlexibleLine][not(OperatorRef) and not(AuthorityRef) and not(@responsibilitySetRef) and not(ancestor::*[@responsibilitySetRef])]")
+
 	// ROUTE validation rules - Enhanced coverage
 	r.addRule("ROUTE_2", "Route missing Name", "Route is missing Name. Add <Name> to each Route for clarity in reporting and passenger information.", types.ERROR,
 		"//*[local-name()='routes']/*[local-name()='Route'][not(*[local-name()='Name']) or normalize-space(*[local-name()='Name'])='']")
@@ -51,6 +55,8 @@ func (r *RuleRegistry) loadBuiltinRules() {
 	r.addRule("ROUTE_8", "Route invalid DirectionType", "Route has invalid DirectionType", types.ERROR,
 		"//routes/Route[DirectionType and not(DirectionType = 'inbound' or DirectionType = 'outbound' or DirectionType = 'clockwise' or DirectionType = 'anticlockwise')]")
 
+	// Non-positive RouteLink and ServiceLink distances are reported with their value, see business.LinkDistanceValidator
+
 	// SERVICE_JOURNEY validation rules - Enhanced coverage
 	r.addRule("SERVICE_JOURNEY_2", "ServiceJourney illegal element Call", "ServiceJourney has illegal element Call", types.ERROR,
 		"//vehicleJourneys/ServiceJourney/calls")
@@ -85,8 +91,7 @@ func (r *RuleRegistry) loadBuiltinRules() {
 	r.addRule("SERVICE_JOURNEY_12", "ServiceJourney missing OperatorRef", "ServiceJourney is missing OperatorRef", types.ERROR,
 		"//vehicleJourneys/ServiceJourney[not(OperatorRef) and not(//ServiceFrame/lines/*[self::Line or self::FlexibleLine]/OperatorRef)]")
 
-	r.addRule("SERVICE_JOURNEY_13", "ServiceJourney missing reference to calendar data", "ServiceJourney is missing reference to calendar data", types.ERROR,
-		"//vehicleJourneys/ServiceJourney[not(dayTypes/DayTypeRef) and not(@id=//TimetableFrame/vehicleJourneys/DatedServiceJourney/ServiceJourneyRef/@ref)]")
+	// SERVICE_JOURNEY_13 resolves DatedServiceJourneys across files, see business.ServiceJourneyCalendarValidator
 
 	r.addRule("SERVICE_JOURNEY_14", "ServiceJourney duplicated reference to calendar data", "ServiceJourney has duplicated reference to calendar data", types.ERROR,
 		"//vehicleJourneys/ServiceJourney[dayTypes/DayTypeRef and @id=//TimetableFrame/vehicleJourneys/DatedServiceJourney/ServiceJourneyRef/@ref]")
@@ -100,6 +105,12 @@ func (r *RuleRegistry) loadBuiltinRules() {
 	r.addRule("SERVICE_JOURNEY_17", "ServiceJourney duplicate TimetabledPassingTime IDs", "ServiceJourney has duplicate TimetabledPassingTime IDs", types.ERROR,
 		"//vehicleJourneys/ServiceJourney/passingTimes/TimetabledPassingTime[@id = preceding-sibling::TimetabledPassingTime/@id or @id = following-sibling::TimetabledPassingTime/@id]")
 
+	// SERVICE_JOURNEY_20 names the ServiceJourney of each passing time without a StopPointInJourneyPatternRef, see business.PassingTimeStopPointValidator
+
+	// SERVICE_JOURNEY_21 reports the passing time count, see business.PassingTimeCountValidator
+
+	// SERVICE_JOURNEY_22 compares passing times with the order of the JourneyPattern across files, see business.PassingTimePatternValidator
+
 	// FLEXIBLE_LINE validation rules
 	r.addRule("FLEXIBLE_LINE_1", "FlexibleLine missing FlexibleLineType", "FlexibleLine is missing FlexibleLineType", types.ERROR,
 		"//lines/FlexibleLine[not(FlexibleLineType)]")
@@ -137,7 +148,7 @@ func (r *RuleRegistry) loadBuiltinRules() {
 		"//journeyPatterns/JourneyPattern[not(RouteRef)]")
 
 	r.addRule("JOURNEY_PATTERN_2", "JourneyPattern missing pointsInSequence", "JourneyPattern is missing pointsInSequence", types.ERROR,
-		"//journeyPatterns/JourneyPattern[not(pointsInSequence)]")
+		"//journeyPatterns/*[self::JourneyPattern or self::ServiceJourneyPattern][not(pointsInSequence)]")
 
 	// STOP_POINT validation rules
 	r.addRule("STOP_POINT_1", "StopPoint missing ScheduledStopPointRef", "StopPoint is missing ScheduledStopPointRef", types.ERROR,
@@ -157,6 +168,10 @@ func (r *RuleRegistry) loadBuiltinRules() {
 	r.addRule("TRANSPORT_MODE_ON_SERVICE_JOURNEY", "ServiceJourney with illegal TransportMode", "ServiceJourney has illegal TransportMode", types.ERROR,
 		"//vehicleJourneys/ServiceJourney[TransportMode and not(TransportMode = 'coach' or TransportMode = 'bus' or TransportMode = 'tram' or TransportMode = 'rail' or TransportMode = 'metro' or TransportMode = 'air' or TransportMode = 'taxi' or TransportMode = 'water' or TransportMode = 'cableway' or TransportMode = 'funicular' or TransportMode = 'unknown')]")
 
+	// Opt-in: 'unknown' is a legal mode but usually means the mode was never filled in
+	r.addRule("TRANSPORT_MODE_UNKNOWN", "TransportMode unknown", "TransportMode is 'unknown', which usually signals incomplete data", types.WARNING,
+		"//lines/*[self::Line or self::FlexibleLine][TransportMode = 'unknown'] | //vehicleJourneys/ServiceJourney[TransportMode = 'unknown']")
+
 	// TRANSPORT_SUB_MODE validation rules - Context-specific validation
 	r.addRule("TRANSPORT_SUB_MODE_BUS_INVALID", "Line with invalid bus TransportSubMode", "Line has invalid TransportSubMode for bus transport", types.ERROR,
 		"//lines/*[self::Line or self::FlexibleLine][TransportMode = 'bus' and TransportSubmode and not(TransportSubmode = 'localBus' or TransportSubmode = 'regionalBus' or TransportSubmode = 'expressBus' or TransportSubmode = 'nightBus' or TransportSubmode = 'postBus' or TransportSubmode = 'specialNeedsBus' or TransportSubmode = 'mobilityBus' or TransportSubmode = 'mobilityBusForRegisteredDisabled' or TransportSubmode = 'sightseeingBus' or TransportSubmode = 'shuttleBus' or TransportSubmode = 'schoolBus' or TransportSubmode = 'schoolAndPublicServiceBus' or TransportSubmode = 'railReplacementBus' or TransportSubmode = 'demandAndResponseBus' or TransportSubmode = 'airportLinkBus')]")
@@ -192,6 +207,9 @@ func (r *RuleRegistry) loadBuiltinRules() {
 	r.addRule("SERVICE_CALENDAR_2", "ServiceCalendar missing OperatingPeriod", "ServiceCalendar is missing OperatingPeriod", types.ERROR,
 		"//ServiceCalendar[not(operatingPeriods)]")
 
+	r.addRule("SERVICE_CALENDAR_3", "ServiceCalendar empty", "ServiceCalendar has neither DayTypes nor OperatingPeriods and defines no service", types.ERROR,
+		"//ServiceCalendar[not(dayTypes/*) and not(operatingPeriods/*)]")
+
 	r.addRule("VALIDITY_CONDITIONS_1", "ValidityConditions missing AvailabilityCondition", "ValidityConditions is missing AvailabilityCondition", types.WARNING,
 		"//validityConditions[not(AvailabilityCondition)]")
 
@@ -205,8 +223,7 @@ func (r *RuleRegistry) loadBuiltinRules() {
 	r.addRule("DATED_SERVICE_JOURNEY_2", "DatedServiceJourney missing OperatingDayRef", "DatedServiceJourney is missing OperatingDayRef", types.ERROR,
 		"//vehicleJourneys/DatedServiceJourney[not(OperatingDayRef)]")
 
-	r.addRule("DATED_SERVICE_JOURNEY_3", "DatedServiceJourney invalid OperatingDayRef", "DatedServiceJourney has invalid OperatingDayRef", types.ERROR,
-		"//vehicleJourneys/DatedServiceJourney[OperatingDayRef]")
+	// DATED_SERVICE_JOURNEY_3 resolves OperatingDayRefs across files, see business.TypedReferenceValidator
 
 	// DEAD_RUN validation rules
 	r.addRule("DEAD_RUN_1", "DeadRun missing RouteRef", "DeadRun is missing RouteRef", types.ERROR,
@@ -228,6 +245,11 @@ func (r *RuleRegistry) loadBuiltinRules() {
 	r.addRule("INTERCHANGE_4", "ServiceJourneyInterchange missing ToServiceJourneyRef", "ServiceJourneyInterchange is missing ToServiceJourneyRef", types.ERROR,
 		"//interchanges/ServiceJourneyInterchange[not(ToServiceJourneyRef)]")
 
+	r.addRule("INTERCHANGE_8", "ServiceJourneyInterchange missing transfer time", "ServiceJourneyInterchange has no StandardTransferTime, MinimumTransferTime or MaximumTransferTime", types.WARNING,
+		"//ServiceJourneyInterchange[not(StandardTransferTime) and not(MinimumTransferTime) and not(MaximumTransferTime)]")
+
+	// INTERCHANGE_9 checks interchange stop points across files, see business.InterchangeStopPointValidator
+
 	// NOTICE validation rules
 	r.addRule("NOTICE_1", "Notice missing Name", "Notice is missing Name", types.WARNING,
 		"//notices/Notice[not(Name) or normalize-space(Name) = '']")
@@ -251,6 +273,14 @@ func (r *RuleRegistry) loadBuiltinRules() {
 	r.addRule("TIMETABLE_FRAME_1", "TimetableFrame missing in CompositeFrame", "CompositeFrame is missing TimetableFrame", types.WARNING,
 		"//CompositeFrame[not(TimetableFrame)]")
 
+	// GeneralFrames are left out: some profiles declare them standalone
+	r.addRule("FRAME_OUTSIDE_COMPOSITE_FRAME", "Frame outside CompositeFrame", "Frame is declared directly under dataObjects instead of inside a CompositeFrame", types.WARNING,
+		"//dataObjects/*[self::ResourceFrame or self::ServiceFrame or self::SiteFrame or self::TimetableFrame or self::ServiceCalendarFrame or self::VehicleScheduleFrame or self::InfrastructureFrame or self::FareFrame or self::SalesTransactionFrame or self::DriverScheduleFrame]")
+
+	// An absent dataObjects is reported by the basic schema checks
+	r.addRule("FRAME_EMPTY_DATAOBJECTS", "Empty dataObjects", "PublicationDelivery has no frames in dataObjects", types.ERROR,
+		"//dataObjects[not(*)]")
+
 	// FLEXIBLE_SERVICE validation rules
 	r.addRule("FLEXIBLE_SERVICE_1", "FlexibleService missing FlexibleServiceType", "FlexibleService is missing FlexibleServiceType", types.ERROR,
 		"//FlexibleService[not(FlexibleServiceType)]")
@@ -297,6 +327,12 @@ func (r *RuleRegistry) loadBuiltinRules() {
 	r.addRule("GROUP_OF_SERVICES_1", "GroupOfServices missing Name", "GroupOfServices is missing Name", types.WARNING,
 		"//GroupOfServices[not(Name) or normalize-space(Name) = '']")
 
+	r.addRule("GROUP_OF_LINES_2", "GroupOfLines without members", "GroupOfLines has no member Lines", types.WARNING,
+		"//groupsOfLines/GroupOfLines[not(members/*)]")
+
+	r.addRule("GROUP_OF_SERVICES_2", "GroupOfServices without members", "GroupOfServices has no member services", types.WARNING,
+		"//GroupOfServices[not(members/*)]")
+
 	// Load additional extended rules to reach parity with Java version
 	r.loadExtendedBuiltinRules()
 }
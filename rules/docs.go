@@ -0,0 +1,100 @@
+package rules
+
+// RuleDoc is the prose documentation of a rule: a violating example and how to fix it
+type RuleDoc struct {
+	Example string
+	Fix     string
+}
+
+// ruleDocs documents the rules users most often ask about. Rules without an entry
+// are described by their registry metadata alone.
+var ruleDocs = map[string]RuleDoc{
+	"LINE_2": {
+		Example: `<Line id="NO:Line:1" version="1">
+  <TransportMode>bus</TransportMode>
+</Line>`,
+		Fix: "Add a non-empty <Name> to the Line, as shown to passengers, e.g. <Name>Airport Express</Name>.",
+	},
+	"LINE_3": {
+		Example: `<Line id="NO:Line:1" version="1">
+  <Name>Airport Express</Name>
+</Line>`,
+		Fix: "Add the <PublicCode> passengers see on vehicles and timetables, e.g. <PublicCode>42</PublicCode>.",
+	},
+	"LINE_4": {
+		Example: `<Line id="NO:Line:1" version="1">
+  <Name>Airport Express</Name>
+  <PublicCode>42</PublicCode>
+</Line>`,
+		Fix: "Add <TransportMode> with one of bus, rail, tram, metro, air, water, taxi, cableway, funicular or coach, e.g. <TransportMode>bus</TransportMode>.",
+	},
+	"LINE_5": {
+		Example: `<Line id="NO:Line:1" version="1">
+  <TransportMode>bus</TransportMode>
+</Line>`,
+		Fix: "Add a <TransportSubmode> matching the mode, e.g. <TransportSubmode><BusSubmode>localBus</BusSubmode></TransportSubmode>.",
+	},
+	"LINE_6": {
+		Example: `<Line id="NO:Line:1" version="1">
+  <routes>
+    <Route id="NO:Route:1" version="1"/>
+  </routes>
+</Line>`,
+		Fix: "Move the Routes to the routes of the ServiceFrame and reference the Line from each with <LineRef ref=\"NO:Line:1\"/>.",
+	},
+	"LINE_7": {
+		Example: `<Line id="NO:Line:1" version="1">
+  <Name>Airport Express</Name>
+</Line>`,
+		Fix: "Reference the Network or GroupOfLines the Line belongs to, e.g. <RepresentedByGroupRef ref=\"NO:Network:1\"/>.",
+	},
+	"LINE_8": {
+		Example: `<Line id="NO:Line:1" version="1">
+  <AuthorityRef ref="NO:Authority:1"/>
+</Line>`,
+		Fix: "Reference the Operator running the Line, e.g. <OperatorRef ref=\"NO:Operator:1\"/>.",
+	},
+	"LINE_9": {
+		Example: `<Line id="NO:Line:1" version="1">
+  <OperatorRef ref="NO:Operator:1"/>
+</Line>`,
+		Fix: "Reference the Authority responsible for the Line, e.g. <AuthorityRef ref=\"NO:Authority:1\"/>.",
+	},
+	"LINE_10": {
+		Example: `<Line id="NO:Line:1" version="1">
+  <Name>Airport Express</Name>
+</Line>`,
+		Fix: "Add an <OperatorRef> or <AuthorityRef>, or a responsibilitySetRef on the Line or its frame.",
+	},
+	"INTERCHANGE_8": {
+		Example: `<ServiceJourneyInterchange id="NO:ServiceJourneyInterchange:1" version="1">
+  <FromJourneyRef ref="NO:ServiceJourney:1"/>
+  <ToJourneyRef ref="NO:ServiceJourney:2"/>
+</ServiceJourneyInterchange>`,
+		Fix: "Give the time needed for the connection, e.g. <StandardTransferTime>PT5M</StandardTransferTime>, or its bounds with <MinimumTransferTime> and <MaximumTransferTime>.",
+	},
+	"ROUTE_2": {
+		Example: `<Route id="NO:Route:1" version="1">
+  <LineRef ref="NO:Line:1"/>
+</Route>`,
+		Fix: "Add a non-empty <Name> to the Route, e.g. <Name>Airport - City Centre</Name>.",
+	},
+	"ROUTE_3": {
+		Example: `<Route id="NO:Route:1" version="1">
+  <Name>Airport - City Centre</Name>
+</Route>`,
+		Fix: "Reference the Line the Route belongs to with <LineRef ref=\"NO:Line:1\"/>, or <FlexibleLineRef> for a FlexibleLine.",
+	},
+	"ROUTE_4": {
+		Example: `<Route id="NO:Route:1" version="1">
+  <LineRef ref="NO:Line:1"/>
+</Route>`,
+		Fix: "List the points of the Route in order in <pointsInSequence>, one <PointOnRoute> per RoutePoint.",
+	},
+}
+
+// GetRuleDoc returns the prose documentation of a rule, if it has any
+func GetRuleDoc(code string) (RuleDoc, bool) {
+	doc, ok := ruleDocs[code]
+	return doc, ok
+}
@@ -120,6 +120,22 @@ func (r *RuleRegistry) addStopPlaceRules() {
 
 	r.addRule("STOP_PLACE_5", "Invalid StopPlaceType", "StopPlace has invalid type", types.ERROR,
 		"//stopPlaces/StopPlace/StopPlaceType[not(text() = 'onstreetBus' or text() = 'onstreetTram' or text() = 'airport' or text() = 'railStation' or text() = 'metroStation' or text() = 'busStation' or text() = 'coachStation' or text() = 'tramStation' or text() = 'harbourPort' or text() = 'ferryPort' or text() = 'ferryStop' or text() = 'liftStation' or text() = 'vehicleRailInterchange' or text() = 'other')]")
+
+	// A Centroid is only usable with numeric coordinates; number(.) = number(.) is false for NaN
+	r.addRule("STOP_PLACE_6", "StopPlace Centroid incomplete", "StopPlace Centroid Location must have a numeric Longitude and Latitude", types.ERROR,
+		"//stopPlaces/StopPlace[Centroid[not(Location/Longitude[number(.) = number(.)]) or not(Location/Latitude[number(.) = number(.)])]]")
+
+	r.addRule("STOP_PLACE_7", "Quay Centroid incomplete", "Quay Centroid Location must have a numeric Longitude and Latitude", types.ERROR,
+		"//stopPlaces/StopPlace/quays/Quay[Centroid[not(Location/Longitude[number(.) = number(.)]) or not(Location/Latitude[number(.) = number(.)])]]")
+
+	r.addRule("STOP_PLACE_9", "Quay outside StopPlace", "Quay must be declared within the quays of a StopPlace", types.ERROR,
+		"//Quay[not(ancestor::StopPlace)]")
+
+	// STOP_PLACE_10 compares assignments with the StopPlace of their Quay across files, see business.QuayStopPlaceValidator
+
+	// Some profiles allow StopPlaces without Quays, so this stays a warning
+	r.addRule("STOP_PLACE_8", "StopPlace without Quays", "StopPlace should have at least one Quay", types.WARNING,
+		"//stopPlaces/StopPlace[not(quays/Quay)]")
 }
 
 // addJourneyPatternRules adds journey pattern validation rules
@@ -130,14 +146,12 @@ func (r *RuleRegistry) addJourneyPatternRules() {
 	r.addRule("JOURNEY_PATTERN_2", "JourneyPattern missing RouteRef", "JourneyPattern must reference a Route", types.ERROR,
 		"//journeyPatterns/*[self::JourneyPattern or self::ServiceJourneyPattern][not(RouteRef)]")
 
-	r.addRule("JOURNEY_PATTERN_3", "JourneyPattern missing StopPoints", "JourneyPattern must have stop points", types.ERROR,
-		"//journeyPatterns/*[self::JourneyPattern or self::ServiceJourneyPattern][not(pointsInSequence) or count(pointsInSequence/StopPointInJourneyPattern) < 2]")
+	// JOURNEY_PATTERN_3 reports the stop count, see business.JourneyPatternStopsValidator
 
 	r.addRule("JOURNEY_PATTERN_4", "StopPointInJourneyPattern missing order", "StopPointInJourneyPattern must have order", types.ERROR,
 		"//journeyPatterns/*[self::JourneyPattern or self::ServiceJourneyPattern]/pointsInSequence/StopPointInJourneyPattern[not(@order)]")
 
-	r.addRule("JOURNEY_PATTERN_5", "Duplicate order in JourneyPattern", "Order values must be unique within JourneyPattern", types.ERROR,
-		"//journeyPatterns/*[self::JourneyPattern or self::ServiceJourneyPattern]/pointsInSequence/StopPointInJourneyPattern[@order = preceding-sibling::StopPointInJourneyPattern/@order or @order = following-sibling::StopPointInJourneyPattern/@order]")
+	// JOURNEY_PATTERN_5 reports each collision once with all stop points, see business.JourneyPatternOrderValidator
 }
 
 // addNetworkRules adds network and operator validation rules
@@ -171,6 +185,12 @@ func (r *RuleRegistry) addFareRules() {
 
 	r.addRule("FARE_4", "Missing price for FareProduct", "FareProduct should have a price", types.WARNING,
 		"//fareProducts/FareProduct[not(prices)]")
+
+	r.addRule("FARE_5", "FareZone without members", "FareZone has no members and covers nothing", types.WARNING,
+		"//fareZones/FareZone[not(members/*)]")
+
+	r.addRule("FARE_6", "TariffZone without members", "TariffZone has no members and covers nothing", types.WARNING,
+		"//tariffZones/TariffZone[not(members/*)]")
 }
 
 // addCalendarRules adds calendar and validity period validation rules
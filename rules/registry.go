@@ -14,6 +14,7 @@ type Rule struct {
 	XPath       string
 	Category    string
 	Description string
+	DocURL      string // Documentation of the rule, overriding the docs base URL
 }
 
 // RuleRegistry manages all validation rules
@@ -42,7 +43,8 @@ func (r *RuleRegistry) WithProfile(profile string) *RuleRegistry {
 	return r
 }
 
-// GetEnabledRules returns all enabled rules based on configuration
+// GetEnabledRules returns all enabled rules based on configuration. Rules of a
+// category disabled in the configuration are left out.
 func (r *RuleRegistry) GetEnabledRules() []Rule {
 	var enabled []Rule
 
@@ -59,7 +61,10 @@ func (r *RuleRegistry) GetEnabledRules() []Rule {
 		}
 	}
 
-	// Add custom rules from config
+	// Add custom rules from config, unless their category is disabled
+	if !r.config.IsCategoryEnabled("custom") {
+		return enabled
+	}
 	for _, customRule := range r.config.GetCustomRules() {
 		enabled = append(enabled, Rule{
 			Code:     customRule.Code,
@@ -68,6 +73,7 @@ func (r *RuleRegistry) GetEnabledRules() []Rule {
 			Severity: customRule.Severity,
 			XPath:    customRule.XPath,
 			Category: "custom",
+			DocURL:   customRule.DocURL,
 		})
 	}
 
@@ -78,7 +84,7 @@ func (r *RuleRegistry) GetEnabledRules() []Rule {
 func isEUCategory(category string) bool {
 	// Conservative allow-list; expand as EU set is curated
 	switch category {
-	case "line", "route", "transport_mode", "version", "journey_pattern", "stop_point", "calendar", "validity", "interchange", "group", "tariff_zone", "responsibility_set", "type_of_service":
+	case "line", "route", "transport_mode", "version", "journey_pattern", "stop_point", "stop_place", "calendar", "validity", "interchange", "group", "tariff_zone", "responsibility_set", "type_of_service", "frame_structure":
 		return true
 	default:
 		return false
@@ -132,60 +138,8 @@ func (r *RuleRegistry) addRule(code, name, message string, severity types.Severi
 		Message:  message,
 		Severity: severity,
 		XPath:    xpath,
-		Category: r.getCategoryFromCode(code),
+		Category: config.RuleCategory(code),
 	}
 
 	r.rules = append(r.rules, rule)
 }
-
-// getCategoryFromCode determines the category from rule code
-func (r *RuleRegistry) getCategoryFromCode(code string) string {
-	categoryMap := map[string]string{
-		"LINE_":                  "line",
-		"ROUTE_":                 "route",
-		"SERVICE_JOURNEY_":       "service_journey",
-		"FLEXIBLE_LINE_":         "flexible_line",
-		"NETWORK_":               "network",
-		"AUTHORITY_":             "network",
-		"OPERATOR_":              "network",
-		"JOURNEY_PATTERN_":       "journey_pattern",
-		"STOP_POINT_":            "stop_point",
-		"SCHEDULED_STOP_":        "stop_point",
-		"VERSION_":               "version",
-		"TRANSPORT_MODE_":        "transport_mode",
-		"TRANSPORT_SUB_MODE_":    "transport_mode",
-		"BOOKING_":               "booking",
-		"FLEXIBLE_LINE_TYPE_":    "flexible_line",
-		"LINE_INVALID_COLOR":     "line",
-		"SERVICE_CALENDAR_":      "calendar",
-		"VALIDITY_CONDITIONS_":   "validity",
-		"DATED_SERVICE_JOURNEY_": "dated_service_journey",
-		"DEAD_RUN_":              "dead_run",
-		"INTERCHANGE_":           "interchange",
-		"NOTICE_":                "notice",
-		"COMPOSITE_FRAME_":       "frame",
-		"TIMETABLE_FRAME_":       "frame",
-		"SERVICE_FRAME_":         "frame",
-		"RESOURCE_FRAME_":        "frame",
-		"SITE_FRAME_":            "frame",
-		"INFRASTRUCTURE_FRAME_":  "frame",
-		"FLEXIBLE_SERVICE_":      "flexible_service",
-		"FLEXIBLE_STOP_":         "flexible_service",
-		"FLEXIBLE_AREA_":         "flexible_service",
-		"BLOCK_":                 "block",
-		"COURSE_OF_JOURNEYS_":    "course_of_journeys",
-		"TARIFF_ZONE_":           "tariff_zone",
-		"RESPONSIBILITY_SET_":    "responsibility_set",
-		"TYPE_OF_SERVICE_":       "type_of_service",
-		"GROUP_OF_":              "group",
-		"FARE_":                  "group",
-	}
-
-	for prefix, category := range categoryMap {
-		if len(code) >= len(prefix) && code[:len(prefix)] == prefix {
-			return category
-		}
-	}
-
-	return "custom"
-}
@@ -55,3 +55,37 @@ func TestRuleStruct(t *testing.T) {
 		}
 	})
 }
+
+func TestRuleDocsMatchRegistry(t *testing.T) {
+	registry := NewRuleRegistry(config.DefaultConfig())
+	for code, doc := range ruleDocs {
+		if _, ok := registry.GetRuleByCode(code); !ok {
+			t.Errorf("Documented rule %s is not in the registry", code)
+		}
+		if doc.Example == "" || doc.Fix == "" {
+			t.Errorf("Expected rule %s to document an example and a fix", code)
+		}
+	}
+}
+
+func TestRuleRegistry_DisabledCategory(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Rules.Categories["route"] = config.RuleCategoryConfig{Enabled: false}
+	registry := NewRuleRegistry(cfg)
+
+	if len(registry.GetRulesByCategory("route")) == 0 {
+		t.Fatal("Expected built-in route rules")
+	}
+	for _, rule := range registry.GetEnabledRules() {
+		if rule.Category == "route" {
+			t.Errorf("Expected rule %s of the disabled route category to be left out", rule.Code)
+		}
+	}
+
+	// The registry categorizes rules like the configuration does
+	for _, rule := range registry.rules {
+		if want := config.RuleCategory(rule.Code); rule.Category != want {
+			t.Errorf("Rule %s has category %s, configuration category %s", rule.Code, rule.Category, want)
+		}
+	}
+}
@@ -11,6 +11,12 @@ type IdVersion struct {
 	ID       string
 	Version  string
 	FileName string
+	// ElementType is the name of the element declaring the ID, such as Line, or
+	// holding the reference, such as LineRef, when extracted from a document
+	ElementType string
+	// SourceID is the ID of the nearest enclosing element with an ID of an extracted
+	// reference, such as the Route holding a LineRef
+	SourceID string
 }
 
 // NewIdVersion creates a new IdVersion
@@ -22,6 +28,43 @@ func NewIdVersion(id, version, fileName string) IdVersion {
 	}
 }
 
+// ExpectedCount is the number of elements of a type a dataset should contain, from
+// Min to Max inclusive. An exact count has Min equal to Max; a Max of 0 leaves
+// the count unbounded above.
+type ExpectedCount struct {
+	Min int `json:"min" yaml:"min"`
+	Max int `json:"max" yaml:"max"`
+}
+
+// String describes the expected count, e.g. "42", "1100-1300" or "at least 10"
+func (c ExpectedCount) String() string {
+	switch {
+	case c.Max == 0:
+		return fmt.Sprintf("at least %d", c.Min)
+	case c.Min == c.Max:
+		return fmt.Sprintf("%d", c.Min)
+	default:
+		return fmt.Sprintf("%d-%d", c.Min, c.Max)
+	}
+}
+
+// UnmarshalJSON decodes an expected count from a number, for an exact count, or from
+// an object with min and max
+func (c *ExpectedCount) UnmarshalJSON(b []byte) error {
+	var exact int
+	if err := json.Unmarshal(b, &exact); err == nil {
+		*c = ExpectedCount{Min: exact, Max: exact}
+		return nil
+	}
+	type expectedCount ExpectedCount
+	var count expectedCount
+	if err := json.Unmarshal(b, &count); err != nil {
+		return fmt.Errorf("expected count must be a number or {\"min\": n, \"max\": n}: %w", err)
+	}
+	*c = ExpectedCount(count)
+	return nil
+}
+
 // DataLocation represents the location of data in an XML document
 type DataLocation struct {
 	FileName   string
@@ -105,23 +148,31 @@ type ValidationRule struct {
 	Name     string   `yaml:"name"`
 	Message  string   `yaml:"message"`
 	Severity Severity `yaml:"severity"`
+	DocURL   string   `yaml:"docUrl,omitempty"` // Documentation of the rule, overriding the docs base URL
 }
 
 // ValidationIssue represents a validation finding
 type ValidationIssue struct {
-	Rule     ValidationRule
-	Location DataLocation
-	Message  string
-	Data     interface{} // Optional additional data
+	Rule           ValidationRule
+	Location       DataLocation
+	Message        string
+	MatchedSnippet string      // Serialized XML of the matched element, set in explain mode
+	RuleXPath      string      // XPath of the rule that matched, set when rule XPaths are included
+	Data           interface{} // Optional additional data
 }
 
 // ValidationReportEntry represents a single entry in a validation report
 type ValidationReportEntry struct {
-	Name     string       `json:"name"`
-	Message  string       `json:"message"`
-	Severity Severity     `json:"severity"`
-	FileName string       `json:"fileName"`
-	Location DataLocation `json:"location"`
+	RuleCode       string       `json:"ruleCode,omitempty"`
+	Name           string       `json:"name"`
+	Message        string       `json:"message"`
+	Severity       Severity     `json:"severity"`
+	FileName       string       `json:"fileName"`
+	Location       DataLocation `json:"location"`
+	MatchedSnippet string       `json:"matchedSnippet,omitempty"`
+	RuleXPath      string       `json:"ruleXPath,omitempty"`
+	Fingerprint    string       `json:"fingerprint,omitempty"`
+	DocURL         string       `json:"docUrl,omitempty"`
 }
 
 // ValidationReport represents the complete validation report
@@ -131,6 +182,14 @@ type ValidationReport struct {
 	CreationDate                     time.Time               `json:"creationDate"`
 	ValidationReportEntries          []ValidationReportEntry `json:"validationReportEntries"`
 	NumberOfValidationEntriesPerRule map[string]int64        `json:"numberOfValidationEntriesPerRule"`
+
+	// Entries moved to disk once more than spillThreshold are held in memory, see
+	// SpillTo. ValidationReportEntries then holds only the entries added since.
+	spill          *Spill[ValidationReportEntry]
+	spillDir       string
+	spillThreshold int
+	spillErr       error
+	spilledError   bool
 }
 
 // NewValidationReport creates a new validation report
@@ -148,6 +207,88 @@ func NewValidationReport(codespace, reportID string) *ValidationReport {
 func (vr *ValidationReport) AddValidationReportEntry(entry ValidationReportEntry) {
 	vr.ValidationReportEntries = append(vr.ValidationReportEntries, entry)
 	vr.NumberOfValidationEntriesPerRule[entry.Name]++
+	if vr.spillThreshold > 0 && vr.spillErr == nil && len(vr.ValidationReportEntries) > vr.spillThreshold {
+		vr.spillEntries()
+	}
+}
+
+// SpillTo makes the report move its entries to an NDJSON file in dir whenever more
+// than threshold entries are held in memory, so the memory used by a report does not
+// grow with its number of findings. An empty dir uses the default temporary
+// directory. The entries are read back in order through ForEachEntry, and the file is
+// removed by Close.
+func (vr *ValidationReport) SpillTo(dir string, threshold int) {
+	vr.spillDir = dir
+	vr.spillThreshold = threshold
+}
+
+// spillEntries moves the entries held in memory to the spill file. When the file
+// cannot be written the entries stay in memory and SpillErr reports why.
+func (vr *ValidationReport) spillEntries() {
+	if vr.spill == nil {
+		spill, err := NewSpill[ValidationReportEntry](vr.spillDir)
+		if err != nil {
+			vr.spillErr = err
+			return
+		}
+		vr.spill = spill
+	}
+	if err := vr.spill.Append(vr.ValidationReportEntries...); err != nil {
+		vr.spillErr = err
+		return
+	}
+	for _, entry := range vr.ValidationReportEntries {
+		if entry.Severity == ERROR || entry.Severity == CRITICAL {
+			vr.spilledError = true
+			break
+		}
+	}
+	vr.ValidationReportEntries = make([]ValidationReportEntry, 0, vr.spillThreshold)
+}
+
+// SpillErr returns the error that stopped the report from spilling entries to disk,
+// if any. The report keeps its entries in memory from then on.
+func (vr *ValidationReport) SpillErr() error {
+	return vr.spillErr
+}
+
+// SpilledEntryCount returns the number of entries moved to disk
+func (vr *ValidationReport) SpilledEntryCount() int {
+	if vr.spill == nil {
+		return 0
+	}
+	return vr.spill.Len()
+}
+
+// EntryCount returns the number of entries in the report, spilled or not
+func (vr *ValidationReport) EntryCount() int {
+	return vr.SpilledEntryCount() + len(vr.ValidationReportEntries)
+}
+
+// ForEachEntry calls fn for every entry in the order they were added, reading
+// spilled entries back from disk, and stops at the first error
+func (vr *ValidationReport) ForEachEntry(fn func(ValidationReportEntry) error) error {
+	if vr.spill != nil {
+		if err := vr.spill.ForEach(fn); err != nil {
+			return err
+		}
+	}
+	for _, entry := range vr.ValidationReportEntries {
+		if err := fn(entry); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Close removes the spill file of the report, if any. Spilled entries are lost.
+func (vr *ValidationReport) Close() error {
+	if vr.spill == nil {
+		return nil
+	}
+	err := vr.spill.Close()
+	vr.spill = nil
+	return err
 }
 
 // AddAllValidationReportEntries adds multiple validation report entries
@@ -159,6 +300,9 @@ func (vr *ValidationReport) AddAllValidationReportEntries(entries []ValidationRe
 
 // HasError returns true if the validation report contains any errors or critical issues
 func (vr *ValidationReport) HasError() bool {
+	if vr.spilledError {
+		return true
+	}
 	for _, entry := range vr.ValidationReportEntries {
 		if entry.Severity == ERROR || entry.Severity == CRITICAL {
 			return true
@@ -173,8 +317,9 @@ func (vr *ValidationReport) MergeReport(other *ValidationReport) {
 		return
 	}
 
-	// Add all entries from the other report
-	for _, entry := range other.ValidationReportEntries {
+	// Add all entries from the other report, including those it spilled
+	_ = other.ForEachEntry(func(entry ValidationReportEntry) error {
 		vr.AddValidationReportEntry(entry)
-	}
+		return nil
+	})
 }
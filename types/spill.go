@@ -0,0 +1,81 @@
+package types
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+)
+
+// Spill is an append-only sequence of values kept in a temporary NDJSON file, one
+// JSON value per line, for collections such as the findings of a nationwide
+// dataset that do not fit in memory. It is not safe for concurrent use.
+type Spill[T any] struct {
+	file   *os.File
+	writer *bufio.Writer
+	count  int
+}
+
+// NewSpill creates a spill file in dir, or in the default temporary directory when
+// dir is empty
+func NewSpill[T any](dir string) (*Spill[T], error) {
+	file, err := os.CreateTemp(dir, "netex-spill-*.ndjson")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create spill file: %w", err)
+	}
+	return &Spill[T]{file: file, writer: bufio.NewWriter(file)}, nil
+}
+
+// Append writes values to the end of the spill file
+func (s *Spill[T]) Append(values ...T) error {
+	encoder := json.NewEncoder(s.writer)
+	for _, value := range values {
+		if err := encoder.Encode(value); err != nil {
+			return fmt.Errorf("failed to write to spill file: %w", err)
+		}
+		s.count++
+	}
+	return nil
+}
+
+// Len returns the number of values written
+func (s *Spill[T]) Len() int {
+	return s.count
+}
+
+// ForEach reads the values back in the order they were written and calls fn for
+// each, stopping at the first error
+func (s *Spill[T]) ForEach(fn func(T) error) error {
+	if err := s.writer.Flush(); err != nil {
+		return fmt.Errorf("failed to write to spill file: %w", err)
+	}
+	file, err := os.Open(s.file.Name())
+	if err != nil {
+		return fmt.Errorf("failed to read spill file: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+
+	decoder := json.NewDecoder(bufio.NewReader(file))
+	for {
+		var value T
+		if err := decoder.Decode(&value); errors.Is(err, io.EOF) {
+			return nil
+		} else if err != nil {
+			return fmt.Errorf("failed to read spill file: %w", err)
+		}
+		if err := fn(value); err != nil {
+			return err
+		}
+	}
+}
+
+// Close removes the spill file
+func (s *Spill[T]) Close() error {
+	closeErr := s.file.Close()
+	if err := os.Remove(s.file.Name()); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return closeErr
+}
@@ -51,6 +51,9 @@ func NewOptimizedHTTPClient(opts *HTTPClientOptions) *OptimizedHTTPClient {
 
 	// Create custom transport with optimized settings
 	transport := &http.Transport{
+		// Honor HTTP_PROXY, HTTPS_PROXY and NO_PROXY
+		Proxy: http.ProxyFromEnvironment,
+
 		// Connection pooling settings
 		MaxIdleConns:        opts.MaxIdleConns,
 		MaxIdleConnsPerHost: opts.MaxIdleConnsPerHost,
@@ -106,6 +109,12 @@ func (c *OptimizedHTTPClient) Get(ctx context.Context, url string) (*http.Respon
 	return c.doWithRetry(req)
 }
 
+// Do performs req with the client's retry logic. Unlike Get it sets no headers,
+// so the transport decompresses gzip responses transparently.
+func (c *OptimizedHTTPClient) Do(req *http.Request) (*http.Response, error) {
+	return c.doWithRetry(req)
+}
+
 // doWithRetry performs an HTTP request with exponential backoff retry logic
 func (c *OptimizedHTTPClient) doWithRetry(req *http.Request) (*http.Response, error) {
 	var lastErr error
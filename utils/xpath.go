@@ -1,6 +1,10 @@
 package utils
 
 import (
+	"fmt"
+	"strings"
+
+	"github.com/antchfx/xmlquery"
 	"github.com/theoremus-urban-solutions/netex-validator/types"
 	"github.com/theoremus-urban-solutions/netex-validator/validation/context"
 )
@@ -57,3 +61,41 @@ func (v *XPathRuleValidator) GetRules() []types.ValidationRule {
 	}
 	return rules
 }
+
+// NodeXPath builds a simple positional XPath-like location for a node,
+// e.g. /PublicationDelivery[1]/dataObjects[1]/ServiceFrame[1]
+func NodeXPath(n *xmlquery.Node) string {
+	if n == nil {
+		return ""
+	}
+	var parts []string
+	for cur := n; cur != nil && cur.Type != xmlquery.DocumentNode; cur = cur.Parent {
+		// position among siblings with same name
+		pos := 1
+		for sib := cur.PrevSibling; sib != nil; sib = sib.PrevSibling {
+			if sib.Type == xmlquery.ElementNode && sib.Data == cur.Data {
+				pos++
+			}
+		}
+		parts = append(parts, fmt.Sprintf("/%s[%d]", cur.Data, pos))
+	}
+	// reverse
+	for i, j := 0, len(parts)-1; i < j; i, j = i+1, j-1 {
+		parts[i], parts[j] = parts[j], parts[i]
+	}
+	return strings.Join(parts, "")
+}
+
+// NodeSnippet serializes a node to XML with whitespace collapsed, truncated to
+// at most limit characters followed by "..."
+func NodeSnippet(n *xmlquery.Node, limit int) string {
+	if n == nil {
+		return ""
+	}
+	snippet := strings.Join(strings.Fields(n.OutputXML(true)), " ")
+	snippet = strings.ReplaceAll(snippet, "> <", "><")
+	if runes := []rune(snippet); limit > 0 && len(runes) > limit {
+		snippet = string(runes[:limit]) + "..."
+	}
+	return snippet
+}
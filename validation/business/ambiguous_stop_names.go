@@ -0,0 +1,125 @@
+package business
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/antchfx/xmlquery"
+	"github.com/theoremus-urban-solutions/netex-validator/interfaces"
+	"github.com/theoremus-urban-solutions/netex-validator/types"
+	"github.com/theoremus-urban-solutions/netex-validator/utils"
+	"github.com/theoremus-urban-solutions/netex-validator/validation/context"
+)
+
+// namedStopPoint is a ScheduledStopPoint with a Name and where it was declared
+type namedStopPoint struct {
+	id       string
+	fileName string
+	xpath    string
+}
+
+// AmbiguousStopNameValidator reports ScheduledStopPoint Names shared by more stop
+// points than a threshold. Many stops called "Church" or "School" leave passengers
+// unable to tell them apart, but some repetition is normal, so this is a soft signal.
+type AmbiguousStopNameValidator struct {
+	mu        sync.Mutex
+	threshold int
+	names     map[string][]namedStopPoint // name -> stop points with that name
+	rules     []types.ValidationRule
+}
+
+// NewAmbiguousStopNameValidator creates a validator reporting Names shared by more
+// than threshold ScheduledStopPoints
+func NewAmbiguousStopNameValidator(threshold int) *AmbiguousStopNameValidator {
+	return &AmbiguousStopNameValidator{
+		threshold: threshold,
+		names:     make(map[string][]namedStopPoint),
+		rules: []types.ValidationRule{
+			{
+				Code:     "SCHEDULED_STOP_POINT_AMBIGUOUS_NAME",
+				Name:     "ScheduledStopPoint ambiguous Name",
+				Message:  "Many ScheduledStopPoints share the same Name",
+				Severity: types.INFO,
+			},
+		},
+	}
+}
+
+// Collect records the Name of every ScheduledStopPoint in a file
+func (v *AmbiguousStopNameValidator) Collect(ctx context.XPathValidationContext) error {
+	if ctx.Document == nil {
+		return nil
+	}
+
+	names := make(map[string][]namedStopPoint)
+	for _, node := range xmlquery.Find(ctx.Document, "//scheduledStopPoints/ScheduledStopPoint[@id]") {
+		name := childText(node, "Name")
+		if name == "" {
+			continue
+		}
+		names[name] = append(names[name], namedStopPoint{
+			id:       node.SelectAttr("id"),
+			fileName: ctx.GetFileName(),
+			xpath:    utils.NodeXPath(node),
+		})
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	for name, stops := range names {
+		v.names[name] = append(v.names[name], stops...)
+	}
+	return nil
+}
+
+// Validate reports each Name shared by more stop points than the threshold, located
+// at the stop point with the lowest id
+func (v *AmbiguousStopNameValidator) Validate(repository interfaces.IdRepository) ([]types.ValidationIssue, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	names := make([]string, 0, len(v.names))
+	for name, stops := range v.names {
+		if len(stops) > v.threshold {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	var issues []types.ValidationIssue
+	for _, name := range names {
+		stops := v.names[name]
+		sort.SliceStable(stops, func(i, j int) bool { return stops[i].id < stops[j].id })
+		ids := make([]string, len(stops))
+		for i, stop := range stops {
+			ids[i] = stop.id
+		}
+
+		issues = append(issues, types.ValidationIssue{
+			Rule: v.rules[0],
+			Location: types.DataLocation{
+				FileName:  stops[0].fileName,
+				XPath:     stops[0].xpath,
+				ElementID: stops[0].id,
+			},
+			Message: fmt.Sprintf("Name '%s' is shared by %d ScheduledStopPoints: %s",
+				name, len(stops), strings.Join(ids, ", ")),
+		})
+	}
+
+	return issues, nil
+}
+
+// GetRules returns the rules implemented by this validator
+func (v *AmbiguousStopNameValidator) GetRules() []types.ValidationRule {
+	return v.rules
+}
+
+// Reset clears all collected data
+func (v *AmbiguousStopNameValidator) Reset() {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.names = make(map[string][]namedStopPoint)
+}
@@ -0,0 +1,67 @@
+package business
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/theoremus-urban-solutions/netex-validator/types"
+	"github.com/theoremus-urban-solutions/netex-validator/validation/ids"
+)
+
+// stopPointsDocument returns a document declaring count ScheduledStopPoints named
+// name, with ids numbered from first
+func stopPointsDocument(name string, first, count int) string {
+	var b strings.Builder
+	b.WriteString(`<?xml version="1.0" encoding="UTF-8"?>
+<PublicationDelivery xmlns="http://www.netex.org.uk/netex" version="1.15">
+	<dataObjects>
+		<ServiceFrame id="TEST:ServiceFrame:1" version="1">
+			<scheduledStopPoints>
+`)
+	for i := first; i < first+count; i++ {
+		fmt.Fprintf(&b, "\t\t\t\t<ScheduledStopPoint id=\"TEST:ScheduledStopPoint:%03d\" version=\"1\"><Name>%s</Name></ScheduledStopPoint>\n", i, name)
+	}
+	b.WriteString(`			</scheduledStopPoints>
+		</ServiceFrame>
+	</dataObjects>
+</PublicationDelivery>`)
+	return b.String()
+}
+
+func TestAmbiguousStopNameValidator(t *testing.T) {
+	validator := NewAmbiguousStopNameValidator(30)
+	for _, file := range []struct {
+		name, document string
+	}{
+		{"north.xml", stopPointsDocument("Church", 1, 20)},
+		{"south.xml", stopPointsDocument(" Church ", 21, 20)},
+		{"school.xml", stopPointsDocument("School", 41, 30)},
+		{"unique.xml", stopPointsDocument("Central Station", 71, 1)},
+	} {
+		if err := validator.Collect(newTestXPathContext(t, file.name, file.document)); err != nil {
+			t.Fatalf("Collect(%s) error = %v", file.name, err)
+		}
+	}
+
+	issues, err := validator.Validate(ids.NewNetexIdRepository())
+	if err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if len(issues) != 1 {
+		t.Fatalf("Expected 1 issue, got %d: %+v", len(issues), issues)
+	}
+
+	issue := issues[0]
+	if issue.Rule.Code != "SCHEDULED_STOP_POINT_AMBIGUOUS_NAME" || issue.Rule.Severity != types.INFO {
+		t.Errorf("Expected SCHEDULED_STOP_POINT_AMBIGUOUS_NAME info, got %s (%v)", issue.Rule.Code, issue.Rule.Severity)
+	}
+	if issue.Location.ElementID != "TEST:ScheduledStopPoint:001" || issue.Location.FileName != "north.xml" {
+		t.Errorf("Expected issue on TEST:ScheduledStopPoint:001 in north.xml, got %+v", issue.Location)
+	}
+	for _, want := range []string{"Name 'Church' is shared by 40 ScheduledStopPoints", "TEST:ScheduledStopPoint:021", "TEST:ScheduledStopPoint:040"} {
+		if !strings.Contains(issue.Message, want) {
+			t.Errorf("Expected message to contain %q, got %q", want, issue.Message)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package business
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/antchfx/xmlquery"
+	"github.com/theoremus-urban-solutions/netex-validator/types"
+	"github.com/theoremus-urban-solutions/netex-validator/utils"
+	"github.com/theoremus-urban-solutions/netex-validator/validation/context"
+)
+
+// BookingContactValidator checks that the BookingContacts of FlexibleLines, their
+// own or those of their booking arrangements, give passengers a way to book. Only
+// plausibility is checked: an Email needs an @, a Url an http(s) scheme and a
+// Phone some digits.
+type BookingContactValidator struct {
+	rules []types.ValidationRule
+}
+
+// NewBookingContactValidator creates a new booking contact validator
+func NewBookingContactValidator() *BookingContactValidator {
+	return &BookingContactValidator{
+		rules: []types.ValidationRule{
+			{
+				Code:     "BOOKING_CONTACT_EMPTY",
+				Name:     "FlexibleLine booking contact empty",
+				Message:  "BookingContact should have a Phone, Email or Url",
+				Severity: types.WARNING,
+			},
+			{
+				Code:     "BOOKING_CONTACT_INVALID",
+				Name:     "FlexibleLine booking contact invalid",
+				Message:  "BookingContact Phone, Email or Url is not plausible",
+				Severity: types.WARNING,
+			},
+		},
+	}
+}
+
+// Validate checks every BookingContact within a FlexibleLine of the document
+func (v *BookingContactValidator) Validate(ctx context.XPathValidationContext) ([]types.ValidationIssue, error) {
+	var issues []types.ValidationIssue
+	if ctx.Document == nil {
+		return issues, nil
+	}
+
+	for _, line := range xmlquery.Find(ctx.Document, "//lines/FlexibleLine") {
+		id := line.SelectAttr("id")
+		for _, contact := range xmlquery.Find(line, ".//BookingContact") {
+			phone := childText(contact, "Phone")
+			email := childText(contact, "Email")
+			url := childText(contact, "Url")
+			if phone == "" && email == "" && url == "" {
+				issues = append(issues, v.newIssue(ctx, v.rules[0], contact, id,
+					fmt.Sprintf("FlexibleLine '%s' has a BookingContact without Phone, Email or Url", id)))
+				continue
+			}
+
+			if phone != "" && !strings.ContainsAny(phone, "0123456789") {
+				issues = append(issues, v.newIssue(ctx, v.rules[1], contact, id,
+					fmt.Sprintf("FlexibleLine '%s' has BookingContact Phone '%s' without digits", id, phone)))
+			}
+			if email != "" && !isPlausibleEmail(email) {
+				issues = append(issues, v.newIssue(ctx, v.rules[1], contact, id,
+					fmt.Sprintf("FlexibleLine '%s' has BookingContact Email '%s' that is not an email address", id, email)))
+			}
+			if url != "" && !strings.HasPrefix(url, "http://") && !strings.HasPrefix(url, "https://") {
+				issues = append(issues, v.newIssue(ctx, v.rules[1], contact, id,
+					fmt.Sprintf("FlexibleLine '%s' has BookingContact Url '%s' that does not start with http:// or https://", id, url)))
+			}
+		}
+	}
+
+	return issues, nil
+}
+
+// GetRules returns the rules implemented by this validator
+func (v *BookingContactValidator) GetRules() []types.ValidationRule {
+	return v.rules
+}
+
+func (v *BookingContactValidator) newIssue(ctx context.XPathValidationContext, rule types.ValidationRule, node *xmlquery.Node, id, message string) types.ValidationIssue {
+	return types.ValidationIssue{
+		Rule: rule,
+		Location: types.DataLocation{
+			FileName:  ctx.GetFileName(),
+			XPath:     utils.NodeXPath(node),
+			ElementID: id,
+		},
+		Message: message,
+	}
+}
+
+// isPlausibleEmail reports whether value has a local part and a domain around an @
+func isPlausibleEmail(value string) bool {
+	local, domain, ok := strings.Cut(value, "@")
+	return ok && local != "" && domain != "" && !strings.ContainsAny(value, " \t")
+}
@@ -0,0 +1,70 @@
+package business
+
+import (
+	"testing"
+)
+
+func TestBookingContactValidator(t *testing.T) {
+	document := `<?xml version="1.0" encoding="UTF-8"?>
+<PublicationDelivery xmlns="http://www.netex.org.uk/netex" version="1.15">
+	<dataObjects>
+		<ServiceFrame id="TEST:ServiceFrame:1" version="1">
+			<lines>
+				<FlexibleLine id="TEST:FlexibleLine:Valid" version="1">
+					<BookingContact>
+						<Phone>+47 22 00 00 00</Phone>
+						<Email>booking@example.org</Email>
+						<Url>https://example.org/book</Url>
+					</BookingContact>
+				</FlexibleLine>
+				<FlexibleLine id="TEST:FlexibleLine:Empty" version="1">
+					<BookingContact>
+						<Phone> </Phone>
+					</BookingContact>
+				</FlexibleLine>
+				<FlexibleLine id="TEST:FlexibleLine:Invalid" version="1">
+					<BookingContact>
+						<Phone>call the office</Phone>
+						<Email>booking.example.org</Email>
+						<Url>www.example.org</Url>
+					</BookingContact>
+				</FlexibleLine>
+				<FlexibleLine id="TEST:FlexibleLine:Arrangements" version="1">
+					<bookingArrangements>
+						<BookingArrangement id="TEST:BookingArrangement:1" version="1">
+							<BookingContact>
+								<Email>@example.org</Email>
+							</BookingContact>
+						</BookingArrangement>
+					</bookingArrangements>
+				</FlexibleLine>
+				<Line id="TEST:Line:1" version="1">
+					<BookingContact/>
+				</Line>
+			</lines>
+		</ServiceFrame>
+	</dataObjects>
+</PublicationDelivery>`
+
+	issues, err := NewBookingContactValidator().Validate(newTestXPathContext(t, "lines.xml", document))
+	if err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+
+	want := []struct{ code, id, message string }{
+		{"BOOKING_CONTACT_EMPTY", "TEST:FlexibleLine:Empty", "FlexibleLine 'TEST:FlexibleLine:Empty' has a BookingContact without Phone, Email or Url"},
+		{"BOOKING_CONTACT_INVALID", "TEST:FlexibleLine:Invalid", "FlexibleLine 'TEST:FlexibleLine:Invalid' has BookingContact Phone 'call the office' without digits"},
+		{"BOOKING_CONTACT_INVALID", "TEST:FlexibleLine:Invalid", "FlexibleLine 'TEST:FlexibleLine:Invalid' has BookingContact Email 'booking.example.org' that is not an email address"},
+		{"BOOKING_CONTACT_INVALID", "TEST:FlexibleLine:Invalid", "FlexibleLine 'TEST:FlexibleLine:Invalid' has BookingContact Url 'www.example.org' that does not start with http:// or https://"},
+		{"BOOKING_CONTACT_INVALID", "TEST:FlexibleLine:Arrangements", "FlexibleLine 'TEST:FlexibleLine:Arrangements' has BookingContact Email '@example.org' that is not an email address"},
+	}
+	if len(issues) != len(want) {
+		t.Fatalf("Expected %d issues, got %d: %+v", len(want), len(issues), issues)
+	}
+	for i, w := range want {
+		if issues[i].Rule.Code != w.code || issues[i].Location.ElementID != w.id || issues[i].Message != w.message {
+			t.Errorf("Issue %d: expected %s on %s with %q, got %s on %s with %q", i,
+				w.code, w.id, w.message, issues[i].Rule.Code, issues[i].Location.ElementID, issues[i].Message)
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package business
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/antchfx/xmlquery"
+	"github.com/theoremus-urban-solutions/netex-validator/types"
+	"github.com/theoremus-urban-solutions/netex-validator/utils"
+	"github.com/theoremus-urban-solutions/netex-validator/validation/context"
+)
+
+// calendarDateFormat classifies how a calendar date is written
+type calendarDateFormat string
+
+const (
+	formatDate     calendarDateFormat = "date"      // 2023-01-01
+	formatDateTime calendarDateFormat = "date-time" // 2023-01-01T00:00:00
+)
+
+// classifyCalendarDate returns the format of a FromDate or ToDate value, or false
+// for a value that is neither an xsd:date nor an xsd:dateTime
+func classifyCalendarDate(value string) (calendarDateFormat, bool) {
+	for _, layout := range xsdDateLayouts {
+		if _, err := time.Parse(layout, value); err == nil {
+			return formatDate, true
+		}
+	}
+	for _, layout := range xsdDateTimeLayouts {
+		if _, err := time.Parse(layout, value); err == nil {
+			return formatDateTime, true
+		}
+	}
+	return "", false
+}
+
+// calendarDate is a FromDate or ToDate of a calendar element
+type calendarDate struct {
+	node   *xmlquery.Node
+	value  string
+	format calendarDateFormat
+}
+
+// CalendarDateFormatValidator flags files whose ServiceCalendar, OperatingPeriod and
+// AvailabilityCondition dates mix plain dates and date-times. Consumers that parse
+// all of them with one layout then misread or reject some. The format most dates of
+// the file use is taken as intended, the first one in the file on a tie, and every
+// date in the other format is reported.
+type CalendarDateFormatValidator struct {
+	rules []types.ValidationRule
+}
+
+// NewCalendarDateFormatValidator creates a new calendar date format validator
+func NewCalendarDateFormatValidator() *CalendarDateFormatValidator {
+	return &CalendarDateFormatValidator{
+		rules: []types.ValidationRule{
+			{
+				Code:     "SERVICE_CALENDAR_MIXED_DATE_FORMATS",
+				Name:     "Mixed calendar date formats",
+				Message:  "Calendar FromDate and ToDate values should use one format within a file",
+				Severity: types.WARNING,
+			},
+		},
+	}
+}
+
+// Validate classifies every FromDate and ToDate of the document's calendar elements
+// and reports those not in the file's prevailing format
+func (v *CalendarDateFormatValidator) Validate(ctx context.XPathValidationContext) ([]types.ValidationIssue, error) {
+	var issues []types.ValidationIssue
+	if ctx.Document == nil {
+		return issues, nil
+	}
+
+	var dates []calendarDate
+	counts := make(map[calendarDateFormat]int)
+	for _, node := range xmlquery.Find(ctx.Document,
+		"//ServiceCalendar/FromDate | //ServiceCalendar/ToDate | //OperatingPeriod/FromDate | //OperatingPeriod/ToDate | //AvailabilityCondition/FromDate | //AvailabilityCondition/ToDate") {
+		value := strings.TrimSpace(node.InnerText())
+		// Malformed dates are left to the schema
+		format, ok := classifyCalendarDate(value)
+		if !ok {
+			continue
+		}
+		dates = append(dates, calendarDate{node: node, value: value, format: format})
+		counts[format]++
+	}
+	if len(counts) < 2 {
+		return issues, nil
+	}
+
+	prevailing := dates[0].format
+	for format, count := range counts {
+		if count > counts[prevailing] {
+			prevailing = format
+		}
+	}
+
+	for _, date := range dates {
+		if date.format == prevailing {
+			continue
+		}
+		parent := date.node.Parent
+		id := parent.SelectAttr("id")
+		issues = append(issues, types.ValidationIssue{
+			Rule: v.rules[0],
+			Location: types.DataLocation{
+				FileName:  ctx.GetFileName(),
+				XPath:     utils.NodeXPath(date.node),
+				ElementID: id,
+			},
+			Message: fmt.Sprintf("%s '%s' has %s '%s' written as a %s, while %d of the file's %d calendar dates are written as a %s",
+				parent.Data, id, date.node.Data, date.value, date.format, counts[prevailing], len(dates), prevailing),
+		})
+	}
+
+	return issues, nil
+}
+
+// GetRules returns the rules implemented by this validator
+func (v *CalendarDateFormatValidator) GetRules() []types.ValidationRule {
+	return v.rules
+}
@@ -0,0 +1,84 @@
+package business
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCalendarDateFormatValidator(t *testing.T) {
+	document := `<?xml version="1.0" encoding="UTF-8"?>
+<PublicationDelivery xmlns="http://www.netex.org.uk/netex" version="1.15">
+	<dataObjects>
+		<ServiceCalendarFrame id="TEST:ServiceCalendarFrame:1" version="1">
+			<validityConditions>
+				<AvailabilityCondition id="TEST:AvailabilityCondition:1" version="1">
+					<FromDate>2024-01-01T00:00:00</FromDate>
+					<ToDate>2024-12-31T23:59:59</ToDate>
+				</AvailabilityCondition>
+			</validityConditions>
+			<ServiceCalendar id="TEST:ServiceCalendar:1" version="1">
+				<FromDate>2024-01-01</FromDate>
+				<ToDate>2024-12-31T00:00:00</ToDate>
+			</ServiceCalendar>
+			<operatingPeriods>
+				<OperatingPeriod id="TEST:OperatingPeriod:1" version="1">
+					<FromDate>2024-01-01T00:00:00</FromDate>
+					<ToDate>2024-06-30+01:00</ToDate>
+				</OperatingPeriod>
+				<OperatingPeriod id="TEST:OperatingPeriod:Malformed" version="1">
+					<FromDate>01.07.2024</FromDate>
+				</OperatingPeriod>
+			</operatingPeriods>
+		</ServiceCalendarFrame>
+	</dataObjects>
+</PublicationDelivery>`
+
+	validator := NewCalendarDateFormatValidator()
+	issues, err := validator.Validate(newTestXPathContext(t, "calendar.xml", document))
+	if err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+
+	// Four date-times against two dates: the dates are reported
+	if len(issues) != 2 {
+		t.Fatalf("Expected 2 issues, got %d: %v", len(issues), issues)
+	}
+	for i, want := range []string{
+		"ServiceCalendar 'TEST:ServiceCalendar:1' has FromDate '2024-01-01' written as a date, while 4 of the file's 6 calendar dates are written as a date-time",
+		"OperatingPeriod 'TEST:OperatingPeriod:1' has ToDate '2024-06-30+01:00' written as a date",
+	} {
+		if !strings.HasPrefix(issues[i].Message, want) {
+			t.Errorf("Issue %d: expected message starting with %q, got %q", i, want, issues[i].Message)
+		}
+		if issues[i].Rule.Code != "SERVICE_CALENDAR_MIXED_DATE_FORMATS" {
+			t.Errorf("Issue %d: expected SERVICE_CALENDAR_MIXED_DATE_FORMATS, got %s", i, issues[i].Rule.Code)
+		}
+	}
+}
+
+func TestCalendarDateFormatValidator_ConsistentFormats(t *testing.T) {
+	for _, dates := range [][2]string{
+		{"2024-01-01", "2024-12-31Z"},
+		{"2024-01-01T00:00:00", "2024-12-31T23:59:59+01:00"},
+	} {
+		document := `<?xml version="1.0" encoding="UTF-8"?>
+<PublicationDelivery xmlns="http://www.netex.org.uk/netex" version="1.15">
+	<dataObjects>
+		<ServiceCalendarFrame id="TEST:ServiceCalendarFrame:1" version="1">
+			<ServiceCalendar id="TEST:ServiceCalendar:1" version="1">
+				<FromDate>` + dates[0] + `</FromDate>
+				<ToDate>` + dates[1] + `</ToDate>
+			</ServiceCalendar>
+		</ServiceCalendarFrame>
+	</dataObjects>
+</PublicationDelivery>`
+
+		issues, err := NewCalendarDateFormatValidator().Validate(newTestXPathContext(t, "calendar.xml", document))
+		if err != nil {
+			t.Fatalf("Validate() error = %v", err)
+		}
+		if len(issues) != 0 {
+			t.Errorf("Expected no issues for %v, got %v", dates, issues)
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package business
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/antchfx/xmlquery"
+	"github.com/theoremus-urban-solutions/netex-validator/interfaces"
+	"github.com/theoremus-urban-solutions/netex-validator/types"
+	"github.com/theoremus-urban-solutions/netex-validator/utils"
+	"github.com/theoremus-urban-solutions/netex-validator/validation/context"
+)
+
+// declaredJourney records where a ServiceJourney without DayTypeRefs was declared
+type declaredJourney struct {
+	id       string
+	fileName string
+	xpath    string
+}
+
+// ServiceJourneyCalendarValidator reports ServiceJourneys that have no DayTypeRef
+// and are not referenced by any DatedServiceJourney, i.e. journeys that never
+// operate. DatedServiceJourneys are often published in a different file than the
+// journeys they date, so the linkage is resolved across the dataset.
+type ServiceJourneyCalendarValidator struct {
+	mu       sync.Mutex
+	journeys map[string]declaredJourney
+	dated    map[string]struct{}
+	rules    []types.ValidationRule
+}
+
+// NewServiceJourneyCalendarValidator creates a new service journey calendar validator
+func NewServiceJourneyCalendarValidator() *ServiceJourneyCalendarValidator {
+	return &ServiceJourneyCalendarValidator{
+		journeys: make(map[string]declaredJourney),
+		dated:    make(map[string]struct{}),
+		rules: []types.ValidationRule{
+			{
+				Code:     "SERVICE_JOURNEY_13",
+				Name:     "ServiceJourney missing reference to calendar data",
+				Message:  "ServiceJourney has no DayTypeRef and is not referenced by any DatedServiceJourney",
+				Severity: types.ERROR,
+			},
+		},
+	}
+}
+
+// Collect records the ServiceJourneys without DayTypeRefs and the ServiceJourneyRefs
+// of the DatedServiceJourneys in a file
+func (v *ServiceJourneyCalendarValidator) Collect(ctx context.XPathValidationContext) error {
+	if ctx.Document == nil {
+		return nil
+	}
+
+	var journeys []declaredJourney
+	for _, node := range xmlquery.Find(ctx.Document, "//vehicleJourneys/ServiceJourney[@id][not(dayTypes/DayTypeRef)]") {
+		journeys = append(journeys, declaredJourney{
+			id:       node.SelectAttr("id"),
+			fileName: ctx.GetFileName(),
+			xpath:    utils.NodeXPath(node),
+		})
+	}
+
+	var refs []string
+	for _, node := range xmlquery.Find(ctx.Document, "//DatedServiceJourney/ServiceJourneyRef") {
+		if ref := refValue(node); ref != "" {
+			refs = append(refs, ref)
+		}
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	for _, journey := range journeys {
+		v.journeys[journey.id] = journey
+	}
+	for _, ref := range refs {
+		v.dated[ref] = struct{}{}
+	}
+	return nil
+}
+
+// Validate reports every collected ServiceJourney that no DatedServiceJourney refers to
+func (v *ServiceJourneyCalendarValidator) Validate(repository interfaces.IdRepository) ([]types.ValidationIssue, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	ids := make([]string, 0, len(v.journeys))
+	for id := range v.journeys {
+		if _, dated := v.dated[id]; !dated {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+
+	issues := make([]types.ValidationIssue, 0, len(ids))
+	for _, id := range ids {
+		journey := v.journeys[id]
+		issues = append(issues, types.ValidationIssue{
+			Rule: v.rules[0],
+			Location: types.DataLocation{
+				FileName:  journey.fileName,
+				XPath:     journey.xpath,
+				ElementID: journey.id,
+			},
+			Message: fmt.Sprintf("ServiceJourney '%s' has no DayTypeRef and is not referenced by any DatedServiceJourney, so it never operates", journey.id),
+		})
+	}
+
+	return issues, nil
+}
+
+// GetRules returns the rules implemented by this validator
+func (v *ServiceJourneyCalendarValidator) GetRules() []types.ValidationRule {
+	return v.rules
+}
+
+// Reset clears all collected data
+func (v *ServiceJourneyCalendarValidator) Reset() {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.journeys = make(map[string]declaredJourney)
+	v.dated = make(map[string]struct{})
+}
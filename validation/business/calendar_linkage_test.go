@@ -0,0 +1,78 @@
+package business
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/theoremus-urban-solutions/netex-validator/validation/ids"
+)
+
+func TestServiceJourneyCalendarValidator(t *testing.T) {
+	journeys := `<?xml version="1.0" encoding="UTF-8"?>
+<PublicationDelivery xmlns="http://www.netex.org.uk/netex" version="1.15">
+	<dataObjects>
+		<TimetableFrame id="TEST:TimetableFrame:1" version="1">
+			<vehicleJourneys>
+				<ServiceJourney id="TEST:ServiceJourney:DayType" version="1">
+					<dayTypes><DayTypeRef ref="TEST:DayType:Weekdays"/></dayTypes>
+				</ServiceJourney>
+				<ServiceJourney id="TEST:ServiceJourney:Dated" version="1"/>
+				<ServiceJourney id="TEST:ServiceJourney:Unlinked" version="1"/>
+			</vehicleJourneys>
+		</TimetableFrame>
+	</dataObjects>
+</PublicationDelivery>`
+
+	dated := `<?xml version="1.0" encoding="UTF-8"?>
+<PublicationDelivery xmlns="http://www.netex.org.uk/netex" version="1.15">
+	<dataObjects>
+		<TimetableFrame id="TEST:TimetableFrame:2" version="1">
+			<vehicleJourneys>
+				<DatedServiceJourney id="TEST:DatedServiceJourney:1" version="1">
+					<ServiceJourneyRef ref="TEST:ServiceJourney:Dated"/>
+					<OperatingDayRef ref="TEST:OperatingDay:1"/>
+				</DatedServiceJourney>
+			</vehicleJourneys>
+		</TimetableFrame>
+	</dataObjects>
+</PublicationDelivery>`
+
+	validator := NewServiceJourneyCalendarValidator()
+	if err := validator.Collect(newTestXPathContext(t, "journeys.xml", journeys)); err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+	if err := validator.Collect(newTestXPathContext(t, "dated.xml", dated)); err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+
+	issues, err := validator.Validate(ids.NewNetexIdRepository())
+	if err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if len(issues) != 1 {
+		t.Fatalf("Expected 1 issue, got %d: %+v", len(issues), issues)
+	}
+
+	issue := issues[0]
+	if issue.Rule.Code != "SERVICE_JOURNEY_13" {
+		t.Errorf("Expected SERVICE_JOURNEY_13, got %s", issue.Rule.Code)
+	}
+	if issue.Location.ElementID != "TEST:ServiceJourney:Unlinked" {
+		t.Errorf("Expected unlinked journey to be reported, got %s", issue.Location.ElementID)
+	}
+	if issue.Location.FileName != "journeys.xml" {
+		t.Errorf("Expected journeys.xml, got %s", issue.Location.FileName)
+	}
+	if !strings.Contains(issue.Message, "never operates") {
+		t.Errorf("Expected message to explain the journey never operates, got %q", issue.Message)
+	}
+
+	validator.Reset()
+	issues, err = validator.Validate(nil)
+	if err != nil {
+		t.Fatalf("Validate() after Reset error = %v", err)
+	}
+	if len(issues) != 0 {
+		t.Errorf("Expected no issues after Reset, got %d", len(issues))
+	}
+}
@@ -0,0 +1,117 @@
+package business
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/antchfx/xmlquery"
+	"github.com/theoremus-urban-solutions/netex-validator/interfaces"
+	"github.com/theoremus-urban-solutions/netex-validator/types"
+	"github.com/theoremus-urban-solutions/netex-validator/validation/context"
+)
+
+// CodespacePrefixValidator checks that every declared id starts with a known
+// codespace, as in NO:Line:1 for codespace NO. Known codespaces are the one the
+// dataset is validated for and those declared by Codespace elements, whose Xmlns
+// is the prefix. The ids themselves come from the ID repository once all files
+// are loaded.
+type CodespacePrefixValidator struct {
+	mu         sync.Mutex
+	codespace  string
+	codespaces map[string]bool // Xmlns values of declared Codespaces
+	rules      []types.ValidationRule
+}
+
+// NewCodespacePrefixValidator creates a new codespace prefix validator for the
+// given validation codespace. An empty codespace only admits declared codespaces.
+func NewCodespacePrefixValidator(codespace string) *CodespacePrefixValidator {
+	return &CodespacePrefixValidator{
+		codespace:  codespace,
+		codespaces: make(map[string]bool),
+		rules: []types.ValidationRule{
+			{
+				Code:     "ID_CODESPACE_PREFIX",
+				Name:     "Id without codespace prefix",
+				Message:  "Element id does not start with a declared codespace",
+				Severity: types.WARNING,
+			},
+		},
+	}
+}
+
+// Collect records the codespaces declared in a file
+func (v *CodespacePrefixValidator) Collect(ctx context.XPathValidationContext) error {
+	if ctx.Document == nil {
+		return nil
+	}
+
+	var declared []string
+	for _, node := range xmlquery.Find(ctx.Document, "//codespaces/Codespace") {
+		if xmlns := childText(node, "Xmlns"); xmlns != "" {
+			declared = append(declared, xmlns)
+		}
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	for _, xmlns := range declared {
+		v.codespaces[xmlns] = true
+	}
+	return nil
+}
+
+// Validate reports each registered id whose prefix is not a known codespace
+func (v *CodespacePrefixValidator) Validate(repository interfaces.IdRepository) ([]types.ValidationIssue, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	known := make(map[string]bool, len(v.codespaces)+1)
+	for xmlns := range v.codespaces {
+		known[xmlns] = true
+	}
+	if v.codespace != "" {
+		known[v.codespace] = true
+	}
+	if len(known) == 0 || repository == nil {
+		return nil, nil
+	}
+
+	expected := make([]string, 0, len(known))
+	for codespace := range known {
+		expected = append(expected, codespace+":")
+	}
+	sort.Strings(expected)
+
+	var issues []types.ValidationIssue
+	for id, idVersion := range repository.GetAllIds() {
+		prefix, _, _ := strings.Cut(id, ":")
+		if known[prefix] {
+			continue
+		}
+		issues = append(issues, types.ValidationIssue{
+			Rule: v.rules[0],
+			Location: types.DataLocation{
+				FileName:  idVersion.FileName,
+				ElementID: id,
+			},
+			Message: fmt.Sprintf("Id '%s' has prefix '%s', expected %s", id, prefix, strings.Join(expected, " or ")),
+		})
+	}
+
+	sort.Slice(issues, func(i, j int) bool { return issues[i].Location.ElementID < issues[j].Location.ElementID })
+	return issues, nil
+}
+
+// GetRules returns the rules implemented by this validator
+func (v *CodespacePrefixValidator) GetRules() []types.ValidationRule {
+	return v.rules
+}
+
+// Reset clears all collected data
+func (v *CodespacePrefixValidator) Reset() {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.codespaces = make(map[string]bool)
+}
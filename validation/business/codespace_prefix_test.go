@@ -0,0 +1,122 @@
+package business
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/theoremus-urban-solutions/netex-validator/types"
+	"github.com/theoremus-urban-solutions/netex-validator/validation/ids"
+)
+
+func TestCodespacePrefixValidator(t *testing.T) {
+	codespaces := `<?xml version="1.0" encoding="UTF-8"?>
+<PublicationDelivery xmlns="http://www.netex.org.uk/netex" version="1.15">
+	<dataObjects>
+		<CompositeFrame id="TEST:CompositeFrame:1" version="1">
+			<codespaces>
+				<Codespace id="nsr"><Xmlns>NSR</Xmlns><XmlnsUrl>http://www.rutebanken.org/ns/nsr</XmlnsUrl></Codespace>
+			</codespaces>
+		</CompositeFrame>
+	</dataObjects>
+</PublicationDelivery>`
+
+	repository := ids.NewNetexIdRepository()
+	for _, id := range []string{"TEST:Line:1", "NSR:StopPlace:1", "OTHER:Line:2", "Line3"} {
+		if err := repository.AddId(id, "1", "line.xml"); err != nil {
+			t.Fatalf("AddId(%s) error = %v", id, err)
+		}
+	}
+
+	validator := NewCodespacePrefixValidator("TEST")
+	if err := validator.Collect(newTestXPathContext(t, "_common.xml", codespaces)); err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+
+	issues, err := validator.Validate(repository)
+	if err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if len(issues) != 2 {
+		t.Fatalf("Expected 2 issues, got %d: %+v", len(issues), issues)
+	}
+
+	// Sorted by id: an id without any prefix, then a mismatching prefix
+	if issues[0].Location.ElementID != "Line3" {
+		t.Errorf("Expected Line3 without prefix to be reported first, got %s", issues[0].Location.ElementID)
+	}
+	if issues[1].Location.ElementID != "OTHER:Line:2" {
+		t.Errorf("Expected OTHER:Line:2 to be reported, got %s", issues[1].Location.ElementID)
+	}
+	want := "Id 'OTHER:Line:2' has prefix 'OTHER', expected NSR: or TEST:"
+	if issues[1].Message != want {
+		t.Errorf("Expected message %q, got %q", want, issues[1].Message)
+	}
+	for _, issue := range issues {
+		if issue.Rule.Code != "ID_CODESPACE_PREFIX" || issue.Rule.Severity != types.WARNING {
+			t.Errorf("Expected ID_CODESPACE_PREFIX warning, got %+v", issue.Rule)
+		}
+		if issue.Location.FileName != "line.xml" {
+			t.Errorf("Expected line.xml, got %s", issue.Location.FileName)
+		}
+	}
+
+	// Reset forgets the declared codespaces, so NSR ids no longer match
+	validator.Reset()
+	issues, err = validator.Validate(repository)
+	if err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if len(issues) != 3 || issues[1].Location.ElementID != "NSR:StopPlace:1" {
+		t.Errorf("Expected NSR:StopPlace:1 to be reported after Reset(), got %+v", issues)
+	}
+}
+
+func TestCodespacePrefixValidator_NoKnownCodespace(t *testing.T) {
+	repository := ids.NewNetexIdRepository()
+	if err := repository.AddId("OTHER:Line:1", "1", "line.xml"); err != nil {
+		t.Fatalf("AddId() error = %v", err)
+	}
+
+	// Without a validation codespace or declared codespaces there is nothing to match
+	validator := NewCodespacePrefixValidator("")
+	issues, err := validator.Validate(repository)
+	if err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if len(issues) != 0 {
+		t.Errorf("Expected no issues without known codespaces, got %+v", issues)
+	}
+
+	// Only declared codespaces are admitted without a validation codespace
+	declared := `<?xml version="1.0" encoding="UTF-8"?>
+<PublicationDelivery xmlns="http://www.netex.org.uk/netex" version="1.15">
+	<dataObjects>
+		<CompositeFrame id="OTHER:CompositeFrame:1" version="1">
+			<codespaces>
+				<Codespace id="other"><Xmlns>OTHER</Xmlns></Codespace>
+			</codespaces>
+		</CompositeFrame>
+	</dataObjects>
+</PublicationDelivery>`
+	if err := validator.Collect(newTestXPathContext(t, "line.xml", declared)); err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+	issues, err = validator.Validate(repository)
+	if err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if len(issues) != 0 {
+		t.Errorf("Expected OTHER:Line:1 to match its declared codespace, got %+v", issues)
+	}
+
+	if err := repository.AddId("TEST:Line:2", "1", "line.xml"); err != nil {
+		t.Fatalf("AddId() error = %v", err)
+	}
+	issues, err = validator.Validate(repository)
+	if err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if len(issues) != 1 || !strings.Contains(issues[0].Message, "expected OTHER:") {
+		t.Errorf("Expected TEST:Line:2 to be reported against OTHER:, got %+v", issues)
+	}
+}
@@ -0,0 +1,243 @@
+package business
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/antchfx/xmlquery"
+	"github.com/theoremus-urban-solutions/netex-validator/interfaces"
+	"github.com/theoremus-urban-solutions/netex-validator/types"
+	"github.com/theoremus-urban-solutions/netex-validator/utils"
+	"github.com/theoremus-urban-solutions/netex-validator/validation/context"
+)
+
+// coordinateEpsilon is the largest difference in degrees at which two coordinates
+// count as identical, roughly 0.1 m
+const coordinateEpsilon = 1e-6
+
+// coordinate is a WGS84 position
+type coordinate struct {
+	longitude float64
+	latitude  float64
+}
+
+// declaredStopPoint records where a ScheduledStopPoint was declared
+type declaredStopPoint struct {
+	id       string
+	fileName string
+	xpath    string
+}
+
+// locatedStopPoint is a ScheduledStopPoint with its resolved position and the id
+// of the Quay or StopPlace the position was taken from
+type locatedStopPoint struct {
+	declaredStopPoint
+	position coordinate
+	place    string
+}
+
+// stopAssignment records the Quay and StopPlace a ScheduledStopPoint is assigned to
+type stopAssignment struct {
+	quayRef      string
+	stopPlaceRef string
+}
+
+// CoincidentStopPointValidator reports ScheduledStopPoints located at the same
+// coordinate, which usually means a stop was declared twice. Stop points assigned
+// to the same Quay share its position by design and are not compared. A ScheduledStopPoint
+// has no position of its own; it takes the Centroid of the Quay, or failing that
+// the StopPlace, it is assigned to by a PassengerStopAssignment. Stop points,
+// assignments and stop places are typically spread over several files.
+type CoincidentStopPointValidator struct {
+	mu          sync.Mutex
+	stopPoints  map[string]declaredStopPoint
+	assignments map[string]stopAssignment
+	places      map[string]coordinate // Quay and StopPlace centroids by id
+	rules       []types.ValidationRule
+}
+
+// NewCoincidentStopPointValidator creates a new coincident stop point validator
+func NewCoincidentStopPointValidator() *CoincidentStopPointValidator {
+	return &CoincidentStopPointValidator{
+		stopPoints:  make(map[string]declaredStopPoint),
+		assignments: make(map[string]stopAssignment),
+		places:      make(map[string]coordinate),
+		rules: []types.ValidationRule{
+			{
+				Code:     "SCHEDULED_STOP_POINT_2",
+				Name:     "ScheduledStopPoints share coordinates",
+				Message:  "ScheduledStopPoints are assigned to identical coordinates and may be duplicates",
+				Severity: types.WARNING,
+			},
+		},
+	}
+}
+
+// Collect records the stop points, stop assignments and stop place centroids in a file
+func (v *CoincidentStopPointValidator) Collect(ctx context.XPathValidationContext) error {
+	if ctx.Document == nil {
+		return nil
+	}
+
+	var stopPoints []declaredStopPoint
+	for _, node := range xmlquery.Find(ctx.Document, "//scheduledStopPoints/ScheduledStopPoint[@id]") {
+		stopPoints = append(stopPoints, declaredStopPoint{
+			id:       node.SelectAttr("id"),
+			fileName: ctx.GetFileName(),
+			xpath:    utils.NodeXPath(node),
+		})
+	}
+
+	assignments := make(map[string]stopAssignment)
+	for _, node := range xmlquery.Find(ctx.Document, "//stopAssignments/PassengerStopAssignment") {
+		stopPoint := childRef(node, "ScheduledStopPointRef")
+		if stopPoint == "" {
+			continue
+		}
+		assignments[stopPoint] = stopAssignment{
+			quayRef:      childRef(node, "QuayRef"),
+			stopPlaceRef: childRef(node, "StopPlaceRef"),
+		}
+	}
+
+	places := make(map[string]coordinate)
+	for _, node := range xmlquery.Find(ctx.Document, "//stopPlaces/StopPlace[@id] | //quays/Quay[@id]") {
+		if position, ok := centroid(node); ok {
+			places[node.SelectAttr("id")] = position
+		}
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	for _, stopPoint := range stopPoints {
+		v.stopPoints[stopPoint.id] = stopPoint
+	}
+	for id, assignment := range assignments {
+		v.assignments[id] = assignment
+	}
+	for id, position := range places {
+		v.places[id] = position
+	}
+	return nil
+}
+
+// centroid reads the numeric Centroid location of a StopPlace or Quay
+func centroid(node *xmlquery.Node) (coordinate, bool) {
+	longitude, lonErr := strconv.ParseFloat(childText(node, "Centroid/Location/Longitude"), 64)
+	latitude, latErr := strconv.ParseFloat(childText(node, "Centroid/Location/Latitude"), 64)
+	if lonErr != nil || latErr != nil {
+		return coordinate{}, false
+	}
+	return coordinate{longitude: longitude, latitude: latitude}, true
+}
+
+// Validate reports each cluster of stop points whose resolved coordinates coincide,
+// once, at the stop point with the lowest id. Stop points without an assignment to
+// a located Quay or StopPlace are skipped.
+func (v *CoincidentStopPointValidator) Validate(repository interfaces.IdRepository) ([]types.ValidationIssue, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	var located []locatedStopPoint
+	for id, stopPoint := range v.stopPoints {
+		if position, place, ok := v.resolve(id); ok {
+			located = append(located, locatedStopPoint{declaredStopPoint: stopPoint, position: position, place: place})
+		}
+	}
+
+	// Sweep in latitude order so only neighbours within epsilon are compared
+	sort.Slice(located, func(i, j int) bool {
+		if located[i].position.latitude != located[j].position.latitude {
+			return located[i].position.latitude < located[j].position.latitude
+		}
+		return located[i].id < located[j].id
+	})
+
+	// Coinciding stop points are joined into clusters, each named by its root
+	roots := make([]int, len(located))
+	for i := range roots {
+		roots[i] = i
+	}
+	var find func(i int) int
+	find = func(i int) int {
+		if roots[i] != i {
+			roots[i] = find(roots[i])
+		}
+		return roots[i]
+	}
+	for i := range located {
+		for j := i + 1; j < len(located) && located[j].position.latitude-located[i].position.latitude <= coordinateEpsilon; j++ {
+			if math.Abs(located[j].position.longitude-located[i].position.longitude) > coordinateEpsilon {
+				continue
+			}
+			if located[i].place == located[j].place {
+				continue
+			}
+			roots[find(j)] = find(i)
+		}
+	}
+
+	clusters := make(map[int][]locatedStopPoint)
+	for i, stopPoint := range located {
+		root := find(i)
+		clusters[root] = append(clusters[root], stopPoint)
+	}
+
+	var issues []types.ValidationIssue
+	for _, cluster := range clusters {
+		if len(cluster) < 2 {
+			continue
+		}
+		sort.Slice(cluster, func(i, j int) bool { return cluster[i].id < cluster[j].id })
+		ids := make([]string, len(cluster))
+		for i, stopPoint := range cluster {
+			ids[i] = "'" + stopPoint.id + "'"
+		}
+		first := cluster[0]
+		issues = append(issues, types.ValidationIssue{
+			Rule: v.rules[0],
+			Location: types.DataLocation{
+				FileName:  first.fileName,
+				XPath:     first.xpath,
+				ElementID: first.id,
+			},
+			Message: fmt.Sprintf("%d ScheduledStopPoints share the coordinate (%g, %g): %s",
+				len(cluster), first.position.longitude, first.position.latitude, strings.Join(ids, ", ")),
+		})
+	}
+
+	sort.SliceStable(issues, func(i, j int) bool { return issues[i].Location.ElementID < issues[j].Location.ElementID })
+	return issues, nil
+}
+
+// resolve returns the position of a stop point through its stop assignment, and
+// the id of the Quay or StopPlace it was taken from
+func (v *CoincidentStopPointValidator) resolve(stopPointID string) (coordinate, string, bool) {
+	assignment, ok := v.assignments[stopPointID]
+	if !ok {
+		return coordinate{}, "", false
+	}
+	if position, ok := v.places[assignment.quayRef]; ok {
+		return position, assignment.quayRef, true
+	}
+	position, ok := v.places[assignment.stopPlaceRef]
+	return position, assignment.stopPlaceRef, ok
+}
+
+// GetRules returns the rules implemented by this validator
+func (v *CoincidentStopPointValidator) GetRules() []types.ValidationRule {
+	return v.rules
+}
+
+// Reset clears all collected data
+func (v *CoincidentStopPointValidator) Reset() {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.stopPoints = make(map[string]declaredStopPoint)
+	v.assignments = make(map[string]stopAssignment)
+	v.places = make(map[string]coordinate)
+}
@@ -0,0 +1,117 @@
+package business
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/theoremus-urban-solutions/netex-validator/validation/ids"
+)
+
+func TestCoincidentStopPointValidator(t *testing.T) {
+	stopPoints := `<?xml version="1.0" encoding="UTF-8"?>
+<PublicationDelivery xmlns="http://www.netex.org.uk/netex" version="1.15">
+	<dataObjects>
+		<ServiceFrame id="TEST:ServiceFrame:1" version="1">
+			<scheduledStopPoints>
+				<ScheduledStopPoint id="TEST:ScheduledStopPoint:A" version="1"/>
+				<ScheduledStopPoint id="TEST:ScheduledStopPoint:B" version="1"/>
+				<ScheduledStopPoint id="TEST:ScheduledStopPoint:C" version="1"/>
+				<ScheduledStopPoint id="TEST:ScheduledStopPoint:D" version="1"/>
+				<ScheduledStopPoint id="TEST:ScheduledStopPoint:E" version="1"/>
+				<ScheduledStopPoint id="TEST:ScheduledStopPoint:Unassigned" version="1"/>
+			</scheduledStopPoints>
+			<stopAssignments>
+				<PassengerStopAssignment id="TEST:PassengerStopAssignment:A" version="1" order="1">
+					<ScheduledStopPointRef ref="TEST:ScheduledStopPoint:A"/>
+					<StopPlaceRef ref="TEST:StopPlace:1"/>
+					<QuayRef ref="TEST:Quay:1"/>
+				</PassengerStopAssignment>
+				<PassengerStopAssignment id="TEST:PassengerStopAssignment:B" version="1" order="2">
+					<ScheduledStopPointRef ref="TEST:ScheduledStopPoint:B"/>
+					<StopPlaceRef ref="TEST:StopPlace:2"/>
+				</PassengerStopAssignment>
+				<PassengerStopAssignment id="TEST:PassengerStopAssignment:C" version="1" order="3">
+					<ScheduledStopPointRef ref="TEST:ScheduledStopPoint:C"/>
+					<QuayRef ref="TEST:Quay:3"/>
+				</PassengerStopAssignment>
+				<PassengerStopAssignment id="TEST:PassengerStopAssignment:D" version="1" order="4">
+					<ScheduledStopPointRef ref="TEST:ScheduledStopPoint:D"/>
+					<StopPlaceRef ref="TEST:StopPlace:3"/>
+				</PassengerStopAssignment>
+				<PassengerStopAssignment id="TEST:PassengerStopAssignment:E" version="1" order="5">
+					<ScheduledStopPointRef ref="TEST:ScheduledStopPoint:E"/>
+					<QuayRef ref="TEST:Quay:3"/>
+				</PassengerStopAssignment>
+			</stopAssignments>
+		</ServiceFrame>
+	</dataObjects>
+</PublicationDelivery>`
+
+	stopPlaces := `<?xml version="1.0" encoding="UTF-8"?>
+<PublicationDelivery xmlns="http://www.netex.org.uk/netex" version="1.15">
+	<dataObjects>
+		<SiteFrame id="TEST:SiteFrame:1" version="1">
+			<stopPlaces>
+				<StopPlace id="TEST:StopPlace:1" version="1">
+					<Centroid><Location><Longitude>10.0</Longitude><Latitude>59.0</Latitude></Location></Centroid>
+					<quays>
+						<Quay id="TEST:Quay:1" version="1">
+							<Centroid><Location><Longitude>10.7522</Longitude><Latitude>59.9139</Latitude></Location></Centroid>
+						</Quay>
+					</quays>
+				</StopPlace>
+				<StopPlace id="TEST:StopPlace:2" version="1">
+					<Centroid><Location><Longitude>10.75220001</Longitude><Latitude>59.9139</Latitude></Location></Centroid>
+					<quays>
+						<Quay id="TEST:Quay:3" version="1">
+							<Centroid><Location><Longitude>10.7600</Longitude><Latitude>59.9139</Latitude></Location></Centroid>
+						</Quay>
+					</quays>
+				</StopPlace>
+				<StopPlace id="TEST:StopPlace:3" version="1">
+					<Centroid><Location><Longitude>10.7522</Longitude><Latitude>59.91390001</Latitude></Location></Centroid>
+				</StopPlace>
+			</stopPlaces>
+		</SiteFrame>
+	</dataObjects>
+</PublicationDelivery>`
+
+	validator := NewCoincidentStopPointValidator()
+	if err := validator.Collect(newTestXPathContext(t, "stop_points.xml", stopPoints)); err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+	if err := validator.Collect(newTestXPathContext(t, "stop_places.xml", stopPlaces)); err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+
+	issues, err := validator.Validate(ids.NewNetexIdRepository())
+	if err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if len(issues) != 1 {
+		t.Fatalf("Expected 1 issue, got %d: %+v", len(issues), issues)
+	}
+
+	issue := issues[0]
+	if issue.Rule.Code != "SCHEDULED_STOP_POINT_2" {
+		t.Errorf("Expected SCHEDULED_STOP_POINT_2, got %s", issue.Rule.Code)
+	}
+	if issue.Location.ElementID != "TEST:ScheduledStopPoint:A" || issue.Location.FileName != "stop_points.xml" {
+		t.Errorf("Expected issue on TEST:ScheduledStopPoint:A in stop_points.xml, got %+v", issue.Location)
+	}
+	// C and E are assigned to the same Quay and only D joins the cluster of A and B
+	for _, want := range []string{"3 ScheduledStopPoints", "TEST:ScheduledStopPoint:A", "TEST:ScheduledStopPoint:B", "TEST:ScheduledStopPoint:D", "10.7522", "59.9139"} {
+		if !strings.Contains(issue.Message, want) {
+			t.Errorf("Expected message to contain %q, got %q", want, issue.Message)
+		}
+	}
+
+	validator.Reset()
+	issues, err = validator.Validate(ids.NewNetexIdRepository())
+	if err != nil {
+		t.Fatalf("Validate() after Reset() error = %v", err)
+	}
+	if len(issues) != 0 {
+		t.Errorf("Expected no issues after Reset(), got %d", len(issues))
+	}
+}
@@ -0,0 +1,216 @@
+package business
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/antchfx/xmlquery"
+	"github.com/theoremus-urban-solutions/netex-validator/interfaces"
+	"github.com/theoremus-urban-solutions/netex-validator/types"
+	"github.com/theoremus-urban-solutions/netex-validator/utils"
+	"github.com/theoremus-urban-solutions/netex-validator/validation/context"
+)
+
+// daysOfWeek is a set of days of the week, Monday being the lowest bit
+type daysOfWeek uint8
+
+// allDaysOfWeek holds every day of the week
+const allDaysOfWeek daysOfWeek = 1<<7 - 1
+
+// weekdayNames are the DayOfWeekEnumeration values of single days, in week order
+var weekdayNames = []string{"Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday", "Sunday"}
+
+// parseDaysOfWeek parses a DaysOfWeek list of DayOfWeekEnumeration values, such as
+// "Monday Tuesday" or "Weekdays". False is returned for unknown values.
+func parseDaysOfWeek(value string) (daysOfWeek, bool) {
+	var days daysOfWeek
+	for _, token := range strings.Fields(value) {
+		switch token {
+		case "Everyday":
+			days |= allDaysOfWeek
+		case "Weekdays":
+			days |= 1<<5 - 1
+		case "Weekend":
+			days |= 1<<5 | 1<<6
+		case "none":
+		default:
+			i := indexOf(weekdayNames, token)
+			if i < 0 {
+				return 0, false
+			}
+			days |= 1 << i
+		}
+	}
+	return days, true
+}
+
+// String lists the days in week order, e.g. "Monday Friday", or "no day"
+func (d daysOfWeek) String() string {
+	var names []string
+	for i, name := range weekdayNames {
+		if d&(1<<i) != 0 {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return "no day"
+	}
+	return strings.Join(names, " ")
+}
+
+// indexOf returns the index of value in values, or -1
+func indexOf(values []string, value string) int {
+	for i, v := range values {
+		if v == value {
+			return i
+		}
+	}
+	return -1
+}
+
+// dayTypeJourney is a ServiceJourney referencing more than one DayType
+type dayTypeJourney struct {
+	dayTypes []string
+	location lineLocation
+}
+
+// DayTypeConflictValidator reports ServiceJourneys whose DayTypes have no day of the
+// week in common, such as one DayType for Mondays only and another for weekends,
+// which leaves the journey an empty effective calendar. The DaysOfWeek of all
+// PropertyOfDay elements of a DayType are combined; DayTypes without DaysOfWeek
+// restrict no days and are left out. DayTypes are often shared in another file
+// than the journeys, so the references are resolved across the dataset.
+type DayTypeConflictValidator struct {
+	mu       sync.Mutex
+	dayTypes map[string]daysOfWeek // DayType id -> days of its DaysOfWeek
+	journeys map[string]dayTypeJourney
+	rules    []types.ValidationRule
+}
+
+// NewDayTypeConflictValidator creates a new day type conflict validator
+func NewDayTypeConflictValidator() *DayTypeConflictValidator {
+	return &DayTypeConflictValidator{
+		dayTypes: make(map[string]daysOfWeek),
+		journeys: make(map[string]dayTypeJourney),
+		rules: []types.ValidationRule{
+			{
+				Code:     "SERVICE_JOURNEY_CONFLICTING_DAY_TYPES",
+				Name:     "ServiceJourney DayTypes without common days",
+				Message:  "The DayTypes of the ServiceJourney have no day of the week in common",
+				Severity: types.WARNING,
+			},
+		},
+	}
+}
+
+// Collect records the days of week of each DayType and the DayTypeRefs of the
+// ServiceJourneys referencing more than one DayType in a file
+func (v *DayTypeConflictValidator) Collect(ctx context.XPathValidationContext) error {
+	if ctx.Document == nil {
+		return nil
+	}
+
+	dayTypes := make(map[string]daysOfWeek)
+	for _, node := range xmlquery.Find(ctx.Document, "//dayTypes/DayType[@id]") {
+		values := xmlquery.Find(node, "properties/PropertyOfDay/DaysOfWeek")
+		if len(values) == 0 {
+			continue
+		}
+		var days daysOfWeek
+		valid := true
+		for _, value := range values {
+			parsed, ok := parseDaysOfWeek(value.InnerText())
+			if !ok {
+				// Invalid values are left to the schema
+				valid = false
+				break
+			}
+			days |= parsed
+		}
+		if valid {
+			dayTypes[node.SelectAttr("id")] = days
+		}
+	}
+
+	journeys := make(map[string]dayTypeJourney)
+	for _, node := range xmlquery.Find(ctx.Document, "//vehicleJourneys/ServiceJourney[@id][count(dayTypes/DayTypeRef) > 1]") {
+		var refs []string
+		for _, ref := range xmlquery.Find(node, "dayTypes/DayTypeRef") {
+			if id := refValue(ref); id != "" && indexOf(refs, id) < 0 {
+				refs = append(refs, id)
+			}
+		}
+		journeys[node.SelectAttr("id")] = dayTypeJourney{
+			dayTypes: refs,
+			location: lineLocation{fileName: ctx.GetFileName(), xpath: utils.NodeXPath(node)},
+		}
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	for id, days := range dayTypes {
+		v.dayTypes[id] = days
+	}
+	for id, journey := range journeys {
+		v.journeys[id] = journey
+	}
+	return nil
+}
+
+// Validate intersects the days of week of each journey's DayTypes and reports the
+// journeys left without any day, naming the DayTypes with their days
+func (v *DayTypeConflictValidator) Validate(repository interfaces.IdRepository) ([]types.ValidationIssue, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	ids := make([]string, 0, len(v.journeys))
+	for id := range v.journeys {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	var issues []types.ValidationIssue
+	for _, id := range ids {
+		journey := v.journeys[id]
+		common := allDaysOfWeek
+		var restricting []string
+		for _, ref := range journey.dayTypes {
+			days, ok := v.dayTypes[ref]
+			if !ok {
+				continue
+			}
+			common &= days
+			restricting = append(restricting, fmt.Sprintf("'%s' (%s)", ref, days))
+		}
+		if len(restricting) < 2 || common != 0 {
+			continue
+		}
+		issues = append(issues, types.ValidationIssue{
+			Rule: v.rules[0],
+			Location: types.DataLocation{
+				FileName:  journey.location.fileName,
+				XPath:     journey.location.xpath,
+				ElementID: id,
+			},
+			Message: fmt.Sprintf("ServiceJourney '%s' references DayTypes with no day of the week in common: %s",
+				id, strings.Join(restricting, ", ")),
+		})
+	}
+
+	return issues, nil
+}
+
+// GetRules returns the rules implemented by this validator
+func (v *DayTypeConflictValidator) GetRules() []types.ValidationRule {
+	return v.rules
+}
+
+// Reset clears all collected data
+func (v *DayTypeConflictValidator) Reset() {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.dayTypes = make(map[string]daysOfWeek)
+	v.journeys = make(map[string]dayTypeJourney)
+}
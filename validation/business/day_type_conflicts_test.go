@@ -0,0 +1,103 @@
+package business
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/theoremus-urban-solutions/netex-validator/types"
+	"github.com/theoremus-urban-solutions/netex-validator/validation/ids"
+)
+
+func TestParseDaysOfWeek(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+		ok    bool
+	}{
+		{"Monday", "Monday", true},
+		{"Friday Monday", "Monday Friday", true},
+		{"Weekdays", "Monday Tuesday Wednesday Thursday Friday", true},
+		{"Weekend Monday", "Monday Saturday Sunday", true},
+		{"Everyday", "Monday Tuesday Wednesday Thursday Friday Saturday Sunday", true},
+		{"none", "no day", true},
+		{"Mondays", "", false},
+	}
+	for _, tt := range tests {
+		days, ok := parseDaysOfWeek(tt.value)
+		if ok != tt.ok || (ok && days.String() != tt.want) {
+			t.Errorf("parseDaysOfWeek(%q) = %s, %v, want %s, %v", tt.value, days, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestDayTypeConflictValidator(t *testing.T) {
+	common := `<?xml version="1.0" encoding="UTF-8"?>
+<PublicationDelivery xmlns="http://www.netex.org.uk/netex" version="1.15">
+	<dataObjects>
+		<ServiceCalendarFrame id="TEST:ServiceCalendarFrame:1" version="1">
+			<dayTypes>
+				<DayType id="TEST:DayType:Monday" version="1">
+					<properties><PropertyOfDay><DaysOfWeek>Monday</DaysOfWeek></PropertyOfDay></properties>
+				</DayType>
+				<DayType id="TEST:DayType:Weekend" version="1">
+					<properties><PropertyOfDay><DaysOfWeek>Saturday</DaysOfWeek></PropertyOfDay><PropertyOfDay><DaysOfWeek>Sunday</DaysOfWeek></PropertyOfDay></properties>
+				</DayType>
+				<DayType id="TEST:DayType:Weekdays" version="1">
+					<properties><PropertyOfDay><DaysOfWeek>Weekdays</DaysOfWeek></PropertyOfDay></properties>
+				</DayType>
+				<DayType id="TEST:DayType:Holiday" version="1"/>
+			</dayTypes>
+		</ServiceCalendarFrame>
+	</dataObjects>
+</PublicationDelivery>`
+
+	journeys := `<?xml version="1.0" encoding="UTF-8"?>
+<PublicationDelivery xmlns="http://www.netex.org.uk/netex" version="1.15">
+	<dataObjects>
+		<TimetableFrame id="TEST:TimetableFrame:1" version="1">
+			<vehicleJourneys>
+				<ServiceJourney id="TEST:ServiceJourney:Empty" version="1">
+					<dayTypes><DayTypeRef ref="TEST:DayType:Monday"/><DayTypeRef ref="TEST:DayType:Holiday"/><DayTypeRef ref="TEST:DayType:Weekend"/></dayTypes>
+				</ServiceJourney>
+				<ServiceJourney id="TEST:ServiceJourney:Mondays" version="1">
+					<dayTypes><DayTypeRef ref="TEST:DayType:Monday"/><DayTypeRef ref="TEST:DayType:Weekdays"/></dayTypes>
+				</ServiceJourney>
+				<ServiceJourney id="TEST:ServiceJourney:Unrestricted" version="1">
+					<dayTypes><DayTypeRef ref="TEST:DayType:Weekend"/><DayTypeRef ref="TEST:DayType:Holiday"/></dayTypes>
+				</ServiceJourney>
+				<ServiceJourney id="TEST:ServiceJourney:Single" version="1">
+					<dayTypes><DayTypeRef ref="TEST:DayType:Monday"/></dayTypes>
+				</ServiceJourney>
+			</vehicleJourneys>
+		</TimetableFrame>
+	</dataObjects>
+</PublicationDelivery>`
+
+	validator := NewDayTypeConflictValidator()
+	if err := validator.Collect(newTestXPathContext(t, "line.xml", journeys)); err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+	if err := validator.Collect(newTestXPathContext(t, "_common.xml", common)); err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+
+	issues, err := validator.Validate(ids.NewNetexIdRepository())
+	if err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if len(issues) != 1 {
+		t.Fatalf("Expected 1 issue, got %d: %+v", len(issues), issues)
+	}
+
+	issue := issues[0]
+	if issue.Rule.Code != "SERVICE_JOURNEY_CONFLICTING_DAY_TYPES" || issue.Rule.Severity != types.WARNING {
+		t.Errorf("Expected SERVICE_JOURNEY_CONFLICTING_DAY_TYPES warning, got %s (%v)", issue.Rule.Code, issue.Rule.Severity)
+	}
+	if issue.Location.ElementID != "TEST:ServiceJourney:Empty" || issue.Location.FileName != "line.xml" {
+		t.Errorf("Expected issue on TEST:ServiceJourney:Empty in line.xml, got %+v", issue.Location)
+	}
+	want := "'TEST:DayType:Monday' (Monday), 'TEST:DayType:Weekend' (Saturday Sunday)"
+	if !strings.Contains(issue.Message, want) {
+		t.Errorf("Expected message to contain %q, got %q", want, issue.Message)
+	}
+}
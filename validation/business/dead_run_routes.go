@@ -0,0 +1,151 @@
+package business
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/antchfx/xmlquery"
+	"github.com/theoremus-urban-solutions/netex-validator/interfaces"
+	"github.com/theoremus-urban-solutions/netex-validator/types"
+	"github.com/theoremus-urban-solutions/netex-validator/utils"
+	"github.com/theoremus-urban-solutions/netex-validator/validation/context"
+)
+
+// journeyRoute records how a journey reaches its Route: directly through a RouteRef
+// or through the RouteRef of its journey pattern
+type journeyRoute struct {
+	id         string
+	routeRef   string
+	patternRef string
+	fileName   string
+	xpath      string
+}
+
+// DeadRunRouteValidator reports DeadRuns that run on a Route passenger
+// ServiceJourneys also use. Journeys, dead runs and patterns may sit in different
+// files, so the Route of each journey is resolved once the whole dataset is collected.
+type DeadRunRouteValidator struct {
+	mu            sync.Mutex
+	patternRoutes map[string]string // journey pattern id -> route id
+	deadRuns      []journeyRoute
+	journeys      []journeyRoute
+	rules         []types.ValidationRule
+}
+
+// NewDeadRunRouteValidator creates a new dead run route validator
+func NewDeadRunRouteValidator() *DeadRunRouteValidator {
+	return &DeadRunRouteValidator{
+		patternRoutes: make(map[string]string),
+		rules: []types.ValidationRule{
+			{
+				Code:     "DEAD_RUN_3",
+				Name:     "DeadRun on passenger route",
+				Message:  "DeadRun uses a Route that passenger ServiceJourneys also use",
+				Severity: types.WARNING,
+			},
+		},
+	}
+}
+
+// Collect records the journey patterns, ServiceJourneys and DeadRuns in a file
+func (v *DeadRunRouteValidator) Collect(ctx context.XPathValidationContext) error {
+	if ctx.Document == nil {
+		return nil
+	}
+
+	patternRoutes := make(map[string]string)
+	for _, node := range xmlquery.Find(ctx.Document, "//journeyPatterns/*[self::JourneyPattern or self::ServiceJourneyPattern or self::DeadRunJourneyPattern][@id]") {
+		if route := childRef(node, "RouteRef"); route != "" {
+			patternRoutes[node.SelectAttr("id")] = route
+		}
+	}
+
+	collect := func(path string) []journeyRoute {
+		var journeys []journeyRoute
+		for _, node := range xmlquery.Find(ctx.Document, path) {
+			journeys = append(journeys, journeyRoute{
+				id:         node.SelectAttr("id"),
+				routeRef:   childRef(node, "RouteRef"),
+				patternRef: childRef(node, "JourneyPatternRef|ServiceJourneyPatternRef|DeadRunJourneyPatternRef"),
+				fileName:   ctx.GetFileName(),
+				xpath:      utils.NodeXPath(node),
+			})
+		}
+		return journeys
+	}
+	journeys := collect("//vehicleJourneys/ServiceJourney[@id]")
+	deadRuns := collect("//vehicleJourneys/DeadRun[@id]")
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	for pattern, route := range patternRoutes {
+		v.patternRoutes[pattern] = route
+	}
+	v.journeys = append(v.journeys, journeys...)
+	v.deadRuns = append(v.deadRuns, deadRuns...)
+	return nil
+}
+
+// route resolves the Route of a journey, preferring its own RouteRef
+func (v *DeadRunRouteValidator) route(journey journeyRoute) string {
+	if journey.routeRef != "" {
+		return journey.routeRef
+	}
+	return v.patternRoutes[journey.patternRef]
+}
+
+// Validate reports every DeadRun whose Route is also used by a ServiceJourney
+func (v *DeadRunRouteValidator) Validate(repository interfaces.IdRepository) ([]types.ValidationIssue, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	passengerRoutes := make(map[string]string) // route id -> first ServiceJourney using it
+	for _, journey := range v.journeys {
+		route := v.route(journey)
+		if route == "" {
+			continue
+		}
+		if first, ok := passengerRoutes[route]; !ok || journey.id < first {
+			passengerRoutes[route] = journey.id
+		}
+	}
+
+	sort.SliceStable(v.deadRuns, func(i, j int) bool { return v.deadRuns[i].id < v.deadRuns[j].id })
+
+	var issues []types.ValidationIssue
+	for _, deadRun := range v.deadRuns {
+		route := v.route(deadRun)
+		journey, shared := passengerRoutes[route]
+		if route == "" || !shared {
+			continue
+		}
+
+		issues = append(issues, types.ValidationIssue{
+			Rule: v.rules[0],
+			Location: types.DataLocation{
+				FileName:  deadRun.fileName,
+				XPath:     deadRun.xpath,
+				ElementID: deadRun.id,
+			},
+			Message: fmt.Sprintf("DeadRun '%s' uses Route '%s', which is also used by ServiceJourney '%s'",
+				deadRun.id, route, journey),
+		})
+	}
+
+	return issues, nil
+}
+
+// GetRules returns the rules implemented by this validator
+func (v *DeadRunRouteValidator) GetRules() []types.ValidationRule {
+	return v.rules
+}
+
+// Reset clears all collected data
+func (v *DeadRunRouteValidator) Reset() {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.patternRoutes = make(map[string]string)
+	v.journeys = nil
+	v.deadRuns = nil
+}
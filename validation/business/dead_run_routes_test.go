@@ -0,0 +1,109 @@
+package business
+
+import (
+	"testing"
+
+	"github.com/theoremus-urban-solutions/netex-validator/types"
+)
+
+func TestDeadRunRouteValidator(t *testing.T) {
+	patterns := `<?xml version="1.0" encoding="UTF-8"?>
+<PublicationDelivery xmlns="http://www.netex.org.uk/netex" version="1.15">
+	<dataObjects>
+		<ServiceFrame id="TEST:ServiceFrame:1" version="1">
+			<journeyPatterns>
+				<ServiceJourneyPattern id="TEST:ServiceJourneyPattern:1" version="1"><RouteRef ref="TEST:Route:1"/></ServiceJourneyPattern>
+				<DeadRunJourneyPattern id="TEST:DeadRunJourneyPattern:1" version="1"><RouteRef ref="TEST:Route:1"/></DeadRunJourneyPattern>
+				<DeadRunJourneyPattern id="TEST:DeadRunJourneyPattern:2" version="1"><RouteRef ref="TEST:Route:2"/></DeadRunJourneyPattern>
+			</journeyPatterns>
+		</ServiceFrame>
+	</dataObjects>
+</PublicationDelivery>`
+
+	journeys := `<?xml version="1.0" encoding="UTF-8"?>
+<PublicationDelivery xmlns="http://www.netex.org.uk/netex" version="1.15">
+	<dataObjects>
+		<TimetableFrame id="TEST:TimetableFrame:1" version="1">
+			<vehicleJourneys>
+				<ServiceJourney id="TEST:ServiceJourney:2" version="1"><ServiceJourneyPatternRef ref="TEST:ServiceJourneyPattern:1"/></ServiceJourney>
+				<ServiceJourney id="TEST:ServiceJourney:1" version="1"><ServiceJourneyPatternRef ref="TEST:ServiceJourneyPattern:1"/></ServiceJourney>
+				<ServiceJourney id="TEST:ServiceJourney:3" version="1"><RouteRef ref="TEST:Route:3"/></ServiceJourney>
+				<DeadRun id="TEST:DeadRun:1" version="1"><DeadRunJourneyPatternRef ref="TEST:DeadRunJourneyPattern:1"/></DeadRun>
+				<DeadRun id="TEST:DeadRun:2" version="1"><DeadRunJourneyPatternRef ref="TEST:DeadRunJourneyPattern:2"/></DeadRun>
+				<DeadRun id="TEST:DeadRun:3" version="1"><RouteRef ref="TEST:Route:3"/></DeadRun>
+				<DeadRun id="TEST:DeadRun:4" version="1"/>
+			</vehicleJourneys>
+		</TimetableFrame>
+	</dataObjects>
+</PublicationDelivery>`
+
+	validator := NewDeadRunRouteValidator()
+	if err := validator.Collect(newTestXPathContext(t, "journeys.xml", journeys)); err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+	if err := validator.Collect(newTestXPathContext(t, "_common.xml", patterns)); err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+
+	issues, err := validator.Validate(nil)
+	if err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+
+	// TEST:DeadRun:2 has a Route of its own and TEST:DeadRun:4 none at all
+	if len(issues) != 2 {
+		t.Fatalf("Expected 2 issues, got %d: %+v", len(issues), issues)
+	}
+
+	want := "DeadRun 'TEST:DeadRun:1' uses Route 'TEST:Route:1', which is also used by ServiceJourney 'TEST:ServiceJourney:1'"
+	if issues[0].Location.ElementID != "TEST:DeadRun:1" || issues[0].Message != want {
+		t.Errorf("Expected TEST:DeadRun:1 to share TEST:Route:1 through its pattern, got %+v", issues[0])
+	}
+	if issues[1].Location.ElementID != "TEST:DeadRun:3" {
+		t.Errorf("Expected TEST:DeadRun:3 to share TEST:Route:3 through its RouteRef, got %+v", issues[1])
+	}
+	for _, issue := range issues {
+		if issue.Rule.Code != "DEAD_RUN_3" || issue.Rule.Severity != types.WARNING {
+			t.Errorf("Expected DEAD_RUN_3 warning, got %+v", issue.Rule)
+		}
+		if issue.Location.FileName != "journeys.xml" || issue.Location.XPath == "" {
+			t.Errorf("Expected a location in journeys.xml with an XPath, got %+v", issue.Location)
+		}
+	}
+
+	// Reset clears collected state for the next dataset
+	validator.Reset()
+	issues, err = validator.Validate(nil)
+	if err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if len(issues) != 0 {
+		t.Errorf("Expected no issues after Reset(), got %d", len(issues))
+	}
+}
+
+func TestDeadRunRouteValidator_NoPassengerJourneys(t *testing.T) {
+	content := `<?xml version="1.0" encoding="UTF-8"?>
+<PublicationDelivery xmlns="http://www.netex.org.uk/netex" version="1.15">
+	<dataObjects>
+		<TimetableFrame id="TEST:TimetableFrame:1" version="1">
+			<vehicleJourneys>
+				<DeadRun id="TEST:DeadRun:1" version="1"><RouteRef ref="TEST:Route:1"/></DeadRun>
+				<DeadRun id="TEST:DeadRun:2" version="1"><RouteRef ref="TEST:Route:1"/></DeadRun>
+			</vehicleJourneys>
+		</TimetableFrame>
+	</dataObjects>
+</PublicationDelivery>`
+
+	validator := NewDeadRunRouteValidator()
+	if err := validator.Collect(newTestXPathContext(t, "test.xml", content)); err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+	issues, err := validator.Validate(nil)
+	if err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if len(issues) != 0 {
+		t.Errorf("Expected DeadRuns sharing a Route only with each other to pass, got %+v", issues)
+	}
+}
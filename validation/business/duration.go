@@ -0,0 +1,47 @@
+package business
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// isoDurationPattern matches the xsd:duration subset used by NetEX (PnDTnHnMnS)
+var isoDurationPattern = regexp.MustCompile(`^(-)?P(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+(?:\.\d+)?)S)?)?$`)
+
+// parseISODuration parses an xsd:duration value such as PT5M or P1DT2H.
+// Year and month components are not supported since their length is ambiguous.
+func parseISODuration(value string) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+	m := isoDurationPattern.FindStringSubmatch(value)
+	if m == nil || value == "P" || value == "-P" || strings.HasSuffix(value, "T") {
+		return 0, fmt.Errorf("invalid duration: %s", value)
+	}
+
+	var d time.Duration
+	units := []time.Duration{24 * time.Hour, time.Hour, time.Minute}
+	for i, unit := range units {
+		if m[i+2] == "" {
+			continue
+		}
+		n, err := strconv.ParseInt(m[i+2], 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration: %s", value)
+		}
+		d += time.Duration(n) * unit
+	}
+	if m[5] != "" {
+		secs, err := strconv.ParseFloat(m[5], 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration: %s", value)
+		}
+		d += time.Duration(secs * float64(time.Second))
+	}
+
+	if m[1] == "-" {
+		d = -d
+	}
+	return d, nil
+}
@@ -0,0 +1,92 @@
+package business
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/theoremus-urban-solutions/netex-validator/interfaces"
+	"github.com/theoremus-urban-solutions/netex-validator/types"
+	"github.com/theoremus-urban-solutions/netex-validator/validation/context"
+)
+
+// ExpectedCountValidator compares the number of elements of each type declared in a
+// dataset with a manifest of expected counts, such as 42 Lines and 1200
+// ScheduledStopPoints. A count outside its range is a warning; one below half the
+// minimum or above twice the maximum is an error, as it usually means a truncated
+// or bloated export. The counts are the distinct ids per element name recorded in
+// the ID repository once all files are loaded.
+type ExpectedCountValidator struct {
+	manifest map[string]types.ExpectedCount
+	rules    []types.ValidationRule
+}
+
+// NewExpectedCountValidator creates a new expected count validator for a manifest
+// of element name to expected count
+func NewExpectedCountValidator(manifest map[string]types.ExpectedCount) *ExpectedCountValidator {
+	return &ExpectedCountValidator{
+		manifest: manifest,
+		rules: []types.ValidationRule{
+			{
+				Code:     "DATASET_COUNT_DEVIATION",
+				Name:     "Element count differs from manifest",
+				Message:  "Number of elements is outside the expected range",
+				Severity: types.WARNING,
+			},
+			{
+				Code:     "DATASET_COUNT_SEVERE_DEVIATION",
+				Name:     "Element count far from manifest",
+				Message:  "Number of elements is less than half or more than twice the expected range",
+				Severity: types.ERROR,
+			},
+		},
+	}
+}
+
+// Collect does nothing: the counts come from the ID repository
+func (v *ExpectedCountValidator) Collect(ctx context.XPathValidationContext) error {
+	return nil
+}
+
+// Validate reports each element type of the manifest whose count is out of range,
+// in the order of the element names
+func (v *ExpectedCountValidator) Validate(repository interfaces.IdRepository) ([]types.ValidationIssue, error) {
+	if repository == nil || len(v.manifest) == 0 {
+		return nil, nil
+	}
+	counts := repository.ElementCounts()
+
+	elementTypes := make([]string, 0, len(v.manifest))
+	for elementType := range v.manifest {
+		elementTypes = append(elementTypes, elementType)
+	}
+	sort.Strings(elementTypes)
+
+	var issues []types.ValidationIssue
+	for _, elementType := range elementTypes {
+		expected := v.manifest[elementType]
+		actual := counts[elementType]
+		tooFew := actual < expected.Min
+		tooMany := expected.Max > 0 && actual > expected.Max
+		if !tooFew && !tooMany {
+			continue
+		}
+
+		rule := v.rules[0]
+		if (tooFew && actual*2 < expected.Min) || (tooMany && actual > expected.Max*2) {
+			rule = v.rules[1]
+		}
+		issues = append(issues, types.ValidationIssue{
+			Rule:    rule,
+			Message: fmt.Sprintf("Dataset has %d %s elements, expected %s", actual, elementType, expected),
+		})
+	}
+	return issues, nil
+}
+
+// GetRules returns the rules implemented by this validator
+func (v *ExpectedCountValidator) GetRules() []types.ValidationRule {
+	return v.rules
+}
+
+// Reset does nothing: the validator keeps no state between datasets
+func (v *ExpectedCountValidator) Reset() {}
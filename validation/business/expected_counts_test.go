@@ -0,0 +1,66 @@
+package business
+
+import (
+	"testing"
+
+	"github.com/theoremus-urban-solutions/netex-validator/types"
+	"github.com/theoremus-urban-solutions/netex-validator/validation/ids"
+)
+
+func TestExpectedCountValidator(t *testing.T) {
+	document := `<PublicationDelivery xmlns="http://www.netex.org.uk/netex">
+	<dataObjects>
+		<ServiceFrame id="TEST:ServiceFrame:1" version="1">
+			<lines>
+				<Line id="TEST:Line:1" version="1"/>
+				<Line id="TEST:Line:2" version="1"/>
+			</lines>
+			<scheduledStopPoints>
+				<ScheduledStopPoint id="TEST:ScheduledStopPoint:1" version="1"/>
+				<ScheduledStopPoint id="TEST:ScheduledStopPoint:2" version="1"/>
+				<ScheduledStopPoint id="TEST:ScheduledStopPoint:3" version="1"/>
+			</scheduledStopPoints>
+			<routes>
+				<Route id="TEST:Route:1" version="1"/>
+			</routes>
+		</ServiceFrame>
+	</dataObjects>
+</PublicationDelivery>`
+	idValidator := ids.NewNetexIdValidator(ids.NewNetexIdRepository(), ids.NewNetexIdExtractor())
+	if err := idValidator.ExtractIds("lines.xml", []byte(document)); err != nil {
+		t.Fatal(err)
+	}
+	// Declared again in another file, still one ScheduledStopPoint
+	if err := idValidator.ExtractIds("copy.xml", []byte(document)); err != nil {
+		t.Fatal(err)
+	}
+
+	validator := NewExpectedCountValidator(map[string]types.ExpectedCount{
+		"Line":               {Min: 5, Max: 5},  // 2 is less than half of 5
+		"ScheduledStopPoint": {Min: 1, Max: 2},  // 3 is too many, but not twice as many
+		"Route":              {Min: 1, Max: 10}, // in range
+		"ServiceJourney":     {Min: 1},          // none at all
+		"StopPlace":          {},                // anything goes
+	})
+	issues, err := validator.Validate(idValidator.GetRepository())
+	if err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+
+	want := []struct {
+		code    string
+		message string
+	}{
+		{"DATASET_COUNT_SEVERE_DEVIATION", "Dataset has 2 Line elements, expected 5"},
+		{"DATASET_COUNT_DEVIATION", "Dataset has 3 ScheduledStopPoint elements, expected 1-2"},
+		{"DATASET_COUNT_SEVERE_DEVIATION", "Dataset has 0 ServiceJourney elements, expected at least 1"},
+	}
+	if len(issues) != len(want) {
+		t.Fatalf("Expected %d issues, got %d: %v", len(want), len(issues), issues)
+	}
+	for i, w := range want {
+		if issues[i].Rule.Code != w.code || issues[i].Message != w.message {
+			t.Errorf("Issue %d: expected %s %q, got %s %q", i, w.code, w.message, issues[i].Rule.Code, issues[i].Message)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package business
+
+import (
+	"fmt"
+
+	"github.com/antchfx/xmlquery"
+	"github.com/theoremus-urban-solutions/netex-validator/types"
+	"github.com/theoremus-urban-solutions/netex-validator/utils"
+	"github.com/theoremus-urban-solutions/netex-validator/validation/context"
+)
+
+// ExpiredDataValidator flags AvailabilityConditions and ServiceCalendars whose
+// ToDate lies before the date of the file's PublicationTimestamp. Data that stopped
+// being valid before it was published is often a stale export, but publishing
+// history on purpose is legitimate, so findings are informational.
+type ExpiredDataValidator struct {
+	rules []types.ValidationRule
+}
+
+// NewExpiredDataValidator creates a new expired data validator
+func NewExpiredDataValidator() *ExpiredDataValidator {
+	return &ExpiredDataValidator{
+		rules: []types.ValidationRule{
+			{
+				Code:     "VALIDITY_CONDITIONS_EXPIRED",
+				Name:     "Possibly expired data",
+				Message:  "Validity ended before the PublicationTimestamp",
+				Severity: types.INFO,
+			},
+		},
+	}
+}
+
+// Validate compares the ToDate of every AvailabilityCondition and ServiceCalendar
+// with the date of the document's PublicationTimestamp
+func (v *ExpiredDataValidator) Validate(ctx context.XPathValidationContext) ([]types.ValidationIssue, error) {
+	var issues []types.ValidationIssue
+	if ctx.Document == nil {
+		return issues, nil
+	}
+
+	delivery := xmlquery.FindOne(ctx.Document, "/PublicationDelivery")
+	if delivery == nil {
+		return issues, nil
+	}
+	// A missing or malformed timestamp is reported by PublicationTimestampValidator
+	published, ok := parseCalendarDate(childText(delivery, "PublicationTimestamp"))
+	if !ok {
+		return issues, nil
+	}
+
+	for _, node := range xmlquery.Find(ctx.Document, "//AvailabilityCondition[ToDate] | //ServiceCalendar[ToDate]") {
+		toDate := childText(node, "ToDate")
+		expired, ok := parseCalendarDate(toDate)
+		if !ok || !expired.Before(published) {
+			continue
+		}
+
+		id := node.SelectAttr("id")
+		gap := fmt.Sprintf("%d days", int(published.Sub(expired).Hours()/24))
+		if gap == "1 days" {
+			gap = "1 day"
+		}
+		issues = append(issues, types.ValidationIssue{
+			Rule: v.rules[0],
+			Location: types.DataLocation{
+				FileName:  ctx.GetFileName(),
+				XPath:     utils.NodeXPath(node),
+				ElementID: id,
+			},
+			Message: fmt.Sprintf("%s '%s' ended on %s, %s before the PublicationTimestamp %s",
+				node.Data, id, expired.Format("2006-01-02"), gap, published.Format("2006-01-02")),
+		})
+	}
+
+	return issues, nil
+}
+
+// GetRules returns the rules implemented by this validator
+func (v *ExpiredDataValidator) GetRules() []types.ValidationRule {
+	return v.rules
+}
@@ -0,0 +1,81 @@
+package business
+
+import (
+	"testing"
+)
+
+func TestExpiredDataValidator(t *testing.T) {
+	document := `<?xml version="1.0" encoding="UTF-8"?>
+<PublicationDelivery xmlns="http://www.netex.org.uk/netex" version="1.15">
+	<PublicationTimestamp>2024-06-01T08:00:00</PublicationTimestamp>
+	<dataObjects>
+		<ServiceCalendarFrame id="TEST:ServiceCalendarFrame:1" version="1">
+			<validityConditions>
+				<AvailabilityCondition id="TEST:AvailabilityCondition:Past" version="1">
+					<FromDate>2023-01-01T00:00:00</FromDate>
+					<ToDate>2024-05-01T23:59:59</ToDate>
+				</AvailabilityCondition>
+				<AvailabilityCondition id="TEST:AvailabilityCondition:Current" version="1">
+					<FromDate>2024-01-01T00:00:00</FromDate>
+					<ToDate>2024-12-31T23:59:59</ToDate>
+				</AvailabilityCondition>
+				<AvailabilityCondition id="TEST:AvailabilityCondition:SameDay" version="1">
+					<ToDate>2024-06-01T00:00:00</ToDate>
+				</AvailabilityCondition>
+				<AvailabilityCondition id="TEST:AvailabilityCondition:OpenEnded" version="1">
+					<FromDate>2020-01-01T00:00:00</FromDate>
+				</AvailabilityCondition>
+			</validityConditions>
+			<ServiceCalendar id="TEST:ServiceCalendar:Past" version="1">
+				<FromDate>2023-01-01</FromDate>
+				<ToDate>2023-12-31</ToDate>
+			</ServiceCalendar>
+		</ServiceCalendarFrame>
+	</dataObjects>
+</PublicationDelivery>`
+
+	validator := NewExpiredDataValidator()
+	issues, err := validator.Validate(newTestXPathContext(t, "calendar.xml", document))
+	if err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if len(issues) != 2 {
+		t.Fatalf("Expected 2 issues, got %d: %+v", len(issues), issues)
+	}
+
+	for i, want := range []struct{ id, message string }{
+		{"TEST:AvailabilityCondition:Past", "AvailabilityCondition 'TEST:AvailabilityCondition:Past' ended on 2024-05-01, 31 days before the PublicationTimestamp 2024-06-01"},
+		{"TEST:ServiceCalendar:Past", "ServiceCalendar 'TEST:ServiceCalendar:Past' ended on 2023-12-31, 153 days before the PublicationTimestamp 2024-06-01"},
+	} {
+		if issues[i].Location.ElementID != want.id {
+			t.Errorf("Issue %d: expected element %s, got %s", i, want.id, issues[i].Location.ElementID)
+		}
+		if issues[i].Message != want.message {
+			t.Errorf("Issue %d: expected message %q, got %q", i, want.message, issues[i].Message)
+		}
+		if issues[i].Rule.Code != "VALIDITY_CONDITIONS_EXPIRED" {
+			t.Errorf("Issue %d: expected rule VALIDITY_CONDITIONS_EXPIRED, got %s", i, issues[i].Rule.Code)
+		}
+	}
+}
+
+func TestExpiredDataValidator_WithoutPublicationTimestamp(t *testing.T) {
+	document := `<?xml version="1.0" encoding="UTF-8"?>
+<PublicationDelivery xmlns="http://www.netex.org.uk/netex" version="1.15">
+	<dataObjects>
+		<ServiceCalendarFrame id="TEST:ServiceCalendarFrame:1" version="1">
+			<ServiceCalendar id="TEST:ServiceCalendar:1" version="1">
+				<ToDate>2001-12-31</ToDate>
+			</ServiceCalendar>
+		</ServiceCalendarFrame>
+	</dataObjects>
+</PublicationDelivery>`
+
+	issues, err := NewExpiredDataValidator().Validate(newTestXPathContext(t, "calendar.xml", document))
+	if err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if len(issues) != 0 {
+		t.Errorf("Expected no issues without a PublicationTimestamp, got %+v", issues)
+	}
+}
@@ -0,0 +1,143 @@
+package business
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/antchfx/xmlquery"
+	"github.com/theoremus-urban-solutions/netex-validator/interfaces"
+	"github.com/theoremus-urban-solutions/netex-validator/types"
+	"github.com/theoremus-urban-solutions/netex-validator/utils"
+	"github.com/theoremus-urban-solutions/netex-validator/validation/context"
+	"github.com/theoremus-urban-solutions/netex-validator/validation/ids"
+)
+
+// placedElement records an element found in a file it does not belong in
+type placedElement struct {
+	kind     string
+	id       string
+	fileName string
+	xpath    string
+}
+
+// FilePlacementValidator checks that a dataset keeps shared resources and line
+// data apart. By the Nordic convention, files whose names start with an underscore
+// (such as _common.xml) hold data shared by the whole dataset and every other file
+// holds the data of a single line. Organisations declared in a line file are only
+// flagged when the dataset has a common file they could have been placed in.
+type FilePlacementValidator struct {
+	mu              sync.Mutex
+	hasCommonFile   bool
+	lineFileOrgs    []placedElement
+	commonFileLines []placedElement
+	rules           []types.ValidationRule
+}
+
+// NewFilePlacementValidator creates a new file placement validator
+func NewFilePlacementValidator() *FilePlacementValidator {
+	return &FilePlacementValidator{
+		rules: []types.ValidationRule{
+			{
+				Code:     "FILE_STRUCTURE_1",
+				Name:     "Organisation declared in line file",
+				Message:  "Operators and Authorities should be declared in a common file, not in a line file",
+				Severity: types.WARNING,
+			},
+			{
+				Code:     "FILE_STRUCTURE_2",
+				Name:     "Line declared in common file",
+				Message:  "Lines should be declared in their own line file, not in a common file",
+				Severity: types.WARNING,
+			},
+		},
+	}
+}
+
+// Collect records the organisations of line files and the lines of common files
+func (v *FilePlacementValidator) Collect(ctx context.XPathValidationContext) error {
+	if ctx.Document == nil {
+		return nil
+	}
+
+	fileName := ctx.GetFileName()
+	common := ids.IsCommonFileName(fileName)
+	expression := "//organisations/Operator[@id] | //organisations/Authority[@id]"
+	if common {
+		expression = "//lines/Line[@id] | //lines/FlexibleLine[@id]"
+	}
+
+	var misplaced []placedElement
+	for _, node := range xmlquery.Find(ctx.Document, expression) {
+		misplaced = append(misplaced, placedElement{
+			kind:     node.Data,
+			id:       node.SelectAttr("id"),
+			fileName: fileName,
+			xpath:    utils.NodeXPath(node),
+		})
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if common {
+		v.hasCommonFile = true
+		v.commonFileLines = append(v.commonFileLines, misplaced...)
+	} else {
+		v.lineFileOrgs = append(v.lineFileOrgs, misplaced...)
+	}
+	return nil
+}
+
+// Validate reports the misplaced elements collected from all files
+func (v *FilePlacementValidator) Validate(repository interfaces.IdRepository) ([]types.ValidationIssue, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	var issues []types.ValidationIssue
+	if v.hasCommonFile {
+		for _, element := range v.lineFileOrgs {
+			issues = append(issues, v.newIssue(v.rules[0], element,
+				fmt.Sprintf("Line file '%s' declares %s '%s', which belongs in a common file",
+					element.fileName, element.kind, element.id)))
+		}
+	}
+	for _, element := range v.commonFileLines {
+		issues = append(issues, v.newIssue(v.rules[1], element,
+			fmt.Sprintf("Common file '%s' declares %s '%s', which belongs in its own line file",
+				element.fileName, element.kind, element.id)))
+	}
+
+	sort.SliceStable(issues, func(i, j int) bool {
+		if issues[i].Location.FileName != issues[j].Location.FileName {
+			return issues[i].Location.FileName < issues[j].Location.FileName
+		}
+		return issues[i].Location.ElementID < issues[j].Location.ElementID
+	})
+	return issues, nil
+}
+
+func (v *FilePlacementValidator) newIssue(rule types.ValidationRule, element placedElement, message string) types.ValidationIssue {
+	return types.ValidationIssue{
+		Rule: rule,
+		Location: types.DataLocation{
+			FileName:  element.fileName,
+			XPath:     element.xpath,
+			ElementID: element.id,
+		},
+		Message: message,
+	}
+}
+
+// GetRules returns the rules implemented by this validator
+func (v *FilePlacementValidator) GetRules() []types.ValidationRule {
+	return v.rules
+}
+
+// Reset clears all collected data
+func (v *FilePlacementValidator) Reset() {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.hasCommonFile = false
+	v.lineFileOrgs = nil
+	v.commonFileLines = nil
+}
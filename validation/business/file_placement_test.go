@@ -0,0 +1,92 @@
+package business
+
+import (
+	"testing"
+
+	"github.com/theoremus-urban-solutions/netex-validator/types"
+)
+
+func TestFilePlacementValidator(t *testing.T) {
+	common := `<?xml version="1.0" encoding="UTF-8"?>
+<PublicationDelivery xmlns="http://www.netex.org.uk/netex" version="1.15">
+	<dataObjects>
+		<ResourceFrame id="TEST:ResourceFrame:1" version="1">
+			<organisations>
+				<Authority id="TEST:Authority:1" version="1"><Name>Authority</Name></Authority>
+			</organisations>
+		</ResourceFrame>
+		<ServiceFrame id="TEST:ServiceFrame:1" version="1">
+			<lines>
+				<FlexibleLine id="TEST:FlexibleLine:1" version="1"><Name>In common file</Name></FlexibleLine>
+			</lines>
+		</ServiceFrame>
+	</dataObjects>
+</PublicationDelivery>`
+
+	line := `<?xml version="1.0" encoding="UTF-8"?>
+<PublicationDelivery xmlns="http://www.netex.org.uk/netex" version="1.15">
+	<dataObjects>
+		<ResourceFrame id="TEST:ResourceFrame:2" version="1">
+			<organisations>
+				<Operator id="TEST:Operator:1" version="1"><Name>In line file</Name></Operator>
+			</organisations>
+		</ResourceFrame>
+		<ServiceFrame id="TEST:ServiceFrame:2" version="1">
+			<lines>
+				<Line id="TEST:Line:1" version="1"><Name>Line</Name></Line>
+			</lines>
+		</ServiceFrame>
+	</dataObjects>
+</PublicationDelivery>`
+
+	validator := NewFilePlacementValidator()
+	if err := validator.Collect(newTestXPathContext(t, "line_1.xml", line)); err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+
+	// Without a common file the line file's Operator has nowhere else to go
+	issues, err := validator.Validate(nil)
+	if err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if len(issues) != 0 {
+		t.Fatalf("Expected no issues without a common file, got %+v", issues)
+	}
+
+	if err := validator.Collect(newTestXPathContext(t, "_common.xml", common)); err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+	issues, err = validator.Validate(nil)
+	if err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if len(issues) != 2 {
+		t.Fatalf("Expected 2 issues, got %d: %+v", len(issues), issues)
+	}
+
+	// Sorted by file name
+	if issues[0].Rule.Code != "FILE_STRUCTURE_2" || issues[0].Location.ElementID != "TEST:FlexibleLine:1" || issues[0].Location.FileName != "_common.xml" {
+		t.Errorf("Expected TEST:FlexibleLine:1 in _common.xml as FILE_STRUCTURE_2, got %+v", issues[0])
+	}
+	if issues[1].Rule.Code != "FILE_STRUCTURE_1" || issues[1].Location.ElementID != "TEST:Operator:1" || issues[1].Location.FileName != "line_1.xml" {
+		t.Errorf("Expected TEST:Operator:1 in line_1.xml as FILE_STRUCTURE_1, got %+v", issues[1])
+	}
+	for _, issue := range issues {
+		if issue.Rule.Severity != types.WARNING {
+			t.Errorf("Expected WARNING severity, got %v", issue.Rule.Severity)
+		}
+		if issue.Location.XPath == "" {
+			t.Errorf("Expected an XPath for %s", issue.Location.ElementID)
+		}
+	}
+
+	// Reset clears collected state for the next dataset
+	validator.Reset()
+	issues, err = validator.Validate(nil)
+	if err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if len(issues) != 0 {
+		t.Errorf("Expected no issues after Reset(), got %d", len(issues))
+	}
+}
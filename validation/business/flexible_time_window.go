@@ -0,0 +1,109 @@
+package business
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/antchfx/xmlquery"
+	"github.com/theoremus-urban-solutions/netex-validator/types"
+	"github.com/theoremus-urban-solutions/netex-validator/utils"
+	"github.com/theoremus-urban-solutions/netex-validator/validation/context"
+)
+
+// FlexibleTimeWindowValidator flags TimetabledPassingTimes of flexible services
+// whose EarliestDepartureTime lies after their LatestArrivalTime, a window no
+// passenger can be served in. Day offsets are taken into account, so windows
+// spanning midnight are compared correctly.
+type FlexibleTimeWindowValidator struct {
+	rules []types.ValidationRule
+}
+
+// NewFlexibleTimeWindowValidator creates a new flexible time window validator
+func NewFlexibleTimeWindowValidator() *FlexibleTimeWindowValidator {
+	return &FlexibleTimeWindowValidator{
+		rules: []types.ValidationRule{
+			{
+				Code:     "TIMETABLED_PASSING_TIME_INVERTED_WINDOW",
+				Name:     "TimetabledPassingTime inverted time window",
+				Message:  "TimetabledPassingTime EarliestDepartureTime is after its LatestArrivalTime",
+				Severity: types.ERROR,
+			},
+		},
+	}
+}
+
+// Validate compares the time window bounds of every TimetabledPassingTime in the document
+func (v *FlexibleTimeWindowValidator) Validate(ctx context.XPathValidationContext) ([]types.ValidationIssue, error) {
+	var issues []types.ValidationIssue
+	if ctx.Document == nil {
+		return issues, nil
+	}
+
+	for _, passingTime := range xmlquery.Find(ctx.Document, "//passingTimes/TimetabledPassingTime[EarliestDepartureTime and LatestArrivalTime]") {
+		earliestText := childText(passingTime, "EarliestDepartureTime")
+		latestText := childText(passingTime, "LatestArrivalTime")
+		earliest, ok := passingTimeSeconds(earliestText, childText(passingTime, "EarliestDepartureDayOffset"))
+		if !ok {
+			continue
+		}
+		latest, ok := passingTimeSeconds(latestText, childText(passingTime, "LatestArrivalDayOffset"))
+		if !ok || earliest <= latest {
+			continue
+		}
+
+		id := passingTime.SelectAttr("id")
+		subject := "TimetabledPassingTime"
+		if id != "" {
+			subject = fmt.Sprintf("TimetabledPassingTime '%s'", id)
+		}
+		issues = append(issues, types.ValidationIssue{
+			Rule: v.rules[0],
+			Location: types.DataLocation{
+				FileName:  ctx.GetFileName(),
+				XPath:     utils.NodeXPath(passingTime),
+				ElementID: id,
+			},
+			Message: fmt.Sprintf("%s has EarliestDepartureTime %s after LatestArrivalTime %s",
+				subject, windowBound(passingTime, earliestText, "EarliestDepartureDayOffset"),
+				windowBound(passingTime, latestText, "LatestArrivalDayOffset")),
+		})
+	}
+
+	return issues, nil
+}
+
+// GetRules returns the rules implemented by this validator
+func (v *FlexibleTimeWindowValidator) GetRules() []types.ValidationRule {
+	return v.rules
+}
+
+// passingTimeSeconds converts an HH:MM:SS passing time and its optional day offset
+// to seconds since midnight of the operating day. Malformed values are left to
+// TIMETABLED_PASSING_TIME_INVALID_FORMAT and reported as not ok.
+func passingTimeSeconds(value, dayOffset string) (int, bool) {
+	if !passingTimeFormat.MatchString(value) {
+		return 0, false
+	}
+	parts := strings.Split(value, ":")
+	hours, _ := strconv.Atoi(parts[0])
+	minutes, _ := strconv.Atoi(parts[1])
+	seconds, _ := strconv.Atoi(parts[2])
+
+	days := 0
+	if dayOffset != "" {
+		var err error
+		if days, err = strconv.Atoi(dayOffset); err != nil {
+			return 0, false
+		}
+	}
+	return days*24*3600 + hours*3600 + minutes*60 + seconds, true
+}
+
+// windowBound formats a time window bound for a message, with its day offset if any
+func windowBound(passingTime *xmlquery.Node, value, offsetElement string) string {
+	if offset := childText(passingTime, offsetElement); offset != "" && offset != "0" {
+		return fmt.Sprintf("'%s' (day offset %s)", value, offset)
+	}
+	return fmt.Sprintf("'%s'", value)
+}
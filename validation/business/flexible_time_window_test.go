@@ -0,0 +1,74 @@
+package business
+
+import (
+	"testing"
+
+	"github.com/theoremus-urban-solutions/netex-validator/types"
+)
+
+func TestFlexibleTimeWindowValidator(t *testing.T) {
+	document := `<?xml version="1.0" encoding="UTF-8"?>
+<PublicationDelivery xmlns="http://www.netex.org.uk/netex" version="1.15">
+	<dataObjects>
+		<TimetableFrame id="TEST:TimetableFrame:1" version="1">
+			<vehicleJourneys>
+				<ServiceJourney id="TEST:ServiceJourney:1" version="1">
+					<passingTimes>
+						<TimetabledPassingTime id="TEST:TimetabledPassingTime:Valid" version="1">
+							<EarliestDepartureTime>09:00:00</EarliestDepartureTime>
+							<LatestArrivalTime>09:30:00</LatestArrivalTime>
+						</TimetabledPassingTime>
+						<TimetabledPassingTime id="TEST:TimetabledPassingTime:Inverted" version="1">
+							<EarliestDepartureTime>10:30:00</EarliestDepartureTime>
+							<LatestArrivalTime>10:00:00</LatestArrivalTime>
+						</TimetabledPassingTime>
+						<TimetabledPassingTime id="TEST:TimetabledPassingTime:Midnight" version="1">
+							<EarliestDepartureTime>23:45:00</EarliestDepartureTime>
+							<LatestArrivalTime>00:15:00</LatestArrivalTime>
+							<LatestArrivalDayOffset>1</LatestArrivalDayOffset>
+						</TimetabledPassingTime>
+						<TimetabledPassingTime id="TEST:TimetabledPassingTime:Offset" version="1">
+							<EarliestDepartureTime>00:15:00</EarliestDepartureTime>
+							<EarliestDepartureDayOffset>1</EarliestDepartureDayOffset>
+							<LatestArrivalTime>23:45:00</LatestArrivalTime>
+						</TimetabledPassingTime>
+						<TimetabledPassingTime id="TEST:TimetabledPassingTime:Malformed" version="1">
+							<EarliestDepartureTime>10:30</EarliestDepartureTime>
+							<LatestArrivalTime>10:00:00</LatestArrivalTime>
+						</TimetabledPassingTime>
+						<TimetabledPassingTime id="TEST:TimetabledPassingTime:Fixed" version="1">
+							<ArrivalTime>11:00:00</ArrivalTime>
+							<DepartureTime>10:00:00</DepartureTime>
+						</TimetabledPassingTime>
+					</passingTimes>
+				</ServiceJourney>
+			</vehicleJourneys>
+		</TimetableFrame>
+	</dataObjects>
+</PublicationDelivery>`
+
+	validator := NewFlexibleTimeWindowValidator()
+	issues, err := validator.Validate(newTestXPathContext(t, "timetable.xml", document))
+	if err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if len(issues) != 2 {
+		t.Fatalf("Expected 2 issues, got %d: %+v", len(issues), issues)
+	}
+
+	for i, want := range []struct{ id, message string }{
+		{"TEST:TimetabledPassingTime:Inverted", "TimetabledPassingTime 'TEST:TimetabledPassingTime:Inverted' has EarliestDepartureTime '10:30:00' after LatestArrivalTime '10:00:00'"},
+		{"TEST:TimetabledPassingTime:Offset", "TimetabledPassingTime 'TEST:TimetabledPassingTime:Offset' has EarliestDepartureTime '00:15:00' (day offset 1) after LatestArrivalTime '23:45:00'"},
+	} {
+		issue := issues[i]
+		if issue.Rule.Code != "TIMETABLED_PASSING_TIME_INVERTED_WINDOW" || issue.Rule.Severity != types.ERROR {
+			t.Errorf("Expected TIMETABLED_PASSING_TIME_INVERTED_WINDOW error, got %s (%v)", issue.Rule.Code, issue.Rule.Severity)
+		}
+		if issue.Location.ElementID != want.id {
+			t.Errorf("Expected issue on %q, got %q", want.id, issue.Location.ElementID)
+		}
+		if issue.Message != want.message {
+			t.Errorf("Expected message %q, got %q", want.message, issue.Message)
+		}
+	}
+}
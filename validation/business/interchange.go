@@ -0,0 +1,110 @@
+package business
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/antchfx/xmlquery"
+	"github.com/theoremus-urban-solutions/netex-validator/types"
+	"github.com/theoremus-urban-solutions/netex-validator/utils"
+	"github.com/theoremus-urban-solutions/netex-validator/validation/context"
+)
+
+// DefaultMaxTransferTime is the StandardTransferTime above which an interchange is
+// considered implausible
+const DefaultMaxTransferTime = 2 * time.Hour
+
+// InterchangeTransferTimeValidator checks that ServiceJourneyInterchange transfer
+// times are plausible. It complements INTERCHANGE_5, which only checks that the
+// standard transfer time is positive.
+type InterchangeTransferTimeValidator struct {
+	maxTransferTime time.Duration
+	rules           []types.ValidationRule
+}
+
+// NewInterchangeTransferTimeValidator creates a new interchange transfer time validator.
+// A non-positive maxTransferTime falls back to DefaultMaxTransferTime.
+func NewInterchangeTransferTimeValidator(maxTransferTime time.Duration) *InterchangeTransferTimeValidator {
+	if maxTransferTime <= 0 {
+		maxTransferTime = DefaultMaxTransferTime
+	}
+
+	return &InterchangeTransferTimeValidator{
+		maxTransferTime: maxTransferTime,
+		rules: []types.ValidationRule{
+			{
+				Code:     "INTERCHANGE_6",
+				Name:     "Implausible interchange transfer time",
+				Message:  "ServiceJourneyInterchange StandardTransferTime is implausibly large",
+				Severity: types.WARNING,
+			},
+			{
+				Code:     "INTERCHANGE_7",
+				Name:     "Inconsistent interchange transfer time bounds",
+				Message:  "ServiceJourneyInterchange MinimumTransferTime exceeds MaximumTransferTime",
+				Severity: types.ERROR,
+			},
+		},
+	}
+}
+
+// Validate checks the transfer times of every ServiceJourneyInterchange in the document
+func (v *InterchangeTransferTimeValidator) Validate(ctx context.XPathValidationContext) ([]types.ValidationIssue, error) {
+	var issues []types.ValidationIssue
+	if ctx.Document == nil {
+		return issues, nil
+	}
+
+	for _, node := range xmlquery.Find(ctx.Document, "//ServiceJourneyInterchange") {
+		id := node.SelectAttr("id")
+
+		if standard, raw, ok := durationChild(node, "StandardTransferTime"); ok && standard > v.maxTransferTime {
+			issues = append(issues, v.newIssue(ctx, v.rules[0], node, id,
+				fmt.Sprintf("ServiceJourneyInterchange '%s' has StandardTransferTime %s which exceeds the plausible maximum of %s",
+					id, raw, v.maxTransferTime)))
+		}
+
+		minimum, rawMin, okMin := durationChild(node, "MinimumTransferTime")
+		maximum, rawMax, okMax := durationChild(node, "MaximumTransferTime")
+		if okMin && okMax && minimum > maximum {
+			issues = append(issues, v.newIssue(ctx, v.rules[1], node, id,
+				fmt.Sprintf("ServiceJourneyInterchange '%s' has MinimumTransferTime %s greater than MaximumTransferTime %s",
+					id, rawMin, rawMax)))
+		}
+	}
+
+	return issues, nil
+}
+
+// GetRules returns the rules implemented by this validator
+func (v *InterchangeTransferTimeValidator) GetRules() []types.ValidationRule {
+	return v.rules
+}
+
+func (v *InterchangeTransferTimeValidator) newIssue(ctx context.XPathValidationContext, rule types.ValidationRule, node *xmlquery.Node, id, message string) types.ValidationIssue {
+	return types.ValidationIssue{
+		Rule: rule,
+		Location: types.DataLocation{
+			FileName:  ctx.GetFileName(),
+			XPath:     utils.NodeXPath(node),
+			ElementID: id,
+		},
+		Message: message,
+	}
+}
+
+// durationChild parses the duration held by the named child element. Missing or
+// malformed values are reported as not ok; format problems are left to other rules.
+func durationChild(node *xmlquery.Node, name string) (time.Duration, string, bool) {
+	child := xmlquery.FindOne(node, name)
+	if child == nil {
+		return 0, "", false
+	}
+	raw := strings.TrimSpace(child.InnerText())
+	d, err := parseISODuration(raw)
+	if err != nil {
+		return 0, raw, false
+	}
+	return d, raw, true
+}
@@ -0,0 +1,175 @@
+package business
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/antchfx/xmlquery"
+	"github.com/theoremus-urban-solutions/netex-validator/interfaces"
+	"github.com/theoremus-urban-solutions/netex-validator/types"
+	"github.com/theoremus-urban-solutions/netex-validator/utils"
+	"github.com/theoremus-urban-solutions/netex-validator/validation/context"
+)
+
+// interchangeEndpoint is one side of a ServiceJourneyInterchange
+type interchangeEndpoint struct {
+	name      string // "From" or "To"
+	stopPoint string
+	journey   string
+}
+
+// collectedInterchange is a ServiceJourneyInterchange found while collecting a file
+type collectedInterchange struct {
+	id        string
+	fileName  string
+	xpath     string
+	endpoints []interchangeEndpoint
+}
+
+// InterchangeStopPointValidator checks that each end of a ServiceJourneyInterchange
+// is a stop the journey on that end actually serves. The stop point has to appear in
+// the journey pattern of the referenced ServiceJourney, which usually lives in
+// another file than the interchange. Journeys or patterns that cannot be resolved are
+// skipped; dangling references are reported by the ID validation.
+type InterchangeStopPointValidator struct {
+	mu           sync.Mutex
+	journeys     map[string]string              // ServiceJourney id -> journey pattern id
+	patterns     map[string]map[string]struct{} // journey pattern id -> served stop points
+	interchanges []collectedInterchange
+	rules        []types.ValidationRule
+}
+
+// NewInterchangeStopPointValidator creates a new interchange stop point validator
+func NewInterchangeStopPointValidator() *InterchangeStopPointValidator {
+	return &InterchangeStopPointValidator{
+		journeys: make(map[string]string),
+		patterns: make(map[string]map[string]struct{}),
+		rules: []types.ValidationRule{
+			{
+				Code:     "INTERCHANGE_9",
+				Name:     "Interchange stop point not served",
+				Message:  "ServiceJourneyInterchange stop point is not in the journey pattern of its ServiceJourney",
+				Severity: types.ERROR,
+			},
+		},
+	}
+}
+
+// Collect records the journeys, journey patterns and interchanges in a file.
+// Both the Nordic (FromStopPointRef, FromServiceJourneyRef) and the generic NeTEx
+// (FromPointRef, FromJourneyRef) interchange references are understood.
+func (v *InterchangeStopPointValidator) Collect(ctx context.XPathValidationContext) error {
+	if ctx.Document == nil {
+		return nil
+	}
+
+	journeys := make(map[string]string)
+	for _, node := range xmlquery.Find(ctx.Document, "//vehicleJourneys/ServiceJourney[@id]") {
+		pattern := childRef(node, "JourneyPatternRef")
+		if pattern == "" {
+			pattern = childRef(node, "ServiceJourneyPatternRef")
+		}
+		if pattern != "" {
+			journeys[node.SelectAttr("id")] = pattern
+		}
+	}
+
+	patterns := make(map[string][]string)
+	for _, node := range xmlquery.Find(ctx.Document, "//journeyPatterns/*[self::JourneyPattern or self::ServiceJourneyPattern][@id]") {
+		var stopPoints []string
+		for _, ref := range xmlquery.Find(node, "pointsInSequence/StopPointInJourneyPattern/ScheduledStopPointRef") {
+			if stopPoint := refValue(ref); stopPoint != "" {
+				stopPoints = append(stopPoints, stopPoint)
+			}
+		}
+		patterns[node.SelectAttr("id")] = stopPoints
+	}
+
+	var interchanges []collectedInterchange
+	for _, node := range xmlquery.Find(ctx.Document, "//ServiceJourneyInterchange[@id]") {
+		interchange := collectedInterchange{
+			id:       node.SelectAttr("id"),
+			fileName: ctx.GetFileName(),
+			xpath:    utils.NodeXPath(node),
+		}
+		for _, end := range []string{"From", "To"} {
+			interchange.endpoints = append(interchange.endpoints, interchangeEndpoint{
+				name:      end,
+				stopPoint: childRef(node, end+"StopPointRef|"+end+"PointRef"),
+				journey:   childRef(node, end+"ServiceJourneyRef|"+end+"JourneyRef"),
+			})
+		}
+		interchanges = append(interchanges, interchange)
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	for journey, pattern := range journeys {
+		v.journeys[journey] = pattern
+	}
+	for pattern, stopPoints := range patterns {
+		served := make(map[string]struct{}, len(stopPoints))
+		for _, stopPoint := range stopPoints {
+			served[stopPoint] = struct{}{}
+		}
+		v.patterns[pattern] = served
+	}
+	v.interchanges = append(v.interchanges, interchanges...)
+	return nil
+}
+
+// Validate reports interchange endpoints whose stop point the journey does not serve
+func (v *InterchangeStopPointValidator) Validate(repository interfaces.IdRepository) ([]types.ValidationIssue, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	sort.SliceStable(v.interchanges, func(i, j int) bool { return v.interchanges[i].id < v.interchanges[j].id })
+
+	var issues []types.ValidationIssue
+	for _, interchange := range v.interchanges {
+		for _, end := range interchange.endpoints {
+			if end.stopPoint == "" || end.journey == "" {
+				continue
+			}
+			pattern, ok := v.journeys[end.journey]
+			if !ok {
+				continue
+			}
+			served, ok := v.patterns[pattern]
+			if !ok {
+				continue
+			}
+			if _, ok := served[end.stopPoint]; ok {
+				continue
+			}
+
+			issues = append(issues, types.ValidationIssue{
+				Rule: v.rules[0],
+				Location: types.DataLocation{
+					FileName:  interchange.fileName,
+					XPath:     interchange.xpath,
+					ElementID: interchange.id,
+				},
+				Message: fmt.Sprintf("ServiceJourneyInterchange '%s' %s stop point '%s' is not served by ServiceJourney '%s' (journey pattern '%s')",
+					interchange.id, end.name, end.stopPoint, end.journey, pattern),
+			})
+		}
+	}
+
+	return issues, nil
+}
+
+// GetRules returns the rules implemented by this validator
+func (v *InterchangeStopPointValidator) GetRules() []types.ValidationRule {
+	return v.rules
+}
+
+// Reset clears all collected data
+func (v *InterchangeStopPointValidator) Reset() {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.journeys = make(map[string]string)
+	v.patterns = make(map[string]map[string]struct{})
+	v.interchanges = nil
+}
@@ -0,0 +1,116 @@
+package business
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/theoremus-urban-solutions/netex-validator/validation/ids"
+)
+
+func TestInterchangeStopPointValidator(t *testing.T) {
+	lineFile := `<?xml version="1.0" encoding="UTF-8"?>
+<PublicationDelivery xmlns="http://www.netex.org.uk/netex" version="1.15">
+	<dataObjects>
+		<ServiceFrame id="TEST:ServiceFrame:1" version="1">
+			<journeyPatterns>
+				<JourneyPattern id="TEST:JourneyPattern:1" version="1">
+					<pointsInSequence>
+						<StopPointInJourneyPattern id="TEST:StopPointInJourneyPattern:1-1" version="1" order="1">
+							<ScheduledStopPointRef ref="TEST:ScheduledStopPoint:A"/>
+						</StopPointInJourneyPattern>
+						<StopPointInJourneyPattern id="TEST:StopPointInJourneyPattern:1-2" version="1" order="2">
+							<ScheduledStopPointRef ref="TEST:ScheduledStopPoint:B"/>
+						</StopPointInJourneyPattern>
+					</pointsInSequence>
+				</JourneyPattern>
+				<ServiceJourneyPattern id="TEST:ServiceJourneyPattern:2" version="1">
+					<pointsInSequence>
+						<StopPointInJourneyPattern id="TEST:StopPointInJourneyPattern:2-1" version="1" order="1">
+							<ScheduledStopPointRef ref="TEST:ScheduledStopPoint:B"/>
+						</StopPointInJourneyPattern>
+						<StopPointInJourneyPattern id="TEST:StopPointInJourneyPattern:2-2" version="1" order="2">
+							<ScheduledStopPointRef ref="TEST:ScheduledStopPoint:C"/>
+						</StopPointInJourneyPattern>
+					</pointsInSequence>
+				</ServiceJourneyPattern>
+			</journeyPatterns>
+		</ServiceFrame>
+		<TimetableFrame id="TEST:TimetableFrame:1" version="1">
+			<vehicleJourneys>
+				<ServiceJourney id="TEST:ServiceJourney:1" version="1">
+					<JourneyPatternRef ref="TEST:JourneyPattern:1"/>
+				</ServiceJourney>
+				<ServiceJourney id="TEST:ServiceJourney:2" version="1">
+					<ServiceJourneyPatternRef ref="TEST:ServiceJourneyPattern:2"/>
+				</ServiceJourney>
+			</vehicleJourneys>
+		</TimetableFrame>
+	</dataObjects>
+</PublicationDelivery>`
+
+	interchangeFile := `<?xml version="1.0" encoding="UTF-8"?>
+<PublicationDelivery xmlns="http://www.netex.org.uk/netex" version="1.15">
+	<dataObjects>
+		<TimetableFrame id="TEST:TimetableFrame:2" version="1">
+			<journeyInterchanges>
+				<ServiceJourneyInterchange id="TEST:ServiceJourneyInterchange:Valid" version="1">
+					<FromPointRef ref="TEST:ScheduledStopPoint:B"/>
+					<ToPointRef ref="TEST:ScheduledStopPoint:B"/>
+					<FromJourneyRef ref="TEST:ServiceJourney:1"/>
+					<ToJourneyRef ref="TEST:ServiceJourney:2"/>
+				</ServiceJourneyInterchange>
+				<ServiceJourneyInterchange id="TEST:ServiceJourneyInterchange:Invalid" version="1">
+					<FromStopPointRef ref="TEST:ScheduledStopPoint:B"/>
+					<ToStopPointRef ref="TEST:ScheduledStopPoint:A"/>
+					<FromServiceJourneyRef ref="TEST:ServiceJourney:1"/>
+					<ToServiceJourneyRef ref="TEST:ServiceJourney:2"/>
+				</ServiceJourneyInterchange>
+				<ServiceJourneyInterchange id="TEST:ServiceJourneyInterchange:Unresolved" version="1">
+					<FromStopPointRef ref="TEST:ScheduledStopPoint:C"/>
+					<ToStopPointRef ref="TEST:ScheduledStopPoint:C"/>
+					<FromServiceJourneyRef ref="TEST:ServiceJourney:Missing"/>
+					<ToServiceJourneyRef ref="TEST:ServiceJourney:2"/>
+				</ServiceJourneyInterchange>
+			</journeyInterchanges>
+		</TimetableFrame>
+	</dataObjects>
+</PublicationDelivery>`
+
+	validator := NewInterchangeStopPointValidator()
+	if err := validator.Collect(newTestXPathContext(t, "interchanges.xml", interchangeFile)); err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+	if err := validator.Collect(newTestXPathContext(t, "line.xml", lineFile)); err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+
+	issues, err := validator.Validate(ids.NewNetexIdRepository())
+	if err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if len(issues) != 1 {
+		t.Fatalf("Expected 1 issue, got %d: %+v", len(issues), issues)
+	}
+
+	issue := issues[0]
+	if issue.Rule.Code != "INTERCHANGE_9" {
+		t.Errorf("Expected INTERCHANGE_9, got %s", issue.Rule.Code)
+	}
+	if issue.Location.ElementID != "TEST:ServiceJourneyInterchange:Invalid" || issue.Location.FileName != "interchanges.xml" {
+		t.Errorf("Expected issue on TEST:ServiceJourneyInterchange:Invalid in interchanges.xml, got %+v", issue.Location)
+	}
+	for _, want := range []string{"To stop point", "TEST:ScheduledStopPoint:A", "TEST:ServiceJourney:2"} {
+		if !strings.Contains(issue.Message, want) {
+			t.Errorf("Expected message to contain %q, got %q", want, issue.Message)
+		}
+	}
+
+	validator.Reset()
+	issues, err = validator.Validate(ids.NewNetexIdRepository())
+	if err != nil {
+		t.Fatalf("Validate() after Reset() error = %v", err)
+	}
+	if len(issues) != 0 {
+		t.Errorf("Expected no issues after Reset(), got %d", len(issues))
+	}
+}
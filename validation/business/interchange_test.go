@@ -0,0 +1,174 @@
+package business
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/antchfx/xmlquery"
+	"github.com/theoremus-urban-solutions/netex-validator/types"
+	"github.com/theoremus-urban-solutions/netex-validator/validation/context"
+)
+
+func newTestXPathContext(t *testing.T, fileName, xml string) context.XPathValidationContext {
+	t.Helper()
+	doc, err := xmlquery.Parse(strings.NewReader(xml))
+	if err != nil {
+		t.Fatalf("Failed to parse test XML: %v", err)
+	}
+	return *context.NewXPathValidationContext(fileName, "TEST", "report", doc, nil, nil)
+}
+
+func interchangeDocument(interchanges string) string {
+	return `<?xml version="1.0" encoding="UTF-8"?>
+<PublicationDelivery xmlns="http://www.netex.org.uk/netex" version="1.15">
+	<dataObjects>
+		<ServiceFrame id="TEST:ServiceFrame:1" version="1">
+			<interchanges>` + interchanges + `</interchanges>
+		</ServiceFrame>
+	</dataObjects>
+</PublicationDelivery>`
+}
+
+func TestInterchangeTransferTimeValidator(t *testing.T) {
+	tests := []struct {
+		name         string
+		interchanges string
+		maxTransfer  time.Duration
+		expected     map[string]types.Severity
+	}{
+		{
+			name: "Plausible transfer times",
+			interchanges: `<ServiceJourneyInterchange id="TEST:ServiceJourneyInterchange:1" version="1">
+					<StandardTransferTime>PT5M</StandardTransferTime>
+					<MinimumTransferTime>PT2M</MinimumTransferTime>
+					<MaximumTransferTime>PT15M</MaximumTransferTime>
+				</ServiceJourneyInterchange>`,
+			expected: map[string]types.Severity{},
+		},
+		{
+			name: "Standard transfer time above default threshold",
+			interchanges: `<ServiceJourneyInterchange id="TEST:ServiceJourneyInterchange:2" version="1">
+					<StandardTransferTime>PT2H30M</StandardTransferTime>
+				</ServiceJourneyInterchange>`,
+			expected: map[string]types.Severity{"INTERCHANGE_6": types.WARNING},
+		},
+		{
+			name: "Standard transfer time above custom threshold",
+			interchanges: `<ServiceJourneyInterchange id="TEST:ServiceJourneyInterchange:3" version="1">
+					<StandardTransferTime>PT45M</StandardTransferTime>
+				</ServiceJourneyInterchange>`,
+			maxTransfer: 30 * time.Minute,
+			expected:    map[string]types.Severity{"INTERCHANGE_6": types.WARNING},
+		},
+		{
+			name: "Minimum exceeds maximum",
+			interchanges: `<ServiceJourneyInterchange id="TEST:ServiceJourneyInterchange:4" version="1">
+					<MinimumTransferTime>PT10M</MinimumTransferTime>
+					<MaximumTransferTime>PT5M</MaximumTransferTime>
+				</ServiceJourneyInterchange>`,
+			expected: map[string]types.Severity{"INTERCHANGE_7": types.ERROR},
+		},
+		{
+			name: "Malformed durations are ignored",
+			interchanges: `<ServiceJourneyInterchange id="TEST:ServiceJourneyInterchange:5" version="1">
+					<StandardTransferTime>five minutes</StandardTransferTime>
+					<MinimumTransferTime>PT10M</MinimumTransferTime>
+					<MaximumTransferTime>soon</MaximumTransferTime>
+				</ServiceJourneyInterchange>`,
+			expected: map[string]types.Severity{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			validator := NewInterchangeTransferTimeValidator(tt.maxTransfer)
+			ctx := newTestXPathContext(t, "interchanges.xml", interchangeDocument(tt.interchanges))
+
+			issues, err := validator.Validate(ctx)
+			if err != nil {
+				t.Fatalf("Validate() error = %v", err)
+			}
+
+			if len(issues) != len(tt.expected) {
+				t.Fatalf("Expected %d issues, got %d: %+v", len(tt.expected), len(issues), issues)
+			}
+			for _, issue := range issues {
+				severity, ok := tt.expected[issue.Rule.Code]
+				if !ok {
+					t.Errorf("Unexpected rule %s", issue.Rule.Code)
+					continue
+				}
+				if issue.Rule.Severity != severity {
+					t.Errorf("Rule %s: expected severity %v, got %v", issue.Rule.Code, severity, issue.Rule.Severity)
+				}
+				if issue.Location.ElementID == "" || !strings.Contains(issue.Message, issue.Location.ElementID) {
+					t.Errorf("Expected message to name the interchange, got %q", issue.Message)
+				}
+				if issue.Location.FileName != "interchanges.xml" {
+					t.Errorf("Expected file name interchanges.xml, got %s", issue.Location.FileName)
+				}
+			}
+		})
+	}
+}
+
+func TestInterchangeTransferTimeValidator_ReportsDurations(t *testing.T) {
+	validator := NewInterchangeTransferTimeValidator(0)
+	ctx := newTestXPathContext(t, "interchanges.xml", interchangeDocument(
+		`<ServiceJourneyInterchange id="TEST:ServiceJourneyInterchange:1" version="1">
+			<StandardTransferTime>P1D</StandardTransferTime>
+			<MinimumTransferTime>PT20M</MinimumTransferTime>
+			<MaximumTransferTime>PT10M</MaximumTransferTime>
+		</ServiceJourneyInterchange>`))
+
+	issues, err := validator.Validate(ctx)
+	if err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if len(issues) != 2 {
+		t.Fatalf("Expected 2 issues, got %d", len(issues))
+	}
+
+	for _, want := range []string{"P1D", "PT20M", "PT10M"} {
+		found := false
+		for _, issue := range issues {
+			if strings.Contains(issue.Message, want) {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("Expected a message mentioning duration %s", want)
+		}
+	}
+}
+
+func TestParseISODuration(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected time.Duration
+		wantErr  bool
+	}{
+		{"PT5M", 5 * time.Minute, false},
+		{"PT1H30M", 90 * time.Minute, false},
+		{"P1DT2H", 26 * time.Hour, false},
+		{"PT0.5S", 500 * time.Millisecond, false},
+		{"-PT10M", -10 * time.Minute, false},
+		{"P", 0, true},
+		{"PT", 0, true},
+		{"10", 0, true},
+		{"P1Y", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := parseISODuration(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseISODuration(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if got != tt.expected {
+				t.Errorf("parseISODuration(%q) = %v, want %v", tt.input, got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package business
+
+import (
+	"fmt"
+
+	"github.com/antchfx/xmlquery"
+	"github.com/theoremus-urban-solutions/netex-validator/types"
+	"github.com/theoremus-urban-solutions/netex-validator/utils"
+	"github.com/theoremus-urban-solutions/netex-validator/validation/context"
+)
+
+// PassingTimeCountValidator flags service journeys whose passingTimes holds fewer
+// than two TimetabledPassingTimes. A single-stop journey carries no passengers anywhere.
+// Journeys without passingTimes are reported by SERVICE_JOURNEY_3 instead.
+type PassingTimeCountValidator struct {
+	rules []types.ValidationRule
+}
+
+// NewPassingTimeCountValidator creates a new passing time count validator
+func NewPassingTimeCountValidator() *PassingTimeCountValidator {
+	return &PassingTimeCountValidator{
+		rules: []types.ValidationRule{
+			{
+				Code:     "SERVICE_JOURNEY_21",
+				Name:     "ServiceJourney with fewer than 2 passing times",
+				Message:  "ServiceJourney must have at least 2 TimetabledPassingTimes",
+				Severity: types.ERROR,
+			},
+		},
+	}
+}
+
+// Validate checks the number of TimetabledPassingTime in every service journey
+func (v *PassingTimeCountValidator) Validate(ctx context.XPathValidationContext) ([]types.ValidationIssue, error) {
+	var issues []types.ValidationIssue
+	if ctx.Document == nil {
+		return issues, nil
+	}
+
+	journeys := xmlquery.Find(ctx.Document,
+		"//vehicleJourneys/ServiceJourney[passingTimes][count(passingTimes/TimetabledPassingTime) < 2]")
+	for _, journey := range journeys {
+		id := journey.SelectAttr("id")
+		count := len(xmlquery.Find(journey, "passingTimes/TimetabledPassingTime"))
+		issues = append(issues, types.ValidationIssue{
+			Rule: v.rules[0],
+			Location: types.DataLocation{
+				FileName:  ctx.GetFileName(),
+				XPath:     utils.NodeXPath(journey),
+				ElementID: id,
+			},
+			Message: fmt.Sprintf("ServiceJourney '%s' has %d TimetabledPassingTime(s); at least 2 are needed for a departure and an arrival",
+				id, count),
+		})
+	}
+
+	return issues, nil
+}
+
+// GetRules returns the rules implemented by this validator
+func (v *PassingTimeCountValidator) GetRules() []types.ValidationRule {
+	return v.rules
+}
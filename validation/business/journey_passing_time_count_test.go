@@ -0,0 +1,67 @@
+package business
+
+import (
+	"testing"
+
+	"github.com/theoremus-urban-solutions/netex-validator/types"
+)
+
+func TestPassingTimeCountValidator(t *testing.T) {
+	document := `<?xml version="1.0" encoding="UTF-8"?>
+<PublicationDelivery xmlns="http://www.netex.org.uk/netex" version="1.15">
+	<dataObjects>
+		<TimetableFrame id="TEST:TimetableFrame:1" version="1">
+			<vehicleJourneys>
+				<ServiceJourney id="TEST:ServiceJourney:OneStop" version="1">
+					<passingTimes>
+						<TimetabledPassingTime id="TEST:TimetabledPassingTime:1" version="1">
+							<DepartureTime>08:00:00</DepartureTime>
+						</TimetabledPassingTime>
+					</passingTimes>
+				</ServiceJourney>
+				<ServiceJourney id="TEST:ServiceJourney:TwoStops" version="1">
+					<passingTimes>
+						<TimetabledPassingTime id="TEST:TimetabledPassingTime:2" version="1">
+							<DepartureTime>08:00:00</DepartureTime>
+						</TimetabledPassingTime>
+						<TimetabledPassingTime id="TEST:TimetabledPassingTime:3" version="1">
+							<ArrivalTime>08:10:00</ArrivalTime>
+						</TimetabledPassingTime>
+					</passingTimes>
+				</ServiceJourney>
+				<ServiceJourney id="TEST:ServiceJourney:NoStops" version="1">
+					<passingTimes/>
+				</ServiceJourney>
+				<ServiceJourney id="TEST:ServiceJourney:NoPassingTimes" version="1"/>
+			</vehicleJourneys>
+		</TimetableFrame>
+	</dataObjects>
+</PublicationDelivery>`
+
+	validator := NewPassingTimeCountValidator()
+	issues, err := validator.Validate(newTestXPathContext(t, "journeys.xml", document))
+	if err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+
+	expected := map[string]string{
+		"TEST:ServiceJourney:OneStop": "ServiceJourney 'TEST:ServiceJourney:OneStop' has 1 TimetabledPassingTime(s); at least 2 are needed for a departure and an arrival",
+		"TEST:ServiceJourney:NoStops": "ServiceJourney 'TEST:ServiceJourney:NoStops' has 0 TimetabledPassingTime(s); at least 2 are needed for a departure and an arrival",
+	}
+	if len(issues) != len(expected) {
+		t.Fatalf("Expected %d issues, got %d: %+v", len(expected), len(issues), issues)
+	}
+	for _, issue := range issues {
+		if issue.Rule.Code != "SERVICE_JOURNEY_21" || issue.Rule.Severity != types.ERROR {
+			t.Errorf("Expected SERVICE_JOURNEY_21 ERROR, got %s %v", issue.Rule.Code, issue.Rule.Severity)
+		}
+		message, ok := expected[issue.Location.ElementID]
+		if !ok {
+			t.Errorf("Unexpected issue on %s", issue.Location.ElementID)
+			continue
+		}
+		if issue.Message != message {
+			t.Errorf("Expected message %q, got %q", message, issue.Message)
+		}
+	}
+}
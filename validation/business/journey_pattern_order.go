@@ -0,0 +1,87 @@
+package business
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/antchfx/xmlquery"
+	"github.com/theoremus-urban-solutions/netex-validator/types"
+	"github.com/theoremus-urban-solutions/netex-validator/utils"
+	"github.com/theoremus-urban-solutions/netex-validator/validation/context"
+)
+
+// JourneyPatternOrderValidator flags order values shared by several stop points
+// of one journey pattern. Each duplicated order is reported once, listing every
+// StopPointInJourneyPattern that uses it, so all colliding points can be found.
+type JourneyPatternOrderValidator struct {
+	rules []types.ValidationRule
+}
+
+// NewJourneyPatternOrderValidator creates a new journey pattern order validator
+func NewJourneyPatternOrderValidator() *JourneyPatternOrderValidator {
+	return &JourneyPatternOrderValidator{
+		rules: []types.ValidationRule{
+			{
+				Code:     "JOURNEY_PATTERN_5",
+				Name:     "Duplicate order in JourneyPattern",
+				Message:  "Order values must be unique within JourneyPattern",
+				Severity: types.ERROR,
+			},
+		},
+	}
+}
+
+// Validate groups the stop points of every journey pattern by order
+func (v *JourneyPatternOrderValidator) Validate(ctx context.XPathValidationContext) ([]types.ValidationIssue, error) {
+	var issues []types.ValidationIssue
+	if ctx.Document == nil {
+		return issues, nil
+	}
+
+	for _, pattern := range xmlquery.Find(ctx.Document, "//journeyPatterns/*[self::JourneyPattern or self::ServiceJourneyPattern]") {
+		var orders []string
+		stopPoints := make(map[string][]string)
+		for _, node := range xmlquery.Find(pattern, "pointsInSequence/StopPointInJourneyPattern[@order]") {
+			order := normalizeOrder(node.SelectAttr("order"))
+			if _, seen := stopPoints[order]; !seen {
+				orders = append(orders, order)
+			}
+			stopPoints[order] = append(stopPoints[order], node.SelectAttr("id"))
+		}
+
+		patternID := pattern.SelectAttr("id")
+		for _, order := range orders {
+			ids := stopPoints[order]
+			if len(ids) < 2 {
+				continue
+			}
+			issues = append(issues, types.ValidationIssue{
+				Rule: v.rules[0],
+				Location: types.DataLocation{
+					FileName:  ctx.GetFileName(),
+					XPath:     utils.NodeXPath(pattern),
+					ElementID: patternID,
+				},
+				Message: fmt.Sprintf("%s '%s' uses order %s for %d StopPointInJourneyPatterns: '%s'",
+					pattern.Data, patternID, order, len(ids), strings.Join(ids, "', '")),
+			})
+		}
+	}
+
+	return issues, nil
+}
+
+// normalizeOrder parses an order attribute so that e.g. "01" and "1" collide
+func normalizeOrder(order string) string {
+	order = strings.TrimSpace(order)
+	if n, err := strconv.Atoi(order); err == nil {
+		return strconv.Itoa(n)
+	}
+	return order
+}
+
+// GetRules returns the rules implemented by this validator
+func (v *JourneyPatternOrderValidator) GetRules() []types.ValidationRule {
+	return v.rules
+}
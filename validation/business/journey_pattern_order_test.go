@@ -0,0 +1,56 @@
+package business
+
+import (
+	"testing"
+
+	"github.com/theoremus-urban-solutions/netex-validator/types"
+)
+
+func TestJourneyPatternOrderValidator(t *testing.T) {
+	document := `<?xml version="1.0" encoding="UTF-8"?>
+<PublicationDelivery xmlns="http://www.netex.org.uk/netex" version="1.15">
+	<dataObjects>
+		<ServiceFrame id="TEST:ServiceFrame:1" version="1">
+			<journeyPatterns>
+				<JourneyPattern id="TEST:JourneyPattern:1" version="1">
+					<pointsInSequence>
+						<StopPointInJourneyPattern id="TEST:StopPointInJourneyPattern:A" version="1" order="1"/>
+						<StopPointInJourneyPattern id="TEST:StopPointInJourneyPattern:B" version="1" order="2"/>
+						<StopPointInJourneyPattern id="TEST:StopPointInJourneyPattern:C" version="1" order="02"/>
+						<StopPointInJourneyPattern id="TEST:StopPointInJourneyPattern:D" version="1" order="3"/>
+						<StopPointInJourneyPattern id="TEST:StopPointInJourneyPattern:E" version="1" order="2"/>
+					</pointsInSequence>
+				</JourneyPattern>
+				<ServiceJourneyPattern id="TEST:ServiceJourneyPattern:1" version="1">
+					<pointsInSequence>
+						<StopPointInJourneyPattern id="TEST:StopPointInJourneyPattern:F" version="1" order="1"/>
+						<StopPointInJourneyPattern id="TEST:StopPointInJourneyPattern:G" version="1" order="2"/>
+					</pointsInSequence>
+				</ServiceJourneyPattern>
+			</journeyPatterns>
+		</ServiceFrame>
+	</dataObjects>
+</PublicationDelivery>`
+
+	validator := NewJourneyPatternOrderValidator()
+	issues, err := validator.Validate(newTestXPathContext(t, "patterns.xml", document))
+	if err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if len(issues) != 1 {
+		t.Fatalf("Expected 1 issue for the three-way collision, got %d: %+v", len(issues), issues)
+	}
+
+	issue := issues[0]
+	if issue.Rule.Code != "JOURNEY_PATTERN_5" || issue.Rule.Severity != types.ERROR {
+		t.Errorf("Expected JOURNEY_PATTERN_5 ERROR, got %s %v", issue.Rule.Code, issue.Rule.Severity)
+	}
+	if issue.Location.ElementID != "TEST:JourneyPattern:1" {
+		t.Errorf("Expected issue on TEST:JourneyPattern:1, got %s", issue.Location.ElementID)
+	}
+	expected := "JourneyPattern 'TEST:JourneyPattern:1' uses order 2 for 3 StopPointInJourneyPatterns: " +
+		"'TEST:StopPointInJourneyPattern:B', 'TEST:StopPointInJourneyPattern:C', 'TEST:StopPointInJourneyPattern:E'"
+	if issue.Message != expected {
+		t.Errorf("Expected message %q, got %q", expected, issue.Message)
+	}
+}
@@ -0,0 +1,63 @@
+package business
+
+import (
+	"fmt"
+
+	"github.com/antchfx/xmlquery"
+	"github.com/theoremus-urban-solutions/netex-validator/types"
+	"github.com/theoremus-urban-solutions/netex-validator/utils"
+	"github.com/theoremus-urban-solutions/netex-validator/validation/context"
+)
+
+// JourneyPatternStopsValidator flags journey patterns whose pointsInSequence holds
+// fewer than two stop points. A journey needs at least an origin and a destination.
+// Patterns without pointsInSequence are reported by JOURNEY_PATTERN_2 instead.
+type JourneyPatternStopsValidator struct {
+	rules []types.ValidationRule
+}
+
+// NewJourneyPatternStopsValidator creates a new journey pattern stop count validator
+func NewJourneyPatternStopsValidator() *JourneyPatternStopsValidator {
+	return &JourneyPatternStopsValidator{
+		rules: []types.ValidationRule{
+			{
+				Code:     "JOURNEY_PATTERN_3",
+				Name:     "JourneyPattern missing StopPoints",
+				Message:  "JourneyPattern must have at least 2 stop points",
+				Severity: types.ERROR,
+			},
+		},
+	}
+}
+
+// Validate checks the number of StopPointInJourneyPattern in every journey pattern
+func (v *JourneyPatternStopsValidator) Validate(ctx context.XPathValidationContext) ([]types.ValidationIssue, error) {
+	var issues []types.ValidationIssue
+	if ctx.Document == nil {
+		return issues, nil
+	}
+
+	patterns := xmlquery.Find(ctx.Document,
+		"//journeyPatterns/*[self::JourneyPattern or self::ServiceJourneyPattern][pointsInSequence][count(pointsInSequence/StopPointInJourneyPattern) < 2]")
+	for _, pattern := range patterns {
+		id := pattern.SelectAttr("id")
+		stops := len(xmlquery.Find(pattern, "pointsInSequence/StopPointInJourneyPattern"))
+		issues = append(issues, types.ValidationIssue{
+			Rule: v.rules[0],
+			Location: types.DataLocation{
+				FileName:  ctx.GetFileName(),
+				XPath:     utils.NodeXPath(pattern),
+				ElementID: id,
+			},
+			Message: fmt.Sprintf("%s '%s' has %d stop point(s) in pointsInSequence; at least 2 are needed for an origin and a destination",
+				pattern.Data, id, stops),
+		})
+	}
+
+	return issues, nil
+}
+
+// GetRules returns the rules implemented by this validator
+func (v *JourneyPatternStopsValidator) GetRules() []types.ValidationRule {
+	return v.rules
+}
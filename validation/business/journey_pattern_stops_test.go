@@ -0,0 +1,67 @@
+package business
+
+import (
+	"testing"
+
+	"github.com/theoremus-urban-solutions/netex-validator/types"
+)
+
+func TestJourneyPatternStopsValidator(t *testing.T) {
+	document := `<?xml version="1.0" encoding="UTF-8"?>
+<PublicationDelivery xmlns="http://www.netex.org.uk/netex" version="1.15">
+	<dataObjects>
+		<ServiceFrame id="TEST:ServiceFrame:1" version="1">
+			<journeyPatterns>
+				<JourneyPattern id="TEST:JourneyPattern:NoStops" version="1">
+					<pointsInSequence/>
+				</JourneyPattern>
+				<ServiceJourneyPattern id="TEST:ServiceJourneyPattern:OneStop" version="1">
+					<pointsInSequence>
+						<StopPointInJourneyPattern id="TEST:StopPointInJourneyPattern:1" version="1" order="1">
+							<ScheduledStopPointRef ref="TEST:ScheduledStopPoint:1"/>
+						</StopPointInJourneyPattern>
+					</pointsInSequence>
+				</ServiceJourneyPattern>
+				<JourneyPattern id="TEST:JourneyPattern:TwoStops" version="1">
+					<pointsInSequence>
+						<StopPointInJourneyPattern id="TEST:StopPointInJourneyPattern:2" version="1" order="1">
+							<ScheduledStopPointRef ref="TEST:ScheduledStopPoint:1"/>
+						</StopPointInJourneyPattern>
+						<StopPointInJourneyPattern id="TEST:StopPointInJourneyPattern:3" version="1" order="2">
+							<ScheduledStopPointRef ref="TEST:ScheduledStopPoint:2"/>
+						</StopPointInJourneyPattern>
+					</pointsInSequence>
+				</JourneyPattern>
+				<JourneyPattern id="TEST:JourneyPattern:NoSequence" version="1"/>
+			</journeyPatterns>
+		</ServiceFrame>
+	</dataObjects>
+</PublicationDelivery>`
+
+	validator := NewJourneyPatternStopsValidator()
+	issues, err := validator.Validate(newTestXPathContext(t, "patterns.xml", document))
+	if err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+
+	expected := map[string]string{
+		"TEST:JourneyPattern:NoStops":        "JourneyPattern 'TEST:JourneyPattern:NoStops' has 0 stop point(s) in pointsInSequence; at least 2 are needed for an origin and a destination",
+		"TEST:ServiceJourneyPattern:OneStop": "ServiceJourneyPattern 'TEST:ServiceJourneyPattern:OneStop' has 1 stop point(s) in pointsInSequence; at least 2 are needed for an origin and a destination",
+	}
+	if len(issues) != len(expected) {
+		t.Fatalf("Expected %d issues, got %d: %+v", len(expected), len(issues), issues)
+	}
+	for _, issue := range issues {
+		if issue.Rule.Code != "JOURNEY_PATTERN_3" || issue.Rule.Severity != types.ERROR {
+			t.Errorf("Expected JOURNEY_PATTERN_3 ERROR, got %s %v", issue.Rule.Code, issue.Rule.Severity)
+		}
+		message, ok := expected[issue.Location.ElementID]
+		if !ok {
+			t.Errorf("Unexpected issue on %s", issue.Location.ElementID)
+			continue
+		}
+		if issue.Message != message {
+			t.Errorf("Expected message %q, got %q", message, issue.Message)
+		}
+	}
+}
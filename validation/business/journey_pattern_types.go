@@ -0,0 +1,177 @@
+package business
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/antchfx/xmlquery"
+	"github.com/theoremus-urban-solutions/netex-validator/interfaces"
+	"github.com/theoremus-urban-solutions/netex-validator/types"
+	"github.com/theoremus-urban-solutions/netex-validator/utils"
+	"github.com/theoremus-urban-solutions/netex-validator/validation/context"
+)
+
+// declaredPattern is a JourneyPattern or ServiceJourneyPattern found while collecting
+type declaredPattern struct {
+	id       string
+	typ      string
+	fileName string
+	xpath    string
+}
+
+// patternReference is the journey pattern reference of a ServiceJourney
+type patternReference struct {
+	journeyID string
+	refType   string // JourneyPatternRef or ServiceJourneyPatternRef
+	ref       string
+	fileName  string
+	xpath     string
+}
+
+// patternRefTypes maps each reference element to the pattern type it names
+var patternRefTypes = map[string]string{
+	"JourneyPatternRef":        "JourneyPattern",
+	"ServiceJourneyPatternRef": "ServiceJourneyPattern",
+}
+
+// JourneyPatternTypeValidator reports datasets mixing JourneyPatterns and
+// ServiceJourneyPatterns, and ServiceJourneys whose pattern reference names the
+// other type than the pattern it resolves to. Both are legal NeTEx, but tools
+// that only follow one of the two types lose journeys.
+type JourneyPatternTypeValidator struct {
+	mu         sync.Mutex
+	patterns   []declaredPattern
+	references []patternReference
+	rules      []types.ValidationRule
+}
+
+// NewJourneyPatternTypeValidator creates a new journey pattern type validator
+func NewJourneyPatternTypeValidator() *JourneyPatternTypeValidator {
+	return &JourneyPatternTypeValidator{
+		rules: []types.ValidationRule{
+			{
+				Code:     "JOURNEY_PATTERN_6",
+				Name:     "Mixed JourneyPattern and ServiceJourneyPattern",
+				Message:  "Dataset uses both JourneyPatterns and ServiceJourneyPatterns",
+				Severity: types.INFO,
+			},
+			{
+				Code:     "JOURNEY_PATTERN_7",
+				Name:     "ServiceJourney pattern reference type mismatch",
+				Message:  "ServiceJourney references its journey pattern with the reference element of the other pattern type",
+				Severity: types.INFO,
+			},
+		},
+	}
+}
+
+// Collect records the journey patterns and the ServiceJourney pattern references in a file
+func (v *JourneyPatternTypeValidator) Collect(ctx context.XPathValidationContext) error {
+	if ctx.Document == nil {
+		return nil
+	}
+
+	var patterns []declaredPattern
+	for _, node := range xmlquery.Find(ctx.Document, "//journeyPatterns/*[self::JourneyPattern or self::ServiceJourneyPattern][@id]") {
+		patterns = append(patterns, declaredPattern{
+			id:       node.SelectAttr("id"),
+			typ:      node.Data,
+			fileName: ctx.GetFileName(),
+			xpath:    utils.NodeXPath(node),
+		})
+	}
+
+	var references []patternReference
+	for _, node := range xmlquery.Find(ctx.Document, "//vehicleJourneys/ServiceJourney[@id]") {
+		ref := xmlquery.FindOne(node, "JourneyPatternRef|ServiceJourneyPatternRef")
+		if refValue(ref) == "" {
+			continue
+		}
+		references = append(references, patternReference{
+			journeyID: node.SelectAttr("id"),
+			refType:   ref.Data,
+			ref:       refValue(ref),
+			fileName:  ctx.GetFileName(),
+			xpath:     utils.NodeXPath(node),
+		})
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.patterns = append(v.patterns, patterns...)
+	v.references = append(v.references, references...)
+	return nil
+}
+
+// Validate reports mixed pattern types once for the dataset and every
+// ServiceJourney whose reference type does not match the resolved pattern.
+// References to undeclared patterns are left to the ID validation.
+func (v *JourneyPatternTypeValidator) Validate(repository interfaces.IdRepository) ([]types.ValidationIssue, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	sort.SliceStable(v.patterns, func(i, j int) bool { return v.patterns[i].id < v.patterns[j].id })
+	sort.SliceStable(v.references, func(i, j int) bool { return v.references[i].journeyID < v.references[j].journeyID })
+
+	declared := make(map[string]string, len(v.patterns)) // pattern id -> type
+	byType := make(map[string][]declaredPattern)
+	for _, pattern := range v.patterns {
+		declared[pattern.id] = pattern.typ
+		byType[pattern.typ] = append(byType[pattern.typ], pattern)
+	}
+
+	var issues []types.ValidationIssue
+
+	journeyPatterns, serviceJourneyPatterns := byType["JourneyPattern"], byType["ServiceJourneyPattern"]
+	if len(journeyPatterns) > 0 && len(serviceJourneyPatterns) > 0 {
+		// Point at the less used type, which is the likely odd one out
+		first := serviceJourneyPatterns[0]
+		if len(journeyPatterns) < len(serviceJourneyPatterns) {
+			first = journeyPatterns[0]
+		}
+		issues = append(issues, types.ValidationIssue{
+			Rule: v.rules[0],
+			Location: types.DataLocation{
+				FileName:  first.fileName,
+				XPath:     first.xpath,
+				ElementID: first.id,
+			},
+			Message: fmt.Sprintf("Dataset uses %d JourneyPattern(s) and %d ServiceJourneyPattern(s); use one type consistently",
+				len(journeyPatterns), len(serviceJourneyPatterns)),
+		})
+	}
+
+	for _, reference := range v.references {
+		target, ok := declared[reference.ref]
+		if !ok || target == patternRefTypes[reference.refType] {
+			continue
+		}
+
+		issues = append(issues, types.ValidationIssue{
+			Rule: v.rules[1],
+			Location: types.DataLocation{
+				FileName:  reference.fileName,
+				XPath:     reference.xpath,
+				ElementID: reference.journeyID,
+			},
+			Message: fmt.Sprintf("ServiceJourney '%s' uses %s '%s', which is a %s",
+				reference.journeyID, reference.refType, reference.ref, target),
+		})
+	}
+
+	return issues, nil
+}
+
+// GetRules returns the rules implemented by this validator
+func (v *JourneyPatternTypeValidator) GetRules() []types.ValidationRule {
+	return v.rules
+}
+
+// Reset clears all collected data
+func (v *JourneyPatternTypeValidator) Reset() {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.patterns = nil
+	v.references = nil
+}
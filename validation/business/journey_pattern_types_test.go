@@ -0,0 +1,101 @@
+package business
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/theoremus-urban-solutions/netex-validator/types"
+	"github.com/theoremus-urban-solutions/netex-validator/validation/ids"
+)
+
+func TestJourneyPatternTypeValidator(t *testing.T) {
+	patternsFile := `<?xml version="1.0" encoding="UTF-8"?>
+<PublicationDelivery xmlns="http://www.netex.org.uk/netex" version="1.15">
+	<dataObjects>
+		<ServiceFrame id="TEST:ServiceFrame:1" version="1">
+			<journeyPatterns>
+				<JourneyPattern id="TEST:JourneyPattern:1" version="1"/>
+				<JourneyPattern id="TEST:JourneyPattern:2" version="1"/>
+				<ServiceJourneyPattern id="TEST:ServiceJourneyPattern:1" version="1"/>
+			</journeyPatterns>
+		</ServiceFrame>
+	</dataObjects>
+</PublicationDelivery>`
+
+	journeysFile := `<?xml version="1.0" encoding="UTF-8"?>
+<PublicationDelivery xmlns="http://www.netex.org.uk/netex" version="1.15">
+	<dataObjects>
+		<TimetableFrame id="TEST:TimetableFrame:1" version="1">
+			<vehicleJourneys>
+				<ServiceJourney id="TEST:ServiceJourney:1" version="1">
+					<JourneyPatternRef ref="TEST:JourneyPattern:1"/>
+				</ServiceJourney>
+				<ServiceJourney id="TEST:ServiceJourney:2" version="1">
+					<JourneyPatternRef ref="TEST:ServiceJourneyPattern:1"/>
+				</ServiceJourney>
+				<ServiceJourney id="TEST:ServiceJourney:3" version="1">
+					<ServiceJourneyPatternRef ref="TEST:JourneyPattern:2"/>
+				</ServiceJourney>
+				<ServiceJourney id="TEST:ServiceJourney:4" version="1">
+					<ServiceJourneyPatternRef ref="TEST:ServiceJourneyPattern:1"/>
+				</ServiceJourney>
+				<ServiceJourney id="TEST:ServiceJourney:5" version="1">
+					<JourneyPatternRef ref="TEST:JourneyPattern:Missing"/>
+				</ServiceJourney>
+			</vehicleJourneys>
+		</TimetableFrame>
+	</dataObjects>
+</PublicationDelivery>`
+
+	validator := NewJourneyPatternTypeValidator()
+	if err := validator.Collect(newTestXPathContext(t, "journeys.xml", journeysFile)); err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+	if err := validator.Collect(newTestXPathContext(t, "patterns.xml", patternsFile)); err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+
+	issues, err := validator.Validate(ids.NewNetexIdRepository())
+	if err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if len(issues) != 3 {
+		t.Fatalf("Expected 3 issues, got %d: %+v", len(issues), issues)
+	}
+
+	mixed := issues[0]
+	if mixed.Rule.Code != "JOURNEY_PATTERN_6" || mixed.Rule.Severity != types.INFO {
+		t.Errorf("Expected INFO JOURNEY_PATTERN_6, got %s %s", mixed.Rule.Severity, mixed.Rule.Code)
+	}
+	if mixed.Location.ElementID != "TEST:ServiceJourneyPattern:1" || mixed.Location.FileName != "patterns.xml" {
+		t.Errorf("Expected mixed usage on TEST:ServiceJourneyPattern:1 in patterns.xml, got %+v", mixed.Location)
+	}
+	if !strings.Contains(mixed.Message, "2 JourneyPattern(s) and 1 ServiceJourneyPattern(s)") {
+		t.Errorf("Expected message to report the counts, got %q", mixed.Message)
+	}
+
+	for i, want := range []struct{ journey, message string }{
+		{"TEST:ServiceJourney:2", "uses JourneyPatternRef 'TEST:ServiceJourneyPattern:1', which is a ServiceJourneyPattern"},
+		{"TEST:ServiceJourney:3", "uses ServiceJourneyPatternRef 'TEST:JourneyPattern:2', which is a JourneyPattern"},
+	} {
+		issue := issues[i+1]
+		if issue.Rule.Code != "JOURNEY_PATTERN_7" {
+			t.Errorf("Expected JOURNEY_PATTERN_7, got %s", issue.Rule.Code)
+		}
+		if issue.Location.ElementID != want.journey || issue.Location.FileName != "journeys.xml" {
+			t.Errorf("Expected issue on %s in journeys.xml, got %+v", want.journey, issue.Location)
+		}
+		if !strings.Contains(issue.Message, want.message) {
+			t.Errorf("Expected message to contain %q, got %q", want.message, issue.Message)
+		}
+	}
+
+	validator.Reset()
+	issues, err = validator.Validate(ids.NewNetexIdRepository())
+	if err != nil {
+		t.Fatalf("Validate() after Reset() error = %v", err)
+	}
+	if len(issues) != 0 {
+		t.Errorf("Expected no issues after Reset(), got %d", len(issues))
+	}
+}
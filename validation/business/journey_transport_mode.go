@@ -0,0 +1,164 @@
+package business
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/antchfx/xmlquery"
+	"github.com/theoremus-urban-solutions/netex-validator/interfaces"
+	"github.com/theoremus-urban-solutions/netex-validator/types"
+	"github.com/theoremus-urban-solutions/netex-validator/utils"
+	"github.com/theoremus-urban-solutions/netex-validator/validation/context"
+)
+
+// journeyLine records how a ServiceJourney with a TransportMode reaches its Line:
+// directly through a LineRef or through its journey pattern and Route
+type journeyLine struct {
+	journeyRoute
+	lineRef string
+}
+
+// JourneyTransportModeValidator reports ServiceJourneys that declare a
+// TransportMode while their Line has none. The mode belongs on the Line; a
+// journey only overrides it. Lines, routes, patterns and journeys may sit in
+// different files, so the Line of each journey is resolved once the whole
+// dataset is collected.
+type JourneyTransportModeValidator struct {
+	mu            sync.Mutex
+	lineModes     map[string]bool   // line id -> whether it has a TransportMode
+	routeLines    map[string]string // route id -> line id
+	patternRoutes map[string]string // journey pattern id -> route id
+	journeys      []journeyLine
+	rules         []types.ValidationRule
+}
+
+// NewJourneyTransportModeValidator creates a new journey transport mode validator
+func NewJourneyTransportModeValidator() *JourneyTransportModeValidator {
+	return &JourneyTransportModeValidator{
+		lineModes:     make(map[string]bool),
+		routeLines:    make(map[string]string),
+		patternRoutes: make(map[string]string),
+		rules: []types.ValidationRule{
+			{
+				Code:     "SERVICE_JOURNEY_19",
+				Name:     "ServiceJourney TransportMode without Line TransportMode",
+				Message:  "ServiceJourney has a TransportMode while its Line has none; set the mode on the Line",
+				Severity: types.WARNING,
+			},
+		},
+	}
+}
+
+// Collect records the Lines, Routes, journey patterns and ServiceJourneys with a TransportMode in a file
+func (v *JourneyTransportModeValidator) Collect(ctx context.XPathValidationContext) error {
+	if ctx.Document == nil {
+		return nil
+	}
+
+	lineModes := make(map[string]bool)
+	for _, node := range xmlquery.Find(ctx.Document, "//lines/*[self::Line or self::FlexibleLine][@id]") {
+		lineModes[node.SelectAttr("id")] = childText(node, "TransportMode") != ""
+	}
+
+	routeLines := make(map[string]string)
+	for _, node := range xmlquery.Find(ctx.Document, "//routes/Route[@id]") {
+		if line := childRef(node, "LineRef|FlexibleLineRef"); line != "" {
+			routeLines[node.SelectAttr("id")] = line
+		}
+	}
+
+	patternRoutes := make(map[string]string)
+	for _, node := range xmlquery.Find(ctx.Document, "//journeyPatterns/*[self::JourneyPattern or self::ServiceJourneyPattern][@id]") {
+		if route := childRef(node, "RouteRef"); route != "" {
+			patternRoutes[node.SelectAttr("id")] = route
+		}
+	}
+
+	var journeys []journeyLine
+	for _, node := range xmlquery.Find(ctx.Document, "//vehicleJourneys/ServiceJourney[@id][normalize-space(TransportMode) != '']") {
+		journeys = append(journeys, journeyLine{
+			journeyRoute: journeyRoute{
+				id:         node.SelectAttr("id"),
+				routeRef:   childRef(node, "RouteRef"),
+				patternRef: childRef(node, "JourneyPatternRef|ServiceJourneyPatternRef"),
+				fileName:   ctx.GetFileName(),
+				xpath:      utils.NodeXPath(node),
+			},
+			lineRef: childRef(node, "LineRef|FlexibleLineRef"),
+		})
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	for line, hasMode := range lineModes {
+		v.lineModes[line] = hasMode
+	}
+	for route, line := range routeLines {
+		v.routeLines[route] = line
+	}
+	for pattern, route := range patternRoutes {
+		v.patternRoutes[pattern] = route
+	}
+	v.journeys = append(v.journeys, journeys...)
+	return nil
+}
+
+// line resolves the Line of a journey, preferring its own LineRef over the one
+// reached through its Route or journey pattern
+func (v *JourneyTransportModeValidator) line(journey journeyLine) string {
+	if journey.lineRef != "" {
+		return journey.lineRef
+	}
+	route := journey.routeRef
+	if route == "" {
+		route = v.patternRoutes[journey.patternRef]
+	}
+	return v.routeLines[route]
+}
+
+// Validate reports every ServiceJourney with a TransportMode whose Line has none.
+// Journeys whose Line cannot be resolved are left to the reference checks.
+func (v *JourneyTransportModeValidator) Validate(repository interfaces.IdRepository) ([]types.ValidationIssue, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	sort.SliceStable(v.journeys, func(i, j int) bool { return v.journeys[i].id < v.journeys[j].id })
+
+	var issues []types.ValidationIssue
+	for _, journey := range v.journeys {
+		line := v.line(journey)
+		hasMode, known := v.lineModes[line]
+		if line == "" || !known || hasMode {
+			continue
+		}
+
+		issues = append(issues, types.ValidationIssue{
+			Rule: v.rules[0],
+			Location: types.DataLocation{
+				FileName:  journey.fileName,
+				XPath:     journey.xpath,
+				ElementID: journey.id,
+			},
+			Message: fmt.Sprintf("ServiceJourney '%s' has a TransportMode but its Line '%s' has none; set the mode on the Line",
+				journey.id, line),
+		})
+	}
+
+	return issues, nil
+}
+
+// GetRules returns the rules implemented by this validator
+func (v *JourneyTransportModeValidator) GetRules() []types.ValidationRule {
+	return v.rules
+}
+
+// Reset clears all collected data
+func (v *JourneyTransportModeValidator) Reset() {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.lineModes = make(map[string]bool)
+	v.routeLines = make(map[string]string)
+	v.patternRoutes = make(map[string]string)
+	v.journeys = nil
+}
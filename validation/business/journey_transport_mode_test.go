@@ -0,0 +1,100 @@
+package business
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/theoremus-urban-solutions/netex-validator/validation/ids"
+)
+
+func TestJourneyTransportModeValidator(t *testing.T) {
+	linesFile := `<?xml version="1.0" encoding="UTF-8"?>
+<PublicationDelivery xmlns="http://www.netex.org.uk/netex" version="1.15">
+	<dataObjects>
+		<ServiceFrame id="TEST:ServiceFrame:1" version="1">
+			<lines>
+				<Line id="TEST:Line:NoMode" version="1">
+					<Name>No mode</Name>
+				</Line>
+				<Line id="TEST:Line:Bus" version="1">
+					<Name>Bus</Name>
+					<TransportMode>bus</TransportMode>
+				</Line>
+			</lines>
+			<routes>
+				<Route id="TEST:Route:1" version="1">
+					<LineRef ref="TEST:Line:NoMode"/>
+				</Route>
+			</routes>
+			<journeyPatterns>
+				<JourneyPattern id="TEST:JourneyPattern:1" version="1">
+					<RouteRef ref="TEST:Route:1"/>
+				</JourneyPattern>
+			</journeyPatterns>
+		</ServiceFrame>
+	</dataObjects>
+</PublicationDelivery>`
+
+	journeysFile := `<?xml version="1.0" encoding="UTF-8"?>
+<PublicationDelivery xmlns="http://www.netex.org.uk/netex" version="1.15">
+	<dataObjects>
+		<TimetableFrame id="TEST:TimetableFrame:1" version="1">
+			<vehicleJourneys>
+				<ServiceJourney id="TEST:ServiceJourney:ViaPattern" version="1">
+					<TransportMode>bus</TransportMode>
+					<JourneyPatternRef ref="TEST:JourneyPattern:1"/>
+				</ServiceJourney>
+				<ServiceJourney id="TEST:ServiceJourney:LineHasMode" version="1">
+					<TransportMode>bus</TransportMode>
+					<LineRef ref="TEST:Line:Bus"/>
+				</ServiceJourney>
+				<ServiceJourney id="TEST:ServiceJourney:NoMode" version="1">
+					<LineRef ref="TEST:Line:NoMode"/>
+				</ServiceJourney>
+				<ServiceJourney id="TEST:ServiceJourney:UnknownLine" version="1">
+					<TransportMode>bus</TransportMode>
+					<LineRef ref="TEST:Line:Missing"/>
+				</ServiceJourney>
+			</vehicleJourneys>
+		</TimetableFrame>
+	</dataObjects>
+</PublicationDelivery>`
+
+	validator := NewJourneyTransportModeValidator()
+	if err := validator.Collect(newTestXPathContext(t, "journeys.xml", journeysFile)); err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+	if err := validator.Collect(newTestXPathContext(t, "lines.xml", linesFile)); err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+
+	issues, err := validator.Validate(ids.NewNetexIdRepository())
+	if err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if len(issues) != 1 {
+		t.Fatalf("Expected 1 issue, got %d: %+v", len(issues), issues)
+	}
+
+	issue := issues[0]
+	if issue.Rule.Code != "SERVICE_JOURNEY_19" {
+		t.Errorf("Expected SERVICE_JOURNEY_19, got %s", issue.Rule.Code)
+	}
+	if issue.Location.ElementID != "TEST:ServiceJourney:ViaPattern" || issue.Location.FileName != "journeys.xml" {
+		t.Errorf("Expected issue on TEST:ServiceJourney:ViaPattern in journeys.xml, got %+v", issue.Location)
+	}
+	for _, want := range []string{"TEST:ServiceJourney:ViaPattern", "TEST:Line:NoMode"} {
+		if !strings.Contains(issue.Message, want) {
+			t.Errorf("Expected message to contain %q, got %q", want, issue.Message)
+		}
+	}
+
+	validator.Reset()
+	issues, err = validator.Validate(ids.NewNetexIdRepository())
+	if err != nil {
+		t.Fatalf("Validate() after Reset() error = %v", err)
+	}
+	if len(issues) != 0 {
+		t.Errorf("Expected no issues after Reset(), got %d", len(issues))
+	}
+}
@@ -0,0 +1,76 @@
+package business
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/antchfx/xmlquery"
+	"github.com/theoremus-urban-solutions/netex-validator/types"
+	"github.com/theoremus-urban-solutions/netex-validator/utils"
+	"github.com/theoremus-urban-solutions/netex-validator/validation/context"
+)
+
+// LinkDistanceValidator flags RouteLinks and ServiceLinks whose Distance is zero
+// or negative, which breaks the routing of journey planners. Links without a
+// Distance, or with one that is not a number, are left to other checks.
+type LinkDistanceValidator struct {
+	rules []types.ValidationRule
+}
+
+// NewLinkDistanceValidator creates a new link distance validator
+func NewLinkDistanceValidator() *LinkDistanceValidator {
+	return &LinkDistanceValidator{
+		rules: []types.ValidationRule{
+			{
+				Code:     "ROUTE_LINK_NON_POSITIVE_DISTANCE",
+				Name:     "RouteLink non-positive Distance",
+				Message:  "RouteLink Distance must be greater than zero",
+				Severity: types.WARNING,
+			},
+			{
+				Code:     "SERVICE_LINK_NON_POSITIVE_DISTANCE",
+				Name:     "ServiceLink non-positive Distance",
+				Message:  "ServiceLink Distance must be greater than zero",
+				Severity: types.WARNING,
+			},
+		},
+	}
+}
+
+// Validate checks the Distance of every RouteLink and ServiceLink in the document
+func (v *LinkDistanceValidator) Validate(ctx context.XPathValidationContext) ([]types.ValidationIssue, error) {
+	var issues []types.ValidationIssue
+	if ctx.Document == nil {
+		return issues, nil
+	}
+
+	for i, path := range []string{"//routeLinks/RouteLink[Distance]", "//serviceLinks/ServiceLink[Distance]"} {
+		for _, link := range xmlquery.Find(ctx.Document, path) {
+			value := childText(link, "Distance")
+			distance, err := strconv.ParseFloat(value, 64)
+			if err != nil || distance > 0 || math.IsNaN(distance) {
+				continue
+			}
+
+			id := link.SelectAttr("id")
+			issues = append(issues, types.ValidationIssue{
+				Rule: v.rules[i],
+				Location: types.DataLocation{
+					FileName:  ctx.GetFileName(),
+					XPath:     utils.NodeXPath(link),
+					ElementID: id,
+				},
+				Message: fmt.Sprintf("%s '%s' has Distance %s; link distances must be greater than zero",
+					link.Data, id, value),
+			})
+		}
+	}
+
+	return issues, nil
+}
+
+// GetRules returns the rules implemented by this validator
+func (v *LinkDistanceValidator) GetRules() []types.ValidationRule {
+	return v.rules
+}
@@ -0,0 +1,62 @@
+package business
+
+import (
+	"testing"
+
+	"github.com/theoremus-urban-solutions/netex-validator/types"
+)
+
+func TestLinkDistanceValidator(t *testing.T) {
+	document := `<?xml version="1.0" encoding="UTF-8"?>
+<PublicationDelivery xmlns="http://www.netex.org.uk/netex" version="1.15">
+	<dataObjects>
+		<ServiceFrame id="TEST:ServiceFrame:1" version="1">
+			<routeLinks>
+				<RouteLink id="TEST:RouteLink:Valid" version="1">
+					<Distance>250.5</Distance>
+				</RouteLink>
+				<RouteLink id="TEST:RouteLink:Zero" version="1">
+					<Distance>0</Distance>
+				</RouteLink>
+				<RouteLink id="TEST:RouteLink:NoDistance" version="1"/>
+			</routeLinks>
+			<serviceLinks>
+				<ServiceLink id="TEST:ServiceLink:Negative" version="1">
+					<Distance> -12 </Distance>
+				</ServiceLink>
+				<ServiceLink id="TEST:ServiceLink:NotANumber" version="1">
+					<Distance>far</Distance>
+				</ServiceLink>
+				<ServiceLink id="TEST:ServiceLink:Valid" version="1">
+					<Distance>1200</Distance>
+				</ServiceLink>
+			</serviceLinks>
+		</ServiceFrame>
+	</dataObjects>
+</PublicationDelivery>`
+
+	validator := NewLinkDistanceValidator()
+	issues, err := validator.Validate(newTestXPathContext(t, "links.xml", document))
+	if err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if len(issues) != 2 {
+		t.Fatalf("Expected 2 issues, got %d: %+v", len(issues), issues)
+	}
+
+	for i, want := range []struct{ code, id, message string }{
+		{"ROUTE_LINK_NON_POSITIVE_DISTANCE", "TEST:RouteLink:Zero", "RouteLink 'TEST:RouteLink:Zero' has Distance 0; link distances must be greater than zero"},
+		{"SERVICE_LINK_NON_POSITIVE_DISTANCE", "TEST:ServiceLink:Negative", "ServiceLink 'TEST:ServiceLink:Negative' has Distance -12; link distances must be greater than zero"},
+	} {
+		issue := issues[i]
+		if issue.Rule.Code != want.code || issue.Rule.Severity != types.WARNING {
+			t.Errorf("Expected WARNING %s, got %s %s", want.code, issue.Rule.Severity, issue.Rule.Code)
+		}
+		if issue.Location.ElementID != want.id || issue.Location.FileName != "links.xml" {
+			t.Errorf("Expected issue on %s in links.xml, got %+v", want.id, issue.Location)
+		}
+		if issue.Message != want.message {
+			t.Errorf("Expected message %q, got %q", want.message, issue.Message)
+		}
+	}
+}
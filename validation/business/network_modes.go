@@ -0,0 +1,184 @@
+package business
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/antchfx/xmlquery"
+	"github.com/theoremus-urban-solutions/netex-validator/interfaces"
+	"github.com/theoremus-urban-solutions/netex-validator/types"
+	"github.com/theoremus-urban-solutions/netex-validator/utils"
+	"github.com/theoremus-urban-solutions/netex-validator/validation/context"
+)
+
+// dominantModeShare is the share of a Network's Lines its most common TransportMode
+// must have before Lines in other modes are reported as outliers
+const dominantModeShare = 0.75
+
+// modeLine is a Line or FlexibleLine with its TransportMode and group reference
+type modeLine struct {
+	mode     string
+	groupRef string
+	location lineLocation
+}
+
+// NetworkModeValidator reports Lines whose TransportMode differs from the mode of
+// nearly all other Lines of their Network, such as a lone bus Line in a rail
+// Network, which is often a misclassification. Mixed networks are common, so the
+// finding is informational and only raised when one mode has at least three
+// quarters of the Network's Lines. A Line belongs to the Network its
+// RepresentedByGroupRef points at, directly or through one of the Network's
+// GroupOfLines, or to the Network listing it among its members. Networks and
+// Lines may be declared in different files, so they are matched once the dataset
+// is collected.
+type NetworkModeValidator struct {
+	mu             sync.Mutex
+	lines          map[string]modeLine // line id -> mode and declaration
+	networkOfGroup map[string]string   // Network or GroupOfLines id -> Network id
+	networkOfLine  map[string]string   // line id -> Network listing it as member
+	rules          []types.ValidationRule
+}
+
+// NewNetworkModeValidator creates a new network mode validator
+func NewNetworkModeValidator() *NetworkModeValidator {
+	return &NetworkModeValidator{
+		lines:          make(map[string]modeLine),
+		networkOfGroup: make(map[string]string),
+		networkOfLine:  make(map[string]string),
+		rules: []types.ValidationRule{
+			{
+				Code:     "TRANSPORT_MODE_NETWORK_OUTLIER",
+				Name:     "Line mode differs from its Network",
+				Message:  "The TransportMode of the Line differs from the mode of most Lines of its Network",
+				Severity: types.INFO,
+			},
+		},
+	}
+}
+
+// Collect records the Networks with their groups and members, and the
+// TransportMode and group reference of each Line in a file
+func (v *NetworkModeValidator) Collect(ctx context.XPathValidationContext) error {
+	if ctx.Document == nil {
+		return nil
+	}
+
+	networkOfGroup := make(map[string]string)
+	networkOfLine := make(map[string]string)
+	for _, network := range xmlquery.Find(ctx.Document, "//Network[@id]") {
+		id := network.SelectAttr("id")
+		networkOfGroup[id] = id
+		for _, group := range xmlquery.Find(network, ".//groupsOfLines/GroupOfLines[@id]") {
+			networkOfGroup[group.SelectAttr("id")] = id
+		}
+		for _, member := range xmlquery.Find(network, ".//members/*[self::LineRef or self::FlexibleLineRef]") {
+			if ref := refValue(member); ref != "" {
+				networkOfLine[ref] = id
+			}
+		}
+	}
+
+	lines := make(map[string]modeLine)
+	for _, node := range xmlquery.Find(ctx.Document, "//lines/*[self::Line or self::FlexibleLine][@id]") {
+		mode := childText(node, "TransportMode")
+		// Lines without a known mode have nothing to compare
+		if mode == "" || mode == "unknown" {
+			continue
+		}
+		lines[node.SelectAttr("id")] = modeLine{
+			mode:     mode,
+			groupRef: childRef(node, "RepresentedByGroupRef"),
+			location: lineLocation{fileName: ctx.GetFileName(), xpath: utils.NodeXPath(node)},
+		}
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	for id, network := range networkOfGroup {
+		v.networkOfGroup[id] = network
+	}
+	for id, network := range networkOfLine {
+		v.networkOfLine[id] = network
+	}
+	for id, line := range lines {
+		v.lines[id] = line
+	}
+	return nil
+}
+
+// Validate groups the Lines by Network and reports, for every Network with a
+// dominant mode, the Lines in another mode, ordered by Network and Line id
+func (v *NetworkModeValidator) Validate(repository interfaces.IdRepository) ([]types.ValidationIssue, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	networkLines := make(map[string][]string)
+	for id, line := range v.lines {
+		network, ok := v.networkOfGroup[line.groupRef]
+		if !ok {
+			network, ok = v.networkOfLine[id]
+		}
+		if ok {
+			networkLines[network] = append(networkLines[network], id)
+		}
+	}
+
+	networks := make([]string, 0, len(networkLines))
+	for network := range networkLines {
+		networks = append(networks, network)
+	}
+	sort.Strings(networks)
+
+	var issues []types.ValidationIssue
+	for _, network := range networks {
+		lineIDs := networkLines[network]
+		sort.Strings(lineIDs)
+
+		counts := make(map[string]int)
+		dominant := ""
+		for _, id := range lineIDs {
+			mode := v.lines[id].mode
+			counts[mode]++
+			if counts[mode] > counts[dominant] || (counts[mode] == counts[dominant] && mode < dominant) {
+				dominant = mode
+			}
+		}
+		if len(counts) < 2 || float64(counts[dominant]) < dominantModeShare*float64(len(lineIDs)) {
+			continue
+		}
+
+		for _, id := range lineIDs {
+			line := v.lines[id]
+			if line.mode == dominant {
+				continue
+			}
+			issues = append(issues, types.ValidationIssue{
+				Rule: v.rules[0],
+				Location: types.DataLocation{
+					FileName:  line.location.fileName,
+					XPath:     line.location.xpath,
+					ElementID: id,
+				},
+				Message: fmt.Sprintf("Line '%s' has TransportMode %s, while %d of the %d Lines of Network '%s' have TransportMode %s",
+					id, line.mode, counts[dominant], len(lineIDs), network, dominant),
+			})
+		}
+	}
+
+	return issues, nil
+}
+
+// GetRules returns the rules implemented by this validator
+func (v *NetworkModeValidator) GetRules() []types.ValidationRule {
+	return v.rules
+}
+
+// Reset clears all collected data
+func (v *NetworkModeValidator) Reset() {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.lines = make(map[string]modeLine)
+	v.networkOfGroup = make(map[string]string)
+	v.networkOfLine = make(map[string]string)
+}
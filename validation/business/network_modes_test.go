@@ -0,0 +1,82 @@
+package business
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/theoremus-urban-solutions/netex-validator/types"
+	"github.com/theoremus-urban-solutions/netex-validator/validation/ids"
+)
+
+func TestNetworkModeValidator(t *testing.T) {
+	common := `<?xml version="1.0" encoding="UTF-8"?>
+<PublicationDelivery xmlns="http://www.netex.org.uk/netex" version="1.15">
+	<dataObjects>
+		<ServiceFrame id="TEST:ServiceFrame:1" version="1">
+			<Network id="TEST:Network:Rail" version="1">
+				<groupsOfLines>
+					<GroupOfLines id="TEST:GroupOfLines:Regional" version="1"/>
+				</groupsOfLines>
+			</Network>
+			<Network id="TEST:Network:Mixed" version="1">
+				<members>
+					<LineRef ref="TEST:Line:Tram"/>
+					<LineRef ref="TEST:Line:CityBus"/>
+				</members>
+			</Network>
+		</ServiceFrame>
+	</dataObjects>
+</PublicationDelivery>`
+
+	lines := `<?xml version="1.0" encoding="UTF-8"?>
+<PublicationDelivery xmlns="http://www.netex.org.uk/netex" version="1.15">
+	<dataObjects>
+		<ServiceFrame id="TEST:ServiceFrame:2" version="1">
+			<lines>
+				<Line id="TEST:Line:R1" version="1"><TransportMode>rail</TransportMode><RepresentedByGroupRef ref="TEST:Network:Rail"/></Line>
+				<Line id="TEST:Line:R2" version="1"><TransportMode>rail</TransportMode><RepresentedByGroupRef ref="TEST:Network:Rail"/></Line>
+				<Line id="TEST:Line:R3" version="1"><TransportMode>rail</TransportMode><RepresentedByGroupRef ref="TEST:GroupOfLines:Regional"/></Line>
+				<Line id="TEST:Line:Bus" version="1"><TransportMode>bus</TransportMode><RepresentedByGroupRef ref="TEST:GroupOfLines:Regional"/></Line>
+				<Line id="TEST:Line:Unknown" version="1"><TransportMode>unknown</TransportMode><RepresentedByGroupRef ref="TEST:Network:Rail"/></Line>
+				<Line id="TEST:Line:Tram" version="1"><TransportMode>tram</TransportMode></Line>
+				<Line id="TEST:Line:CityBus" version="1"><TransportMode>bus</TransportMode></Line>
+				<Line id="TEST:Line:Loose" version="1"><TransportMode>ferry</TransportMode></Line>
+			</lines>
+		</ServiceFrame>
+	</dataObjects>
+</PublicationDelivery>`
+
+	validator := NewNetworkModeValidator()
+	if err := validator.Collect(newTestXPathContext(t, "lines.xml", lines)); err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+	if err := validator.Collect(newTestXPathContext(t, "_common.xml", common)); err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+
+	issues, err := validator.Validate(ids.NewNetexIdRepository())
+	if err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	// The evenly mixed Network has no dominant mode
+	if len(issues) != 1 {
+		t.Fatalf("Expected 1 issue, got %d: %+v", len(issues), issues)
+	}
+
+	issue := issues[0]
+	if issue.Rule.Code != "TRANSPORT_MODE_NETWORK_OUTLIER" || issue.Rule.Severity != types.INFO {
+		t.Errorf("Expected TRANSPORT_MODE_NETWORK_OUTLIER info, got %s (%v)", issue.Rule.Code, issue.Rule.Severity)
+	}
+	if issue.Location.ElementID != "TEST:Line:Bus" || issue.Location.FileName != "lines.xml" {
+		t.Errorf("Expected issue on TEST:Line:Bus in lines.xml, got %+v", issue.Location)
+	}
+	want := "Line 'TEST:Line:Bus' has TransportMode bus, while 3 of the 4 Lines of Network 'TEST:Network:Rail' have TransportMode rail"
+	if !strings.Contains(issue.Message, want) {
+		t.Errorf("Expected message %q, got %q", want, issue.Message)
+	}
+
+	validator.Reset()
+	if issues, _ := validator.Validate(ids.NewNetexIdRepository()); len(issues) != 0 {
+		t.Errorf("Expected no issues after Reset, got %d", len(issues))
+	}
+}
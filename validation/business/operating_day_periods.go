@@ -0,0 +1,200 @@
+package business
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/antchfx/xmlquery"
+	"github.com/theoremus-urban-solutions/netex-validator/interfaces"
+	"github.com/theoremus-urban-solutions/netex-validator/types"
+	"github.com/theoremus-urban-solutions/netex-validator/utils"
+	"github.com/theoremus-urban-solutions/netex-validator/validation/context"
+)
+
+// xsdDateLayouts are the accepted forms of an xsd:date, with an optional timezone
+var xsdDateLayouts = []string{"2006-01-02", "2006-01-02Z07:00"}
+
+// operatingDay is an OperatingDay and where it was declared
+type operatingDay struct {
+	context.OperatingDay
+	fileName string
+	xpath    string
+}
+
+// operatingPeriod is an OperatingPeriod bounded either by dates or by references
+// to OperatingDays
+type operatingPeriod struct {
+	context.OperatingPeriod
+	fromDayRef string
+	toDayRef   string
+}
+
+// OperatingDayPeriodValidator reports OperatingDays whose CalendarDate is not
+// covered by any OperatingPeriod of the dataset. Such days are usually left over
+// from an earlier calendar and no longer reachable. Days and periods are often
+// declared in different files, so the check runs once the dataset is collected.
+type OperatingDayPeriodValidator struct {
+	mu      sync.Mutex
+	days    []operatingDay
+	periods []operatingPeriod
+	rules   []types.ValidationRule
+}
+
+// NewOperatingDayPeriodValidator creates a new operating day period validator
+func NewOperatingDayPeriodValidator() *OperatingDayPeriodValidator {
+	return &OperatingDayPeriodValidator{
+		rules: []types.ValidationRule{
+			{
+				Code:     "OPERATING_DAY_OUTSIDE_PERIODS",
+				Name:     "OperatingDay outside OperatingPeriods",
+				Message:  "OperatingDay CalendarDate is not covered by any OperatingPeriod",
+				Severity: types.WARNING,
+			},
+		},
+	}
+}
+
+// Collect records the OperatingDays and OperatingPeriods in a file
+func (v *OperatingDayPeriodValidator) Collect(ctx context.XPathValidationContext) error {
+	if ctx.Document == nil {
+		return nil
+	}
+
+	var days []operatingDay
+	for _, node := range xmlquery.Find(ctx.Document, "//operatingDays/OperatingDay[@id]") {
+		day := operatingDay{fileName: ctx.GetFileName(), xpath: utils.NodeXPath(node)}
+		day.ID = node.SelectAttr("id")
+		day.CalendarDate = childText(node, "CalendarDate")
+		days = append(days, day)
+	}
+
+	var periods []operatingPeriod
+	for _, node := range xmlquery.Find(ctx.Document, "//operatingPeriods/OperatingPeriod") {
+		period := operatingPeriod{
+			fromDayRef: childRef(node, "FromOperatingDayRef"),
+			toDayRef:   childRef(node, "ToOperatingDayRef"),
+		}
+		period.ID = node.SelectAttr("id")
+		period.FromDate = childText(node, "FromDate")
+		period.ToDate = childText(node, "ToDate")
+		periods = append(periods, period)
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.days = append(v.days, days...)
+	v.periods = append(v.periods, periods...)
+	return nil
+}
+
+// Validate reports every OperatingDay whose date lies outside all OperatingPeriods.
+// Datasets without OperatingPeriods are not checked, and days or periods whose dates
+// cannot be parsed are left to the format rules.
+func (v *OperatingDayPeriodValidator) Validate(repository interfaces.IdRepository) ([]types.ValidationIssue, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if len(v.periods) == 0 {
+		return nil, nil
+	}
+
+	dayDates := make(map[string]time.Time, len(v.days))
+	for _, day := range v.days {
+		if date, ok := parseCalendarDate(day.CalendarDate); ok {
+			dayDates[day.ID] = date
+		}
+	}
+
+	// bound resolves one end of a period from its date or its OperatingDay reference;
+	// a missing bound leaves that end of the period open
+	bound := func(value, dayRef string) (date time.Time, open, ok bool) {
+		if value != "" {
+			date, ok = parseCalendarDate(value)
+			return date, false, ok
+		}
+		if dayRef != "" {
+			date, ok = dayDates[dayRef]
+			return date, false, ok
+		}
+		return time.Time{}, true, true
+	}
+
+	type dateRange struct {
+		from, to         time.Time
+		openFrom, openTo bool
+	}
+	var ranges []dateRange
+	for _, period := range v.periods {
+		from, openFrom, fromOK := bound(period.FromDate, period.fromDayRef)
+		to, openTo, toOK := bound(period.ToDate, period.toDayRef)
+		if !fromOK || !toOK {
+			continue
+		}
+		ranges = append(ranges, dateRange{from: from, to: to, openFrom: openFrom, openTo: openTo})
+	}
+	if len(ranges) == 0 {
+		return nil, nil
+	}
+
+	sort.SliceStable(v.days, func(i, j int) bool { return v.days[i].ID < v.days[j].ID })
+
+	var issues []types.ValidationIssue
+	for _, day := range v.days {
+		date, ok := dayDates[day.ID]
+		if !ok {
+			continue
+		}
+
+		covered := false
+		for _, r := range ranges {
+			if (r.openFrom || !date.Before(r.from)) && (r.openTo || !date.After(r.to)) {
+				covered = true
+				break
+			}
+		}
+		if covered {
+			continue
+		}
+
+		issues = append(issues, types.ValidationIssue{
+			Rule: v.rules[0],
+			Location: types.DataLocation{
+				FileName:  day.fileName,
+				XPath:     day.xpath,
+				ElementID: day.ID,
+			},
+			Message: fmt.Sprintf("OperatingDay '%s' has CalendarDate %s, which is not covered by any OperatingPeriod",
+				day.ID, day.CalendarDate),
+		})
+	}
+
+	return issues, nil
+}
+
+// GetRules returns the rules implemented by this validator
+func (v *OperatingDayPeriodValidator) GetRules() []types.ValidationRule {
+	return v.rules
+}
+
+// Reset clears all collected data
+func (v *OperatingDayPeriodValidator) Reset() {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.days = nil
+	v.periods = nil
+}
+
+// parseCalendarDate parses an xsd:date or xsd:dateTime and returns its calendar day
+// at midnight UTC, so that a period ending at 2024-01-31T23:59:59 still covers the
+// OperatingDay of 2024-01-31
+func parseCalendarDate(value string) (time.Time, bool) {
+	layouts := append(append([]string{}, xsdDateLayouts...), xsdDateTimeLayouts...)
+	for _, layout := range layouts {
+		if parsed, err := time.Parse(layout, value); err == nil {
+			return time.Date(parsed.Year(), parsed.Month(), parsed.Day(), 0, 0, 0, 0, time.UTC), true
+		}
+	}
+	return time.Time{}, false
+}
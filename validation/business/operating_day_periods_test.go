@@ -0,0 +1,100 @@
+package business
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/theoremus-urban-solutions/netex-validator/types"
+	"github.com/theoremus-urban-solutions/netex-validator/validation/ids"
+)
+
+func TestOperatingDayPeriodValidator(t *testing.T) {
+	calendar := `<?xml version="1.0" encoding="UTF-8"?>
+<PublicationDelivery xmlns="http://www.netex.org.uk/netex" version="1.15">
+	<dataObjects>
+		<ServiceCalendarFrame id="TEST:ServiceCalendarFrame:1" version="1">
+			<ServiceCalendar id="TEST:ServiceCalendar:1" version="1">
+				<operatingPeriods>
+					<OperatingPeriod id="TEST:OperatingPeriod:January" version="1">
+						<FromDate>2024-01-01T00:00:00</FromDate>
+						<ToDate>2024-01-31T23:59:59</ToDate>
+					</OperatingPeriod>
+					<OperatingPeriod id="TEST:OperatingPeriod:Easter" version="1">
+						<FromOperatingDayRef ref="TEST:OperatingDay:2024-03-29"/>
+						<ToOperatingDayRef ref="TEST:OperatingDay:2024-04-01"/>
+					</OperatingPeriod>
+				</operatingPeriods>
+			</ServiceCalendar>
+		</ServiceCalendarFrame>
+	</dataObjects>
+</PublicationDelivery>`
+
+	days := `<?xml version="1.0" encoding="UTF-8"?>
+<PublicationDelivery xmlns="http://www.netex.org.uk/netex" version="1.15">
+	<dataObjects>
+		<ServiceCalendarFrame id="TEST:ServiceCalendarFrame:2" version="1">
+			<operatingDays>
+				<OperatingDay id="TEST:OperatingDay:2024-01-31" version="1"><CalendarDate>2024-01-31</CalendarDate></OperatingDay>
+				<OperatingDay id="TEST:OperatingDay:2024-02-15" version="1"><CalendarDate>2024-02-15</CalendarDate></OperatingDay>
+				<OperatingDay id="TEST:OperatingDay:2024-03-29" version="1"><CalendarDate>2024-03-29</CalendarDate></OperatingDay>
+				<OperatingDay id="TEST:OperatingDay:2024-03-30" version="1"><CalendarDate>2024-03-30</CalendarDate></OperatingDay>
+				<OperatingDay id="TEST:OperatingDay:2024-04-01" version="1"><CalendarDate>2024-04-01</CalendarDate></OperatingDay>
+				<OperatingDay id="TEST:OperatingDay:Invalid" version="1"><CalendarDate>someday</CalendarDate></OperatingDay>
+			</operatingDays>
+		</ServiceCalendarFrame>
+	</dataObjects>
+</PublicationDelivery>`
+
+	validator := NewOperatingDayPeriodValidator()
+	if err := validator.Collect(newTestXPathContext(t, "calendar.xml", calendar)); err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+	if err := validator.Collect(newTestXPathContext(t, "days.xml", days)); err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+
+	issues, err := validator.Validate(ids.NewNetexIdRepository())
+	if err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if len(issues) != 1 {
+		t.Fatalf("Expected 1 issue, got %d: %+v", len(issues), issues)
+	}
+
+	issue := issues[0]
+	if issue.Rule.Code != "OPERATING_DAY_OUTSIDE_PERIODS" || issue.Rule.Severity != types.WARNING {
+		t.Errorf("Expected OPERATING_DAY_OUTSIDE_PERIODS warning, got %s (%v)", issue.Rule.Code, issue.Rule.Severity)
+	}
+	if issue.Location.ElementID != "TEST:OperatingDay:2024-02-15" || issue.Location.FileName != "days.xml" {
+		t.Errorf("Expected TEST:OperatingDay:2024-02-15 in days.xml, got %+v", issue.Location)
+	}
+	if !strings.Contains(issue.Message, "2024-02-15") {
+		t.Errorf("Expected message to name the date, got %q", issue.Message)
+	}
+}
+
+func TestOperatingDayPeriodValidator_NoPeriods(t *testing.T) {
+	days := `<?xml version="1.0" encoding="UTF-8"?>
+<PublicationDelivery xmlns="http://www.netex.org.uk/netex" version="1.15">
+	<dataObjects>
+		<ServiceCalendarFrame id="TEST:ServiceCalendarFrame:1" version="1">
+			<operatingDays>
+				<OperatingDay id="TEST:OperatingDay:2024-02-15" version="1"><CalendarDate>2024-02-15</CalendarDate></OperatingDay>
+			</operatingDays>
+		</ServiceCalendarFrame>
+	</dataObjects>
+</PublicationDelivery>`
+
+	validator := NewOperatingDayPeriodValidator()
+	if err := validator.Collect(newTestXPathContext(t, "days.xml", days)); err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+
+	issues, err := validator.Validate(ids.NewNetexIdRepository())
+	if err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if len(issues) != 0 {
+		t.Errorf("Expected no issues without OperatingPeriods, got %+v", issues)
+	}
+}
@@ -0,0 +1,81 @@
+package business
+
+import (
+	"fmt"
+
+	"github.com/antchfx/xmlquery"
+	"github.com/theoremus-urban-solutions/netex-validator/types"
+	"github.com/theoremus-urban-solutions/netex-validator/utils"
+	"github.com/theoremus-urban-solutions/netex-validator/validation/context"
+)
+
+// OperatorLegalDetailsValidator checks that each Operator identifies the legal
+// entity behind it and can be reached by passengers. OPERATOR_2 only covers the
+// Name; the EU profile additionally expects a CompanyNumber and a phone number
+// or web address. The OPERATOR_ rules belong to the network category, which the
+// EU rule set of the registry leaves out, so these two run as a business
+// validator and follow the network category of the configuration.
+type OperatorLegalDetailsValidator struct {
+	rules []types.ValidationRule
+}
+
+// NewOperatorLegalDetailsValidator creates a new operator legal details validator
+func NewOperatorLegalDetailsValidator() *OperatorLegalDetailsValidator {
+	return &OperatorLegalDetailsValidator{
+		rules: []types.ValidationRule{
+			{
+				Code:     "OPERATOR_3",
+				Name:     "Operator missing CompanyNumber",
+				Message:  "Operator should have a CompanyNumber identifying the legal entity",
+				Severity: types.WARNING,
+			},
+			{
+				Code:     "OPERATOR_4",
+				Name:     "Operator missing phone or url",
+				Message:  "Operator should have ContactDetails with at least a Phone or Url",
+				Severity: types.WARNING,
+			},
+		},
+	}
+}
+
+// Validate checks every Operator declared in the document
+func (v *OperatorLegalDetailsValidator) Validate(ctx context.XPathValidationContext) ([]types.ValidationIssue, error) {
+	var issues []types.ValidationIssue
+	if ctx.Document == nil {
+		return issues, nil
+	}
+
+	for _, node := range xmlquery.Find(ctx.Document, "//organisations/Operator") {
+		id := node.SelectAttr("id")
+
+		if childText(node, "CompanyNumber") == "" {
+			issues = append(issues, v.newIssue(ctx, v.rules[0], node, id,
+				fmt.Sprintf("Operator '%s' has no CompanyNumber", id)))
+		}
+
+		if childText(node, "ContactDetails/Phone") == "" && childText(node, "ContactDetails/Url") == "" {
+			issues = append(issues, v.newIssue(ctx, v.rules[1], node, id,
+				fmt.Sprintf("Operator '%s' has no ContactDetails with a Phone or Url", id)))
+		}
+	}
+
+	return issues, nil
+}
+
+// GetRules returns the rules implemented by this validator
+func (v *OperatorLegalDetailsValidator) GetRules() []types.ValidationRule {
+	return v.rules
+}
+
+func (v *OperatorLegalDetailsValidator) newIssue(ctx context.XPathValidationContext, rule types.ValidationRule, node *xmlquery.Node, id, message string) types.ValidationIssue {
+	return types.ValidationIssue{
+		Rule: rule,
+		Location: types.DataLocation{
+			FileName:  ctx.GetFileName(),
+			XPath:     utils.NodeXPath(node),
+			ElementID: id,
+		},
+		Message: message,
+	}
+}
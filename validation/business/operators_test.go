@@ -0,0 +1,38 @@
+package business
+
+import "testing"
+
+func TestOperatorLegalDetailsValidator(t *testing.T) {
+	document := `<?xml version="1.0" encoding="UTF-8"?>
+<PublicationDelivery xmlns="http://www.netex.org.uk/netex" version="1.15">
+	<dataObjects>
+		<ResourceFrame id="TEST:ResourceFrame:1" version="1">
+			<organisations>
+				<Operator id="TEST:Operator:Complete" version="1">
+					<CompanyNumber>987654321</CompanyNumber>
+					<Name>Complete</Name>
+					<ContactDetails><Phone>+47 12345678</Phone></ContactDetails>
+				</Operator>
+				<Operator id="TEST:Operator:Blank" version="1">
+					<CompanyNumber> </CompanyNumber>
+					<Name>Blank</Name>
+				</Operator>
+			</organisations>
+		</ResourceFrame>
+	</dataObjects>
+</PublicationDelivery>`
+
+	validator := NewOperatorLegalDetailsValidator()
+	issues, err := validator.Validate(newTestXPathContext(t, "operators.xml", document))
+	if err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+
+	codes := make(map[string]string)
+	for _, issue := range issues {
+		codes[issue.Rule.Code] = issue.Location.ElementID
+	}
+	if len(issues) != 2 || codes["OPERATOR_3"] != "TEST:Operator:Blank" || codes["OPERATOR_4"] != "TEST:Operator:Blank" {
+		t.Errorf("Expected OPERATOR_3 and OPERATOR_4 on TEST:Operator:Blank, got %+v", issues)
+	}
+}
@@ -0,0 +1,129 @@
+package business
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/antchfx/xmlquery"
+	"github.com/theoremus-urban-solutions/netex-validator/interfaces"
+	"github.com/theoremus-urban-solutions/netex-validator/types"
+	"github.com/theoremus-urban-solutions/netex-validator/utils"
+	"github.com/theoremus-urban-solutions/netex-validator/validation/context"
+)
+
+// declaredRoute records where a Route was declared and which Line it belongs to
+type declaredRoute struct {
+	id       string
+	lineRef  string
+	fileName string
+	xpath    string
+}
+
+// OrphanedRouteValidator reports Routes that no JourneyPattern or DeadRun references.
+// Declarations and usages are collected per file, so a JourneyPattern in one file
+// keeps a Route declared in another file alive.
+type OrphanedRouteValidator struct {
+	mu        sync.Mutex
+	routes    map[string]declaredRoute
+	routeRefs map[string]struct{}
+	rules     []types.ValidationRule
+}
+
+// NewOrphanedRouteValidator creates a new orphaned route validator
+func NewOrphanedRouteValidator() *OrphanedRouteValidator {
+	return &OrphanedRouteValidator{
+		routes:    make(map[string]declaredRoute),
+		routeRefs: make(map[string]struct{}),
+		rules: []types.ValidationRule{
+			{
+				Code:     "ROUTE_9",
+				Name:     "Orphaned route",
+				Message:  "Route is not referenced by any JourneyPattern or DeadRun",
+				Severity: types.WARNING,
+			},
+		},
+	}
+}
+
+// Collect records the Routes declared in a file and the RouteRefs used by its
+// journey patterns and dead runs
+func (v *OrphanedRouteValidator) Collect(ctx context.XPathValidationContext) error {
+	if ctx.Document == nil {
+		return nil
+	}
+
+	var routes []declaredRoute
+	for _, node := range xmlquery.Find(ctx.Document, "//routes/Route[@id]") {
+		routes = append(routes, declaredRoute{
+			id:       node.SelectAttr("id"),
+			lineRef:  childRef(node, "LineRef"),
+			fileName: ctx.GetFileName(),
+			xpath:    utils.NodeXPath(node),
+		})
+	}
+
+	var refs []string
+	for _, node := range xmlquery.Find(ctx.Document, "//JourneyPattern/RouteRef | //ServiceJourneyPattern/RouteRef | //DeadRun/RouteRef") {
+		if ref := refValue(node); ref != "" {
+			refs = append(refs, ref)
+		}
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	for _, route := range routes {
+		v.routes[route.id] = route
+	}
+	for _, ref := range refs {
+		v.routeRefs[ref] = struct{}{}
+	}
+	return nil
+}
+
+// Validate reports every declared Route without a referencing JourneyPattern or DeadRun
+func (v *OrphanedRouteValidator) Validate(repository interfaces.IdRepository) ([]types.ValidationIssue, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	ids := make([]string, 0, len(v.routes))
+	for id := range v.routes {
+		if _, used := v.routeRefs[id]; !used {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+
+	issues := make([]types.ValidationIssue, 0, len(ids))
+	for _, id := range ids {
+		route := v.routes[id]
+		line := route.lineRef
+		if line == "" {
+			line = "unknown"
+		}
+		issues = append(issues, types.ValidationIssue{
+			Rule: v.rules[0],
+			Location: types.DataLocation{
+				FileName:  route.fileName,
+				XPath:     route.xpath,
+				ElementID: route.id,
+			},
+			Message: fmt.Sprintf("Route '%s' of Line '%s' is not referenced by any JourneyPattern or DeadRun", route.id, line),
+		})
+	}
+
+	return issues, nil
+}
+
+// GetRules returns the rules implemented by this validator
+func (v *OrphanedRouteValidator) GetRules() []types.ValidationRule {
+	return v.rules
+}
+
+// Reset clears all collected data
+func (v *OrphanedRouteValidator) Reset() {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.routes = make(map[string]declaredRoute)
+	v.routeRefs = make(map[string]struct{})
+}
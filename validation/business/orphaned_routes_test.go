@@ -0,0 +1,75 @@
+package business
+
+import (
+	"testing"
+
+	"github.com/theoremus-urban-solutions/netex-validator/types"
+)
+
+func TestOrphanedRouteValidator(t *testing.T) {
+	routes := `<?xml version="1.0" encoding="UTF-8"?>
+<PublicationDelivery xmlns="http://www.netex.org.uk/netex" version="1.15">
+	<dataObjects>
+		<ServiceFrame id="TEST:ServiceFrame:1" version="1">
+			<routes>
+				<Route id="TEST:Route:1" version="1"><LineRef ref="TEST:Line:1"/></Route>
+				<Route id="TEST:Route:2" version="1"><LineRef ref="TEST:Line:1"/></Route>
+				<Route id="TEST:Route:3" version="1"><LineRef ref="TEST:Line:2"/></Route>
+			</routes>
+		</ServiceFrame>
+	</dataObjects>
+</PublicationDelivery>`
+
+	usages := `<?xml version="1.0" encoding="UTF-8"?>
+<PublicationDelivery xmlns="http://www.netex.org.uk/netex" version="1.15">
+	<dataObjects>
+		<ServiceFrame id="TEST:ServiceFrame:2" version="1">
+			<journeyPatterns>
+				<JourneyPattern id="TEST:JourneyPattern:1" version="1"><RouteRef ref="TEST:Route:1"/></JourneyPattern>
+			</journeyPatterns>
+		</ServiceFrame>
+		<TimetableFrame id="TEST:TimetableFrame:1" version="1">
+			<vehicleJourneys>
+				<DeadRun id="TEST:DeadRun:1" version="1"><RouteRef ref="TEST:Route:3"/></DeadRun>
+			</vehicleJourneys>
+		</TimetableFrame>
+	</dataObjects>
+</PublicationDelivery>`
+
+	validator := NewOrphanedRouteValidator()
+	if err := validator.Collect(newTestXPathContext(t, "routes.xml", routes)); err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+	if err := validator.Collect(newTestXPathContext(t, "usages.xml", usages)); err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+
+	issues, err := validator.Validate(nil)
+	if err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if len(issues) != 1 {
+		t.Fatalf("Expected 1 orphaned route, got %d: %+v", len(issues), issues)
+	}
+
+	issue := issues[0]
+	if issue.Location.ElementID != "TEST:Route:2" {
+		t.Errorf("Expected TEST:Route:2 to be orphaned, got %s", issue.Location.ElementID)
+	}
+	if issue.Location.FileName != "routes.xml" {
+		t.Errorf("Expected routes.xml, got %s", issue.Location.FileName)
+	}
+	if issue.Rule.Severity != types.WARNING {
+		t.Errorf("Expected WARNING severity, got %v", issue.Rule.Severity)
+	}
+
+	// Reset clears collected state for the next dataset
+	validator.Reset()
+	issues, err = validator.Validate(nil)
+	if err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if len(issues) != 0 {
+		t.Errorf("Expected no issues after Reset(), got %d", len(issues))
+	}
+}
@@ -0,0 +1,86 @@
+package business
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/antchfx/xmlquery"
+	"github.com/theoremus-urban-solutions/netex-validator/types"
+	"github.com/theoremus-urban-solutions/netex-validator/utils"
+	"github.com/theoremus-urban-solutions/netex-validator/validation/context"
+)
+
+// ParticipantRefValidator checks that the ParticipantRef of a PublicationDelivery
+// names the codespace the data is validated for, or a codespace the file declares.
+// A mismatch often means the wrong codespace was supplied. Letter case is ignored.
+type ParticipantRefValidator struct {
+	rules []types.ValidationRule
+}
+
+// NewParticipantRefValidator creates a new ParticipantRef validator
+func NewParticipantRefValidator() *ParticipantRefValidator {
+	return &ParticipantRefValidator{
+		rules: []types.ValidationRule{
+			{
+				Code:     "PUBLICATION_3",
+				Name:     "ParticipantRef does not match codespace",
+				Message:  "PublicationDelivery ParticipantRef should match the codespace",
+				Severity: types.WARNING,
+			},
+		},
+	}
+}
+
+// Validate compares the ParticipantRef with the validation codespace and the Xmlns
+// of the Codespaces declared in the document
+func (v *ParticipantRefValidator) Validate(ctx context.XPathValidationContext) ([]types.ValidationIssue, error) {
+	var issues []types.ValidationIssue
+	if ctx.Document == nil {
+		return issues, nil
+	}
+
+	participant := xmlquery.FindOne(ctx.Document, "/PublicationDelivery/ParticipantRef")
+	if participant == nil || strings.TrimSpace(participant.InnerText()) == "" {
+		return issues, nil
+	}
+	participantRef := strings.TrimSpace(participant.InnerText())
+
+	expected := make(map[string]bool)
+	if codespace := ctx.GetCodespace(); codespace != "" {
+		expected[codespace] = true
+	}
+	for _, node := range xmlquery.Find(ctx.Document, "//codespaces/Codespace") {
+		if xmlns := childText(node, "Xmlns"); xmlns != "" {
+			expected[xmlns] = true
+		}
+	}
+	if len(expected) == 0 {
+		return issues, nil
+	}
+
+	codespaces := make([]string, 0, len(expected))
+	for codespace := range expected {
+		if strings.EqualFold(codespace, participantRef) {
+			return issues, nil
+		}
+		codespaces = append(codespaces, codespace)
+	}
+	sort.Strings(codespaces)
+
+	issues = append(issues, types.ValidationIssue{
+		Rule: v.rules[0],
+		Location: types.DataLocation{
+			FileName: ctx.GetFileName(),
+			XPath:    utils.NodeXPath(participant),
+		},
+		Message: fmt.Sprintf("File '%s' has ParticipantRef '%s', expected codespace %s",
+			ctx.GetFileName(), participantRef, strings.Join(codespaces, " or ")),
+	})
+	return issues, nil
+}
+
+// GetRules returns the rules implemented by this validator
+func (v *ParticipantRefValidator) GetRules() []types.ValidationRule {
+	return v.rules
+}
@@ -0,0 +1,68 @@
+package business
+
+import (
+	"testing"
+)
+
+func TestParticipantRefValidator(t *testing.T) {
+	document := func(participantRef, codespaces string) string {
+		return `<?xml version="1.0" encoding="UTF-8"?>
+<PublicationDelivery xmlns="http://www.netex.org.uk/netex" version="1.15">
+	<PublicationTimestamp>2024-06-01T08:00:00</PublicationTimestamp>
+	<ParticipantRef>` + participantRef + `</ParticipantRef>
+	<dataObjects>
+		<CompositeFrame id="TEST:CompositeFrame:1" version="1">
+			<codespaces>` + codespaces + `</codespaces>
+		</CompositeFrame>
+	</dataObjects>
+</PublicationDelivery>`
+	}
+	rut := `<Codespace id="RUT"><Xmlns>RUT</Xmlns></Codespace>`
+
+	tests := []struct {
+		name           string
+		participantRef string
+		codespaces     string
+		wantMessage    string
+	}{
+		{name: "matches codespace", participantRef: "TEST"},
+		{name: "matches ignoring case", participantRef: "test"},
+		{name: "matches declared codespace", participantRef: "RUT", codespaces: rut},
+		{name: "empty", participantRef: ""},
+		{
+			name:           "mismatch",
+			participantRef: "ATB",
+			wantMessage:    "File 'delivery.xml' has ParticipantRef 'ATB', expected codespace TEST",
+		},
+		{
+			name:           "mismatch with declared codespace",
+			participantRef: "ATB",
+			codespaces:     rut,
+			wantMessage:    "File 'delivery.xml' has ParticipantRef 'ATB', expected codespace RUT or TEST",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issues, err := NewParticipantRefValidator().Validate(newTestXPathContext(t, "delivery.xml", document(tt.participantRef, tt.codespaces)))
+			if err != nil {
+				t.Fatalf("Validate() error = %v", err)
+			}
+			if tt.wantMessage == "" {
+				if len(issues) != 0 {
+					t.Errorf("Expected no issues, got %+v", issues)
+				}
+				return
+			}
+			if len(issues) != 1 {
+				t.Fatalf("Expected 1 issue, got %d: %+v", len(issues), issues)
+			}
+			if issues[0].Message != tt.wantMessage {
+				t.Errorf("Expected message %q, got %q", tt.wantMessage, issues[0].Message)
+			}
+			if issues[0].Rule.Code != "PUBLICATION_3" || issues[0].Location.XPath == "" {
+				t.Errorf("Expected a PUBLICATION_3 issue located at the ParticipantRef, got %+v", issues[0])
+			}
+		})
+	}
+}
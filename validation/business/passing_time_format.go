@@ -0,0 +1,79 @@
+package business
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/antchfx/xmlquery"
+	"github.com/theoremus-urban-solutions/netex-validator/types"
+	"github.com/theoremus-urban-solutions/netex-validator/utils"
+	"github.com/theoremus-urban-solutions/netex-validator/validation/context"
+)
+
+// passingTimeFormat is the HH:MM:SS form NeTEx passing times are written in
+var passingTimeFormat = regexp.MustCompile(`^\d{2}:\d{2}:\d{2}$`)
+
+// passingTimeElements are the time elements of a TimetabledPassingTime
+var passingTimeElements = []string{"ArrivalTime", "DepartureTime", "EarliestDepartureTime", "LatestArrivalTime"}
+
+// PassingTimeFormatValidator flags TimetabledPassingTime times not written as
+// HH:MM:SS. Values such as "9:30" or "0930" pass schemas that type the times
+// as plain strings, and XPath 1.0 has no regular expressions to catch them.
+type PassingTimeFormatValidator struct {
+	rules []types.ValidationRule
+}
+
+// NewPassingTimeFormatValidator creates a new passing time format validator
+func NewPassingTimeFormatValidator() *PassingTimeFormatValidator {
+	return &PassingTimeFormatValidator{
+		rules: []types.ValidationRule{
+			{
+				Code:     "TIMETABLED_PASSING_TIME_INVALID_FORMAT",
+				Name:     "TimetabledPassingTime time not HH:MM:SS",
+				Message:  "TimetabledPassingTime times must be written as HH:MM:SS",
+				Severity: types.ERROR,
+			},
+		},
+	}
+}
+
+// Validate checks the times of every TimetabledPassingTime in the document
+func (v *PassingTimeFormatValidator) Validate(ctx context.XPathValidationContext) ([]types.ValidationIssue, error) {
+	var issues []types.ValidationIssue
+	if ctx.Document == nil {
+		return issues, nil
+	}
+
+	for _, passingTime := range xmlquery.Find(ctx.Document, "//passingTimes/TimetabledPassingTime") {
+		id := passingTime.SelectAttr("id")
+		for _, name := range passingTimeElements {
+			node := xmlquery.FindOne(passingTime, name)
+			if node == nil {
+				continue
+			}
+			value := strings.TrimSpace(node.InnerText())
+			if passingTimeFormat.MatchString(value) {
+				continue
+			}
+
+			issues = append(issues, types.ValidationIssue{
+				Rule: v.rules[0],
+				Location: types.DataLocation{
+					FileName:  ctx.GetFileName(),
+					XPath:     utils.NodeXPath(node),
+					ElementID: id,
+				},
+				Message: fmt.Sprintf("TimetabledPassingTime '%s' has %s '%s', which is not in HH:MM:SS format",
+					id, name, value),
+			})
+		}
+	}
+
+	return issues, nil
+}
+
+// GetRules returns the rules implemented by this validator
+func (v *PassingTimeFormatValidator) GetRules() []types.ValidationRule {
+	return v.rules
+}
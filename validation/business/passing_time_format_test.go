@@ -0,0 +1,63 @@
+package business
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPassingTimeFormatValidator(t *testing.T) {
+	document := `<?xml version="1.0" encoding="UTF-8"?>
+<PublicationDelivery xmlns="http://www.netex.org.uk/netex" version="1.15">
+	<dataObjects>
+		<TimetableFrame id="TEST:TimetableFrame:1" version="1">
+			<vehicleJourneys>
+				<ServiceJourney id="TEST:ServiceJourney:1" version="1">
+					<passingTimes>
+						<TimetabledPassingTime id="TEST:TimetabledPassingTime:Valid" version="1">
+							<ArrivalTime>09:30:00</ArrivalTime>
+							<DepartureTime> 09:31:00 </DepartureTime>
+						</TimetabledPassingTime>
+						<TimetabledPassingTime id="TEST:TimetabledPassingTime:ShortHour" version="1">
+							<DepartureTime>9:30</DepartureTime>
+						</TimetabledPassingTime>
+						<TimetabledPassingTime id="TEST:TimetabledPassingTime:NoSeparators" version="1">
+							<ArrivalTime>0930</ArrivalTime>
+							<DepartureTime>09:35:00</DepartureTime>
+						</TimetabledPassingTime>
+						<TimetabledPassingTime id="TEST:TimetabledPassingTime:Window" version="1">
+							<EarliestDepartureTime>10:00:00</EarliestDepartureTime>
+							<LatestArrivalTime>10:30</LatestArrivalTime>
+						</TimetabledPassingTime>
+					</passingTimes>
+				</ServiceJourney>
+			</vehicleJourneys>
+		</TimetableFrame>
+	</dataObjects>
+</PublicationDelivery>`
+
+	validator := NewPassingTimeFormatValidator()
+	issues, err := validator.Validate(newTestXPathContext(t, "timetable.xml", document))
+	if err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if len(issues) != 3 {
+		t.Fatalf("Expected 3 issues, got %d: %+v", len(issues), issues)
+	}
+
+	for i, want := range []struct{ id, message string }{
+		{"TEST:TimetabledPassingTime:ShortHour", "DepartureTime '9:30'"},
+		{"TEST:TimetabledPassingTime:NoSeparators", "ArrivalTime '0930'"},
+		{"TEST:TimetabledPassingTime:Window", "LatestArrivalTime '10:30'"},
+	} {
+		issue := issues[i]
+		if issue.Rule.Code != "TIMETABLED_PASSING_TIME_INVALID_FORMAT" {
+			t.Errorf("Expected TIMETABLED_PASSING_TIME_INVALID_FORMAT, got %s", issue.Rule.Code)
+		}
+		if issue.Location.ElementID != want.id {
+			t.Errorf("Expected issue on %s, got %s", want.id, issue.Location.ElementID)
+		}
+		if !strings.Contains(issue.Message, want.message) {
+			t.Errorf("Expected message to contain %q, got %q", want.message, issue.Message)
+		}
+	}
+}
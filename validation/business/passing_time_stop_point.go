@@ -0,0 +1,67 @@
+package business
+
+import (
+	"fmt"
+
+	"github.com/antchfx/xmlquery"
+	"github.com/theoremus-urban-solutions/netex-validator/types"
+	"github.com/theoremus-urban-solutions/netex-validator/utils"
+	"github.com/theoremus-urban-solutions/netex-validator/validation/context"
+)
+
+// PassingTimeStopPointValidator flags TimetabledPassingTimes without a
+// StopPointInJourneyPatternRef, whose times cannot be associated with a stop.
+// Findings name the ServiceJourney as well, since passing times are often
+// written without ids of their own.
+type PassingTimeStopPointValidator struct {
+	rules []types.ValidationRule
+}
+
+// NewPassingTimeStopPointValidator creates a new passing time stop point validator
+func NewPassingTimeStopPointValidator() *PassingTimeStopPointValidator {
+	return &PassingTimeStopPointValidator{
+		rules: []types.ValidationRule{
+			{
+				Code:     "SERVICE_JOURNEY_20",
+				Name:     "ServiceJourney TimetabledPassingTime missing StopPointInJourneyPatternRef",
+				Message:  "TimetabledPassingTime is missing StopPointInJourneyPatternRef and cannot be associated with a stop",
+				Severity: types.ERROR,
+			},
+		},
+	}
+}
+
+// Validate reports every passing time of a ServiceJourney without a stop point reference
+func (v *PassingTimeStopPointValidator) Validate(ctx context.XPathValidationContext) ([]types.ValidationIssue, error) {
+	var issues []types.ValidationIssue
+	if ctx.Document == nil {
+		return issues, nil
+	}
+
+	for _, journey := range xmlquery.Find(ctx.Document, "//vehicleJourneys/ServiceJourney") {
+		journeyID := journey.SelectAttr("id")
+		for _, passingTime := range xmlquery.Find(journey, "passingTimes/TimetabledPassingTime[not(StopPointInJourneyPatternRef)]") {
+			id := passingTime.SelectAttr("id")
+			subject := "TimetabledPassingTime"
+			if id != "" {
+				subject = fmt.Sprintf("TimetabledPassingTime '%s'", id)
+			}
+			issues = append(issues, types.ValidationIssue{
+				Rule: v.rules[0],
+				Location: types.DataLocation{
+					FileName:  ctx.GetFileName(),
+					XPath:     utils.NodeXPath(passingTime),
+					ElementID: id,
+				},
+				Message: fmt.Sprintf("%s of ServiceJourney '%s' has no StopPointInJourneyPatternRef", subject, journeyID),
+			})
+		}
+	}
+
+	return issues, nil
+}
+
+// GetRules returns the rules implemented by this validator
+func (v *PassingTimeStopPointValidator) GetRules() []types.ValidationRule {
+	return v.rules
+}
@@ -0,0 +1,65 @@
+package business
+
+import (
+	"testing"
+
+	"github.com/theoremus-urban-solutions/netex-validator/types"
+)
+
+func TestPassingTimeStopPointValidator(t *testing.T) {
+	document := `<?xml version="1.0" encoding="UTF-8"?>
+<PublicationDelivery xmlns="http://www.netex.org.uk/netex" version="1.15">
+	<dataObjects>
+		<TimetableFrame id="TEST:TimetableFrame:1" version="1">
+			<vehicleJourneys>
+				<ServiceJourney id="TEST:ServiceJourney:1" version="1">
+					<passingTimes>
+						<TimetabledPassingTime id="TEST:TimetabledPassingTime:Placed" version="1">
+							<StopPointInJourneyPatternRef ref="TEST:StopPointInJourneyPattern:1"/>
+							<DepartureTime>09:30:00</DepartureTime>
+						</TimetabledPassingTime>
+						<TimetabledPassingTime id="TEST:TimetabledPassingTime:Unplaced" version="1">
+							<ArrivalTime>09:45:00</ArrivalTime>
+						</TimetabledPassingTime>
+					</passingTimes>
+				</ServiceJourney>
+				<ServiceJourney id="TEST:ServiceJourney:2" version="1">
+					<passingTimes>
+						<TimetabledPassingTime>
+							<DepartureTime>10:30:00</DepartureTime>
+						</TimetabledPassingTime>
+					</passingTimes>
+				</ServiceJourney>
+			</vehicleJourneys>
+		</TimetableFrame>
+	</dataObjects>
+</PublicationDelivery>`
+
+	validator := NewPassingTimeStopPointValidator()
+	issues, err := validator.Validate(newTestXPathContext(t, "timetable.xml", document))
+	if err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if len(issues) != 2 {
+		t.Fatalf("Expected 2 issues, got %d: %+v", len(issues), issues)
+	}
+
+	for i, want := range []struct{ id, message string }{
+		{"TEST:TimetabledPassingTime:Unplaced", "TimetabledPassingTime 'TEST:TimetabledPassingTime:Unplaced' of ServiceJourney 'TEST:ServiceJourney:1' has no StopPointInJourneyPatternRef"},
+		{"", "TimetabledPassingTime of ServiceJourney 'TEST:ServiceJourney:2' has no StopPointInJourneyPatternRef"},
+	} {
+		issue := issues[i]
+		if issue.Rule.Code != "SERVICE_JOURNEY_20" || issue.Rule.Severity != types.ERROR {
+			t.Errorf("Expected SERVICE_JOURNEY_20 error, got %s (%v)", issue.Rule.Code, issue.Rule.Severity)
+		}
+		if issue.Location.ElementID != want.id {
+			t.Errorf("Expected issue on %q, got %q", want.id, issue.Location.ElementID)
+		}
+		if issue.Message != want.message {
+			t.Errorf("Expected message %q, got %q", want.message, issue.Message)
+		}
+	}
+	if want := "/PublicationDelivery[1]/dataObjects[1]/TimetableFrame[1]/vehicleJourneys[1]/ServiceJourney[2]/passingTimes[1]/TimetabledPassingTime[1]"; issues[1].Location.XPath != want {
+		t.Errorf("Expected XPath %s, got %s", want, issues[1].Location.XPath)
+	}
+}
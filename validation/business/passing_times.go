@@ -0,0 +1,191 @@
+package business
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/antchfx/xmlquery"
+	"github.com/theoremus-urban-solutions/netex-validator/interfaces"
+	"github.com/theoremus-urban-solutions/netex-validator/types"
+	"github.com/theoremus-urban-solutions/netex-validator/utils"
+	"github.com/theoremus-urban-solutions/netex-validator/validation/context"
+)
+
+// journeyPassingTimes records the pattern a ServiceJourney follows and the stop
+// points its passing times refer to
+type journeyPassingTimes struct {
+	id            string
+	patternRef    string
+	stopPointRefs []string
+	fileName      string
+	xpath         string
+}
+
+// PassingTimePatternValidator checks that every TimetabledPassingTime of a
+// ServiceJourney refers to a StopPointInJourneyPattern of the journey's own
+// JourneyPattern, and that the passing times follow the order of those stop
+// points. Patterns and journeys may be declared in different files.
+type PassingTimePatternValidator struct {
+	mu       sync.Mutex
+	patterns map[string]map[string]int // pattern id -> stop point id -> position
+	journeys []journeyPassingTimes
+	rules    []types.ValidationRule
+}
+
+// NewPassingTimePatternValidator creates a new passing time pattern validator
+func NewPassingTimePatternValidator() *PassingTimePatternValidator {
+	return &PassingTimePatternValidator{
+		patterns: make(map[string]map[string]int),
+		rules: []types.ValidationRule{
+			{
+				Code:     "SERVICE_JOURNEY_18",
+				Name:     "Passing time outside journey pattern",
+				Message:  "TimetabledPassingTime references a StopPointInJourneyPattern that is not part of the ServiceJourney's JourneyPattern",
+				Severity: types.ERROR,
+			},
+			{
+				Code:     "SERVICE_JOURNEY_22",
+				Name:     "Passing times out of pattern order",
+				Message:  "TimetabledPassingTimes must follow the stop order of the ServiceJourney's JourneyPattern",
+				Severity: types.ERROR,
+			},
+		},
+	}
+}
+
+// Collect records the stop points of each journey pattern with their positions and
+// the passing time references of each service journey in the file
+func (v *PassingTimePatternValidator) Collect(ctx context.XPathValidationContext) error {
+	if ctx.Document == nil {
+		return nil
+	}
+
+	patterns := make(map[string]map[string]int)
+	for _, node := range xmlquery.Find(ctx.Document, "//JourneyPattern[@id] | //ServiceJourneyPattern[@id]") {
+		patterns[node.SelectAttr("id")] = stopPointPositions(xmlquery.Find(node, "pointsInSequence/StopPointInJourneyPattern[@id]"))
+	}
+
+	var journeys []journeyPassingTimes
+	for _, node := range xmlquery.Find(ctx.Document, "//vehicleJourneys/ServiceJourney[@id]") {
+		journey := journeyPassingTimes{
+			id:         node.SelectAttr("id"),
+			patternRef: childRef(node, "JourneyPatternRef"),
+			fileName:   ctx.GetFileName(),
+			xpath:      utils.NodeXPath(node),
+		}
+		if journey.patternRef == "" {
+			journey.patternRef = childRef(node, "ServiceJourneyPatternRef")
+		}
+		for _, ref := range xmlquery.Find(node, "passingTimes/TimetabledPassingTime/StopPointInJourneyPatternRef") {
+			if id := refValue(ref); id != "" {
+				journey.stopPointRefs = append(journey.stopPointRefs, id)
+			}
+		}
+		if journey.patternRef != "" && len(journey.stopPointRefs) > 0 {
+			journeys = append(journeys, journey)
+		}
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	for id, points := range patterns {
+		v.patterns[id] = points
+	}
+	v.journeys = append(v.journeys, journeys...)
+	return nil
+}
+
+// Validate reports passing times whose stop point is not part of the journey's pattern,
+// and passing times that come before the one of an earlier stop point of the pattern.
+// Journeys whose pattern was not found in the dataset are left to reference validation.
+func (v *PassingTimePatternValidator) Validate(repository interfaces.IdRepository) ([]types.ValidationIssue, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	var known map[string]types.IdVersion
+	if repository != nil {
+		known = repository.GetAllIds()
+	}
+
+	sort.Slice(v.journeys, func(i, j int) bool { return v.journeys[i].id < v.journeys[j].id })
+
+	var issues []types.ValidationIssue
+	for _, journey := range v.journeys {
+		points, ok := v.patterns[journey.patternRef]
+		if !ok {
+			continue
+		}
+		previous := ""
+		for _, ref := range journey.stopPointRefs {
+			if position, inPattern := points[ref]; inPattern {
+				if previous != "" && position < points[previous] {
+					issues = append(issues, types.ValidationIssue{
+						Rule: v.rules[1],
+						Location: types.DataLocation{
+							FileName:  journey.fileName,
+							XPath:     journey.xpath,
+							ElementID: journey.id,
+						},
+						Message: fmt.Sprintf("ServiceJourney '%s' passes StopPointInJourneyPattern '%s' after '%s', which comes later in JourneyPattern '%s'",
+							journey.id, ref, previous, journey.patternRef),
+					})
+				}
+				previous = ref
+				continue
+			}
+
+			detail := fmt.Sprintf("which is not part of JourneyPattern '%s'", journey.patternRef)
+			if known != nil {
+				if _, exists := known[ref]; !exists {
+					detail = fmt.Sprintf("which is not declared in the dataset (expected a stop point of JourneyPattern '%s')", journey.patternRef)
+				}
+			}
+			issues = append(issues, types.ValidationIssue{
+				Rule: v.rules[0],
+				Location: types.DataLocation{
+					FileName:  journey.fileName,
+					XPath:     journey.xpath,
+					ElementID: journey.id,
+				},
+				Message: fmt.Sprintf("ServiceJourney '%s' has a TimetabledPassingTime referencing StopPointInJourneyPattern '%s' %s",
+					journey.id, ref, detail),
+			})
+		}
+	}
+
+	return issues, nil
+}
+
+// GetRules returns the rules implemented by this validator
+func (v *PassingTimePatternValidator) GetRules() []types.ValidationRule {
+	return v.rules
+}
+
+// Reset clears all collected data
+func (v *PassingTimePatternValidator) Reset() {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.patterns = make(map[string]map[string]int)
+	v.journeys = nil
+}
+
+// stopPointPositions numbers the stop points of a pattern by their order attribute,
+// or by their position in pointsInSequence unless every one has a numeric order
+func stopPointPositions(points []*xmlquery.Node) map[string]int {
+	positions := make(map[string]int, len(points))
+	for i, point := range points {
+		positions[point.SelectAttr("id")] = i
+	}
+	orders := make(map[string]int, len(points))
+	for _, point := range points {
+		order, err := strconv.Atoi(strings.TrimSpace(point.SelectAttr("order")))
+		if err != nil {
+			return positions
+		}
+		orders[point.SelectAttr("id")] = order
+	}
+	return orders
+}
@@ -0,0 +1,173 @@
+package business
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/theoremus-urban-solutions/netex-validator/validation/ids"
+)
+
+func TestPassingTimePatternValidator(t *testing.T) {
+	patterns := `<?xml version="1.0" encoding="UTF-8"?>
+<PublicationDelivery xmlns="http://www.netex.org.uk/netex" version="1.15">
+	<dataObjects>
+		<ServiceFrame id="TEST:ServiceFrame:1" version="1">
+			<journeyPatterns>
+				<JourneyPattern id="TEST:JourneyPattern:A" version="1">
+					<pointsInSequence>
+						<StopPointInJourneyPattern id="TEST:StopPointInJourneyPattern:A1" version="1" order="1"/>
+						<StopPointInJourneyPattern id="TEST:StopPointInJourneyPattern:A2" version="1" order="2"/>
+					</pointsInSequence>
+				</JourneyPattern>
+				<JourneyPattern id="TEST:JourneyPattern:B" version="1">
+					<pointsInSequence>
+						<StopPointInJourneyPattern id="TEST:StopPointInJourneyPattern:B1" version="1" order="1"/>
+						<StopPointInJourneyPattern id="TEST:StopPointInJourneyPattern:B2" version="1" order="2"/>
+					</pointsInSequence>
+				</JourneyPattern>
+			</journeyPatterns>
+		</ServiceFrame>
+	</dataObjects>
+</PublicationDelivery>`
+
+	journeys := `<?xml version="1.0" encoding="UTF-8"?>
+<PublicationDelivery xmlns="http://www.netex.org.uk/netex" version="1.15">
+	<dataObjects>
+		<TimetableFrame id="TEST:TimetableFrame:1" version="1">
+			<vehicleJourneys>
+				<ServiceJourney id="TEST:ServiceJourney:Correct" version="1">
+					<JourneyPatternRef ref="TEST:JourneyPattern:A"/>
+					<passingTimes>
+						<TimetabledPassingTime><StopPointInJourneyPatternRef ref="TEST:StopPointInJourneyPattern:A1"/></TimetabledPassingTime>
+						<TimetabledPassingTime><StopPointInJourneyPatternRef ref="TEST:StopPointInJourneyPattern:A2"/></TimetabledPassingTime>
+					</passingTimes>
+				</ServiceJourney>
+				<ServiceJourney id="TEST:ServiceJourney:Mismatched" version="1">
+					<JourneyPatternRef ref="TEST:JourneyPattern:A"/>
+					<passingTimes>
+						<TimetabledPassingTime><StopPointInJourneyPatternRef ref="TEST:StopPointInJourneyPattern:A1"/></TimetabledPassingTime>
+						<TimetabledPassingTime><StopPointInJourneyPatternRef ref="TEST:StopPointInJourneyPattern:B2"/></TimetabledPassingTime>
+					</passingTimes>
+				</ServiceJourney>
+				<ServiceJourney id="TEST:ServiceJourney:UnknownPattern" version="1">
+					<JourneyPatternRef ref="TEST:JourneyPattern:Missing"/>
+					<passingTimes>
+						<TimetabledPassingTime><StopPointInJourneyPatternRef ref="TEST:StopPointInJourneyPattern:A1"/></TimetabledPassingTime>
+					</passingTimes>
+				</ServiceJourney>
+			</vehicleJourneys>
+		</TimetableFrame>
+	</dataObjects>
+</PublicationDelivery>`
+
+	repository := ids.NewNetexIdRepository()
+	for _, id := range []string{"TEST:StopPointInJourneyPattern:A1", "TEST:StopPointInJourneyPattern:A2",
+		"TEST:StopPointInJourneyPattern:B1", "TEST:StopPointInJourneyPattern:B2"} {
+		if err := repository.AddId(id, "1", "patterns.xml"); err != nil {
+			t.Fatalf("AddId() error = %v", err)
+		}
+	}
+
+	validator := NewPassingTimePatternValidator()
+	if err := validator.Collect(newTestXPathContext(t, "journeys.xml", journeys)); err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+	if err := validator.Collect(newTestXPathContext(t, "patterns.xml", patterns)); err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+
+	issues, err := validator.Validate(repository)
+	if err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if len(issues) != 1 {
+		t.Fatalf("Expected 1 issue, got %d: %+v", len(issues), issues)
+	}
+
+	issue := issues[0]
+	if issue.Location.ElementID != "TEST:ServiceJourney:Mismatched" {
+		t.Errorf("Expected mismatched journey to be reported, got %s", issue.Location.ElementID)
+	}
+	if issue.Location.FileName != "journeys.xml" {
+		t.Errorf("Expected journeys.xml, got %s", issue.Location.FileName)
+	}
+	if !strings.Contains(issue.Message, "TEST:StopPointInJourneyPattern:B2") ||
+		!strings.Contains(issue.Message, "not part of JourneyPattern 'TEST:JourneyPattern:A'") {
+		t.Errorf("Expected message to name the dangling ref and pattern, got %q", issue.Message)
+	}
+}
+
+func TestPassingTimePatternValidator_StopOrder(t *testing.T) {
+	document := `<?xml version="1.0" encoding="UTF-8"?>
+<PublicationDelivery xmlns="http://www.netex.org.uk/netex" version="1.15">
+	<dataObjects>
+		<ServiceFrame id="TEST:ServiceFrame:1" version="1">
+			<journeyPatterns>
+				<JourneyPattern id="TEST:JourneyPattern:Ordered" version="1">
+					<pointsInSequence>
+						<StopPointInJourneyPattern id="TEST:StopPointInJourneyPattern:3" version="1" order="3"/>
+						<StopPointInJourneyPattern id="TEST:StopPointInJourneyPattern:1" version="1" order="1"/>
+						<StopPointInJourneyPattern id="TEST:StopPointInJourneyPattern:2" version="1" order="2"/>
+					</pointsInSequence>
+				</JourneyPattern>
+				<JourneyPattern id="TEST:JourneyPattern:Sequence" version="1">
+					<pointsInSequence>
+						<StopPointInJourneyPattern id="TEST:StopPointInJourneyPattern:X" version="1"/>
+						<StopPointInJourneyPattern id="TEST:StopPointInJourneyPattern:Y" version="1"/>
+					</pointsInSequence>
+				</JourneyPattern>
+			</journeyPatterns>
+		</ServiceFrame>
+		<TimetableFrame id="TEST:TimetableFrame:1" version="1">
+			<vehicleJourneys>
+				<ServiceJourney id="TEST:ServiceJourney:Correct" version="1">
+					<JourneyPatternRef ref="TEST:JourneyPattern:Ordered"/>
+					<passingTimes>
+						<TimetabledPassingTime><StopPointInJourneyPatternRef ref="TEST:StopPointInJourneyPattern:1"/></TimetabledPassingTime>
+						<TimetabledPassingTime><StopPointInJourneyPatternRef ref="TEST:StopPointInJourneyPattern:2"/></TimetabledPassingTime>
+						<TimetabledPassingTime><StopPointInJourneyPatternRef ref="TEST:StopPointInJourneyPattern:3"/></TimetabledPassingTime>
+					</passingTimes>
+				</ServiceJourney>
+				<ServiceJourney id="TEST:ServiceJourney:Scrambled" version="1">
+					<JourneyPatternRef ref="TEST:JourneyPattern:Ordered"/>
+					<passingTimes>
+						<TimetabledPassingTime><StopPointInJourneyPatternRef ref="TEST:StopPointInJourneyPattern:1"/></TimetabledPassingTime>
+						<TimetabledPassingTime><StopPointInJourneyPatternRef ref="TEST:StopPointInJourneyPattern:3"/></TimetabledPassingTime>
+						<TimetabledPassingTime><StopPointInJourneyPatternRef ref="TEST:StopPointInJourneyPattern:2"/></TimetabledPassingTime>
+					</passingTimes>
+				</ServiceJourney>
+				<ServiceJourney id="TEST:ServiceJourney:Reversed" version="1">
+					<JourneyPatternRef ref="TEST:JourneyPattern:Sequence"/>
+					<passingTimes>
+						<TimetabledPassingTime><StopPointInJourneyPatternRef ref="TEST:StopPointInJourneyPattern:Y"/></TimetabledPassingTime>
+						<TimetabledPassingTime><StopPointInJourneyPatternRef ref="TEST:StopPointInJourneyPattern:X"/></TimetabledPassingTime>
+					</passingTimes>
+				</ServiceJourney>
+			</vehicleJourneys>
+		</TimetableFrame>
+	</dataObjects>
+</PublicationDelivery>`
+
+	validator := NewPassingTimePatternValidator()
+	if err := validator.Collect(newTestXPathContext(t, "line.xml", document)); err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+	issues, err := validator.Validate(nil)
+	if err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+
+	want := []struct{ id, message string }{
+		{"TEST:ServiceJourney:Reversed", "ServiceJourney 'TEST:ServiceJourney:Reversed' passes StopPointInJourneyPattern 'TEST:StopPointInJourneyPattern:X' after 'TEST:StopPointInJourneyPattern:Y', which comes later in JourneyPattern 'TEST:JourneyPattern:Sequence'"},
+		{"TEST:ServiceJourney:Scrambled", "ServiceJourney 'TEST:ServiceJourney:Scrambled' passes StopPointInJourneyPattern 'TEST:StopPointInJourneyPattern:2' after 'TEST:StopPointInJourneyPattern:3', which comes later in JourneyPattern 'TEST:JourneyPattern:Ordered'"},
+	}
+	if len(issues) != len(want) {
+		t.Fatalf("Expected %d issues, got %d: %+v", len(want), len(issues), issues)
+	}
+	for i, w := range want {
+		if issues[i].Rule.Code != "SERVICE_JOURNEY_22" || issues[i].Location.ElementID != w.id || issues[i].Message != w.message {
+			t.Errorf("Issue %d: expected SERVICE_JOURNEY_22 on %s with %q, got %s on %s with %q", i,
+				w.id, w.message, issues[i].Rule.Code, issues[i].Location.ElementID, issues[i].Message)
+		}
+	}
+}
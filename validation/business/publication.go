@@ -0,0 +1,93 @@
+package business
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/antchfx/xmlquery"
+	"github.com/theoremus-urban-solutions/netex-validator/types"
+	"github.com/theoremus-urban-solutions/netex-validator/utils"
+	"github.com/theoremus-urban-solutions/netex-validator/validation/context"
+)
+
+// xsdDateTimeLayouts are the xsd:dateTime forms with and without a timezone.
+// Fractional seconds are accepted by time.Parse without appearing in the layout.
+var xsdDateTimeLayouts = []string{time.RFC3339, "2006-01-02T15:04:05"}
+
+// PublicationTimestampValidator checks that a PublicationDelivery carries a
+// PublicationTimestamp consumers can use to track how fresh the feed is
+type PublicationTimestampValidator struct {
+	rules []types.ValidationRule
+}
+
+// NewPublicationTimestampValidator creates a new publication timestamp validator
+func NewPublicationTimestampValidator() *PublicationTimestampValidator {
+	return &PublicationTimestampValidator{
+		rules: []types.ValidationRule{
+			{
+				Code:     "PUBLICATION_1",
+				Name:     "PublicationTimestamp missing",
+				Message:  "PublicationDelivery must have a PublicationTimestamp",
+				Severity: types.ERROR,
+			},
+			{
+				Code:     "PUBLICATION_2",
+				Name:     "PublicationTimestamp not a valid dateTime",
+				Message:  "PublicationTimestamp must be a valid xsd:dateTime",
+				Severity: types.WARNING,
+			},
+		},
+	}
+}
+
+// Validate checks the PublicationTimestamp of the document's PublicationDelivery
+func (v *PublicationTimestampValidator) Validate(ctx context.XPathValidationContext) ([]types.ValidationIssue, error) {
+	var issues []types.ValidationIssue
+	if ctx.Document == nil {
+		return issues, nil
+	}
+
+	delivery := xmlquery.FindOne(ctx.Document, "/PublicationDelivery")
+	if delivery == nil {
+		return issues, nil
+	}
+
+	timestamp := xmlquery.FindOne(delivery, "PublicationTimestamp")
+	raw := childText(delivery, "PublicationTimestamp")
+	switch {
+	case raw == "":
+		issues = append(issues, v.newIssue(ctx, v.rules[0], delivery,
+			fmt.Sprintf("File '%s' has no PublicationTimestamp", ctx.GetFileName())))
+	case !isXSDDateTime(raw):
+		issues = append(issues, v.newIssue(ctx, v.rules[1], timestamp,
+			fmt.Sprintf("File '%s' has PublicationTimestamp '%s', which is not a valid dateTime", ctx.GetFileName(), raw)))
+	}
+
+	return issues, nil
+}
+
+// GetRules returns the rules implemented by this validator
+func (v *PublicationTimestampValidator) GetRules() []types.ValidationRule {
+	return v.rules
+}
+
+func (v *PublicationTimestampValidator) newIssue(ctx context.XPathValidationContext, rule types.ValidationRule, node *xmlquery.Node, message string) types.ValidationIssue {
+	return types.ValidationIssue{
+		Rule: rule,
+		Location: types.DataLocation{
+			FileName: ctx.GetFileName(),
+			XPath:    utils.NodeXPath(node),
+		},
+		Message: message,
+	}
+}
+
+// isXSDDateTime reports whether value is an xsd:dateTime with an optional timezone
+func isXSDDateTime(value string) bool {
+	for _, layout := range xsdDateTimeLayouts {
+		if _, err := time.Parse(layout, value); err == nil {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,52 @@
+package business
+
+import "testing"
+
+func TestPublicationTimestampValidator(t *testing.T) {
+	tests := []struct {
+		name      string
+		timestamp string // Raw PublicationTimestamp element, empty to leave it out
+		expected  string // Expected rule code, empty for no issue
+	}{
+		{name: "local time", timestamp: `<PublicationTimestamp>2023-01-01T12:00:00</PublicationTimestamp>`},
+		{name: "UTC", timestamp: `<PublicationTimestamp>2023-01-01T12:00:00Z</PublicationTimestamp>`},
+		{name: "offset with fraction", timestamp: `<PublicationTimestamp>2023-01-01T12:00:00.250+02:00</PublicationTimestamp>`},
+		{name: "missing", expected: "PUBLICATION_1"},
+		{name: "empty", timestamp: `<PublicationTimestamp> </PublicationTimestamp>`, expected: "PUBLICATION_1"},
+		{name: "date only", timestamp: `<PublicationTimestamp>2023-01-01</PublicationTimestamp>`, expected: "PUBLICATION_2"},
+		{name: "out of range", timestamp: `<PublicationTimestamp>2023-13-01T12:00:00</PublicationTimestamp>`, expected: "PUBLICATION_2"},
+	}
+
+	validator := NewPublicationTimestampValidator()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			document := `<?xml version="1.0" encoding="UTF-8"?>
+<PublicationDelivery xmlns="http://www.netex.org.uk/netex" version="1.15">
+	` + tt.timestamp + `
+	<ParticipantRef>TEST</ParticipantRef>
+	<dataObjects/>
+</PublicationDelivery>`
+
+			issues, err := validator.Validate(newTestXPathContext(t, "feed.xml", document))
+			if err != nil {
+				t.Fatalf("Validate() error = %v", err)
+			}
+
+			if tt.expected == "" {
+				if len(issues) != 0 {
+					t.Errorf("Expected no issues, got %+v", issues)
+				}
+				return
+			}
+			if len(issues) != 1 {
+				t.Fatalf("Expected 1 issue, got %d: %+v", len(issues), issues)
+			}
+			if issues[0].Rule.Code != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, issues[0].Rule.Code)
+			}
+			if issues[0].Location.FileName != "feed.xml" {
+				t.Errorf("Expected issue in feed.xml, got %s", issues[0].Location.FileName)
+			}
+		})
+	}
+}
@@ -0,0 +1,129 @@
+package business
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/antchfx/xmlquery"
+	"github.com/theoremus-urban-solutions/netex-validator/interfaces"
+	"github.com/theoremus-urban-solutions/netex-validator/types"
+	"github.com/theoremus-urban-solutions/netex-validator/utils"
+	"github.com/theoremus-urban-solutions/netex-validator/validation/context"
+)
+
+// quayAssignment is a PassengerStopAssignment placing a ScheduledStopPoint at a Quay
+type quayAssignment struct {
+	id           string
+	quayRef      string
+	stopPlaceRef string
+	fileName     string
+	xpath        string
+}
+
+// QuayStopPlaceValidator reports assignments whose StopPlaceRef names another
+// StopPlace than the one the assigned Quay is declared in. The Quay and the
+// assignment may be in different files of the dataset. Dangling references are
+// left to the reference checks, and Quays of an external stop register have no
+// declared StopPlace to compare with.
+type QuayStopPlaceValidator struct {
+	mu          sync.Mutex
+	quayParents map[string]string // declared quay id -> id of the enclosing StopPlace
+	assignments []quayAssignment
+	rules       []types.ValidationRule
+}
+
+// NewQuayStopPlaceValidator creates a new quay stop place validator
+func NewQuayStopPlaceValidator() *QuayStopPlaceValidator {
+	return &QuayStopPlaceValidator{
+		quayParents: make(map[string]string),
+		rules: []types.ValidationRule{
+			{
+				Code:     "STOP_PLACE_10",
+				Name:     "Quay assigned with another StopPlace",
+				Message:  "StopPlaceRef of a PassengerStopAssignment must be the StopPlace of its Quay",
+				Severity: types.ERROR,
+			},
+		},
+	}
+}
+
+// Collect records the Quays and Quay assignments in a file
+func (v *QuayStopPlaceValidator) Collect(ctx context.XPathValidationContext) error {
+	if ctx.Document == nil {
+		return nil
+	}
+
+	quayParents := make(map[string]string)
+	for _, node := range xmlquery.Find(ctx.Document, "//Quay[@id]") {
+		parent := ""
+		if stopPlace := xmlquery.FindOne(node, "ancestor::StopPlace[@id]"); stopPlace != nil {
+			parent = stopPlace.SelectAttr("id")
+		}
+		quayParents[node.SelectAttr("id")] = parent
+	}
+
+	var assignments []quayAssignment
+	for _, node := range xmlquery.Find(ctx.Document, "//stopAssignments/PassengerStopAssignment[QuayRef]") {
+		assignments = append(assignments, quayAssignment{
+			id:           node.SelectAttr("id"),
+			quayRef:      childRef(node, "QuayRef"),
+			stopPlaceRef: childRef(node, "StopPlaceRef"),
+			fileName:     ctx.GetFileName(),
+			xpath:        utils.NodeXPath(node),
+		})
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	for quay, parent := range quayParents {
+		// A Quay repeated in another file keeps the StopPlace found first
+		if v.quayParents[quay] == "" {
+			v.quayParents[quay] = parent
+		}
+	}
+	v.assignments = append(v.assignments, assignments...)
+	return nil
+}
+
+// Validate reports every assignment whose StopPlaceRef differs from the StopPlace of its Quay
+func (v *QuayStopPlaceValidator) Validate(repository interfaces.IdRepository) ([]types.ValidationIssue, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	sort.SliceStable(v.assignments, func(i, j int) bool { return v.assignments[i].id < v.assignments[j].id })
+
+	var issues []types.ValidationIssue
+	for _, assignment := range v.assignments {
+		parent := v.quayParents[assignment.quayRef]
+		if parent == "" || assignment.stopPlaceRef == "" || assignment.stopPlaceRef == parent {
+			continue
+		}
+
+		issues = append(issues, types.ValidationIssue{
+			Rule: v.rules[0],
+			Location: types.DataLocation{
+				FileName:  assignment.fileName,
+				XPath:     assignment.xpath,
+				ElementID: assignment.id,
+			},
+			Message: fmt.Sprintf("PassengerStopAssignment '%s' assigns Quay '%s' with StopPlace '%s', but the Quay belongs to StopPlace '%s'",
+				assignment.id, assignment.quayRef, assignment.stopPlaceRef, parent),
+		})
+	}
+
+	return issues, nil
+}
+
+// GetRules returns the rules implemented by this validator
+func (v *QuayStopPlaceValidator) GetRules() []types.ValidationRule {
+	return v.rules
+}
+
+// Reset clears all collected data
+func (v *QuayStopPlaceValidator) Reset() {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.quayParents = make(map[string]string)
+	v.assignments = nil
+}
@@ -0,0 +1,93 @@
+package business
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/theoremus-urban-solutions/netex-validator/validation/ids"
+)
+
+func TestQuayStopPlaceValidator(t *testing.T) {
+	stopsFile := `<?xml version="1.0" encoding="UTF-8"?>
+<PublicationDelivery xmlns="http://www.netex.org.uk/netex" version="1.15">
+	<dataObjects>
+		<SiteFrame id="TEST:SiteFrame:1" version="1">
+			<stopPlaces>
+				<StopPlace id="TEST:StopPlace:1" version="1">
+					<quays>
+						<Quay id="TEST:Quay:1" version="1"/>
+					</quays>
+				</StopPlace>
+				<StopPlace id="TEST:StopPlace:2" version="1"/>
+			</stopPlaces>
+		</SiteFrame>
+	</dataObjects>
+</PublicationDelivery>`
+
+	assignmentsFile := `<?xml version="1.0" encoding="UTF-8"?>
+<PublicationDelivery xmlns="http://www.netex.org.uk/netex" version="1.15">
+	<dataObjects>
+		<ServiceFrame id="TEST:ServiceFrame:1" version="1">
+			<stopAssignments>
+				<PassengerStopAssignment id="TEST:PassengerStopAssignment:Consistent" version="1" order="1">
+					<ScheduledStopPointRef ref="TEST:ScheduledStopPoint:1"/>
+					<StopPlaceRef ref="TEST:StopPlace:1"/>
+					<QuayRef ref="TEST:Quay:1"/>
+				</PassengerStopAssignment>
+				<PassengerStopAssignment id="TEST:PassengerStopAssignment:Inconsistent" version="1" order="2">
+					<ScheduledStopPointRef ref="TEST:ScheduledStopPoint:2"/>
+					<StopPlaceRef ref="TEST:StopPlace:2"/>
+					<QuayRef ref="TEST:Quay:1"/>
+				</PassengerStopAssignment>
+				<PassengerStopAssignment id="TEST:PassengerStopAssignment:Unresolved" version="1" order="3">
+					<ScheduledStopPointRef ref="TEST:ScheduledStopPoint:3"/>
+					<StopPlaceRef ref="TEST:StopPlace:Missing"/>
+					<QuayRef ref="NSR:Quay:3"/>
+				</PassengerStopAssignment>
+				<PassengerStopAssignment id="TEST:PassengerStopAssignment:External" version="1" order="4">
+					<ScheduledStopPointRef ref="TEST:ScheduledStopPoint:4"/>
+					<QuayRef ref="NSR:Quay:4"/>
+				</PassengerStopAssignment>
+			</stopAssignments>
+		</ServiceFrame>
+	</dataObjects>
+</PublicationDelivery>`
+
+	validator := NewQuayStopPlaceValidator()
+	if err := validator.Collect(newTestXPathContext(t, "assignments.xml", assignmentsFile)); err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+	if err := validator.Collect(newTestXPathContext(t, "stops.xml", stopsFile)); err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+
+	issues, err := validator.Validate(ids.NewNetexIdRepository())
+	if err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if len(issues) != 1 {
+		t.Fatalf("Expected 1 issue, got %d: %+v", len(issues), issues)
+	}
+
+	issue := issues[0]
+	if issue.Rule.Code != "STOP_PLACE_10" {
+		t.Errorf("Expected STOP_PLACE_10, got %s", issue.Rule.Code)
+	}
+	if issue.Location.ElementID != "TEST:PassengerStopAssignment:Inconsistent" || issue.Location.FileName != "assignments.xml" {
+		t.Errorf("Expected issue on TEST:PassengerStopAssignment:Inconsistent in assignments.xml, got %+v", issue.Location)
+	}
+	for _, want := range []string{"TEST:Quay:1", "TEST:StopPlace:2", "TEST:StopPlace:1"} {
+		if !strings.Contains(issue.Message, want) {
+			t.Errorf("Expected message to contain %q, got %q", want, issue.Message)
+		}
+	}
+
+	validator.Reset()
+	issues, err = validator.Validate(ids.NewNetexIdRepository())
+	if err != nil {
+		t.Fatalf("Validate() after Reset() error = %v", err)
+	}
+	if len(issues) != 0 {
+		t.Errorf("Expected no issues after Reset(), got %d", len(issues))
+	}
+}
@@ -0,0 +1,136 @@
+package business
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/antchfx/xmlquery"
+	"github.com/theoremus-urban-solutions/netex-validator/interfaces"
+	"github.com/theoremus-urban-solutions/netex-validator/types"
+	"github.com/theoremus-urban-solutions/netex-validator/utils"
+	"github.com/theoremus-urban-solutions/netex-validator/validation/context"
+)
+
+// lineLocation is where a Line or FlexibleLine was declared
+type lineLocation struct {
+	fileName string
+	xpath    string
+}
+
+// RouteDirectionValidator reports Lines with more than one Route whose Routes all
+// run in the same DirectionType, such as a bidirectional bus line published with
+// outbound Routes only. Routes and their Lines may be declared in different files,
+// so Routes are grouped by their LineRef once the dataset is collected.
+type RouteDirectionValidator struct {
+	mu         sync.Mutex
+	lines      map[string]lineLocation        // line id -> declaration
+	directions map[string]map[string][]string // line id -> DirectionType -> route ids
+	rules      []types.ValidationRule
+}
+
+// NewRouteDirectionValidator creates a new route direction validator
+func NewRouteDirectionValidator() *RouteDirectionValidator {
+	return &RouteDirectionValidator{
+		lines:      make(map[string]lineLocation),
+		directions: make(map[string]map[string][]string),
+		rules: []types.ValidationRule{
+			{
+				Code:     "ROUTE_SINGLE_DIRECTION",
+				Name:     "Line Routes in a single direction",
+				Message:  "All Routes of the Line have the same DirectionType",
+				Severity: types.WARNING,
+			},
+		},
+	}
+}
+
+// Collect records the Lines in a file and the DirectionType of each Route
+func (v *RouteDirectionValidator) Collect(ctx context.XPathValidationContext) error {
+	if ctx.Document == nil {
+		return nil
+	}
+
+	lines := make(map[string]lineLocation)
+	for _, node := range xmlquery.Find(ctx.Document, "//lines/*[self::Line or self::FlexibleLine][@id]") {
+		lines[node.SelectAttr("id")] = lineLocation{fileName: ctx.GetFileName(), xpath: utils.NodeXPath(node)}
+	}
+
+	type routeDirection struct{ id, line, direction string }
+	var routes []routeDirection
+	for _, node := range xmlquery.Find(ctx.Document, "//routes/Route[@id]") {
+		line := childRef(node, "LineRef|FlexibleLineRef")
+		direction := childText(node, "DirectionType")
+		if line == "" || direction == "" {
+			continue
+		}
+		routes = append(routes, routeDirection{id: node.SelectAttr("id"), line: line, direction: direction})
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	for id, location := range lines {
+		v.lines[id] = location
+	}
+	for _, route := range routes {
+		if v.directions[route.line] == nil {
+			v.directions[route.line] = make(map[string][]string)
+		}
+		v.directions[route.line][route.direction] = append(v.directions[route.line][route.direction], route.id)
+	}
+	return nil
+}
+
+// Validate reports every declared Line whose Routes with a DirectionType number more
+// than one and share a single direction. Routes without a DirectionType are ignored.
+func (v *RouteDirectionValidator) Validate(repository interfaces.IdRepository) ([]types.ValidationIssue, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	lineIDs := make([]string, 0, len(v.directions))
+	for id := range v.directions {
+		lineIDs = append(lineIDs, id)
+	}
+	sort.Strings(lineIDs)
+
+	var issues []types.ValidationIssue
+	for _, id := range lineIDs {
+		directions := v.directions[id]
+		location, declared := v.lines[id]
+		if !declared || len(directions) != 1 {
+			continue
+		}
+		for direction, routes := range directions {
+			if len(routes) < 2 {
+				continue
+			}
+			sort.Strings(routes)
+			issues = append(issues, types.ValidationIssue{
+				Rule: v.rules[0],
+				Location: types.DataLocation{
+					FileName:  location.fileName,
+					XPath:     location.xpath,
+					ElementID: id,
+				},
+				Message: fmt.Sprintf("Line '%s' has %d Routes, all with DirectionType %s (%s); no Route runs in another direction",
+					id, len(routes), direction, strings.Join(routes, ", ")),
+			})
+		}
+	}
+
+	return issues, nil
+}
+
+// GetRules returns the rules implemented by this validator
+func (v *RouteDirectionValidator) GetRules() []types.ValidationRule {
+	return v.rules
+}
+
+// Reset clears all collected data
+func (v *RouteDirectionValidator) Reset() {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.lines = make(map[string]lineLocation)
+	v.directions = make(map[string]map[string][]string)
+}
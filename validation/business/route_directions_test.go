@@ -0,0 +1,77 @@
+package business
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/theoremus-urban-solutions/netex-validator/types"
+	"github.com/theoremus-urban-solutions/netex-validator/validation/ids"
+)
+
+func TestRouteDirectionValidator(t *testing.T) {
+	lines := `<?xml version="1.0" encoding="UTF-8"?>
+<PublicationDelivery xmlns="http://www.netex.org.uk/netex" version="1.15">
+	<dataObjects>
+		<ServiceFrame id="TEST:ServiceFrame:1" version="1">
+			<lines>
+				<Line id="TEST:Line:OneWay" version="1"/>
+				<Line id="TEST:Line:Both" version="1"/>
+				<Line id="TEST:Line:Single" version="1"/>
+				<FlexibleLine id="TEST:FlexibleLine:1" version="1"/>
+			</lines>
+		</ServiceFrame>
+	</dataObjects>
+</PublicationDelivery>`
+
+	routes := `<?xml version="1.0" encoding="UTF-8"?>
+<PublicationDelivery xmlns="http://www.netex.org.uk/netex" version="1.15">
+	<dataObjects>
+		<ServiceFrame id="TEST:ServiceFrame:2" version="1">
+			<routes>
+				<Route id="TEST:Route:OneWay2" version="1"><LineRef ref="TEST:Line:OneWay"/><DirectionType>outbound</DirectionType></Route>
+				<Route id="TEST:Route:OneWay1" version="1"><LineRef ref="TEST:Line:OneWay"/><DirectionType>outbound</DirectionType></Route>
+				<Route id="TEST:Route:Both1" version="1"><LineRef ref="TEST:Line:Both"/><DirectionType>outbound</DirectionType></Route>
+				<Route id="TEST:Route:Both2" version="1"><LineRef ref="TEST:Line:Both"/><DirectionType>inbound</DirectionType></Route>
+				<Route id="TEST:Route:Both3" version="1"><LineRef ref="TEST:Line:Both"/></Route>
+				<Route id="TEST:Route:Single" version="1"><LineRef ref="TEST:Line:Single"/><DirectionType>clockwise</DirectionType></Route>
+				<Route id="TEST:Route:Flexible1" version="1"><FlexibleLineRef ref="TEST:FlexibleLine:1"/><DirectionType>inbound</DirectionType></Route>
+				<Route id="TEST:Route:Flexible2" version="1"><FlexibleLineRef ref="TEST:FlexibleLine:1"/><DirectionType>inbound</DirectionType></Route>
+				<Route id="TEST:Route:Undeclared1" version="1"><LineRef ref="TEST:Line:Elsewhere"/><DirectionType>outbound</DirectionType></Route>
+				<Route id="TEST:Route:Undeclared2" version="1"><LineRef ref="TEST:Line:Elsewhere"/><DirectionType>outbound</DirectionType></Route>
+			</routes>
+		</ServiceFrame>
+	</dataObjects>
+</PublicationDelivery>`
+
+	validator := NewRouteDirectionValidator()
+	if err := validator.Collect(newTestXPathContext(t, "routes.xml", routes)); err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+	if err := validator.Collect(newTestXPathContext(t, "lines.xml", lines)); err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+
+	issues, err := validator.Validate(ids.NewNetexIdRepository())
+	if err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if len(issues) != 2 {
+		t.Fatalf("Expected 2 issues, got %d: %+v", len(issues), issues)
+	}
+
+	for i, want := range []struct{ id, message string }{
+		{"TEST:FlexibleLine:1", "all with DirectionType inbound (TEST:Route:Flexible1, TEST:Route:Flexible2)"},
+		{"TEST:Line:OneWay", "all with DirectionType outbound (TEST:Route:OneWay1, TEST:Route:OneWay2)"},
+	} {
+		issue := issues[i]
+		if issue.Rule.Code != "ROUTE_SINGLE_DIRECTION" || issue.Rule.Severity != types.WARNING {
+			t.Errorf("Expected ROUTE_SINGLE_DIRECTION warning, got %s (%v)", issue.Rule.Code, issue.Rule.Severity)
+		}
+		if issue.Location.ElementID != want.id || issue.Location.FileName != "lines.xml" {
+			t.Errorf("Expected issue on %s in lines.xml, got %+v", want.id, issue.Location)
+		}
+		if !strings.Contains(issue.Message, want.message) {
+			t.Errorf("Expected message to contain %q, got %q", want.message, issue.Message)
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package business
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/antchfx/xmlquery"
+	"github.com/theoremus-urban-solutions/netex-validator/interfaces"
+	"github.com/theoremus-urban-solutions/netex-validator/types"
+	"github.com/theoremus-urban-solutions/netex-validator/utils"
+	"github.com/theoremus-urban-solutions/netex-validator/validation/context"
+)
+
+// RoutelessLineValidator reports Lines that no Route references through its
+// LineRef, leaving the Line without a geographical path. It is the inverse of
+// ROUTE_3, which reports Routes without a LineRef. Routes are often declared in
+// another file than their Line, so the references are matched once the dataset is
+// collected. FlexibleLines are left out, as area-based flexible services need no
+// Route.
+type RoutelessLineValidator struct {
+	mu        sync.Mutex
+	lines     map[string]lineLocation // Line id -> declaration
+	routeRefs map[string]struct{}     // Line ids referenced by a Route
+	rules     []types.ValidationRule
+}
+
+// NewRoutelessLineValidator creates a new routeless line validator
+func NewRoutelessLineValidator() *RoutelessLineValidator {
+	return &RoutelessLineValidator{
+		lines:     make(map[string]lineLocation),
+		routeRefs: make(map[string]struct{}),
+		rules: []types.ValidationRule{
+			{
+				Code:     "LINE_WITHOUT_ROUTE",
+				Name:     "Line without Route",
+				Message:  "Line is not referenced by any Route",
+				Severity: types.WARNING,
+			},
+		},
+	}
+}
+
+// Collect records the Lines declared in a file and the LineRefs of its Routes
+func (v *RoutelessLineValidator) Collect(ctx context.XPathValidationContext) error {
+	if ctx.Document == nil {
+		return nil
+	}
+
+	lines := make(map[string]lineLocation)
+	for _, node := range xmlquery.Find(ctx.Document, "//lines/Line[@id]") {
+		lines[node.SelectAttr("id")] = lineLocation{fileName: ctx.GetFileName(), xpath: utils.NodeXPath(node)}
+	}
+
+	var refs []string
+	for _, node := range xmlquery.Find(ctx.Document, "//routes/Route/LineRef") {
+		if ref := refValue(node); ref != "" {
+			refs = append(refs, ref)
+		}
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	for id, location := range lines {
+		v.lines[id] = location
+	}
+	for _, ref := range refs {
+		v.routeRefs[ref] = struct{}{}
+	}
+	return nil
+}
+
+// Validate reports every declared Line without a Route referencing it
+func (v *RoutelessLineValidator) Validate(repository interfaces.IdRepository) ([]types.ValidationIssue, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	ids := make([]string, 0, len(v.lines))
+	for id := range v.lines {
+		if _, ok := v.routeRefs[id]; !ok {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+
+	issues := make([]types.ValidationIssue, 0, len(ids))
+	for _, id := range ids {
+		location := v.lines[id]
+		issues = append(issues, types.ValidationIssue{
+			Rule: v.rules[0],
+			Location: types.DataLocation{
+				FileName:  location.fileName,
+				XPath:     location.xpath,
+				ElementID: id,
+			},
+			Message: fmt.Sprintf("Line '%s' is not referenced by any Route", id),
+		})
+	}
+
+	return issues, nil
+}
+
+// GetRules returns the rules implemented by this validator
+func (v *RoutelessLineValidator) GetRules() []types.ValidationRule {
+	return v.rules
+}
+
+// Reset clears all collected data
+func (v *RoutelessLineValidator) Reset() {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.lines = make(map[string]lineLocation)
+	v.routeRefs = make(map[string]struct{})
+}
@@ -0,0 +1,69 @@
+package business
+
+import (
+	"testing"
+
+	"github.com/theoremus-urban-solutions/netex-validator/types"
+)
+
+func TestRoutelessLineValidator(t *testing.T) {
+	lines := `<?xml version="1.0" encoding="UTF-8"?>
+<PublicationDelivery xmlns="http://www.netex.org.uk/netex" version="1.15">
+	<dataObjects>
+		<ServiceFrame id="TEST:ServiceFrame:1" version="1">
+			<lines>
+				<Line id="TEST:Line:1" version="1"><Name>With route</Name></Line>
+				<Line id="TEST:Line:2" version="1"><Name>Without route</Name></Line>
+				<FlexibleLine id="TEST:FlexibleLine:1" version="1"><Name>Area service</Name></FlexibleLine>
+			</lines>
+		</ServiceFrame>
+	</dataObjects>
+</PublicationDelivery>`
+
+	routes := `<?xml version="1.0" encoding="UTF-8"?>
+<PublicationDelivery xmlns="http://www.netex.org.uk/netex" version="1.15">
+	<dataObjects>
+		<ServiceFrame id="TEST:ServiceFrame:2" version="1">
+			<routes>
+				<Route id="TEST:Route:1" version="1"><LineRef ref="TEST:Line:1"/></Route>
+			</routes>
+		</ServiceFrame>
+	</dataObjects>
+</PublicationDelivery>`
+
+	validator := NewRoutelessLineValidator()
+	if err := validator.Collect(newTestXPathContext(t, "lines.xml", lines)); err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+	if err := validator.Collect(newTestXPathContext(t, "routes.xml", routes)); err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+
+	issues, err := validator.Validate(nil)
+	if err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if len(issues) != 1 {
+		t.Fatalf("Expected 1 line without route, got %d: %+v", len(issues), issues)
+	}
+
+	issue := issues[0]
+	if issue.Location.ElementID != "TEST:Line:2" {
+		t.Errorf("Expected TEST:Line:2 to be reported, got %s", issue.Location.ElementID)
+	}
+	if issue.Location.FileName != "lines.xml" {
+		t.Errorf("Expected lines.xml, got %s", issue.Location.FileName)
+	}
+	if issue.Rule.Severity != types.WARNING {
+		t.Errorf("Expected WARNING severity, got %v", issue.Rule.Severity)
+	}
+
+	validator.Reset()
+	issues, err = validator.Validate(nil)
+	if err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if len(issues) != 0 {
+		t.Errorf("Expected no issues after Reset(), got %d", len(issues))
+	}
+}
@@ -0,0 +1,68 @@
+package business
+
+import (
+	"fmt"
+
+	"github.com/antchfx/xmlquery"
+	"github.com/theoremus-urban-solutions/netex-validator/types"
+	"github.com/theoremus-urban-solutions/netex-validator/utils"
+	"github.com/theoremus-urban-solutions/netex-validator/validation/context"
+)
+
+// StopPointAccessValidator flags stop points in journey patterns where passengers
+// can neither board nor alight. Such a point only makes sense as a timing point,
+// which is marked with IsWaitPoint.
+type StopPointAccessValidator struct {
+	rules []types.ValidationRule
+}
+
+// NewStopPointAccessValidator creates a new stop point access validator
+func NewStopPointAccessValidator() *StopPointAccessValidator {
+	return &StopPointAccessValidator{
+		rules: []types.ValidationRule{
+			{
+				Code:     "STOP_POINT_4",
+				Name:     "StopPoint without boarding or alighting",
+				Message:  "StopPointInJourneyPattern allows neither boarding nor alighting and is not a timing point",
+				Severity: types.WARNING,
+			},
+		},
+	}
+}
+
+// Validate checks every StopPointInJourneyPattern in the document
+func (v *StopPointAccessValidator) Validate(ctx context.XPathValidationContext) ([]types.ValidationIssue, error) {
+	var issues []types.ValidationIssue
+	if ctx.Document == nil {
+		return issues, nil
+	}
+
+	for _, pattern := range xmlquery.Find(ctx.Document, "//journeyPatterns/*[self::JourneyPattern or self::ServiceJourneyPattern]") {
+		patternID := pattern.SelectAttr("id")
+		for _, node := range xmlquery.Find(pattern, "pointsInSequence/StopPointInJourneyPattern") {
+			if childText(node, "ForBoarding") != "false" || childText(node, "ForAlighting") != "false" ||
+				childText(node, "IsWaitPoint") == "true" {
+				continue
+			}
+
+			id := node.SelectAttr("id")
+			issues = append(issues, types.ValidationIssue{
+				Rule: v.rules[0],
+				Location: types.DataLocation{
+					FileName:  ctx.GetFileName(),
+					XPath:     utils.NodeXPath(node),
+					ElementID: id,
+				},
+				Message: fmt.Sprintf("StopPointInJourneyPattern '%s' in JourneyPattern '%s' has ForBoarding and ForAlighting false and is not a timing point",
+					id, patternID),
+			})
+		}
+	}
+
+	return issues, nil
+}
+
+// GetRules returns the rules implemented by this validator
+func (v *StopPointAccessValidator) GetRules() []types.ValidationRule {
+	return v.rules
+}
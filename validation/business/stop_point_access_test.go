@@ -0,0 +1,60 @@
+package business
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestStopPointAccessValidator(t *testing.T) {
+	document := `<?xml version="1.0" encoding="UTF-8"?>
+<PublicationDelivery xmlns="http://www.netex.org.uk/netex" version="1.15">
+	<dataObjects>
+		<ServiceFrame id="TEST:ServiceFrame:1" version="1">
+			<journeyPatterns>
+				<JourneyPattern id="TEST:JourneyPattern:1" version="1">
+					<pointsInSequence>
+						<StopPointInJourneyPattern id="TEST:StopPointInJourneyPattern:Default" version="1" order="1">
+							<ScheduledStopPointRef ref="TEST:ScheduledStopPoint:1"/>
+						</StopPointInJourneyPattern>
+						<StopPointInJourneyPattern id="TEST:StopPointInJourneyPattern:AlightOnly" version="1" order="2">
+							<ScheduledStopPointRef ref="TEST:ScheduledStopPoint:2"/>
+							<ForBoarding>false</ForBoarding>
+						</StopPointInJourneyPattern>
+						<StopPointInJourneyPattern id="TEST:StopPointInJourneyPattern:Timing" version="1" order="3">
+							<ScheduledStopPointRef ref="TEST:ScheduledStopPoint:3"/>
+							<IsWaitPoint>true</IsWaitPoint>
+							<ForAlighting>false</ForAlighting>
+							<ForBoarding>false</ForBoarding>
+						</StopPointInJourneyPattern>
+						<StopPointInJourneyPattern id="TEST:StopPointInJourneyPattern:Closed" version="1" order="4">
+							<ScheduledStopPointRef ref="TEST:ScheduledStopPoint:4"/>
+							<ForAlighting>false</ForAlighting>
+							<ForBoarding>false</ForBoarding>
+						</StopPointInJourneyPattern>
+					</pointsInSequence>
+				</JourneyPattern>
+			</journeyPatterns>
+		</ServiceFrame>
+	</dataObjects>
+</PublicationDelivery>`
+
+	validator := NewStopPointAccessValidator()
+	issues, err := validator.Validate(newTestXPathContext(t, "patterns.xml", document))
+	if err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if len(issues) != 1 {
+		t.Fatalf("Expected 1 issue, got %d: %+v", len(issues), issues)
+	}
+
+	issue := issues[0]
+	if issue.Rule.Code != "STOP_POINT_4" {
+		t.Errorf("Expected STOP_POINT_4, got %s", issue.Rule.Code)
+	}
+	if issue.Location.ElementID != "TEST:StopPointInJourneyPattern:Closed" {
+		t.Errorf("Expected issue on TEST:StopPointInJourneyPattern:Closed, got %s", issue.Location.ElementID)
+	}
+	if !strings.Contains(issue.Message, "TEST:JourneyPattern:1") {
+		t.Errorf("Expected message to name the journey pattern, got %q", issue.Message)
+	}
+}
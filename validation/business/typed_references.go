@@ -0,0 +1,266 @@
+package business
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/antchfx/xmlquery"
+	"github.com/theoremus-urban-solutions/netex-validator/interfaces"
+	"github.com/theoremus-urban-solutions/netex-validator/types"
+	"github.com/theoremus-urban-solutions/netex-validator/utils"
+	"github.com/theoremus-urban-solutions/netex-validator/validation/context"
+)
+
+// typedReference describes a reference that must resolve to a declared element
+// of a specific type, e.g. a DatedServiceJourney's OperatingDayRef must point at
+// an OperatingDay rather than at any element with a matching id
+type typedReference struct {
+	rule    types.ValidationRule
+	sources []string // element types holding the reference
+	refPath string   // path of the reference relative to the source element
+	targets []string // element types the reference may resolve to
+}
+
+// targetName describes the accepted element types in messages
+func (t typedReference) targetName() string {
+	return strings.Join(t.targets, "/")
+}
+
+// collectedReference is a reference found while collecting a file
+type collectedReference struct {
+	check      int
+	sourceType string
+	sourceID   string
+	ref        string
+	fileName   string
+	xpath      string
+}
+
+// TypedReferenceValidator resolves references against the elements declared
+// anywhere in the dataset and reports references whose target is missing or
+// has the wrong element type. The generic ID validation only checks that a
+// referenced id exists, whatever element declares it.
+type TypedReferenceValidator struct {
+	mu         sync.Mutex
+	checks     []typedReference
+	declared   map[string]map[string]struct{}
+	references []collectedReference
+	rules      []types.ValidationRule
+}
+
+// NewTypedReferenceValidator creates a validator for the built-in typed references
+func NewTypedReferenceValidator() *TypedReferenceValidator {
+	checks := []typedReference{
+		{
+			rule: types.ValidationRule{
+				Code:     "DATED_SERVICE_JOURNEY_3",
+				Name:     "DatedServiceJourney invalid OperatingDayRef",
+				Message:  "DatedServiceJourney OperatingDayRef does not resolve to a declared OperatingDay",
+				Severity: types.ERROR,
+			},
+			sources: []string{"DatedServiceJourney"},
+			refPath: "OperatingDayRef",
+			targets: []string{"OperatingDay"},
+		},
+		{
+			rule: types.ValidationRule{
+				Code:     "BLOCK_REF_UNRESOLVED",
+				Name:     "Block unresolved VehicleJourneyRef",
+				Message:  "Block VehicleJourneyRef does not resolve to a declared ServiceJourney, DatedServiceJourney or DeadRun",
+				Severity: types.ERROR,
+			},
+			sources: []string{"Block"},
+			refPath: "journeys/VehicleJourneyRef",
+			targets: []string{"ServiceJourney", "DatedServiceJourney", "DeadRun"},
+		},
+		{
+			rule: types.ValidationRule{
+				Code:     "GROUP_OF_LINES_REF_UNRESOLVED",
+				Name:     "GroupOfLines unresolved member LineRef",
+				Message:  "GroupOfLines member LineRef does not resolve to a declared Line",
+				Severity: types.ERROR,
+			},
+			sources: []string{"GroupOfLines"},
+			refPath: "members/LineRef",
+			targets: []string{"Line", "FlexibleLine"},
+		},
+		{
+			rule: types.ValidationRule{
+				Code:     "VEHICLE_TYPE_REF_UNRESOLVED",
+				Name:     "Unresolved VehicleTypeRef",
+				Message:  "VehicleTypeRef does not resolve to a declared VehicleType",
+				Severity: types.ERROR,
+			},
+			sources: []string{"Vehicle", "ServiceJourney", "DatedServiceJourney", "DeadRun"},
+			refPath: "VehicleTypeRef",
+			targets: []string{"VehicleType"},
+		},
+		{
+			rule: types.ValidationRule{
+				Code:     "ROUTE_STOP_POINT_REF_UNRESOLVED",
+				Name:     "Route unresolved PointOnRoute ScheduledStopPointRef",
+				Message:  "PointOnRoute ScheduledStopPointRef does not resolve to a declared ScheduledStopPoint",
+				Severity: types.ERROR,
+			},
+			sources: []string{"Route"},
+			refPath: "pointsInSequence/PointOnRoute/ScheduledStopPointRef",
+			targets: []string{"ScheduledStopPoint"},
+		},
+		{
+			rule: types.ValidationRule{
+				Code:     "NETWORK_AUTHORITY_REF_UNRESOLVED",
+				Name:     "Network unresolved AuthorityRef",
+				Message:  "Network AuthorityRef does not resolve to a declared Authority",
+				Severity: types.ERROR,
+			},
+			sources: []string{"Network"},
+			refPath: "AuthorityRef",
+			targets: []string{"Authority"},
+		},
+		{
+			rule: types.ValidationRule{
+				Code:     "LINE_GROUP_REF_UNRESOLVED",
+				Name:     "Line unresolved RepresentedByGroupRef",
+				Message:  "Line RepresentedByGroupRef does not resolve to a declared Network or GroupOfLines",
+				Severity: types.ERROR,
+			},
+			sources: []string{"Line", "FlexibleLine"},
+			refPath: "RepresentedByGroupRef",
+			targets: []string{"Network", "GroupOfLines"},
+		},
+	}
+
+	rules := make([]types.ValidationRule, 0, len(checks))
+	for _, check := range checks {
+		rules = append(rules, check.rule)
+	}
+
+	return &TypedReferenceValidator{
+		checks:   checks,
+		declared: make(map[string]map[string]struct{}),
+		rules:    rules,
+	}
+}
+
+// Collect records the declared target elements and the typed references in a file
+func (v *TypedReferenceValidator) Collect(ctx context.XPathValidationContext) error {
+	if ctx.Document == nil {
+		return nil
+	}
+
+	declared := make(map[string][]string)
+	var references []collectedReference
+	for i, check := range v.checks {
+		for _, target := range check.targets {
+			if _, seen := declared[target]; seen {
+				continue
+			}
+			declared[target] = nil
+			for _, node := range xmlquery.Find(ctx.Document, "//"+target+"[@id]") {
+				declared[target] = append(declared[target], node.SelectAttr("id"))
+			}
+		}
+
+		for _, source := range check.sources {
+			for _, node := range xmlquery.Find(ctx.Document, "//"+source+"[@id]") {
+				for _, refNode := range xmlquery.Find(node, check.refPath) {
+					ref := refValue(refNode)
+					if ref == "" {
+						continue
+					}
+					references = append(references, collectedReference{
+						check:      i,
+						sourceType: source,
+						sourceID:   node.SelectAttr("id"),
+						ref:        ref,
+						fileName:   ctx.GetFileName(),
+						xpath:      utils.NodeXPath(node),
+					})
+				}
+			}
+		}
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	for target, ids := range declared {
+		if v.declared[target] == nil {
+			v.declared[target] = make(map[string]struct{})
+		}
+		for _, id := range ids {
+			v.declared[target][id] = struct{}{}
+		}
+	}
+	v.references = append(v.references, references...)
+	return nil
+}
+
+// Validate reports references that do not resolve to an element of the expected type.
+// The ID repository tells a reference to another element type apart from one whose
+// target is not declared at all.
+func (v *TypedReferenceValidator) Validate(repository interfaces.IdRepository) ([]types.ValidationIssue, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	var known map[string]types.IdVersion
+	if repository != nil {
+		known = repository.GetAllIds()
+	}
+
+	sort.SliceStable(v.references, func(i, j int) bool {
+		if v.references[i].sourceID != v.references[j].sourceID {
+			return v.references[i].sourceID < v.references[j].sourceID
+		}
+		return v.references[i].ref < v.references[j].ref
+	})
+
+	var issues []types.ValidationIssue
+	for _, reference := range v.references {
+		check := v.checks[reference.check]
+		if v.isDeclared(check, reference.ref) {
+			continue
+		}
+
+		detail := "which is not declared in the dataset"
+		if _, exists := known[reference.ref]; exists {
+			detail = fmt.Sprintf("but that id is not of type %s", check.targetName())
+		}
+		issues = append(issues, types.ValidationIssue{
+			Rule: check.rule,
+			Location: types.DataLocation{
+				FileName:  reference.fileName,
+				XPath:     reference.xpath,
+				ElementID: reference.sourceID,
+			},
+			Message: fmt.Sprintf("%s '%s' references %s '%s' %s",
+				reference.sourceType, reference.sourceID, check.targetName(), reference.ref, detail),
+		})
+	}
+
+	return issues, nil
+}
+
+// isDeclared reports whether ref is declared as one of the check's target types
+func (v *TypedReferenceValidator) isDeclared(check typedReference, ref string) bool {
+	for _, target := range check.targets {
+		if _, ok := v.declared[target][ref]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// GetRules returns the rules implemented by this validator
+func (v *TypedReferenceValidator) GetRules() []types.ValidationRule {
+	return v.rules
+}
+
+// Reset clears all collected data
+func (v *TypedReferenceValidator) Reset() {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.declared = make(map[string]map[string]struct{})
+	v.references = nil
+}
@@ -0,0 +1,553 @@
+package business
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/theoremus-urban-solutions/netex-validator/types"
+	"github.com/theoremus-urban-solutions/netex-validator/validation/ids"
+)
+
+func TestTypedReferenceValidator_OperatingDayRef(t *testing.T) {
+	calendar := `<?xml version="1.0" encoding="UTF-8"?>
+<PublicationDelivery xmlns="http://www.netex.org.uk/netex" version="1.15">
+	<dataObjects>
+		<ServiceCalendarFrame id="TEST:ServiceCalendarFrame:1" version="1">
+			<operatingDays>
+				<OperatingDay id="TEST:OperatingDay:2024-01-01" version="1"><CalendarDate>2024-01-01</CalendarDate></OperatingDay>
+			</operatingDays>
+			<dayTypes>
+				<DayType id="TEST:DayType:Weekday" version="1"/>
+			</dayTypes>
+		</ServiceCalendarFrame>
+	</dataObjects>
+</PublicationDelivery>`
+
+	timetable := `<?xml version="1.0" encoding="UTF-8"?>
+<PublicationDelivery xmlns="http://www.netex.org.uk/netex" version="1.15">
+	<dataObjects>
+		<TimetableFrame id="TEST:TimetableFrame:1" version="1">
+			<vehicleJourneys>
+				<DatedServiceJourney id="TEST:DatedServiceJourney:Valid" version="1">
+					<OperatingDayRef ref="TEST:OperatingDay:2024-01-01"/>
+				</DatedServiceJourney>
+				<DatedServiceJourney id="TEST:DatedServiceJourney:Dangling" version="1">
+					<OperatingDayRef ref="TEST:OperatingDay:2024-01-02"/>
+				</DatedServiceJourney>
+				<DatedServiceJourney id="TEST:DatedServiceJourney:WrongType" version="1">
+					<OperatingDayRef ref="TEST:DayType:Weekday"/>
+				</DatedServiceJourney>
+			</vehicleJourneys>
+		</TimetableFrame>
+	</dataObjects>
+</PublicationDelivery>`
+
+	repository := ids.NewNetexIdRepository()
+	for _, id := range []string{"TEST:OperatingDay:2024-01-01", "TEST:DayType:Weekday"} {
+		if err := repository.AddId(id, "1", "calendar.xml"); err != nil {
+			t.Fatalf("AddId() error = %v", err)
+		}
+	}
+
+	validator := NewTypedReferenceValidator()
+	if err := validator.Collect(newTestXPathContext(t, "timetable.xml", timetable)); err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+	if err := validator.Collect(newTestXPathContext(t, "calendar.xml", calendar)); err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+
+	issues, err := validator.Validate(repository)
+	if err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if len(issues) != 2 {
+		t.Fatalf("Expected 2 issues, got %d: %+v", len(issues), issues)
+	}
+
+	expected := []struct {
+		journey string
+		message string
+	}{
+		{"TEST:DatedServiceJourney:Dangling", "OperatingDay 'TEST:OperatingDay:2024-01-02' which is not declared in the dataset"},
+		{"TEST:DatedServiceJourney:WrongType", "OperatingDay 'TEST:DayType:Weekday' but that id is not of type OperatingDay"},
+	}
+	for i, want := range expected {
+		issue := issues[i]
+		if issue.Rule.Code != "DATED_SERVICE_JOURNEY_3" || issue.Rule.Severity != types.ERROR {
+			t.Errorf("Expected DATED_SERVICE_JOURNEY_3 ERROR, got %s %v", issue.Rule.Code, issue.Rule.Severity)
+		}
+		if issue.Location.ElementID != want.journey || issue.Location.FileName != "timetable.xml" {
+			t.Errorf("Expected %s in timetable.xml, got %s in %s", want.journey, issue.Location.ElementID, issue.Location.FileName)
+		}
+		if !strings.Contains(issue.Message, want.message) {
+			t.Errorf("Expected message to contain %q, got %q", want.message, issue.Message)
+		}
+	}
+
+	validator.Reset()
+	issues, err = validator.Validate(repository)
+	if err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if len(issues) != 0 {
+		t.Errorf("Expected no issues after Reset(), got %d", len(issues))
+	}
+}
+
+func TestTypedReferenceValidator_BlockVehicleJourneyRef(t *testing.T) {
+	journeys := `<?xml version="1.0" encoding="UTF-8"?>
+<PublicationDelivery xmlns="http://www.netex.org.uk/netex" version="1.15">
+	<dataObjects>
+		<TimetableFrame id="TEST:TimetableFrame:1" version="1">
+			<vehicleJourneys>
+				<ServiceJourney id="TEST:ServiceJourney:1" version="1"/>
+				<DatedServiceJourney id="TEST:DatedServiceJourney:1" version="1"/>
+				<DeadRun id="TEST:DeadRun:1" version="1"/>
+			</vehicleJourneys>
+		</TimetableFrame>
+		<ServiceFrame id="TEST:ServiceFrame:1" version="1">
+			<lines>
+				<Line id="TEST:Line:1" version="1"/>
+			</lines>
+		</ServiceFrame>
+	</dataObjects>
+</PublicationDelivery>`
+
+	blocks := `<?xml version="1.0" encoding="UTF-8"?>
+<PublicationDelivery xmlns="http://www.netex.org.uk/netex" version="1.15">
+	<dataObjects>
+		<VehicleScheduleFrame id="TEST:VehicleScheduleFrame:1" version="1">
+			<blocks>
+				<Block id="TEST:Block:1" version="1">
+					<journeys>
+						<VehicleJourneyRef ref="TEST:ServiceJourney:1"/>
+						<VehicleJourneyRef ref="TEST:DatedServiceJourney:1"/>
+						<VehicleJourneyRef ref="TEST:DeadRun:1"/>
+						<VehicleJourneyRef ref="TEST:ServiceJourney:Missing"/>
+					</journeys>
+				</Block>
+				<Block id="TEST:Block:2" version="1">
+					<journeys>
+						<VehicleJourneyRef ref="TEST:Line:1"/>
+					</journeys>
+				</Block>
+			</blocks>
+		</VehicleScheduleFrame>
+	</dataObjects>
+</PublicationDelivery>`
+
+	repository := ids.NewNetexIdRepository()
+	for _, id := range []string{"TEST:ServiceJourney:1", "TEST:DatedServiceJourney:1", "TEST:DeadRun:1", "TEST:Line:1"} {
+		if err := repository.AddId(id, "1", "journeys.xml"); err != nil {
+			t.Fatalf("AddId() error = %v", err)
+		}
+	}
+
+	validator := NewTypedReferenceValidator()
+	if err := validator.Collect(newTestXPathContext(t, "blocks.xml", blocks)); err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+	if err := validator.Collect(newTestXPathContext(t, "journeys.xml", journeys)); err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+
+	issues, err := validator.Validate(repository)
+	if err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if len(issues) != 2 {
+		t.Fatalf("Expected 2 issues, got %d: %+v", len(issues), issues)
+	}
+
+	expected := []struct {
+		block   string
+		message string
+	}{
+		{"TEST:Block:1", "'TEST:ServiceJourney:Missing' which is not declared in the dataset"},
+		{"TEST:Block:2", "'TEST:Line:1' but that id is not of type ServiceJourney/DatedServiceJourney/DeadRun"},
+	}
+	for i, want := range expected {
+		issue := issues[i]
+		if issue.Rule.Code != "BLOCK_REF_UNRESOLVED" || issue.Rule.Severity != types.ERROR {
+			t.Errorf("Expected BLOCK_REF_UNRESOLVED ERROR, got %s %v", issue.Rule.Code, issue.Rule.Severity)
+		}
+		if issue.Location.ElementID != want.block || issue.Location.FileName != "blocks.xml" {
+			t.Errorf("Expected %s in blocks.xml, got %s in %s", want.block, issue.Location.ElementID, issue.Location.FileName)
+		}
+		if !strings.Contains(issue.Message, want.message) {
+			t.Errorf("Expected message to contain %q, got %q", want.message, issue.Message)
+		}
+	}
+}
+
+func TestTypedReferenceValidator_GroupOfLinesMembers(t *testing.T) {
+	network := `<?xml version="1.0" encoding="UTF-8"?>
+<PublicationDelivery xmlns="http://www.netex.org.uk/netex" version="1.15">
+	<dataObjects>
+		<ServiceFrame id="TEST:ServiceFrame:Common" version="1">
+			<Network id="TEST:Network:1" version="1">
+				<groupsOfLines>
+					<GroupOfLines id="TEST:GroupOfLines:1" version="1">
+						<members>
+							<LineRef ref="TEST:Line:1"/>
+							<LineRef ref="TEST:FlexibleLine:1"/>
+							<LineRef ref="TEST:Line:Missing"/>
+						</members>
+					</GroupOfLines>
+				</groupsOfLines>
+			</Network>
+		</ServiceFrame>
+	</dataObjects>
+</PublicationDelivery>`
+
+	lines := `<?xml version="1.0" encoding="UTF-8"?>
+<PublicationDelivery xmlns="http://www.netex.org.uk/netex" version="1.15">
+	<dataObjects>
+		<ServiceFrame id="TEST:ServiceFrame:1" version="1">
+			<lines>
+				<Line id="TEST:Line:1" version="1"/>
+				<FlexibleLine id="TEST:FlexibleLine:1" version="1"/>
+			</lines>
+		</ServiceFrame>
+	</dataObjects>
+</PublicationDelivery>`
+
+	repository := ids.NewNetexIdRepository()
+	for _, id := range []string{"TEST:Line:1", "TEST:FlexibleLine:1"} {
+		if err := repository.AddId(id, "1", "line.xml"); err != nil {
+			t.Fatalf("AddId() error = %v", err)
+		}
+	}
+
+	validator := NewTypedReferenceValidator()
+	if err := validator.Collect(newTestXPathContext(t, "_common.xml", network)); err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+	if err := validator.Collect(newTestXPathContext(t, "line.xml", lines)); err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+
+	issues, err := validator.Validate(repository)
+	if err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if len(issues) != 1 {
+		t.Fatalf("Expected 1 issue, got %d: %+v", len(issues), issues)
+	}
+
+	issue := issues[0]
+	if issue.Rule.Code != "GROUP_OF_LINES_REF_UNRESOLVED" || issue.Rule.Severity != types.ERROR {
+		t.Errorf("Expected GROUP_OF_LINES_REF_UNRESOLVED ERROR, got %s %v", issue.Rule.Code, issue.Rule.Severity)
+	}
+	if issue.Location.ElementID != "TEST:GroupOfLines:1" || issue.Location.FileName != "_common.xml" {
+		t.Errorf("Expected TEST:GroupOfLines:1 in _common.xml, got %s in %s", issue.Location.ElementID, issue.Location.FileName)
+	}
+	if !strings.Contains(issue.Message, "'TEST:Line:Missing' which is not declared in the dataset") {
+		t.Errorf("Expected message to name the dangling LineRef, got %q", issue.Message)
+	}
+}
+
+func TestTypedReferenceValidator_VehicleTypeRef(t *testing.T) {
+	resources := `<?xml version="1.0" encoding="UTF-8"?>
+<PublicationDelivery xmlns="http://www.netex.org.uk/netex" version="1.15">
+	<dataObjects>
+		<ResourceFrame id="TEST:ResourceFrame:1" version="1">
+			<vehicleTypes>
+				<VehicleType id="TEST:VehicleType:Bus" version="1"/>
+			</vehicleTypes>
+			<vehicles>
+				<Vehicle id="TEST:Vehicle:1" version="1">
+					<VehicleTypeRef ref="TEST:VehicleType:Bus"/>
+				</Vehicle>
+				<Vehicle id="TEST:Vehicle:2" version="1">
+					<VehicleTypeRef ref="TEST:VehicleType:Tram"/>
+				</Vehicle>
+			</vehicles>
+		</ResourceFrame>
+	</dataObjects>
+</PublicationDelivery>`
+
+	journeys := `<?xml version="1.0" encoding="UTF-8"?>
+<PublicationDelivery xmlns="http://www.netex.org.uk/netex" version="1.15">
+	<dataObjects>
+		<TimetableFrame id="TEST:TimetableFrame:1" version="1">
+			<vehicleJourneys>
+				<ServiceJourney id="TEST:ServiceJourney:1" version="1">
+					<VehicleTypeRef ref="TEST:VehicleType:Bus"/>
+				</ServiceJourney>
+				<DeadRun id="TEST:DeadRun:1" version="1">
+					<VehicleTypeRef ref="TEST:ServiceJourney:1"/>
+				</DeadRun>
+			</vehicleJourneys>
+		</TimetableFrame>
+	</dataObjects>
+</PublicationDelivery>`
+
+	repository := ids.NewNetexIdRepository()
+	for _, id := range []string{"TEST:VehicleType:Bus", "TEST:ServiceJourney:1"} {
+		if err := repository.AddId(id, "1", "resources.xml"); err != nil {
+			t.Fatalf("AddId() error = %v", err)
+		}
+	}
+
+	validator := NewTypedReferenceValidator()
+	if err := validator.Collect(newTestXPathContext(t, "resources.xml", resources)); err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+	if err := validator.Collect(newTestXPathContext(t, "journeys.xml", journeys)); err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+
+	issues, err := validator.Validate(repository)
+	if err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if len(issues) != 2 {
+		t.Fatalf("Expected 2 issues, got %d: %+v", len(issues), issues)
+	}
+
+	expected := map[string]string{
+		"TEST:DeadRun:1": "DeadRun 'TEST:DeadRun:1' references VehicleType 'TEST:ServiceJourney:1' but that id is not of type VehicleType",
+		"TEST:Vehicle:2": "Vehicle 'TEST:Vehicle:2' references VehicleType 'TEST:VehicleType:Tram' which is not declared in the dataset",
+	}
+	for _, issue := range issues {
+		if issue.Rule.Code != "VEHICLE_TYPE_REF_UNRESOLVED" || issue.Rule.Severity != types.ERROR {
+			t.Errorf("Expected VEHICLE_TYPE_REF_UNRESOLVED ERROR, got %s %v", issue.Rule.Code, issue.Rule.Severity)
+		}
+		if message, ok := expected[issue.Location.ElementID]; !ok || issue.Message != message {
+			t.Errorf("Unexpected issue on %s: %q", issue.Location.ElementID, issue.Message)
+		}
+	}
+}
+
+func TestTypedReferenceValidator_PointOnRouteStopPointRef(t *testing.T) {
+	stopPoints := `<?xml version="1.0" encoding="UTF-8"?>
+<PublicationDelivery xmlns="http://www.netex.org.uk/netex" version="1.15">
+	<dataObjects>
+		<ServiceFrame id="TEST:ServiceFrame:Common" version="1">
+			<scheduledStopPoints>
+				<ScheduledStopPoint id="TEST:ScheduledStopPoint:1" version="1"/>
+				<ScheduledStopPoint id="TEST:ScheduledStopPoint:2" version="1"/>
+			</scheduledStopPoints>
+		</ServiceFrame>
+	</dataObjects>
+</PublicationDelivery>`
+
+	routes := `<?xml version="1.0" encoding="UTF-8"?>
+<PublicationDelivery xmlns="http://www.netex.org.uk/netex" version="1.15">
+	<dataObjects>
+		<ServiceFrame id="TEST:ServiceFrame:1" version="1">
+			<routes>
+				<Route id="TEST:Route:Valid" version="1">
+					<pointsInSequence>
+						<PointOnRoute id="TEST:PointOnRoute:V1" version="1" order="1">
+							<ScheduledStopPointRef ref="TEST:ScheduledStopPoint:1"/>
+						</PointOnRoute>
+						<PointOnRoute id="TEST:PointOnRoute:V2" version="1" order="2">
+							<ScheduledStopPointRef ref="TEST:ScheduledStopPoint:2"/>
+						</PointOnRoute>
+					</pointsInSequence>
+				</Route>
+				<Route id="TEST:Route:Dangling" version="1">
+					<pointsInSequence>
+						<PointOnRoute id="TEST:PointOnRoute:D1" version="1" order="1">
+							<ScheduledStopPointRef ref="TEST:ScheduledStopPoint:1"/>
+						</PointOnRoute>
+						<PointOnRoute id="TEST:PointOnRoute:D2" version="1" order="2">
+							<ScheduledStopPointRef ref="TEST:ScheduledStopPoint:Missing"/>
+						</PointOnRoute>
+					</pointsInSequence>
+				</Route>
+			</routes>
+		</ServiceFrame>
+	</dataObjects>
+</PublicationDelivery>`
+
+	validator := NewTypedReferenceValidator()
+	if err := validator.Collect(newTestXPathContext(t, "routes.xml", routes)); err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+	if err := validator.Collect(newTestXPathContext(t, "_common.xml", stopPoints)); err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+
+	issues, err := validator.Validate(ids.NewNetexIdRepository())
+	if err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if len(issues) != 1 {
+		t.Fatalf("Expected 1 issue, got %d: %+v", len(issues), issues)
+	}
+
+	issue := issues[0]
+	if issue.Rule.Code != "ROUTE_STOP_POINT_REF_UNRESOLVED" || issue.Rule.Severity != types.ERROR {
+		t.Errorf("Expected ROUTE_STOP_POINT_REF_UNRESOLVED ERROR, got %s %v", issue.Rule.Code, issue.Rule.Severity)
+	}
+	if issue.Location.ElementID != "TEST:Route:Dangling" || issue.Location.FileName != "routes.xml" {
+		t.Errorf("Expected issue on TEST:Route:Dangling in routes.xml, got %+v", issue.Location)
+	}
+	expected := "Route 'TEST:Route:Dangling' references ScheduledStopPoint 'TEST:ScheduledStopPoint:Missing' which is not declared in the dataset"
+	if issue.Message != expected {
+		t.Errorf("Expected message %q, got %q", expected, issue.Message)
+	}
+}
+
+func TestTypedReferenceValidator_NetworkAuthorityRef(t *testing.T) {
+	common := `<?xml version="1.0" encoding="UTF-8"?>
+<PublicationDelivery xmlns="http://www.netex.org.uk/netex" version="1.15">
+	<dataObjects>
+		<ResourceFrame id="TEST:ResourceFrame:Common" version="1">
+			<organisations>
+				<Authority id="TEST:Authority:1" version="1"/>
+				<Operator id="TEST:Operator:1" version="1"/>
+			</organisations>
+		</ResourceFrame>
+	</dataObjects>
+</PublicationDelivery>`
+
+	networks := `<?xml version="1.0" encoding="UTF-8"?>
+<PublicationDelivery xmlns="http://www.netex.org.uk/netex" version="1.15">
+	<dataObjects>
+		<ServiceFrame id="TEST:ServiceFrame:1" version="1">
+			<Network id="TEST:Network:Valid" version="1">
+				<AuthorityRef ref="TEST:Authority:1"/>
+			</Network>
+			<Network id="TEST:Network:Dangling" version="1">
+				<AuthorityRef ref="TEST:Authority:Missing"/>
+			</Network>
+			<Network id="TEST:Network:WrongType" version="1">
+				<AuthorityRef ref="TEST:Operator:1"/>
+			</Network>
+		</ServiceFrame>
+	</dataObjects>
+</PublicationDelivery>`
+
+	repository := ids.NewNetexIdRepository()
+	for _, id := range []string{"TEST:Authority:1", "TEST:Operator:1"} {
+		if err := repository.AddId(id, "1", "_common.xml"); err != nil {
+			t.Fatalf("AddId() error = %v", err)
+		}
+	}
+
+	validator := NewTypedReferenceValidator()
+	if err := validator.Collect(newTestXPathContext(t, "networks.xml", networks)); err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+	if err := validator.Collect(newTestXPathContext(t, "_common.xml", common)); err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+
+	issues, err := validator.Validate(repository)
+	if err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if len(issues) != 2 {
+		t.Fatalf("Expected 2 issues, got %d: %+v", len(issues), issues)
+	}
+
+	expected := []struct {
+		network string
+		message string
+	}{
+		{"TEST:Network:Dangling", "Network 'TEST:Network:Dangling' references Authority 'TEST:Authority:Missing' which is not declared in the dataset"},
+		{"TEST:Network:WrongType", "Network 'TEST:Network:WrongType' references Authority 'TEST:Operator:1' but that id is not of type Authority"},
+	}
+	for i, want := range expected {
+		issue := issues[i]
+		if issue.Rule.Code != "NETWORK_AUTHORITY_REF_UNRESOLVED" || issue.Rule.Severity != types.ERROR {
+			t.Errorf("Expected NETWORK_AUTHORITY_REF_UNRESOLVED ERROR, got %s %v", issue.Rule.Code, issue.Rule.Severity)
+		}
+		if issue.Location.ElementID != want.network || issue.Location.FileName != "networks.xml" {
+			t.Errorf("Expected %s in networks.xml, got %s in %s", want.network, issue.Location.ElementID, issue.Location.FileName)
+		}
+		if issue.Message != want.message {
+			t.Errorf("Expected message %q, got %q", want.message, issue.Message)
+		}
+	}
+}
+
+func TestTypedReferenceValidator_LineRepresentedByGroupRef(t *testing.T) {
+	common := `<?xml version="1.0" encoding="UTF-8"?>
+<PublicationDelivery xmlns="http://www.netex.org.uk/netex" version="1.15">
+	<dataObjects>
+		<ServiceFrame id="TEST:ServiceFrame:Common" version="1">
+			<Network id="TEST:Network:1" version="1">
+				<groupsOfLines>
+					<GroupOfLines id="TEST:GroupOfLines:1" version="1"/>
+				</groupsOfLines>
+			</Network>
+		</ServiceFrame>
+		<ResourceFrame id="TEST:ResourceFrame:Common" version="1">
+			<organisations>
+				<Operator id="TEST:Operator:1" version="1"/>
+			</organisations>
+		</ResourceFrame>
+	</dataObjects>
+</PublicationDelivery>`
+
+	lines := `<?xml version="1.0" encoding="UTF-8"?>
+<PublicationDelivery xmlns="http://www.netex.org.uk/netex" version="1.15">
+	<dataObjects>
+		<ServiceFrame id="TEST:ServiceFrame:1" version="1">
+			<lines>
+				<Line id="TEST:Line:Network" version="1">
+					<RepresentedByGroupRef ref="TEST:Network:1"/>
+				</Line>
+				<Line id="TEST:Line:Group" version="1">
+					<RepresentedByGroupRef ref="TEST:GroupOfLines:1"/>
+				</Line>
+				<FlexibleLine id="TEST:FlexibleLine:Dangling" version="1">
+					<RepresentedByGroupRef ref="TEST:Network:Missing"/>
+				</FlexibleLine>
+				<Line id="TEST:Line:WrongType" version="1">
+					<RepresentedByGroupRef ref="TEST:Operator:1"/>
+				</Line>
+			</lines>
+		</ServiceFrame>
+	</dataObjects>
+</PublicationDelivery>`
+
+	repository := ids.NewNetexIdRepository()
+	for _, id := range []string{"TEST:Network:1", "TEST:GroupOfLines:1", "TEST:Operator:1"} {
+		if err := repository.AddId(id, "1", "_common.xml"); err != nil {
+			t.Fatalf("AddId() error = %v", err)
+		}
+	}
+
+	validator := NewTypedReferenceValidator()
+	if err := validator.Collect(newTestXPathContext(t, "lines.xml", lines)); err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+	if err := validator.Collect(newTestXPathContext(t, "_common.xml", common)); err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+
+	issues, err := validator.Validate(repository)
+	if err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if len(issues) != 2 {
+		t.Fatalf("Expected 2 issues, got %d: %+v", len(issues), issues)
+	}
+
+	expected := []struct {
+		line    string
+		message string
+	}{
+		{"TEST:FlexibleLine:Dangling", "FlexibleLine 'TEST:FlexibleLine:Dangling' references Network/GroupOfLines 'TEST:Network:Missing' which is not declared in the dataset"},
+		{"TEST:Line:WrongType", "Line 'TEST:Line:WrongType' references Network/GroupOfLines 'TEST:Operator:1' but that id is not of type Network/GroupOfLines"},
+	}
+	for i, want := range expected {
+		issue := issues[i]
+		if issue.Rule.Code != "LINE_GROUP_REF_UNRESOLVED" || issue.Rule.Severity != types.ERROR {
+			t.Errorf("Expected LINE_GROUP_REF_UNRESOLVED ERROR, got %s %v", issue.Rule.Code, issue.Rule.Severity)
+		}
+		if issue.Location.ElementID != want.line || issue.Location.FileName != "lines.xml" {
+			t.Errorf("Expected %s in lines.xml, got %s in %s", want.line, issue.Location.ElementID, issue.Location.FileName)
+		}
+		if issue.Message != want.message {
+			t.Errorf("Expected message %q, got %q", want.message, issue.Message)
+		}
+	}
+}
@@ -0,0 +1,132 @@
+package business
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/antchfx/xmlquery"
+	"github.com/theoremus-urban-solutions/netex-validator/interfaces"
+	"github.com/theoremus-urban-solutions/netex-validator/types"
+	"github.com/theoremus-urban-solutions/netex-validator/utils"
+	"github.com/theoremus-urban-solutions/netex-validator/validation/context"
+)
+
+// lineRouteDirections counts the Routes of a Line and those with a DirectionType
+type lineRouteDirections struct {
+	routes   int
+	directed int
+}
+
+// UndirectedRouteValidator reports Lines with more than one Route where no Route has
+// a DirectionType, leaving the directionality of the Line undefined. ROUTE_7 reports
+// each such Route on its own; this check raises one finding per Line, so the Line
+// stands out among the per-Route findings. Routes and their Lines may be declared in
+// different files, so Routes are grouped by their LineRef once the dataset is
+// collected.
+type UndirectedRouteValidator struct {
+	mu     sync.Mutex
+	lines  map[string]lineLocation        // line id -> declaration
+	routes map[string]lineRouteDirections // line id -> route counts
+	rules  []types.ValidationRule
+}
+
+// NewUndirectedRouteValidator creates a new undirected route validator
+func NewUndirectedRouteValidator() *UndirectedRouteValidator {
+	return &UndirectedRouteValidator{
+		lines:  make(map[string]lineLocation),
+		routes: make(map[string]lineRouteDirections),
+		rules: []types.ValidationRule{
+			{
+				Code:     "ROUTE_DIRECTIONS_UNDEFINED",
+				Name:     "Line Routes without DirectionType",
+				Message:  "None of the Routes of the Line has a DirectionType",
+				Severity: types.WARNING,
+			},
+		},
+	}
+}
+
+// Collect records the Lines in a file and whether each Route has a DirectionType
+func (v *UndirectedRouteValidator) Collect(ctx context.XPathValidationContext) error {
+	if ctx.Document == nil {
+		return nil
+	}
+
+	lines := make(map[string]lineLocation)
+	for _, node := range xmlquery.Find(ctx.Document, "//lines/*[self::Line or self::FlexibleLine][@id]") {
+		lines[node.SelectAttr("id")] = lineLocation{fileName: ctx.GetFileName(), xpath: utils.NodeXPath(node)}
+	}
+
+	routes := make(map[string]lineRouteDirections)
+	for _, node := range xmlquery.Find(ctx.Document, "//routes/Route[@id]") {
+		line := childRef(node, "LineRef|FlexibleLineRef")
+		if line == "" {
+			continue
+		}
+		counts := routes[line]
+		counts.routes++
+		if childText(node, "DirectionType") != "" {
+			counts.directed++
+		}
+		routes[line] = counts
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	for id, location := range lines {
+		v.lines[id] = location
+	}
+	for line, counts := range routes {
+		total := v.routes[line]
+		total.routes += counts.routes
+		total.directed += counts.directed
+		v.routes[line] = total
+	}
+	return nil
+}
+
+// Validate reports every declared Line with more than one Route and no Route with a
+// DirectionType
+func (v *UndirectedRouteValidator) Validate(repository interfaces.IdRepository) ([]types.ValidationIssue, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	lineIDs := make([]string, 0, len(v.routes))
+	for id, counts := range v.routes {
+		if _, declared := v.lines[id]; declared && counts.routes > 1 && counts.directed == 0 {
+			lineIDs = append(lineIDs, id)
+		}
+	}
+	sort.Strings(lineIDs)
+
+	issues := make([]types.ValidationIssue, 0, len(lineIDs))
+	for _, id := range lineIDs {
+		location := v.lines[id]
+		issues = append(issues, types.ValidationIssue{
+			Rule: v.rules[0],
+			Location: types.DataLocation{
+				FileName:  location.fileName,
+				XPath:     location.xpath,
+				ElementID: id,
+			},
+			Message: fmt.Sprintf("Line '%s' has %d Routes and none of them has a DirectionType, so the directionality of the Line is undefined",
+				id, v.routes[id].routes),
+		})
+	}
+
+	return issues, nil
+}
+
+// GetRules returns the rules implemented by this validator
+func (v *UndirectedRouteValidator) GetRules() []types.ValidationRule {
+	return v.rules
+}
+
+// Reset clears all collected data
+func (v *UndirectedRouteValidator) Reset() {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.lines = make(map[string]lineLocation)
+	v.routes = make(map[string]lineRouteDirections)
+}
@@ -0,0 +1,74 @@
+package business
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/theoremus-urban-solutions/netex-validator/types"
+	"github.com/theoremus-urban-solutions/netex-validator/validation/ids"
+)
+
+func TestUndirectedRouteValidator(t *testing.T) {
+	lines := `<?xml version="1.0" encoding="UTF-8"?>
+<PublicationDelivery xmlns="http://www.netex.org.uk/netex" version="1.15">
+	<dataObjects>
+		<ServiceFrame id="TEST:ServiceFrame:1" version="1">
+			<lines>
+				<Line id="TEST:Line:Undirected" version="1"/>
+				<Line id="TEST:Line:PartlyDirected" version="1"/>
+				<Line id="TEST:Line:Single" version="1"/>
+			</lines>
+		</ServiceFrame>
+	</dataObjects>
+</PublicationDelivery>`
+
+	routes := `<?xml version="1.0" encoding="UTF-8"?>
+<PublicationDelivery xmlns="http://www.netex.org.uk/netex" version="1.15">
+	<dataObjects>
+		<ServiceFrame id="TEST:ServiceFrame:2" version="1">
+			<routes>
+				<Route id="TEST:Route:Undirected1" version="1"><LineRef ref="TEST:Line:Undirected"/></Route>
+				<Route id="TEST:Route:Undirected2" version="1"><LineRef ref="TEST:Line:Undirected"/></Route>
+				<Route id="TEST:Route:Undirected3" version="1"><LineRef ref="TEST:Line:Undirected"/></Route>
+				<Route id="TEST:Route:Partly1" version="1"><LineRef ref="TEST:Line:PartlyDirected"/><DirectionType>outbound</DirectionType></Route>
+				<Route id="TEST:Route:Partly2" version="1"><LineRef ref="TEST:Line:PartlyDirected"/></Route>
+				<Route id="TEST:Route:Single" version="1"><LineRef ref="TEST:Line:Single"/></Route>
+				<Route id="TEST:Route:Undeclared1" version="1"><LineRef ref="TEST:Line:Elsewhere"/></Route>
+				<Route id="TEST:Route:Undeclared2" version="1"><LineRef ref="TEST:Line:Elsewhere"/></Route>
+			</routes>
+		</ServiceFrame>
+	</dataObjects>
+</PublicationDelivery>`
+
+	validator := NewUndirectedRouteValidator()
+	if err := validator.Collect(newTestXPathContext(t, "routes.xml", routes)); err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+	if err := validator.Collect(newTestXPathContext(t, "lines.xml", lines)); err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+
+	issues, err := validator.Validate(ids.NewNetexIdRepository())
+	if err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if len(issues) != 1 {
+		t.Fatalf("Expected 1 issue, got %d: %+v", len(issues), issues)
+	}
+
+	issue := issues[0]
+	if issue.Rule.Code != "ROUTE_DIRECTIONS_UNDEFINED" || issue.Rule.Severity != types.WARNING {
+		t.Errorf("Expected ROUTE_DIRECTIONS_UNDEFINED warning, got %s (%v)", issue.Rule.Code, issue.Rule.Severity)
+	}
+	if issue.Location.ElementID != "TEST:Line:Undirected" || issue.Location.FileName != "lines.xml" {
+		t.Errorf("Expected issue on TEST:Line:Undirected in lines.xml, got %+v", issue.Location)
+	}
+	if !strings.Contains(issue.Message, "has 3 Routes") {
+		t.Errorf("Expected message to give the route count, got %q", issue.Message)
+	}
+
+	validator.Reset()
+	if issues, _ := validator.Validate(ids.NewNetexIdRepository()); len(issues) != 0 {
+		t.Errorf("Expected no issues after Reset(), got %d", len(issues))
+	}
+}
@@ -0,0 +1,33 @@
+package business
+
+import (
+	"strings"
+
+	"github.com/antchfx/xmlquery"
+)
+
+// refValue returns the id a *Ref element points at, read from @ref or, for the
+// text-content form, the element body
+func refValue(node *xmlquery.Node) string {
+	if node == nil {
+		return ""
+	}
+	if ref := node.SelectAttr("ref"); ref != "" {
+		return ref
+	}
+	return strings.TrimSpace(node.InnerText())
+}
+
+// childRef returns the id referenced by the first element matching path below node
+func childRef(node *xmlquery.Node, path string) string {
+	return refValue(xmlquery.FindOne(node, path))
+}
+
+// childText returns the trimmed text of the first element matching path below node
+func childText(node *xmlquery.Node, path string) string {
+	child := xmlquery.FindOne(node, path)
+	if child == nil {
+		return ""
+	}
+	return strings.TrimSpace(child.InnerText())
+}
@@ -57,6 +57,7 @@ type DataObjects struct {
 	SiteFrame            *SiteFrame            `xml:"SiteFrame"`
 	ServiceCalendarFrame *ServiceCalendarFrame `xml:"ServiceCalendarFrame"`
 	VehicleScheduleFrame *VehicleScheduleFrame `xml:"VehicleScheduleFrame"`
+	FareFrame            *FareFrame            `xml:"FareFrame"`
 }
 
 // CompositeFrame represents a NetEX composite frame
@@ -75,6 +76,7 @@ type Frames struct {
 	SiteFrame            *SiteFrame            `xml:"SiteFrame"`
 	ServiceCalendarFrame *ServiceCalendarFrame `xml:"ServiceCalendarFrame"`
 	VehicleScheduleFrame *VehicleScheduleFrame `xml:"VehicleScheduleFrame"`
+	FareFrame            *FareFrame            `xml:"FareFrame"`
 }
 
 // ResourceFrame contains organizational data
@@ -92,11 +94,14 @@ type ServiceFrame struct {
 	Networks            *Networks            `xml:"networks"`
 	Lines               *Lines               `xml:"lines"`
 	Routes              *Routes              `xml:"routes"`
+	RouteLinks          *RouteLinks          `xml:"routeLinks"`
+	ServiceLinks        *ServiceLinks        `xml:"serviceLinks"`
 	JourneyPatterns     *JourneyPatterns     `xml:"journeyPatterns"`
 	VehicleJourneys     *VehicleJourneys     `xml:"vehicleJourneys"`
 	ScheduledStopPoints *ScheduledStopPoints `xml:"scheduledStopPoints"`
 	StopAssignments     *StopAssignments     `xml:"stopAssignments"`
 	Interchanges        *Interchanges        `xml:"interchanges"`
+	GroupsOfServices    *GroupsOfServices    `xml:"groupsOfServices"`
 }
 
 // TimetableFrame contains timetable-related data
@@ -109,8 +114,9 @@ type TimetableFrame struct {
 // SiteFrame contains stop place data
 type SiteFrame struct {
 	BaseNetexObject
-	XMLName    xml.Name    `xml:"SiteFrame"`
-	StopPlaces *StopPlaces `xml:"stopPlaces"`
+	XMLName     xml.Name     `xml:"SiteFrame"`
+	StopPlaces  *StopPlaces  `xml:"stopPlaces"`
+	TariffZones *TariffZones `xml:"tariffZones"`
 }
 
 // ServiceCalendarFrame contains calendar data
@@ -129,6 +135,13 @@ type VehicleScheduleFrame struct {
 	Blocks  *Blocks  `xml:"blocks"`
 }
 
+// FareFrame contains fare data
+type FareFrame struct {
+	BaseNetexObject
+	XMLName   xml.Name   `xml:"FareFrame"`
+	FareZones *FareZones `xml:"fareZones"`
+}
+
 // Organisations contains operators and authorities
 type Organisations struct {
 	Operators   []*Operator  `xml:"Operator"`
@@ -139,6 +152,7 @@ type Organisations struct {
 type Operator struct {
 	BaseNetexObject
 	XMLName        xml.Name        `xml:"Operator"`
+	CompanyNumber  string          `xml:"CompanyNumber"`
 	Name           string          `xml:"Name"`
 	ShortName      string          `xml:"ShortName"`
 	ContactDetails *ContactDetails `xml:"ContactDetails"`
@@ -182,8 +196,38 @@ type GroupsOfLines struct {
 // GroupOfLines represents a group of lines
 type GroupOfLines struct {
 	BaseNetexObject
-	XMLName xml.Name `xml:"GroupOfLines"`
-	Name    string   `xml:"Name"`
+	XMLName xml.Name             `xml:"GroupOfLines"`
+	Name    string               `xml:"Name"`
+	Members *GroupOfLinesMembers `xml:"members"`
+}
+
+// GroupOfLinesMembers contains the lines of a group
+type GroupOfLinesMembers struct {
+	LineRefs         []*LineRef         `xml:"LineRef"`
+	FlexibleLineRefs []*FlexibleLineRef `xml:"FlexibleLineRef"`
+}
+
+// GroupsOfServices contains groups of services
+type GroupsOfServices struct {
+	GroupsOfServices []*GroupOfServices `xml:"GroupOfServices"`
+}
+
+// GroupOfServices represents a group of service journeys
+type GroupOfServices struct {
+	BaseNetexObject
+	XMLName xml.Name                `xml:"GroupOfServices"`
+	Name    string                  `xml:"Name"`
+	Members *GroupOfServicesMembers `xml:"members"`
+}
+
+// GroupOfServicesMembers contains the services of a group
+type GroupOfServicesMembers struct {
+	Members []*GroupOfServicesMember `xml:"GroupOfServicesMember"`
+}
+
+// GroupOfServicesMember references a service journey of a group
+type GroupOfServicesMember struct {
+	ServiceJourneyRef *ServiceJourneyRef `xml:"ServiceJourneyRef"`
 }
 
 // Lines contains line information
@@ -275,6 +319,33 @@ type PointOnRoute struct {
 	XMLName               xml.Name               `xml:"PointOnRoute"`
 	Order                 int                    `xml:"order,attr"`
 	ScheduledStopPointRef *ScheduledStopPointRef `xml:"ScheduledStopPointRef"`
+	IsWaitPoint           *bool                  `xml:"IsWaitPoint"`
+	ForAlighting          *bool                  `xml:"ForAlighting"`
+	ForBoarding           *bool                  `xml:"ForBoarding"`
+}
+
+// RouteLinks contains the links between route points
+type RouteLinks struct {
+	RouteLinks []*RouteLink `xml:"RouteLink"`
+}
+
+// RouteLink represents the link between two route points
+type RouteLink struct {
+	BaseNetexObject
+	XMLName  xml.Name `xml:"RouteLink"`
+	Distance string   `xml:"Distance"`
+}
+
+// ServiceLinks contains the links between scheduled stop points
+type ServiceLinks struct {
+	ServiceLinks []*ServiceLink `xml:"ServiceLink"`
+}
+
+// ServiceLink represents the link between two scheduled stop points
+type ServiceLink struct {
+	BaseNetexObject
+	XMLName  xml.Name `xml:"ServiceLink"`
+	Distance string   `xml:"Distance"`
 }
 
 // JourneyPatterns contains journey pattern information
@@ -312,6 +383,9 @@ type StopPointInJourneyPattern struct {
 	XMLName               xml.Name               `xml:"StopPointInJourneyPattern"`
 	Order                 int                    `xml:"order,attr"`
 	ScheduledStopPointRef *ScheduledStopPointRef `xml:"ScheduledStopPointRef"`
+	IsWaitPoint           *bool                  `xml:"IsWaitPoint"`
+	ForAlighting          *bool                  `xml:"ForAlighting"`
+	ForBoarding           *bool                  `xml:"ForBoarding"`
 }
 
 // VehicleJourneys contains service journeys
@@ -334,6 +408,7 @@ type ServiceJourney struct {
 	OperatorRef        *OperatorRef        `xml:"OperatorRef"`
 	FlexibleServiceRef *FlexibleServiceRef `xml:"FlexibleServiceRef"`
 	BlockRef           *BlockRef           `xml:"BlockRef"`
+	VehicleTypeRef     *VehicleTypeRef     `xml:"VehicleTypeRef"`
 	DayTypes           *DayTypes           `xml:"dayTypes"`
 	PassingTimes       *PassingTimes       `xml:"passingTimes"`
 }
@@ -344,15 +419,17 @@ type DatedServiceJourney struct {
 	XMLName           xml.Name           `xml:"DatedServiceJourney"`
 	ServiceJourneyRef *ServiceJourneyRef `xml:"ServiceJourneyRef"`
 	OperatingDayRef   *OperatingDayRef   `xml:"OperatingDayRef"`
+	VehicleTypeRef    *VehicleTypeRef    `xml:"VehicleTypeRef"`
 }
 
 // DeadRun represents a dead run
 type DeadRun struct {
 	BaseNetexObject
-	XMLName      xml.Name      `xml:"DeadRun"`
-	Name         string        `xml:"Name"`
-	RouteRef     *RouteRef     `xml:"RouteRef"`
-	PassingTimes *PassingTimes `xml:"passingTimes"`
+	XMLName        xml.Name        `xml:"DeadRun"`
+	Name           string          `xml:"Name"`
+	RouteRef       *RouteRef       `xml:"RouteRef"`
+	VehicleTypeRef *VehicleTypeRef `xml:"VehicleTypeRef"`
+	PassingTimes   *PassingTimes   `xml:"passingTimes"`
 }
 
 // PassingTimes contains timetabled passing times
@@ -519,6 +596,43 @@ type Journeys struct {
 	VehicleJourneyRefs []*VehicleJourneyRef `xml:"VehicleJourneyRef"`
 }
 
+// TariffZones contains tariff zones
+type TariffZones struct {
+	TariffZones []*TariffZone `xml:"TariffZone"`
+}
+
+// TariffZone represents a zone used to define fares
+type TariffZone struct {
+	BaseNetexObject
+	XMLName xml.Name     `xml:"TariffZone"`
+	Name    string       `xml:"Name"`
+	Members *ZoneMembers `xml:"members"`
+}
+
+// FareZones contains fare zones
+type FareZones struct {
+	FareZones []*FareZone `xml:"FareZone"`
+}
+
+// FareZone represents a tariff zone specialised for fare calculation
+type FareZone struct {
+	BaseNetexObject
+	XMLName xml.Name     `xml:"FareZone"`
+	Name    string       `xml:"Name"`
+	Members *ZoneMembers `xml:"members"`
+}
+
+// ZoneMembers contains the points and places a zone covers
+type ZoneMembers struct {
+	Refs []*ZoneMemberRef `xml:",any"`
+}
+
+// ZoneMemberRef references a zone member, e.g. a ScheduledStopPointRef or StopPlaceRef
+type ZoneMemberRef struct {
+	XMLName xml.Name
+	Ref     string `xml:"ref,attr"`
+}
+
 // VehicleTypes contains vehicle types
 type VehicleTypes struct {
 	VehicleTypes []*VehicleType `xml:"VehicleType"`
@@ -561,6 +675,10 @@ type LineRef struct {
 	Ref string `xml:"ref,attr"`
 }
 
+type FlexibleLineRef struct {
+	Ref string `xml:"ref,attr"`
+}
+
 type RouteRef struct {
 	Ref string `xml:"ref,attr"`
 }
@@ -585,6 +703,10 @@ type BlockRef struct {
 	Ref string `xml:"ref,attr"`
 }
 
+type VehicleTypeRef struct {
+	Ref string `xml:"ref,attr"`
+}
+
 type ScheduledStopPointRef struct {
 	Ref string `xml:"ref,attr"`
 }
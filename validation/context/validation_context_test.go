@@ -183,6 +183,7 @@ func TestObjectValidationContext_EntityParsing(t *testing.T) {
 		<ResourceFrame id="TEST:ResourceFrame:1" version="1">
 			<organisations>
 				<Operator id="TEST:Operator:1" version="1">
+					<CompanyNumber>912345678</CompanyNumber>
 					<Name>Test Operator</Name>
 				</Operator>
 				<Operator id="TEST:Operator:2" version="1">
@@ -238,6 +239,11 @@ func TestObjectValidationContext_EntityParsing(t *testing.T) {
 			t.Error("Expected to find operator TEST:Operator:1")
 		} else if operator1.ID != "TEST:Operator:1" {
 			t.Errorf("Expected operator ID TEST:Operator:1, got %s", operator1.ID)
+		} else if operator1.CompanyNumber != "912345678" {
+			t.Errorf("Expected CompanyNumber 912345678, got %q", operator1.CompanyNumber)
+		}
+		if operator2 := ctx.GetOperator("TEST:Operator:2"); operator2 != nil && operator2.CompanyNumber != "" {
+			t.Errorf("Expected no CompanyNumber on TEST:Operator:2, got %q", operator2.CompanyNumber)
 		}
 
 		// Test non-existent operator
@@ -0,0 +1,58 @@
+package engine
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+
+	"github.com/theoremus-urban-solutions/netex-validator/types"
+)
+
+// DefaultMaxXMLDepth is the element nesting depth accepted unless configured
+// otherwise. NeTEx documents rarely nest deeper than 20 levels.
+const DefaultMaxXMLDepth = 256
+
+// xmlTooDeepRule is reported for documents nested deeper than the configured limit
+var xmlTooDeepRule = types.ValidationRule{
+	Code:     "XML_TOO_DEEP",
+	Name:     "XML nesting too deep",
+	Message:  "Document nests elements deeper than the configured maximum depth",
+	Severity: types.CRITICAL,
+}
+
+// checkXMLDepth scans content token by token and returns an issue at the first
+// element nested deeper than maxDepth, before the document is parsed into a tree.
+// Malformed content is left to the parser to report.
+func checkXMLDepth(fileName string, content []byte, maxDepth int) *types.ValidationIssue {
+	if maxDepth <= 0 {
+		return nil
+	}
+
+	decoder := xml.NewDecoder(bytes.NewReader(content))
+	// RawToken skips namespace resolution and end tag matching, which the parser does later
+	depth := 0
+	for {
+		token, err := decoder.RawToken()
+		if err != nil {
+			return nil
+		}
+
+		switch token.(type) {
+		case xml.StartElement:
+			depth++
+			if depth > maxDepth {
+				line, _ := decoder.InputPos()
+				return &types.ValidationIssue{
+					Rule: xmlTooDeepRule,
+					Location: types.DataLocation{
+						FileName:   fileName,
+						LineNumber: line,
+					},
+					Message: fmt.Sprintf("Elements are nested more than %d levels deep; the document was not validated further", maxDepth),
+				}
+			}
+		case xml.EndElement:
+			depth--
+		}
+	}
+}
@@ -3,10 +3,14 @@ package engine
 import (
 	"archive/zip"
 	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strings"
 	"time"
 
@@ -18,6 +22,13 @@ import (
 	"github.com/theoremus-urban-solutions/netex-validator/validation/ids"
 )
 
+var (
+	// ErrParse is wrapped by errors caused by content that is not well-formed XML
+	ErrParse = errors.New("failed to parse XML")
+	// ErrBaselineDataset is wrapped by errors opening the configured baseline dataset
+	ErrBaselineDataset = errors.New("failed to open baseline dataset")
+)
+
 // EnhancedNetexValidatorsRunner orchestrates NetEX validation with improved architecture
 type EnhancedNetexValidatorsRunner struct {
 	schemaValidator    interfaces.SchemaValidator
@@ -28,6 +39,13 @@ type EnhancedNetexValidatorsRunner struct {
 	reportEntryFactory interfaces.ValidationReportEntryFactory
 	maxFindings        int
 	concurrentFiles    int
+	changedFiles       []string
+	baselineDataset    string
+	baselineFiles      map[string]bool
+	deterministic      bool
+	maxDepth           int
+	spillDir           string
+	spillThreshold     int
 }
 
 // EnhancedNetexValidatorsRunnerBuilder builds enhanced validator instances
@@ -40,6 +58,12 @@ type EnhancedNetexValidatorsRunnerBuilder struct {
 	reportEntryFactory interfaces.ValidationReportEntryFactory
 	maxFindings        int
 	concurrentFiles    int
+	changedFiles       []string
+	baselineDataset    string
+	deterministic      bool
+	maxDepth           int
+	spillDir           string
+	spillThreshold     int
 }
 
 // NewEnhancedNetexValidatorsRunnerBuilder creates a new enhanced builder
@@ -103,6 +127,42 @@ func (b *EnhancedNetexValidatorsRunnerBuilder) WithConcurrentFiles(n int) *Enhan
 	return b
 }
 
+// WithChangedFiles restricts full rule execution in ZIP datasets to the listed files.
+// All other files are only loaded for cross-file ID and dataset validation.
+func (b *EnhancedNetexValidatorsRunnerBuilder) WithChangedFiles(paths []string) *EnhancedNetexValidatorsRunnerBuilder {
+	b.changedFiles = paths
+	return b
+}
+
+// WithBaselineDataset sets the full dataset ZIP a delta dataset is validated against.
+// References from the delta into the baseline resolve instead of being reported.
+func (b *EnhancedNetexValidatorsRunnerBuilder) WithBaselineDataset(path string) *EnhancedNetexValidatorsRunnerBuilder {
+	b.baselineDataset = path
+	return b
+}
+
+// WithDeterministic runs validators one after another in registration order and
+// validates ZIP entries one at a time, so findings come out in the same order on every run
+func (b *EnhancedNetexValidatorsRunnerBuilder) WithDeterministic(deterministic bool) *EnhancedNetexValidatorsRunnerBuilder {
+	b.deterministic = deterministic
+	return b
+}
+
+// WithMaxDepth rejects documents nesting elements deeper than depth with a
+// CRITICAL XML_TOO_DEEP finding before they are parsed (0 = no limit)
+func (b *EnhancedNetexValidatorsRunnerBuilder) WithMaxDepth(depth int) *EnhancedNetexValidatorsRunnerBuilder {
+	b.maxDepth = depth
+	return b
+}
+
+// WithResultSpill makes ZIP and dataset reports move their findings to NDJSON files
+// in dir whenever more than threshold are held in memory (threshold 0 = never)
+func (b *EnhancedNetexValidatorsRunnerBuilder) WithResultSpill(dir string, threshold int) *EnhancedNetexValidatorsRunnerBuilder {
+	b.spillDir = dir
+	b.spillThreshold = threshold
+	return b
+}
+
 // Build creates the EnhancedNetexValidatorsRunner
 func (b *EnhancedNetexValidatorsRunnerBuilder) Build() (*EnhancedNetexValidatorsRunner, error) {
 	if b.reportEntryFactory == nil {
@@ -126,6 +186,12 @@ func (b *EnhancedNetexValidatorsRunnerBuilder) Build() (*EnhancedNetexValidators
 		reportEntryFactory: b.reportEntryFactory,
 		maxFindings:        b.maxFindings,
 		concurrentFiles:    b.concurrentFiles,
+		changedFiles:       b.changedFiles,
+		baselineDataset:    b.baselineDataset,
+		deterministic:      b.deterministic,
+		maxDepth:           b.maxDepth,
+		spillDir:           b.spillDir,
+		spillThreshold:     b.spillThreshold,
 	}, nil
 }
 
@@ -137,8 +203,21 @@ func (r *EnhancedNetexValidatorsRunner) ValidateFile(filePath, codespace string,
 	return r.validateSingleXMLFile(filePath, codespace, skipSchema, skipValidators)
 }
 
-// ValidateContent validates NetEX content directly
+// ValidateContent validates NetEX content directly and collects its IDs, references
+// and dataset data for the cross-file checks
 func (r *EnhancedNetexValidatorsRunner) ValidateContent(fileName, codespace string, content []byte, skipSchema, skipValidators bool) (*types.ValidationReport, error) {
+	return r.validateContent(fileName, codespace, content, skipSchema, skipValidators, true)
+}
+
+// ValidateStandaloneContent validates NetEX content as a file of its own. Nothing is
+// collected for the cross-file checks, so it leaves the state of a dataset alone and
+// may run concurrently with other calls.
+func (r *EnhancedNetexValidatorsRunner) ValidateStandaloneContent(fileName, codespace string, content []byte, skipSchema, skipValidators bool) (*types.ValidationReport, error) {
+	return r.validateContent(fileName, codespace, content, skipSchema, skipValidators, false)
+}
+
+// validateContent validates NetEX content, collecting its cross-file data when collect is set
+func (r *EnhancedNetexValidatorsRunner) validateContent(fileName, codespace string, content []byte, skipSchema, skipValidators, collect bool) (*types.ValidationReport, error) {
 	startTime := time.Now()
 	logger := logging.GetDefaultLogger().WithFile(fileName).WithValidation(generateReportID(fileName), codespace)
 
@@ -153,6 +232,13 @@ func (r *EnhancedNetexValidatorsRunner) ValidateContent(fileName, codespace stri
 	reportID := generateReportID(fileName)
 	report := types.NewValidationReport(codespace, reportID)
 
+	// Deeply nested documents are rejected before anything builds a tree from them
+	if issue := checkXMLDepth(fileName, content, r.maxDepth); issue != nil {
+		logger.Warn("Stopping validation due to XML nesting depth", "max_depth", r.maxDepth)
+		r.addEntriesWithCap(report, r.convertIssuesToEntries([]types.ValidationIssue{*issue}))
+		return report, nil
+	}
+
 	// Step 1: Schema validation (blocking)
 	if r.schemaValidator != nil && !skipSchema {
 		schemaStart := time.Now()
@@ -178,6 +264,12 @@ func (r *EnhancedNetexValidatorsRunner) ValidateContent(fileName, codespace stri
 
 		if report.HasError() || r.reachedCap(report) {
 			logger.Info("Stopping validation due to schema errors")
+			// The file's IDs still resolve references from the other files of the dataset
+			if collect && !skipValidators && len(r.xpathValidators) > 0 {
+				if err := r.loadContentForCrossFileValidation(fileName, codespace, content); err != nil {
+					logger.Debug("Skipping cross-file data of unparsable file", "error", err.Error())
+				}
+			}
 			return report, nil // Stop on schema errors
 		}
 	}
@@ -213,12 +305,18 @@ func (r *EnhancedNetexValidatorsRunner) ValidateContent(fileName, codespace stri
 		logger.Info("XPath validation issues found", "count", len(xpathIssues))
 	}
 
+	// Step 4: Cross-file data collection (validated once all files are processed).
+	// Files with errors are collected too, or their IDs would look missing to the others.
+	if collect {
+		r.collectCrossFileData(xpathContext, logger)
+	}
+
 	if report.HasError() || r.reachedCap(report) {
 		logger.Info("Stopping validation due to XPath errors")
 		return report, nil // Stop on XPath errors
 	}
 
-	// Step 4: JAXB validation (non-blocking)
+	// Step 5: JAXB validation (non-blocking)
 	if len(r.jaxbValidators) > 0 {
 		jaxbContext := r.prepareJAXBValidationContext(reportID, codespace, fileName, content, xpathContext.LocalIDs)
 		jaxbIssues, err := r.runJAXBValidators(*jaxbContext)
@@ -230,9 +328,26 @@ func (r *EnhancedNetexValidatorsRunner) ValidateContent(fileName, codespace stri
 		r.addEntriesWithCap(report, entries)
 	}
 
-	// Step 5: ID validation (extract IDs and references for later validation)
-	if r.idValidator != nil {
-		// Extract IDs and references from content
+	totalDuration := time.Since(startTime)
+	issuesFound := report.EntryCount()
+	logger.ValidationComplete(fileName, totalDuration, issuesFound, !report.HasError())
+
+	return report, nil
+}
+
+// collectCrossFileData extracts IDs and references and feeds the dataset validators
+func (r *EnhancedNetexValidatorsRunner) collectCrossFileData(xpathContext *context.XPathValidationContext, logger *logging.Logger) {
+	fileName := xpathContext.GetFileName()
+	if documentValidator, ok := r.idValidator.(interfaces.DocumentIdValidator); ok {
+		// Extract IDs and references from the already parsed document
+		if err := documentValidator.ExtractIdsFromDocument(fileName, xpathContext.Document); err != nil {
+			logger.Warn("ID extraction failed", "error", err.Error())
+		}
+		if err := documentValidator.ExtractReferencesFromDocument(fileName, xpathContext.Document); err != nil {
+			logger.Warn("Reference extraction failed", "error", err.Error())
+		}
+	} else if r.idValidator != nil {
+		content := []byte(xpathContext.Document.OutputXML(true))
 		if err := r.idValidator.ExtractIds(fileName, content); err != nil {
 			logger.Warn("ID extraction failed", "error", err.Error())
 		}
@@ -241,11 +356,146 @@ func (r *EnhancedNetexValidatorsRunner) ValidateContent(fileName, codespace stri
 		}
 	}
 
-	totalDuration := time.Since(startTime)
-	issuesFound := len(report.ValidationReportEntries)
-	logger.ValidationComplete(fileName, totalDuration, issuesFound, !report.HasError())
+	for _, validator := range r.datasetValidators {
+		if err := validator.Collect(*xpathContext); err != nil {
+			logger.Warn("Dataset data collection failed", "error", err.Error())
+		}
+	}
+}
 
-	return report, nil
+// loadContentForCrossFileValidation registers a file's IDs, references and dataset
+// data without running any rules on it
+func (r *EnhancedNetexValidatorsRunner) loadContentForCrossFileValidation(fileName, codespace string, content []byte) error {
+	logger := logging.GetDefaultLogger().WithFile(fileName)
+
+	// Unchanged files are not reported on, so a file nested too deep is only skipped
+	if checkXMLDepth(fileName, content, r.maxDepth) != nil {
+		logger.Warn("Skipping cross-file data of file nested too deep", "max_depth", r.maxDepth)
+		return nil
+	}
+
+	xpathContext, err := r.prepareXPathValidationContext(generateReportID(fileName), codespace, fileName, content)
+	if err != nil {
+		return fmt.Errorf("failed to prepare XPath context: %w", err)
+	}
+
+	r.collectCrossFileData(xpathContext, logger)
+	return nil
+}
+
+// baselineFilePrefix marks baseline entries so their findings can be told apart from the delta's
+const baselineFilePrefix = "baseline/"
+
+// loadBaselineDataset registers the IDs of the baseline dataset as external IDs and feeds
+// its files to the dataset validators. Nothing in the baseline itself is reported.
+func (r *EnhancedNetexValidatorsRunner) loadBaselineDataset(codespace string, logger *logging.Logger) error {
+	zr, err := zip.OpenReader(r.baselineDataset)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrBaselineDataset, err)
+	}
+	defer func() { _ = zr.Close() }()
+
+	var repository interfaces.IdRepository
+	if r.idValidator != nil {
+		repository = r.idValidator.GetRepository()
+	}
+
+	r.baselineFiles = make(map[string]bool)
+	for _, f := range zr.File {
+		if strings.ToLower(filepath.Ext(f.Name)) != ".xml" {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			logger.ValidationError(f.Name, fmt.Errorf("failed to open baseline entry: %w", err))
+			continue
+		}
+		content, err := io.ReadAll(rc)
+		_ = rc.Close()
+		if err != nil {
+			logger.ValidationError(f.Name, fmt.Errorf("failed to read baseline entry: %w", err))
+			continue
+		}
+
+		fileName := baselineFilePrefix + f.Name
+		xpathContext, err := r.prepareXPathValidationContext(generateReportID(fileName), codespace, fileName, content)
+		if err != nil {
+			logger.ValidationError(fileName, err)
+			continue
+		}
+		r.baselineFiles[fileName] = true
+
+		if repository != nil {
+			for _, id := range xpathContext.LocalIDs {
+				repository.AddExternalId(id.ID, id.Version, fileName)
+			}
+		}
+		for _, validator := range r.datasetValidators {
+			if err := validator.Collect(*xpathContext); err != nil {
+				logger.Warn("Dataset data collection failed", "error", err.Error())
+			}
+		}
+	}
+
+	return nil
+}
+
+// filterBaselineFiles drops issues located in the baseline dataset
+func (r *EnhancedNetexValidatorsRunner) filterBaselineFiles(issues []types.ValidationIssue) []types.ValidationIssue {
+	if len(r.baselineFiles) == 0 {
+		return issues
+	}
+
+	filtered := make([]types.ValidationIssue, 0, len(issues))
+	for _, issue := range issues {
+		if !r.baselineFiles[issue.Location.FileName] {
+			filtered = append(filtered, issue)
+		}
+	}
+	return filtered
+}
+
+// isChangedFile reports whether a dataset entry should get full rule execution.
+// Without a changed-files list every file is validated. Listed paths match an entry
+// exactly or when one is a path suffix of the other, so repository paths such as
+// "data/line1.xml" match the ZIP entry "line1.xml".
+func (r *EnhancedNetexValidatorsRunner) isChangedFile(name string) bool {
+	if len(r.changedFiles) == 0 {
+		return true
+	}
+
+	name = normalizeDatasetPath(name)
+	for _, changed := range r.changedFiles {
+		changed = normalizeDatasetPath(changed)
+		if changed == "" {
+			continue
+		}
+		if name == changed || strings.HasSuffix(changed, "/"+name) || strings.HasSuffix(name, "/"+changed) {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeDatasetPath converts a path to the forward-slash form used by ZIP entries
+func normalizeDatasetPath(p string) string {
+	p = filepath.ToSlash(strings.TrimSpace(p))
+	return strings.TrimPrefix(p, "./")
+}
+
+// filterToChangedFiles keeps the cross-file issues located in changed files
+func (r *EnhancedNetexValidatorsRunner) filterToChangedFiles(issues []types.ValidationIssue) []types.ValidationIssue {
+	if len(r.changedFiles) == 0 {
+		return issues
+	}
+
+	filtered := make([]types.ValidationIssue, 0, len(issues))
+	for _, issue := range issues {
+		if issue.Location.FileName == "" || r.isChangedFile(issue.Location.FileName) {
+			filtered = append(filtered, issue)
+		}
+	}
+	return filtered
 }
 
 // FinalizeIdValidation performs cross-file ID validation and returns issues
@@ -257,10 +507,36 @@ func (r *EnhancedNetexValidatorsRunner) FinalizeIdValidation() ([]types.Validati
 	return r.idValidator.ValidateIds()
 }
 
+// FinalizeDatasetValidation runs the dataset validators over the data collected from
+// all files, then resets them so the runner can be reused for another dataset
+func (r *EnhancedNetexValidatorsRunner) FinalizeDatasetValidation() ([]types.ValidationIssue, error) {
+	issues := make([]types.ValidationIssue, 0)
+
+	var repository interfaces.IdRepository
+	if r.idValidator != nil {
+		repository = r.idValidator.GetRepository()
+	}
+
+	var firstErr error
+	for _, validator := range r.datasetValidators {
+		validatorIssues, err := validator.Validate(repository)
+		validator.Reset()
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		issues = append(issues, validatorIssues...)
+	}
+
+	return issues, firstErr
+}
+
 // validateZipDataset validates a ZIP dataset
 func (r *EnhancedNetexValidatorsRunner) validateZipDataset(zipPath, codespace string, skipSchema, skipValidators bool) (*types.ValidationReport, error) {
 	logger := logging.GetDefaultLogger().WithFile(zipPath).WithValidation(generateReportID(zipPath), codespace)
-	report := types.NewValidationReport(codespace, generateReportID(zipPath))
+	report := r.newDatasetReport(codespace, generateReportID(zipPath))
 
 	zr, err := zip.OpenReader(zipPath)
 	if err != nil {
@@ -281,6 +557,13 @@ func (r *EnhancedNetexValidatorsRunner) validateZipDataset(zipPath, codespace st
 		return report, nil
 	}
 
+	// Load the baseline before any delta file so the delta's declarations take precedence
+	if r.baselineDataset != "" && !skipValidators {
+		if err := r.loadBaselineDataset(codespace, logger); err != nil {
+			return nil, err
+		}
+	}
+
 	// Prepare work list
 	type job struct {
 		name    string
@@ -293,7 +576,7 @@ func (r *EnhancedNetexValidatorsRunner) validateZipDataset(zipPath, codespace st
 	errs := make(chan error, expectedFiles)
 
 	workerCount := r.concurrentFiles
-	if workerCount <= 0 {
+	if workerCount <= 0 || r.deterministic {
 		workerCount = 1
 	}
 
@@ -315,6 +598,20 @@ func (r *EnhancedNetexValidatorsRunner) validateZipDataset(zipPath, codespace st
 			}()
 
 			for j := range jobs {
+				if !r.isChangedFile(j.name) {
+					// Unchanged files only contribute IDs so references resolve
+					if !skipValidators {
+						if err := r.loadContentForCrossFileValidation(j.name, codespace, j.content); err != nil {
+							errs <- fmt.Errorf("%s: %w", j.name, err)
+							results <- nil
+							continue
+						}
+					}
+					results <- nil
+					errs <- nil
+					continue
+				}
+
 				subReport, err := r.ValidateContent(j.name, codespace, j.content, skipSchema, skipValidators)
 				if err != nil {
 					errs <- fmt.Errorf("%s: %w", j.name, err)
@@ -364,14 +661,68 @@ func (r *EnhancedNetexValidatorsRunner) validateZipDataset(zipPath, codespace st
 		}
 	}
 
+	r.finalizeCrossFileValidation(report, zipPath, logger)
+	r.Reset()
+
+	return report, nil
+}
+
+// newDatasetReport creates the report findings of a whole dataset are collected in,
+// spilling to disk when configured through WithResultSpill
+func (r *EnhancedNetexValidatorsRunner) newDatasetReport(codespace, reportID string) *types.ValidationReport {
+	report := types.NewValidationReport(codespace, reportID)
+	if r.spillThreshold > 0 {
+		report.SpillTo(r.spillDir, r.spillThreshold)
+	}
+	return report
+}
+
+// finalizeCrossFileValidation adds the cross-file ID and dataset findings over every
+// collected file to the report
+func (r *EnhancedNetexValidatorsRunner) finalizeCrossFileValidation(report *types.ValidationReport, name string, logger *logging.Logger) {
 	// Cross-file ID validation at the end
 	if idIssues, err := r.FinalizeIdValidation(); err == nil && len(idIssues) > 0 {
-		r.addEntriesWithCap(report, r.convertIssuesToEntries(idIssues))
+		r.addEntriesWithCap(report, r.convertIssuesToEntries(r.filterToChangedFiles(idIssues)))
 	} else if err != nil {
-		logger.ValidationError(zipPath, fmt.Errorf("ID finalization failed: %w", err))
+		logger.ValidationError(name, fmt.Errorf("ID finalization failed: %w", err))
 	}
 
-	return report, nil
+	// Dataset-wide validation once every file has been collected
+	datasetIssues, err := r.FinalizeDatasetValidation()
+	if err != nil {
+		logger.ValidationError(name, fmt.Errorf("dataset finalization failed: %w", err))
+	}
+	datasetIssues = r.filterToChangedFiles(r.filterBaselineFiles(datasetIssues))
+	if len(datasetIssues) > 0 {
+		r.addEntriesWithCap(report, r.convertIssuesToEntries(datasetIssues))
+	}
+}
+
+// FinalizeDataset runs the cross-file ID and dataset validation over every file
+// collected through ValidateContent since the last Reset, returns the findings as a
+// report and resets the runner for the next dataset
+func (r *EnhancedNetexValidatorsRunner) FinalizeDataset(codespace, reportID string) *types.ValidationReport {
+	logger := logging.GetDefaultLogger().WithValidation(reportID, codespace)
+	report := r.newDatasetReport(codespace, reportID)
+
+	r.finalizeCrossFileValidation(report, reportID, logger)
+	r.Reset()
+
+	return report
+}
+
+// Reset clears the IDs, references and dataset data collected from earlier files, so
+// the next file or dataset is validated on its own
+func (r *EnhancedNetexValidatorsRunner) Reset() {
+	if r.idValidator != nil {
+		if repository := r.idValidator.GetRepository(); repository != nil {
+			repository.Clear()
+		}
+	}
+	for _, validator := range r.datasetValidators {
+		validator.Reset()
+	}
+	r.baselineFiles = nil
 }
 
 // validateSingleXMLFile validates a single XML file
@@ -398,29 +749,27 @@ func (r *EnhancedNetexValidatorsRunner) prepareXPathValidationContext(
 	// Parse XML document using xmlquery
 	document, err := xmlquery.Parse(bytes.NewReader(fileContent))
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse XML: %w", err)
+		return nil, fmt.Errorf("%w: %w", ErrParse, err)
 	}
 
-	// Use the idValidator to extract IDs and references
-	// Create a temporary NetEX ID extractor
-	extractor := ids.NewNetexIdExtractor()
+	// Extract local IDs and references from the parsed document; the cross-file
+	// collection reuses the same document rather than parsing the content again
+	extractor := &ids.NetexIdExtractor{}
 
-	// Extract local IDs
-	localIDsList, err := extractor.ExtractIds(filename, fileContent)
+	localIDs, err := extractor.ExtractIdsFromDocument(filename, document)
 	if err != nil {
-		return nil, fmt.Errorf("failed to extract local IDs: %w", err)
+		return nil, fmt.Errorf("failed to extract IDs: %w", err)
 	}
 
 	// Convert to map
 	localIDsMap := make(map[string]types.IdVersion)
-	for _, id := range localIDsList {
+	for _, id := range localIDs {
 		localIDsMap[id.ID] = id
 	}
 
-	// Extract local references
-	localRefs, err := extractor.ExtractReferences(filename, fileContent)
+	localRefs, err := extractor.ExtractReferencesFromDocument(filename, document)
 	if err != nil {
-		return nil, fmt.Errorf("failed to extract local references: %w", err)
+		return nil, fmt.Errorf("failed to extract references: %w", err)
 	}
 
 	return context.NewXPathValidationContext(filename, codespace, validationReportID, document, localIDsMap, localRefs), nil
@@ -447,6 +796,18 @@ func (r *EnhancedNetexValidatorsRunner) runXPathValidators(ctx context.XPathVali
 		return r.xpathValidators[0].Validate(ctx)
 	}
 
+	if r.deterministic {
+		var allIssues []types.ValidationIssue
+		for _, validator := range r.xpathValidators {
+			issues, err := validator.Validate(ctx)
+			allIssues = append(allIssues, issues...)
+			if err != nil {
+				return allIssues, err
+			}
+		}
+		return allIssues, nil
+	}
+
 	// Use parallel execution for multiple validators
 	return r.runXPathValidatorsParallel(ctx)
 }
@@ -526,6 +887,18 @@ func (r *EnhancedNetexValidatorsRunner) runJAXBValidators(ctx context.JAXBValida
 		return r.jaxbValidators[0].Validate(ctx)
 	}
 
+	if r.deterministic {
+		var allIssues []types.ValidationIssue
+		for _, validator := range r.jaxbValidators {
+			issues, err := validator.Validate(ctx)
+			allIssues = append(allIssues, issues...)
+			if err != nil {
+				return allIssues, err
+			}
+		}
+		return allIssues, nil
+	}
+
 	// Use parallel execution for multiple validators
 	return r.runJAXBValidatorsParallel(ctx)
 }
@@ -610,7 +983,7 @@ func (r *EnhancedNetexValidatorsRunner) addEntriesWithCap(report *types.Validati
 		report.AddAllValidationReportEntries(entries)
 		return
 	}
-	remaining := r.maxFindings - len(report.ValidationReportEntries)
+	remaining := r.maxFindings - report.EntryCount()
 	if remaining <= 0 {
 		return
 	}
@@ -623,7 +996,7 @@ func (r *EnhancedNetexValidatorsRunner) addEntriesWithCap(report *types.Validati
 
 // reachedCap returns true if max findings cap has been reached
 func (r *EnhancedNetexValidatorsRunner) reachedCap(report *types.ValidationReport) bool {
-	return r.maxFindings > 0 && len(report.ValidationReportEntries) >= r.maxFindings
+	return r.maxFindings > 0 && report.EntryCount() >= r.maxFindings
 }
 
 // generateReportID generates a report ID from filename
@@ -636,7 +1009,15 @@ func generateReportID(fileName string) string {
 }
 
 // DefaultValidationReportEntryFactory is the default implementation
-type DefaultValidationReportEntryFactory struct{}
+type DefaultValidationReportEntryFactory struct {
+	// FingerprintLineNumbers includes the line number in each entry's fingerprint, so
+	// a finding gets a new fingerprint when unrelated edits move it within the file
+	FingerprintLineNumbers bool
+
+	// RuleDocsBaseURL, when set, links each entry to the documentation of its rule at
+	// RuleDocsBaseURL followed by the rule code. A rule's own DocURL takes precedence.
+	RuleDocsBaseURL string
+}
 
 // NewDefaultValidationReportEntryFactory creates a new default factory
 func NewDefaultValidationReportEntryFactory() interfaces.ValidationReportEntryFactory {
@@ -646,17 +1027,63 @@ func NewDefaultValidationReportEntryFactory() interfaces.ValidationReportEntryFa
 // CreateValidationReportEntry creates a validation report entry from an issue
 func (f *DefaultValidationReportEntryFactory) CreateValidationReportEntry(issue types.ValidationIssue) types.ValidationReportEntry {
 	return types.ValidationReportEntry{
-		Name:     issue.Rule.Name,
-		Message:  issue.Message,
-		Severity: issue.Rule.Severity,
-		FileName: issue.Location.FileName,
-		Location: issue.Location,
+		RuleCode:       issue.Rule.Code,
+		Name:           issue.Rule.Name,
+		Message:        issue.Message,
+		Severity:       issue.Rule.Severity,
+		FileName:       issue.Location.FileName,
+		Location:       issue.Location,
+		MatchedSnippet: issue.MatchedSnippet,
+		RuleXPath:      issue.RuleXPath,
+		Fingerprint:    Fingerprint(issue, f.FingerprintLineNumbers),
+		DocURL:         f.docURL(issue.Rule),
 	}
 }
 
+// docURL returns the documentation link of a rule, if any
+func (f *DefaultValidationReportEntryFactory) docURL(rule types.ValidationRule) string {
+	if rule.DocURL != "" {
+		return rule.DocURL
+	}
+	if f.RuleDocsBaseURL == "" || rule.Code == "" {
+		return ""
+	}
+	return f.RuleDocsBaseURL + rule.Code
+}
+
+// Fingerprint returns a stable identifier for an issue, hashed from its rule code,
+// file, element id, XPath and formatted message. Positions are left out of the XPath
+// of findings with an element id so they keep their fingerprint when elements are
+// added or removed before them; findings without one keep the positions, which are
+// all that tells apart sibling elements with the same message. The line number is
+// only part of the hash when includeLineNumber is set.
+func Fingerprint(issue types.ValidationIssue, includeLineNumber bool) string {
+	xpath := issue.Location.XPath
+	if issue.Location.ElementID != "" {
+		xpath = xpathPositions.ReplaceAllString(xpath, "")
+	}
+	parts := []string{
+		issue.Rule.Code,
+		issue.Location.FileName,
+		issue.Location.ElementID,
+		xpath,
+		issue.Message,
+	}
+	if includeLineNumber {
+		parts = append(parts, fmt.Sprint(issue.Location.LineNumber))
+	}
+
+	sum := sha256.Sum256([]byte(strings.Join(parts, "\x00")))
+	return hex.EncodeToString(sum[:16])
+}
+
+// xpathPositions matches the positional predicates of an XPath, e.g. [3]
+var xpathPositions = regexp.MustCompile(`\[\d+\]`)
+
 // TemplateValidationReportEntry creates a template entry from a rule
 func (f *DefaultValidationReportEntryFactory) TemplateValidationReportEntry(rule types.ValidationRule) types.ValidationReportEntry {
 	return types.ValidationReportEntry{
+		RuleCode: rule.Code,
 		Name:     rule.Name,
 		Message:  rule.Message,
 		Severity: rule.Severity,
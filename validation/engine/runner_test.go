@@ -1,10 +1,14 @@
 package engine
 
 import (
+	"reflect"
 	"testing"
 
+	"github.com/theoremus-urban-solutions/netex-validator/interfaces"
 	"github.com/theoremus-urban-solutions/netex-validator/testutil"
 	"github.com/theoremus-urban-solutions/netex-validator/types"
+	"github.com/theoremus-urban-solutions/netex-validator/validation/context"
+	"github.com/theoremus-urban-solutions/netex-validator/validation/ids"
 )
 
 func TestEnhancedNetexValidatorsRunner_ValidateContent(t *testing.T) {
@@ -204,6 +208,178 @@ func TestEnhancedNetexValidatorsRunner_FinalizeIdValidation(t *testing.T) {
 	})
 }
 
+func TestEnhancedNetexValidatorsRunner_ParsesContentOnce(t *testing.T) {
+	// The runner extracts IDs and references from the document it parsed for the XPath
+	// phase; the results must match extracting them from the raw content
+	content := []byte(`<?xml version="1.0" encoding="UTF-8"?>
+<PublicationDelivery xmlns="http://www.netex.org.uk/netex" version="1.15">
+	<PublicationTimestamp>2023-01-01T00:00:00</PublicationTimestamp>
+	<ParticipantRef>TEST</ParticipantRef>
+	<dataObjects>
+		<ServiceFrame id="TEST:ServiceFrame:1" version="1">
+			<lines>
+				<Line id="TEST:Line:1" version="1">
+					<Name>Line 1</Name>
+					<OperatorRef ref="TEST:Operator:Missing" version="1"/>
+				</Line>
+			</lines>
+			<routes>
+				<Route id="TEST:Route:1" version="2">
+					<LineRef ref="TEST:Line:1" version="1"/>
+					<DayTypeRef>TEST:DayType:Missing</DayTypeRef>
+				</Route>
+			</routes>
+		</ServiceFrame>
+	</dataObjects>
+</PublicationDelivery>`)
+
+	capture := &capturingXPathValidator{}
+	runner, err := NewEnhancedNetexValidatorsRunnerBuilder().
+		WithXPathValidators([]interfaces.XPathValidator{capture}).
+		WithValidationReportEntryFactory(NewDefaultValidationReportEntryFactory()).
+		Build()
+	if err != nil {
+		t.Fatalf("Failed to create test runner: %v", err)
+	}
+	if _, err := runner.ValidateContent("test.xml", testutil.TestCodespace, content, true, false); err != nil {
+		t.Fatalf("ValidateContent() failed: %v", err)
+	}
+	issues, err := runner.FinalizeIdValidation()
+	if err != nil {
+		t.Fatalf("FinalizeIdValidation() failed: %v", err)
+	}
+
+	extractor := ids.NewNetexIdExtractor()
+	expectedIds, err := extractor.ExtractIds("test.xml", content)
+	if err != nil {
+		t.Fatalf("ExtractIds() failed: %v", err)
+	}
+	expectedRefs, err := extractor.ExtractReferences("test.xml", content)
+	if err != nil {
+		t.Fatalf("ExtractReferences() failed: %v", err)
+	}
+
+	if len(capture.localIDs) != len(expectedIds) {
+		t.Errorf("Expected %d local IDs, got %d", len(expectedIds), len(capture.localIDs))
+	}
+	for _, id := range expectedIds {
+		if capture.localIDs[id.ID] != id {
+			t.Errorf("Expected local ID %+v, got %+v", id, capture.localIDs[id.ID])
+		}
+	}
+	if !reflect.DeepEqual(capture.localRefs, expectedRefs) {
+		t.Errorf("Expected local references %+v, got %+v", expectedRefs, capture.localRefs)
+	}
+
+	reference := ids.NewNetexIdValidator(ids.NewNetexIdRepository(), extractor)
+	if err := reference.ExtractIds("test.xml", content); err != nil {
+		t.Fatalf("ExtractIds() failed: %v", err)
+	}
+	if err := reference.ExtractReferences("test.xml", content); err != nil {
+		t.Fatalf("ExtractReferences() failed: %v", err)
+	}
+	expectedIssues, err := reference.ValidateIds()
+	if err != nil {
+		t.Fatalf("ValidateIds() failed: %v", err)
+	}
+	if len(expectedIssues) == 0 {
+		t.Fatal("Expected the unresolved references to be reported")
+	}
+	if !reflect.DeepEqual(issues, expectedIssues) {
+		t.Errorf("Expected ID findings %+v, got %+v", expectedIssues, issues)
+	}
+}
+
+func TestEnhancedNetexValidatorsRunner_ValidateStandaloneContent(t *testing.T) {
+	content := []byte(`<?xml version="1.0" encoding="UTF-8"?>
+<PublicationDelivery xmlns="http://www.netex.org.uk/netex" version="1.15">
+	<PublicationTimestamp>2023-01-01T00:00:00</PublicationTimestamp>
+	<ParticipantRef>TEST</ParticipantRef>
+	<dataObjects>
+		<ServiceFrame id="TEST:ServiceFrame:1" version="1">
+			<lines>
+				<Line id="TEST:Line:1" version="1">
+					<OperatorRef ref="TEST:Operator:Missing" version="1"/>
+				</Line>
+			</lines>
+		</ServiceFrame>
+	</dataObjects>
+</PublicationDelivery>`)
+
+	runner, err := NewEnhancedNetexValidatorsRunnerBuilder().
+		WithXPathValidators([]interfaces.XPathValidator{&capturingXPathValidator{}}).
+		WithValidationReportEntryFactory(NewDefaultValidationReportEntryFactory()).
+		Build()
+	if err != nil {
+		t.Fatalf("Failed to create test runner: %v", err)
+	}
+
+	if _, err := runner.ValidateStandaloneContent("test.xml", testutil.TestCodespace, content, true, false); err != nil {
+		t.Fatalf("ValidateStandaloneContent() failed: %v", err)
+	}
+	issues, err := runner.FinalizeIdValidation()
+	if err != nil {
+		t.Fatalf("FinalizeIdValidation() failed: %v", err)
+	}
+	if len(issues) != 0 {
+		t.Errorf("Expected nothing to be collected from a standalone file, got %+v", issues)
+	}
+
+	if _, err := runner.ValidateContent("test.xml", testutil.TestCodespace, content, true, false); err != nil {
+		t.Fatalf("ValidateContent() failed: %v", err)
+	}
+	if issues, _ = runner.FinalizeIdValidation(); len(issues) == 0 {
+		t.Error("Expected the unresolved OperatorRef of a collected file to be reported")
+	}
+}
+
+// contentOnlyIdValidator hides the document methods of the wrapped validator, like an
+// IdValidator implemented before DocumentIdValidator existed
+type contentOnlyIdValidator struct {
+	interfaces.IdValidator
+}
+
+func TestEnhancedNetexValidatorsRunner_ContentOnlyIdValidator(t *testing.T) {
+	content := []byte(`<?xml version="1.0" encoding="UTF-8"?>
+<PublicationDelivery xmlns="http://www.netex.org.uk/netex" version="1.15">
+	<PublicationTimestamp>2023-01-01T00:00:00</PublicationTimestamp>
+	<ParticipantRef>TEST</ParticipantRef>
+	<dataObjects>
+		<ServiceFrame id="TEST:ServiceFrame:1" version="1">
+			<lines>
+				<Line id="TEST:Line:1" version="1">
+					<OperatorRef ref="TEST:Operator:Missing" version="1"/>
+				</Line>
+			</lines>
+		</ServiceFrame>
+	</dataObjects>
+</PublicationDelivery>`)
+
+	idValidator := ids.NewNetexIdValidator(ids.NewNetexIdRepository(), ids.NewNetexIdExtractor())
+	runner, err := NewEnhancedNetexValidatorsRunnerBuilder().
+		WithXPathValidators([]interfaces.XPathValidator{&capturingXPathValidator{}}).
+		WithIdValidator(contentOnlyIdValidator{idValidator}).
+		WithValidationReportEntryFactory(NewDefaultValidationReportEntryFactory()).
+		Build()
+	if err != nil {
+		t.Fatalf("Failed to create test runner: %v", err)
+	}
+
+	if _, err := runner.ValidateContent("test.xml", testutil.TestCodespace, content, true, false); err != nil {
+		t.Fatalf("ValidateContent() failed: %v", err)
+	}
+	if ids := idValidator.GetRepository().GetIdsByFile("test.xml"); len(ids) != 2 {
+		t.Errorf("Expected the 2 IDs of test.xml to be registered, got %v", ids)
+	}
+	issues, err := runner.FinalizeIdValidation()
+	if err != nil {
+		t.Fatalf("FinalizeIdValidation() failed: %v", err)
+	}
+	if len(issues) == 0 {
+		t.Error("Expected the unresolved OperatorRef to be reported")
+	}
+}
+
 func TestEnhancedNetexValidatorsRunnerBuilder(t *testing.T) {
 	t.Run("Builder pattern", func(t *testing.T) {
 		builder := NewEnhancedNetexValidatorsRunnerBuilder()
@@ -286,6 +462,84 @@ func TestDefaultValidationReportEntryFactory(t *testing.T) {
 	})
 }
 
+func TestDefaultValidationReportEntryFactory_DocURL(t *testing.T) {
+	factory := &DefaultValidationReportEntryFactory{RuleDocsBaseURL: "https://docs.example.org/rules/"}
+
+	issue := types.ValidationIssue{Rule: types.ValidationRule{Code: "LINE_2", Name: "Line missing Name"}}
+	if got := factory.CreateValidationReportEntry(issue).DocURL; got != "https://docs.example.org/rules/LINE_2" {
+		t.Errorf("Expected base URL followed by rule code, got %q", got)
+	}
+
+	issue.Rule.DocURL = "https://example.org/line-names"
+	if got := factory.CreateValidationReportEntry(issue).DocURL; got != "https://example.org/line-names" {
+		t.Errorf("Expected the rule's own DocURL to take precedence, got %q", got)
+	}
+
+	if got := NewDefaultValidationReportEntryFactory().CreateValidationReportEntry(types.ValidationIssue{Rule: types.ValidationRule{Code: "LINE_2"}}).DocURL; got != "" {
+		t.Errorf("Expected no DocURL without a base URL, got %q", got)
+	}
+}
+
+func TestFingerprint(t *testing.T) {
+	issue := types.ValidationIssue{
+		Rule: types.ValidationRule{
+			Code:    "LINE_4",
+			Message: "Line missing PublicCode",
+		},
+		Message: "Line 'TEST:Line:1' missing PublicCode",
+		Location: types.DataLocation{
+			FileName:   "line.xml",
+			LineNumber: 12,
+			XPath:      "/PublicationDelivery/dataObjects/ServiceFrame/lines/Line[1]",
+			ElementID:  "TEST:Line:1",
+		},
+	}
+
+	fingerprint := Fingerprint(issue, false)
+	if fingerprint == "" {
+		t.Fatal("Expected a non-empty fingerprint")
+	}
+	if again := Fingerprint(issue, false); again != fingerprint {
+		t.Errorf("Expected identical issues to share a fingerprint, got %s and %s", fingerprint, again)
+	}
+
+	moved := issue
+	moved.Location.LineNumber = 40
+	moved.Location.XPath = "/PublicationDelivery/dataObjects/ServiceFrame/lines/Line[3]"
+	if got := Fingerprint(moved, false); got != fingerprint {
+		t.Errorf("Expected line number and position to be ignored, got %s and %s", fingerprint, got)
+	}
+	if Fingerprint(moved, true) == Fingerprint(issue, true) {
+		t.Error("Expected line numbers to change the fingerprint when included")
+	}
+
+	other := issue
+	other.Location.ElementID = "TEST:Line:2"
+	if Fingerprint(other, false) == fingerprint {
+		t.Error("Expected a different element id to change the fingerprint")
+	}
+
+	reworded := issue
+	reworded.Message = "Line 'TEST:Line:1' missing PublicCode and PrivateCode"
+	if Fingerprint(reworded, false) == fingerprint {
+		t.Error("Expected a different message to change the fingerprint")
+	}
+
+	// Without an element id the position is all that tells siblings apart
+	first := issue
+	first.Location.ElementID = ""
+	second := first
+	second.Location.XPath = "/PublicationDelivery/dataObjects/ServiceFrame/lines/Line[2]"
+	if Fingerprint(first, false) == Fingerprint(second, false) {
+		t.Error("Expected positions to change the fingerprint of findings without an element id")
+	}
+
+	entry := NewDefaultValidationReportEntryFactory().CreateValidationReportEntry(issue)
+	if entry.Fingerprint != fingerprint {
+		t.Errorf("Expected the factory to set fingerprint %s, got %s", fingerprint, entry.Fingerprint)
+	}
+}
+
 // Helper functions
 
 // createTestRunner creates a minimal test runner
@@ -361,3 +615,54 @@ func BenchmarkValidateContent_Large(b *testing.B) {
 		}
 	}
 }
+
+// noopXPathValidator reports nothing; it makes the runner go through document parsing
+// and cross-file data collection without rule evaluation dominating the cost
+type noopXPathValidator struct{}
+
+func (noopXPathValidator) Validate(context.XPathValidationContext) ([]types.ValidationIssue, error) {
+	return nil, nil
+}
+
+func (noopXPathValidator) GetRules() []types.ValidationRule { return nil }
+
+// capturingXPathValidator records the local IDs and references of the last context
+type capturingXPathValidator struct {
+	localIDs  map[string]types.IdVersion
+	localRefs []types.IdVersion
+}
+
+func (v *capturingXPathValidator) Validate(ctx context.XPathValidationContext) ([]types.ValidationIssue, error) {
+	v.localIDs = ctx.LocalIDs
+	v.localRefs = ctx.LocalRefs
+	return nil, nil
+}
+
+func (v *capturingXPathValidator) GetRules() []types.ValidationRule { return nil }
+
+func benchmarkValidateContentParsing(b *testing.B, content []byte) {
+	runner, err := NewEnhancedNetexValidatorsRunnerBuilder().
+		WithXPathValidators([]interfaces.XPathValidator{noopXPathValidator{}}).
+		WithValidationReportEntryFactory(NewDefaultValidationReportEntryFactory()).
+		Build()
+	if err != nil {
+		b.Fatalf("Failed to create test runner: %v", err)
+	}
+
+	b.ReportAllocs()
+	b.SetBytes(int64(len(content)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := runner.ValidateContent("test.xml", testutil.TestCodespace, content, true, false); err != nil {
+			b.Fatalf("Validation failed: %v", err)
+		}
+	}
+}
+
+func BenchmarkValidateContent_Parsing_Medium(b *testing.B) {
+	benchmarkValidateContentParsing(b, []byte(testutil.GetBenchmarkData().MediumDataset))
+}
+
+func BenchmarkValidateContent_Parsing_Large(b *testing.B) {
+	benchmarkValidateContentParsing(b, []byte(testutil.GetBenchmarkData().LargeDataset))
+}
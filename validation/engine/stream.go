@@ -0,0 +1,197 @@
+package engine
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/antchfx/xmlquery"
+	"github.com/theoremus-urban-solutions/netex-validator/interfaces"
+	"github.com/theoremus-urban-solutions/netex-validator/logging"
+	"github.com/theoremus-urban-solutions/netex-validator/types"
+	"github.com/theoremus-urban-solutions/netex-validator/utils"
+	"github.com/theoremus-urban-solutions/netex-validator/validation/context"
+)
+
+// streamElement is an open element while a document is streamed
+type streamElement struct {
+	node     *xmlquery.Node
+	xpath    string         // position of the element in the whole document
+	children map[string]int // element children seen so far by name, outside objects
+}
+
+// ValidateStream validates a document read token by token instead of parsed into one
+// tree, so files too large to hold in memory can be validated. Every element with an
+// id, other than a frame, is built as a tree of its own below empty copies of its
+// ancestors and passed to the validators as the Document of an XPathValidationContext.
+// The validators must therefore only look at one object at a time; matches on the
+// empty ancestors are dropped. Schema, ID and the runner's own validators do not run.
+func (r *EnhancedNetexValidatorsRunner) ValidateStream(fileName, codespace string, reader io.Reader, validators []interfaces.XPathValidator) (*types.ValidationReport, error) {
+	startTime := time.Now()
+	reportID := generateReportID(fileName)
+	logger := logging.GetDefaultLogger().WithFile(fileName).WithValidation(reportID, codespace)
+	logger.ValidationStart(fileName, codespace)
+
+	report := types.NewValidationReport(codespace, reportID)
+	decoder := xml.NewDecoder(reader)
+	spaceToPrefix := make(map[string]string)
+	stack := []*streamElement{{node: &xmlquery.Node{Type: xmlquery.DocumentNode}, children: make(map[string]int)}}
+	var object *streamElement
+
+	for !r.reachedCap(report) {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			logger.ValidationError(fileName, err)
+			return nil, fmt.Errorf("%w: %w", ErrParse, err)
+		}
+
+		switch tok := token.(type) {
+		case xml.StartElement:
+			if r.maxDepth > 0 && len(stack) > r.maxDepth {
+				line, _ := decoder.InputPos()
+				logger.Warn("Stopping validation due to XML nesting depth", "max_depth", r.maxDepth)
+				r.addEntriesWithCap(report, r.convertIssuesToEntries([]types.ValidationIssue{{
+					Rule:     xmlTooDeepRule,
+					Location: types.DataLocation{FileName: fileName, LineNumber: line},
+					Message:  fmt.Sprintf("Elements are nested more than %d levels deep; the document was not validated further", r.maxDepth),
+				}}))
+				return report, nil
+			}
+
+			node := newStreamNode(tok, spaceToPrefix)
+			parent := stack[len(stack)-1]
+			element := &streamElement{node: node}
+			if object != nil {
+				xmlquery.AddChild(parent.node, node)
+			} else {
+				parent.children[tok.Name.Local]++
+				element.xpath = fmt.Sprintf("%s/%s[%d]", parent.xpath, tok.Name.Local, parent.children[tok.Name.Local])
+				element.children = make(map[string]int)
+				if len(stack) > 1 && isStreamObject(tok) {
+					object = element
+					attachToAncestorCopies(stack, node)
+				}
+			}
+			stack = append(stack, element)
+
+		case xml.CharData:
+			if object != nil {
+				xmlquery.AddChild(stack[len(stack)-1].node, &xmlquery.Node{Type: xmlquery.TextNode, Data: string(tok)})
+			}
+
+		case xml.EndElement:
+			element := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if element != object {
+				continue
+			}
+			object = nil
+
+			issues, err := r.validateStreamObject(fileName, codespace, reportID, element, validators)
+			if err != nil {
+				logger.ValidationError(fileName, err)
+				return nil, fmt.Errorf("XPath validation error: %w", err)
+			}
+			r.addEntriesWithCap(report, r.convertIssuesToEntries(issues))
+		}
+	}
+
+	logger.ValidationComplete(fileName, time.Since(startTime), len(report.ValidationReportEntries), !report.HasError())
+	return report, nil
+}
+
+// validateStreamObject runs the validators on one streamed object. Findings on the
+// object are moved to its position in the whole document; findings on the copies of
+// its ancestors are dropped, as those copies have no content of their own.
+func (r *EnhancedNetexValidatorsRunner) validateStreamObject(fileName, codespace, reportID string, object *streamElement, validators []interfaces.XPathValidator) ([]types.ValidationIssue, error) {
+	document := object.node
+	for document.Parent != nil {
+		document = document.Parent
+	}
+	ctx := context.NewXPathValidationContext(fileName, codespace, reportID, document, map[string]types.IdVersion{}, nil)
+
+	treeXPath := utils.NodeXPath(object.node)
+	var issues []types.ValidationIssue
+	for _, validator := range validators {
+		found, err := validator.Validate(*ctx)
+		if err != nil {
+			return issues, err
+		}
+		for _, issue := range found {
+			rest, ok := strings.CutPrefix(issue.Location.XPath, treeXPath)
+			if !ok || (rest != "" && !strings.HasPrefix(rest, "/")) {
+				continue
+			}
+			issue.Location.XPath = object.xpath + rest
+			issues = append(issues, issue)
+		}
+	}
+	return issues, nil
+}
+
+// isStreamObject reports whether an element is validated as one object when
+// streaming: any element with an id except the frames, which hold whole collections
+func isStreamObject(tok xml.StartElement) bool {
+	if strings.HasSuffix(tok.Name.Local, "Frame") {
+		return false
+	}
+	for _, attr := range tok.Attr {
+		if attr.Name.Space == "" && attr.Name.Local == "id" {
+			return true
+		}
+	}
+	return false
+}
+
+// attachToAncestorCopies places node below childless copies of the open elements,
+// so XPaths naming the ancestors of an object still match it
+func attachToAncestorCopies(stack []*streamElement, node *xmlquery.Node) {
+	parent := &xmlquery.Node{Type: xmlquery.DocumentNode}
+	for _, ancestor := range stack[1:] {
+		shell := &xmlquery.Node{
+			Type:         xmlquery.ElementNode,
+			Data:         ancestor.node.Data,
+			Prefix:       ancestor.node.Prefix,
+			NamespaceURI: ancestor.node.NamespaceURI,
+			Attr:         ancestor.node.Attr,
+		}
+		xmlquery.AddChild(parent, shell)
+		parent = shell
+	}
+	xmlquery.AddChild(parent, node)
+}
+
+// newStreamNode converts a start element to a node the way xmlquery.Parse would,
+// resolving namespace URIs back to the prefixes declared in the document
+func newStreamNode(tok xml.StartElement, spaceToPrefix map[string]string) *xmlquery.Node {
+	for _, attr := range tok.Attr {
+		if attr.Name.Space == "" && attr.Name.Local == "xmlns" {
+			spaceToPrefix[attr.Value] = ""
+		} else if attr.Name.Space == "xmlns" {
+			spaceToPrefix[attr.Value] = attr.Name.Local
+		}
+	}
+
+	attrs := make([]xmlquery.Attr, len(tok.Attr))
+	for i, attr := range tok.Attr {
+		name := attr.Name
+		if prefix, ok := spaceToPrefix[name.Space]; ok {
+			name.Space = prefix
+		}
+		attrs[i] = xmlquery.Attr{Name: name, Value: attr.Value, NamespaceURI: attr.Name.Space}
+	}
+
+	return &xmlquery.Node{
+		Type:         xmlquery.ElementNode,
+		Data:         tok.Name.Local,
+		Prefix:       spaceToPrefix[tok.Name.Space],
+		NamespaceURI: tok.Name.Space,
+		Attr:         attrs,
+	}
+}
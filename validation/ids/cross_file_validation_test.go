@@ -1,6 +1,8 @@
 package ids
 
 import (
+	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/theoremus-urban-solutions/netex-validator/types"
@@ -94,6 +96,72 @@ func TestCrossFileValidation(t *testing.T) {
 			t.Errorf("Expected 1 unresolved reference error, got: %d", unresolvedCount)
 		}
 	})
+
+	t.Run("Baseline IDs resolve references without duplicate findings", func(t *testing.T) {
+		repo := NewNetexIdRepository()
+
+		// The baseline declares the line, the delta redeclares it with a new version
+		repo.AddExternalId("TEST:Line:1", "1", "baseline/lines.xml")
+		repo.AddExternalId("TEST:Operator:1", "1", "baseline/lines.xml")
+		if err := repo.AddId("TEST:Line:1", "2", "delta.xml"); err != nil {
+			t.Fatalf("Error adding ID: %v", err)
+		}
+		repo.AddReference("TEST:Operator:1", "1", "delta.xml")
+		repo.AddReference("TEST:Operator:2", "1", "delta.xml")
+
+		issues := repo.ValidateReferences()
+		issues = append(issues, repo.GetDuplicateIds()...)
+		issues = append(issues, repo.ValidateVersionConsistencyAcrossFiles()...)
+
+		if len(issues) != 1 {
+			t.Fatalf("Expected only the dangling reference to be reported, got %d: %+v", len(issues), issues)
+		}
+		if issues[0].Rule.Code != unresolvedReferenceCode || issues[0].Location.ElementID != "TEST:Operator:2" {
+			t.Errorf("Expected TEST:Operator:2 to be unresolved, got %s %s", issues[0].Rule.Code, issues[0].Location.ElementID)
+		}
+	})
+}
+
+func TestVersionConsistencyAcrossFiles(t *testing.T) {
+	repo := NewNetexIdRepository()
+	if err := repo.AddId("TEST:Operator:1", "1", "a.xml"); err != nil {
+		t.Fatalf("AddId failed: %v", err)
+	}
+	// Rejected as a duplicate, but its version still counts
+	_ = repo.AddId("TEST:Operator:1", "2", "b.xml")
+	if err := repo.AddId("TEST:Line:1", "1", "a.xml"); err != nil {
+		t.Fatalf("AddId failed: %v", err)
+	}
+	repo.AddReference("TEST:Line:1", "3", "c.xml")
+	repo.AddReference("TEST:Line:1", "any", "d.xml")
+
+	issues := repo.ValidateVersionConsistencyAcrossFiles()
+	if len(issues) != 1 {
+		t.Fatalf("Expected 1 issue, got %d: %+v", len(issues), issues)
+	}
+	if issues[0].Rule.Code != "NETEX_ID_10" || issues[0].Location.ElementID != "TEST:Operator:1" {
+		t.Errorf("Expected NETEX_ID_10 for TEST:Operator:1, got %s for %s", issues[0].Rule.Code, issues[0].Location.ElementID)
+	}
+	if want := "version '1' in a.xml, version '2' in b.xml"; !strings.Contains(issues[0].Message, want) {
+		t.Errorf("Expected message to contain %q, got %q", want, issues[0].Message)
+	}
+
+	repo.SetVersionReport(true)
+	issues = repo.ValidateVersionConsistencyAcrossFiles()
+	if len(issues) != 2 || issues[1].Rule.Code != "NETEX_ID_14" {
+		t.Fatalf("Expected NETEX_ID_10 and a NETEX_ID_14 report, got %+v", issues)
+	}
+	want := "2 ID(s) have conflicting versions across the dataset: " +
+		"TEST:Line:1 (version '1' in a.xml, referenced version '3' in c.xml); " +
+		"TEST:Operator:1 (version '1' in a.xml, version '2' in b.xml)"
+	if issues[1].Message != want {
+		t.Errorf("Expected message %q, got %q", want, issues[1].Message)
+	}
+
+	repo.Clear()
+	if issues := repo.ValidateVersionConsistencyAcrossFiles(); len(issues) != 0 {
+		t.Errorf("Expected no issues after Clear(), got %+v", issues)
+	}
 }
 
 func TestEntityTypeValidation(t *testing.T) {
@@ -144,6 +212,32 @@ func TestEntityTypeValidation(t *testing.T) {
 	})
 }
 
+func TestCodespacePattern(t *testing.T) {
+	repo := NewNetexIdRepository()
+	if err := repo.AddId("NO:Line:1", "1", "line.xml"); err != nil {
+		t.Fatalf("AddId failed: %v", err)
+	}
+	if err := repo.AddId("TEST:Line:2", "1", "line.xml"); err != nil {
+		t.Fatalf("AddId failed: %v", err)
+	}
+	if err := repo.AddId("123456", "1", "line.xml"); err != nil {
+		t.Fatalf("AddId failed: %v", err)
+	}
+
+	if issues := repo.ValidateIdFormat(); len(issues) != 0 {
+		t.Fatalf("Expected any codespace to be accepted without a pattern, got %+v", issues)
+	}
+
+	repo.SetCodespacePattern(regexp.MustCompile(`^[A-Z]{2}$`))
+	issues := repo.ValidateIdFormat()
+	if len(issues) != 1 {
+		t.Fatalf("Expected 1 issue, got %d: %+v", len(issues), issues)
+	}
+	if issues[0].Rule.Code != "NETEX_ID_7" || issues[0].Location.ElementID != "TEST:Line:2" {
+		t.Errorf("Expected NETEX_ID_7 for TEST:Line:2, got %s for %s", issues[0].Rule.Code, issues[0].Location.ElementID)
+	}
+}
+
 func TestExternalReferenceValidator(t *testing.T) {
 	t.Run("Default external reference validator", func(t *testing.T) {
 		validator := NewDefaultExternalReferenceValidator()
@@ -24,7 +24,11 @@ func (e *NetexIdExtractor) ExtractIds(fileName string, content []byte) ([]types.
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse XML: %w", err)
 	}
+	return e.ExtractIdsFromDocument(fileName, doc)
+}
 
+// ExtractIdsFromDocument extracts all NetEX IDs from an already parsed document
+func (e *NetexIdExtractor) ExtractIdsFromDocument(fileName string, doc *xmlquery.Node) ([]types.IdVersion, error) {
 	var ids []types.IdVersion
 
 	// Find all elements with @id attribute
@@ -34,7 +38,9 @@ func (e *NetexIdExtractor) ExtractIds(fileName string, content []byte) ([]types.
 		version := node.SelectAttr("version")
 
 		if id != "" {
-			ids = append(ids, types.NewIdVersion(id, version, fileName))
+			idVersion := types.NewIdVersion(id, version, fileName)
+			idVersion.ElementType = node.Data
+			ids = append(ids, idVersion)
 		}
 	}
 
@@ -47,7 +53,11 @@ func (e *NetexIdExtractor) ExtractReferences(fileName string, content []byte) ([
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse XML: %w", err)
 	}
+	return e.ExtractReferencesFromDocument(fileName, doc)
+}
 
+// ExtractReferencesFromDocument extracts all NetEX ID references from an already parsed document
+func (e *NetexIdExtractor) ExtractReferencesFromDocument(fileName string, doc *xmlquery.Node) ([]types.IdVersion, error) {
 	var references []types.IdVersion
 
 	// Common NetEX reference patterns
@@ -78,6 +88,7 @@ func (e *NetexIdExtractor) ExtractReferences(fileName string, content []byte) ([
 		"//BlockRef",                   // Block references
 		"//CourseOfJourneysRef",        // Course of journeys references
 		"//DeadRunRef",                 // Dead run references
+		"//VehicleTypeRef",             // Vehicle type references
 		"//InterchangeRef",             // Interchange references
 		"//NoticeRef",                  // Notice references
 		"//NoticeAssignmentRef",        // Notice assignment references
@@ -98,7 +109,7 @@ func (e *NetexIdExtractor) ExtractReferences(fileName string, content []byte) ([
 			}
 
 			if refId != "" {
-				references = append(references, types.NewIdVersion(refId, version, fileName))
+				references = append(references, newReference(node, refId, version, fileName))
 			}
 		}
 	}
@@ -123,7 +134,7 @@ func (e *NetexIdExtractor) ExtractReferences(fileName string, content []byte) ([
 			version := node.SelectAttr("version")
 
 			if refId != "" {
-				references = append(references, types.NewIdVersion(refId, version, fileName))
+				references = append(references, newReference(node, refId, version, fileName))
 			}
 		}
 	}
@@ -131,6 +142,20 @@ func (e *NetexIdExtractor) ExtractReferences(fileName string, content []byte) ([
 	return references, nil
 }
 
+// newReference creates a reference held by the given node, recording the node's name
+// and the ID of the nearest enclosing element with an ID
+func newReference(node *xmlquery.Node, refId, version, fileName string) types.IdVersion {
+	ref := types.NewIdVersion(refId, version, fileName)
+	ref.ElementType = node.Data
+	for parent := node.Parent; parent != nil; parent = parent.Parent {
+		if id := parent.SelectAttr("id"); id != "" {
+			ref.SourceID = id
+			break
+		}
+	}
+	return ref
+}
+
 // NetexIdValidator validates NetEX IDs using a repository
 type NetexIdValidator struct {
 	repository interfaces.IdRepository
@@ -169,6 +194,7 @@ func (v *NetexIdValidator) ValidateIds() ([]types.ValidationIssue, error) {
 	if repo, ok := v.repository.(*NetexIdRepository); ok {
 		consistency := repo.ValidateVersionConsistencyAcrossFiles()
 		allIssues = append(allIssues, consistency...)
+		allIssues = append(allIssues, repo.ValidateSelfReferences()...)
 	}
 
 	return allIssues, nil
@@ -180,6 +206,35 @@ func (v *NetexIdValidator) ExtractIds(fileName string, content []byte) error {
 	if err != nil {
 		return fmt.Errorf("failed to extract IDs: %w", err)
 	}
+	v.registerIds(fileName, ids)
+	return nil
+}
+
+// ExtractIdsFromDocument registers the IDs of an already parsed document
+func (v *NetexIdValidator) ExtractIdsFromDocument(fileName string, doc *xmlquery.Node) error {
+	var ids []types.IdVersion
+	var err error
+	if extractor, ok := v.extractor.(interfaces.DocumentIdExtractor); ok {
+		ids, err = extractor.ExtractIdsFromDocument(fileName, doc)
+	} else {
+		// Extractors without document support get the serialized document instead
+		ids, err = v.extractor.ExtractIds(fileName, []byte(doc.OutputXML(true)))
+	}
+	if err != nil {
+		return fmt.Errorf("failed to extract IDs: %w", err)
+	}
+	v.registerIds(fileName, ids)
+	return nil
+}
+
+// registerIds adds extracted IDs to the repository, marking common files by name
+func (v *NetexIdValidator) registerIds(fileName string, ids []types.IdVersion) {
+	if repo, ok := v.repository.(*NetexIdRepository); ok {
+		if IsCommonFileName(fileName) {
+			repo.MarkAsCommonFile(fileName)
+		}
+		repo.recordElementTypes(ids)
+	}
 
 	for _, id := range ids {
 		if err := v.repository.AddId(id.ID, id.Version, id.FileName); err != nil {
@@ -188,8 +243,6 @@ func (v *NetexIdValidator) ExtractIds(fileName string, content []byte) error {
 			continue
 		}
 	}
-
-	return nil
 }
 
 // ExtractReferences extracts references from XML content and registers them
@@ -198,12 +251,34 @@ func (v *NetexIdValidator) ExtractReferences(fileName string, content []byte) er
 	if err != nil {
 		return fmt.Errorf("failed to extract references: %w", err)
 	}
+	v.registerReferences(references)
+	return nil
+}
+
+// ExtractReferencesFromDocument registers the references of an already parsed document
+func (v *NetexIdValidator) ExtractReferencesFromDocument(fileName string, doc *xmlquery.Node) error {
+	var references []types.IdVersion
+	var err error
+	if extractor, ok := v.extractor.(interfaces.DocumentIdExtractor); ok {
+		references, err = extractor.ExtractReferencesFromDocument(fileName, doc)
+	} else {
+		references, err = v.extractor.ExtractReferences(fileName, []byte(doc.OutputXML(true)))
+	}
+	if err != nil {
+		return fmt.Errorf("failed to extract references: %w", err)
+	}
+	v.registerReferences(references)
+	return nil
+}
 
+// registerReferences adds extracted references to the repository
+func (v *NetexIdValidator) registerReferences(references []types.IdVersion) {
+	if repo, ok := v.repository.(*NetexIdRepository); ok {
+		repo.recordSelfReferences(references)
+	}
 	for _, ref := range references {
 		v.repository.AddReference(ref.ID, ref.Version, ref.FileName)
 	}
-
-	return nil
 }
 
 // GetRepository returns the underlying ID repository
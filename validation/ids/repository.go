@@ -2,7 +2,11 @@ package ids
 
 import (
 	"fmt"
+	"path"
+	"path/filepath"
 	"regexp"
+	"runtime"
+	"sort"
 	"strings"
 	"sync"
 
@@ -12,6 +16,10 @@ import (
 const (
 	// Constants for repeated strings
 	anyVersion = "any"
+
+	// minKeysPerFinalizeShard keeps small repositories on a single goroutine,
+	// where sharding costs more than it saves
+	minKeysPerFinalizeShard = 4096
 )
 
 // NetexIdRepository manages NetEX ID tracking and validation across files
@@ -24,10 +32,26 @@ type NetexIdRepository struct {
 	references map[string][]types.IdVersion
 	// Map of ID -> map[fileName]version for cross-file consistency checks
 	idToFiles map[string]map[string]string
+	// Map of ID -> map[fileName]version of every declaration, including the
+	// redeclarations in other files that are rejected as duplicates
+	declaredVersions map[string]map[string]string
 	// Map of filename -> bool for tracking common files
 	commonFiles map[string]bool
 	// Set of element names to ignore for ID uniqueness validation
 	ignorableElements map[string]bool
+	// Map of ID -> IdVersion for IDs declared outside the validated dataset
+	externalIds map[string]types.IdVersion
+	// Map of ID -> name of the element declaring it, including ignorable elements
+	elementTypes map[string]string
+	// Map of file, element ID and reference element name -> reference of an element
+	// to its own ID
+	selfReferences map[string]types.IdVersion
+	// Number of goroutines used to finalize reference and duplicate validation (0 = GOMAXPROCS)
+	finalizeWorkers int
+	// Pattern the codespace token of structured IDs must match (nil = any codespace)
+	codespacePattern *regexp.Regexp
+	// Whether version consistency also checks references and adds a dataset report
+	versionReport bool
 	// Thread safety
 	mu sync.RWMutex
 }
@@ -49,8 +73,12 @@ func NewNetexIdRepositoryWithIgnorableElements(ignorableElements []string) *Nete
 		fileIds:           make(map[string]map[string]bool),
 		references:        make(map[string][]types.IdVersion),
 		idToFiles:         make(map[string]map[string]string),
+		declaredVersions:  make(map[string]map[string]string),
 		commonFiles:       make(map[string]bool),
 		ignorableElements: ignorableMap,
+		externalIds:       make(map[string]types.IdVersion),
+		elementTypes:      make(map[string]string),
+		selfReferences:    make(map[string]types.IdVersion),
 	}
 }
 
@@ -91,6 +119,29 @@ func (r *NetexIdRepository) AddIdWithElementType(id, version, fileName, elementT
 		return nil // Skip registration for ignorable elements
 	}
 
+	// Keep every file's version, even when the declaration is rejected below
+	if r.declaredVersions[id] == nil {
+		r.declaredVersions[id] = make(map[string]string)
+	}
+	if _, seen := r.declaredVersions[id][fileName]; !seen {
+		r.declaredVersions[id][fileName] = version
+	}
+
+	// Track by file, before the duplicate check so GetDuplicateIds sees every
+	// file declaring the ID
+	if r.fileIds[fileName] == nil {
+		r.fileIds[fileName] = make(map[string]bool)
+	}
+	r.fileIds[fileName][id] = true
+
+	// Track versions per file for cross-file consistency
+	if r.idToFiles[id] == nil {
+		r.idToFiles[id] = make(map[string]string)
+	}
+	if _, seen := r.idToFiles[id][fileName]; !seen {
+		r.idToFiles[id][fileName] = version
+	}
+
 	// Check for duplicates
 	if existing, exists := r.ids[id]; exists {
 		if existing.FileName != fileName {
@@ -105,24 +156,20 @@ func (r *NetexIdRepository) AddIdWithElementType(id, version, fileName, elementT
 	}
 
 	// Register the ID
-	idVersion := types.NewIdVersion(id, version, fileName)
-	r.ids[id] = idVersion
-
-	// Track by file
-	if r.fileIds[fileName] == nil {
-		r.fileIds[fileName] = make(map[string]bool)
-	}
-	r.fileIds[fileName][id] = true
-
-	// Track versions per file for cross-file consistency
-	if r.idToFiles[id] == nil {
-		r.idToFiles[id] = make(map[string]string)
-	}
-	r.idToFiles[id][fileName] = version
+	r.ids[id] = types.NewIdVersion(id, version, fileName)
 
 	return nil
 }
 
+// AddExternalId registers an ID declared outside the validated dataset, such as in
+// the baseline of a delta dataset. References to it resolve, but it takes no part
+// in duplicate, format or version validation.
+func (r *NetexIdRepository) AddExternalId(id, version, fileName string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.externalIds[id] = types.NewIdVersion(id, version, fileName)
+}
+
 // AddReference registers a reference to a NetEX ID
 func (r *NetexIdRepository) AddReference(refId, version, fileName string) {
 	r.mu.Lock()
@@ -142,16 +189,33 @@ func (r *NetexIdRepository) ValidateReferencesForReport(reportId string) []types
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
-	var issues []types.ValidationIssue
-
 	// Get shared IDs from common files
-	sharedIds := r.GetSharedNetexIds(reportId)
+	sharedIds := r.sharedNetexIds()
+
+	refIds := make([]string, 0, len(r.references))
+	for refId := range r.references {
+		refIds = append(refIds, refId)
+	}
+	sort.Strings(refIds)
+
+	issues := r.finalizeSharded(refIds, func(shard []string) []types.ValidationIssue {
+		return r.validateReferenceShard(shard, sharedIds)
+	})
+	sortIssues(issues)
+	return issues
+}
+
+// validateReferenceShard validates the references to the given IDs. The caller must
+// hold the read lock; shards only read the repository maps.
+func (r *NetexIdRepository) validateReferenceShard(refIds []string, sharedIds map[string]bool) []types.ValidationIssue {
+	var issues []types.ValidationIssue
 
-	for refId, references := range r.references {
+	for _, refId := range refIds {
+		references := r.references[refId]
 		// Check if the referenced ID exists locally
 		if _, exists := r.ids[refId]; !exists {
-			// Remove references that are found in shared/common files
-			if !sharedIds[refId] {
+			// Remove references that are found in shared/common files or outside the dataset
+			if _, external := r.externalIds[refId]; !sharedIds[refId] && !external {
 				// Apply external reference validators (if any)
 				validatedExternalRefs := r.validateExternalReferences(references)
 
@@ -290,44 +354,105 @@ func (r *NetexIdRepository) ValidateVersions() []types.ValidationIssue {
 	return issues
 }
 
-// ValidateVersionConsistencyAcrossFiles checks that the same ID has consistent version across files
+// ValidateVersionConsistencyAcrossFiles reports every ID declared with different
+// versions in different files, naming the version found in each file. With the
+// version report enabled, versions on references count as well and a single
+// NETEX_ID_14 finding lists every ID whose versions disagree across the dataset.
 func (r *NetexIdRepository) ValidateVersionConsistencyAcrossFiles() []types.ValidationIssue {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
+	ids := make([]string, 0, len(r.declaredVersions))
+	for id := range r.declaredVersions {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
 	var issues []types.ValidationIssue
-	for id, fileToVersion := range r.idToFiles {
-		var seenVersion string
-		for _, v := range fileToVersion {
-			if v == "" || v == anyVersion {
-				continue
-			}
-			if seenVersion == "" {
-				seenVersion = v
-			} else if v != seenVersion {
-				// version mismatch across files
-				// collect files for context
-				var files []string
-				for fn := range fileToVersion {
-					files = append(files, fn)
-				}
-				issues = append(issues, types.ValidationIssue{
-					Rule: types.ValidationRule{
-						Code:     "NETEX_ID_10",
-						Name:     "NeTEx ID version mismatch across files",
-						Message:  fmt.Sprintf("ID '%s' has conflicting versions across files", id),
-						Severity: types.ERROR,
-					},
-					Location: types.DataLocation{ElementID: id},
-					Message:  fmt.Sprintf("ID '%s' appears with different versions across files: %v", id, files),
-				})
-				break
+	var conflicts []string
+	for _, id := range ids {
+		declared := versionUses(r.declaredVersions[id], "")
+		if distinctVersions(declared) > 1 {
+			issues = append(issues, types.ValidationIssue{
+				Rule: types.ValidationRule{
+					Code:     "NETEX_ID_10",
+					Name:     "NeTEx ID version mismatch across files",
+					Message:  fmt.Sprintf("ID '%s' has conflicting versions across files", id),
+					Severity: types.ERROR,
+				},
+				Location: types.DataLocation{ElementID: id},
+				Message:  fmt.Sprintf("ID '%s' is declared with different versions across files: %s", id, joinVersionUses(declared)),
+			})
+		}
+
+		if !r.versionReport {
+			continue
+		}
+		referenced := make(map[string]string)
+		for _, ref := range r.references[id] {
+			if _, seen := referenced[ref.FileName]; !seen && ref.Version != "" && ref.Version != anyVersion {
+				referenced[ref.FileName] = ref.Version
 			}
 		}
+		uses := append(declared, versionUses(referenced, "referenced ")...)
+		if distinctVersions(uses) > 1 {
+			conflicts = append(conflicts, fmt.Sprintf("%s (%s)", id, joinVersionUses(uses)))
+		}
+	}
+
+	if len(conflicts) > 0 {
+		issues = append(issues, types.ValidationIssue{
+			Rule: types.ValidationRule{
+				Code:     "NETEX_ID_14",
+				Name:     "NeTEx ID versions inconsistent across dataset",
+				Message:  "IDs are declared or referenced with different versions across the dataset",
+				Severity: types.WARNING,
+			},
+			Message: fmt.Sprintf("%d ID(s) have conflicting versions across the dataset: %s", len(conflicts), strings.Join(conflicts, "; ")),
+		})
 	}
 	return issues
 }
 
+// versionUse is the version an ID has in one file
+type versionUse struct {
+	fileName string
+	version  string
+	label    string
+}
+
+// versionUses lists the explicit versions of fileToVersion sorted by file name,
+// skipping empty and "any" versions
+func versionUses(fileToVersion map[string]string, label string) []versionUse {
+	var uses []versionUse
+	for fileName, version := range fileToVersion {
+		if version == "" || version == anyVersion {
+			continue
+		}
+		uses = append(uses, versionUse{fileName: fileName, version: version, label: label})
+	}
+	sort.Slice(uses, func(i, j int) bool { return uses[i].fileName < uses[j].fileName })
+	return uses
+}
+
+// distinctVersions counts the different versions in uses
+func distinctVersions(uses []versionUse) int {
+	versions := make(map[string]struct{}, len(uses))
+	for _, use := range uses {
+		versions[use.version] = struct{}{}
+	}
+	return len(versions)
+}
+
+// joinVersionUses formats uses as "version '1' in a.xml, referenced version '2' in b.xml"
+func joinVersionUses(uses []versionUse) string {
+	parts := make([]string, len(uses))
+	for i, use := range uses {
+		parts[i] = fmt.Sprintf("%sversion '%s' in %s", use.label, use.version, use.fileName)
+	}
+	return strings.Join(parts, ", ")
+}
+
 // GetIdsByFile returns all IDs registered for a specific file
 func (r *NetexIdRepository) GetIdsByFile(fileName string) []string {
 	r.mu.RLock()
@@ -354,6 +479,80 @@ func (r *NetexIdRepository) GetAllIds() map[string]types.IdVersion {
 	return result
 }
 
+// recordElementTypes records the element type of each extracted ID. IDs of
+// ignorable elements are recorded too, as they still count as elements.
+func (r *NetexIdRepository) recordElementTypes(ids []types.IdVersion) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, id := range ids {
+		if id.ElementType != "" {
+			r.elementTypes[id.ID] = id.ElementType
+		}
+	}
+}
+
+// ElementCounts returns the number of distinct IDs declared per element type, such
+// as Line or ScheduledStopPoint
+func (r *NetexIdRepository) ElementCounts() map[string]int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	counts := make(map[string]int)
+	for _, elementType := range r.elementTypes {
+		counts[elementType]++
+	}
+	return counts
+}
+
+// recordSelfReferences records the references of an element to its own ID. The
+// reference patterns of the extractor overlap, so each element's reference is
+// recorded once per reference element name.
+func (r *NetexIdRepository) recordSelfReferences(refs []types.IdVersion) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, ref := range refs {
+		if ref.SourceID != "" && ref.SourceID == ref.ID {
+			r.selfReferences[ref.FileName+"\x00"+ref.ID+"\x00"+ref.ElementType] = ref
+		}
+	}
+}
+
+// ValidateSelfReferences reports every element referencing its own ID, such as a
+// Line whose RepresentedByGroupRef points at the Line itself
+func (r *NetexIdRepository) ValidateSelfReferences() []types.ValidationIssue {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	issues := make([]types.ValidationIssue, 0, len(r.selfReferences))
+	for _, ref := range r.selfReferences {
+		elementType := r.elementTypes[ref.ID]
+		if elementType == "" {
+			elementType = "Element"
+		}
+		issues = append(issues, types.ValidationIssue{
+			Rule: types.ValidationRule{
+				Code:     "SELF_REFERENCE",
+				Name:     "Element references itself",
+				Message:  "An element must not reference its own ID",
+				Severity: types.ERROR,
+			},
+			Location: types.DataLocation{
+				FileName:  ref.FileName,
+				ElementID: ref.ID,
+			},
+			Message: fmt.Sprintf("%s '%s' references itself through %s", elementType, ref.ID, ref.ElementType),
+		})
+	}
+	sortIssues(issues)
+	return issues
+}
+
+// IsCommonFileName reports whether a file holds shared data by naming convention:
+// common files such as _common.xml start with an underscore, per-line files do not
+func IsCommonFileName(fileName string) bool {
+	return strings.HasPrefix(path.Base(filepath.ToSlash(fileName)), "_")
+}
+
 // MarkAsCommonFile marks a file as a common file for special duplicate ID handling
 func (r *NetexIdRepository) MarkAsCommonFile(fileName string) {
 	r.mu.Lock()
@@ -377,7 +576,11 @@ func (r *NetexIdRepository) Clear() {
 	r.fileIds = make(map[string]map[string]bool)
 	r.references = make(map[string][]types.IdVersion)
 	r.idToFiles = make(map[string]map[string]string)
+	r.declaredVersions = make(map[string]map[string]string)
 	r.commonFiles = make(map[string]bool)
+	r.externalIds = make(map[string]types.IdVersion)
+	r.elementTypes = make(map[string]string)
+	r.selfReferences = make(map[string]types.IdVersion)
 }
 
 // isValidNetexIdFormat validates NetEX ID format (flexible validation)
@@ -425,6 +628,10 @@ func (r *NetexIdRepository) isValidNetexIdFormat(id string) bool {
 		return false
 	}
 
+	if r.codespacePattern != nil && !r.codespacePattern.MatchString(tokens[0]) {
+		return false
+	}
+
 	// Find entity type token (can be at position 1 or 2 depending on format)
 	var entity string
 	if len(tokens) >= 3 {
@@ -473,75 +680,82 @@ func (r *NetexIdRepository) GetDuplicateIds() []types.ValidationIssue {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
-	var issues []types.ValidationIssue
-	fileCount := make(map[string]map[string]bool)
-
-	// Count files per ID
-	for fileName, ids := range r.fileIds {
-		for id := range ids {
-			if fileCount[id] == nil {
-				fileCount[id] = make(map[string]bool)
-			}
-			fileCount[id][fileName] = true
+	// idToFiles already maps each ID to the files declaring it
+	var duplicated []string
+	for id, files := range r.idToFiles {
+		if len(files) > 1 {
+			duplicated = append(duplicated, id)
 		}
 	}
+	sort.Strings(duplicated)
 
-	// Find duplicates
-	for id, files := range fileCount {
-		if len(files) > 1 {
-			var fileNames []string
-			var commonFileNames []string
-			var regularFileNames []string
-
-			for fileName := range files {
-				fileNames = append(fileNames, fileName)
-				if r.commonFiles[fileName] {
-					commonFileNames = append(commonFileNames, fileName)
-				} else {
-					regularFileNames = append(regularFileNames, fileName)
-				}
-			}
+	issues := r.finalizeSharded(duplicated, func(shard []string) []types.ValidationIssue {
+		return r.duplicateIdShard(shard)
+	})
+	sortIssues(issues)
+	return issues
+}
 
-			idVersion := r.ids[id]
-
-			// Determine rule based on file types
-			var rule types.ValidationRule
-			switch {
-			case len(commonFileNames) > 0 && len(regularFileNames) > 0:
-				// Mixed common and regular files - use regular duplicate rule
-				rule = types.ValidationRule{
-					Code:     "NETEX_ID_1",
-					Name:     "NeTEx ID duplicated across files",
-					Message:  fmt.Sprintf("NetEX ID '%s' appears in multiple files", id),
-					Severity: types.ERROR,
-				}
-			case len(commonFileNames) > 1:
-				// Only common files - use common duplicate rule (warning)
-				rule = types.ValidationRule{
-					Code:     "NETEX_ID_10",
-					Name:     "Duplicate NeTEx ID across common files",
-					Message:  fmt.Sprintf("NetEX ID '%s' appears in multiple common files", id),
-					Severity: types.WARNING,
-				}
-			default:
-				// Regular duplicate across non-common files
-				rule = types.ValidationRule{
-					Code:     "NETEX_ID_1",
-					Name:     "NeTEx ID duplicated across files",
-					Message:  fmt.Sprintf("NetEX ID '%s' appears in multiple files", id),
-					Severity: types.ERROR,
-				}
-			}
+// duplicateIdShard reports the given duplicated IDs. The caller must hold the read lock.
+func (r *NetexIdRepository) duplicateIdShard(duplicated []string) []types.ValidationIssue {
+	issues := make([]types.ValidationIssue, 0, len(duplicated))
 
-			issues = append(issues, types.ValidationIssue{
-				Rule: rule,
-				Location: types.DataLocation{
-					FileName:  idVersion.FileName,
-					ElementID: id,
-				},
-				Message: fmt.Sprintf("NetEX ID '%s' is duplicated in files: %s", id, strings.Join(fileNames, ", ")),
-			})
+	for _, id := range duplicated {
+		files := r.idToFiles[id]
+
+		var fileNames []string
+		var commonFileNames []string
+		var regularFileNames []string
+
+		for fileName := range files {
+			fileNames = append(fileNames, fileName)
+			if r.commonFiles[fileName] {
+				commonFileNames = append(commonFileNames, fileName)
+			} else {
+				regularFileNames = append(regularFileNames, fileName)
+			}
+		}
+		sort.Strings(fileNames)
+
+		idVersion := r.ids[id]
+
+		// Determine rule based on file types
+		var rule types.ValidationRule
+		switch {
+		case len(commonFileNames) > 0 && len(regularFileNames) > 0:
+			// Mixed common and regular files - use regular duplicate rule
+			rule = types.ValidationRule{
+				Code:     "NETEX_ID_1",
+				Name:     "NeTEx ID duplicated across files",
+				Message:  fmt.Sprintf("NetEX ID '%s' appears in multiple files", id),
+				Severity: types.ERROR,
+			}
+		case len(commonFileNames) > 1:
+			// Only common files - use common duplicate rule (warning)
+			rule = types.ValidationRule{
+				Code:     "NETEX_ID_10",
+				Name:     "Duplicate NeTEx ID across common files",
+				Message:  fmt.Sprintf("NetEX ID '%s' appears in multiple common files", id),
+				Severity: types.WARNING,
+			}
+		default:
+			// Regular duplicate across non-common files
+			rule = types.ValidationRule{
+				Code:     "NETEX_ID_1",
+				Name:     "NeTEx ID duplicated across files",
+				Message:  fmt.Sprintf("NetEX ID '%s' appears in multiple files", id),
+				Severity: types.ERROR,
+			}
 		}
+
+		issues = append(issues, types.ValidationIssue{
+			Rule: rule,
+			Location: types.DataLocation{
+				FileName:  idVersion.FileName,
+				ElementID: id,
+			},
+			Message: fmt.Sprintf("NetEX ID '%s' is duplicated in files: %s", id, strings.Join(fileNames, ", ")),
+		})
 	}
 
 	return issues
@@ -551,13 +765,17 @@ func (r *NetexIdRepository) GetDuplicateIds() []types.ValidationIssue {
 func (r *NetexIdRepository) GetSharedNetexIds(reportId string) map[string]bool {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
+	return r.sharedNetexIds()
+}
 
+// sharedNetexIds collects the IDs declared in common files. The caller must hold the lock.
+func (r *NetexIdRepository) sharedNetexIds() map[string]bool {
 	// For now, we'll return IDs from all common files
 	// This should be enhanced to track report-specific shared IDs
 	sharedIds := make(map[string]bool)
 
 	for fileName, ids := range r.fileIds {
-		if r.IsCommonFile(fileName) {
+		if r.commonFiles[fileName] {
 			for id := range ids {
 				sharedIds[id] = true
 			}
@@ -604,3 +822,93 @@ func (r *NetexIdRepository) validateExternalReferences(references []types.IdVers
 
 	return unvalidatedRefs
 }
+
+// SetFinalizeWorkers sets the number of goroutines used by ValidateReferences and
+// GetDuplicateIds. Zero or a negative value uses GOMAXPROCS.
+func (r *NetexIdRepository) SetFinalizeWorkers(n int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.finalizeWorkers = n
+}
+
+// SetVersionReport makes ValidateVersionConsistencyAcrossFiles also compare the
+// versions on references with the declared versions and add one finding listing
+// every ID with conflicting versions in the dataset
+func (r *NetexIdRepository) SetVersionReport(enabled bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.versionReport = enabled
+}
+
+// SetCodespacePattern restricts the codespace token of structured IDs, such as NO in
+// NO:Line:1, to values matching pattern. IDs with another codespace fail the format
+// check. A nil pattern accepts any codespace.
+func (r *NetexIdRepository) SetCodespacePattern(pattern *regexp.Regexp) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.codespacePattern = pattern
+}
+
+// finalizeSharded splits the sorted keys into contiguous shards, runs fn on each shard
+// concurrently and merges the results in shard order. The caller must hold the read
+// lock for the whole call, so shards can read the repository maps without locking.
+func (r *NetexIdRepository) finalizeSharded(keys []string, fn func(shard []string) []types.ValidationIssue) []types.ValidationIssue {
+	workers := r.finalizeWorkers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	if maxShards := (len(keys) + minKeysPerFinalizeShard - 1) / minKeysPerFinalizeShard; workers > maxShards {
+		workers = maxShards
+	}
+	if workers <= 1 {
+		return fn(keys)
+	}
+
+	shardSize := (len(keys) + workers - 1) / workers
+	results := make([][]types.ValidationIssue, workers)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		start := i * shardSize
+		if start >= len(keys) {
+			break
+		}
+		end := start + shardSize
+		if end > len(keys) {
+			end = len(keys)
+		}
+		wg.Add(1)
+		go func(i int, shard []string) {
+			defer wg.Done()
+			results[i] = fn(shard)
+		}(i, keys[start:end])
+	}
+	wg.Wait()
+
+	total := 0
+	for _, result := range results {
+		total += len(result)
+	}
+	issues := make([]types.ValidationIssue, 0, total)
+	for _, result := range results {
+		issues = append(issues, result...)
+	}
+	return issues
+}
+
+// sortIssues orders finalization issues by element, file and rule so that the
+// output does not depend on map iteration or shard scheduling
+func sortIssues(issues []types.ValidationIssue) {
+	sort.SliceStable(issues, func(i, j int) bool {
+		a, b := issues[i], issues[j]
+		if a.Location.ElementID != b.Location.ElementID {
+			return a.Location.ElementID < b.Location.ElementID
+		}
+		if a.Location.FileName != b.Location.FileName {
+			return a.Location.FileName < b.Location.FileName
+		}
+		if a.Rule.Code != b.Rule.Code {
+			return a.Rule.Code < b.Rule.Code
+		}
+		return a.Message < b.Message
+	})
+}
@@ -0,0 +1,80 @@
+package ids
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/theoremus-urban-solutions/netex-validator/types"
+)
+
+// newSyntheticRepository builds a repository with the given number of declared IDs
+// spread over files, one resolving reference per ID, plus a share of unresolved
+// references and version mismatches
+func newSyntheticRepository(tb testing.TB, idCount int) *NetexIdRepository {
+	tb.Helper()
+
+	repo := NewNetexIdRepository()
+	for i := 0; i < idCount; i++ {
+		id := fmt.Sprintf("TEST:ScheduledStopPoint:%d", i)
+		fileName := fmt.Sprintf("line_%d.xml", i%50)
+		if err := repo.AddId(id, "1", fileName); err != nil {
+			tb.Fatalf("AddId() error = %v", err)
+		}
+
+		switch {
+		case i%100 == 0:
+			repo.AddReference(fmt.Sprintf("TEST:ScheduledStopPoint:missing-%d", i), "1", fileName)
+		case i%100 == 1:
+			repo.AddReference(id, "2", fileName)
+		default:
+			repo.AddReference(id, "1", fileName)
+		}
+		if i%1000 == 0 {
+			// Rejected with an error like the extractor's duplicates, but still reported
+			_ = repo.AddId(id, "1", "shared.xml")
+		}
+	}
+	return repo
+}
+
+func finalizeIssues(repo *NetexIdRepository) []types.ValidationIssue {
+	issues := repo.ValidateReferences()
+	return append(issues, repo.GetDuplicateIds()...)
+}
+
+func TestNetexIdRepository_ParallelFinalizeMatchesSerial(t *testing.T) {
+	repo := newSyntheticRepository(t, 20000)
+
+	repo.SetFinalizeWorkers(1)
+	serial := finalizeIssues(repo)
+
+	repo.SetFinalizeWorkers(8)
+	parallel := finalizeIssues(repo)
+
+	// 200 unresolved references, 200 version mismatches and 20 duplicates
+	if len(serial) != 420 {
+		t.Fatalf("Expected 420 issues, got %d", len(serial))
+	}
+	if !reflect.DeepEqual(serial, parallel) {
+		t.Error("Expected parallel finalization to return the same issues in the same order as serial finalization")
+	}
+}
+
+func benchmarkFinalize(b *testing.B, workers int) {
+	repo := newSyntheticRepository(b, 300000)
+	repo.SetFinalizeWorkers(workers)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		finalizeIssues(repo)
+	}
+}
+
+func BenchmarkNetexIdRepository_FinalizeSerial(b *testing.B) {
+	benchmarkFinalize(b, 1)
+}
+
+func BenchmarkNetexIdRepository_FinalizeParallel(b *testing.B) {
+	benchmarkFinalize(b, 0)
+}
@@ -1,8 +1,12 @@
 package ids
 
 import (
+	"errors"
+	"strings"
 	"testing"
 
+	"github.com/antchfx/xmlquery"
+	"github.com/theoremus-urban-solutions/netex-validator/interfaces"
 	"github.com/theoremus-urban-solutions/netex-validator/types"
 )
 
@@ -117,3 +121,103 @@ func TestNetexIdValidatorBasic(t *testing.T) {
 		t.Logf("Found %d validation issues", len(issues))
 	})
 }
+
+func TestNetexIdValidatorSelfReference(t *testing.T) {
+	content := []byte(`<PublicationDelivery>
+  <lines>
+    <Line id="TEST:Line:1" version="1">
+      <RepresentedByGroupRef ref="TEST:Line:1" version="1"/>
+    </Line>
+    <Line id="TEST:Line:2" version="1">
+      <RepresentedByGroupRef ref="TEST:Network:1" version="1"/>
+    </Line>
+  </lines>
+</PublicationDelivery>`)
+
+	repo := NewNetexIdRepository()
+	validator := NewNetexIdValidator(repo, NewNetexIdExtractor())
+	if err := validator.ExtractIds("line.xml", content); err != nil {
+		t.Fatalf("ExtractIds: %v", err)
+	}
+	if err := validator.ExtractReferences("line.xml", content); err != nil {
+		t.Fatalf("ExtractReferences: %v", err)
+	}
+
+	issues, err := validator.ValidateIds()
+	if err != nil {
+		t.Fatalf("ValidateIds: %v", err)
+	}
+	var selfRefs []types.ValidationIssue
+	for _, issue := range issues {
+		if issue.Rule.Code == "SELF_REFERENCE" {
+			selfRefs = append(selfRefs, issue)
+		}
+	}
+	// The generic @ref and the RepresentedByGroupRef patterns both match, once reported
+	if len(selfRefs) != 1 {
+		t.Fatalf("expected 1 SELF_REFERENCE issue, got %d: %v", len(selfRefs), selfRefs)
+	}
+	issue := selfRefs[0]
+	if issue.Rule.Severity != types.ERROR {
+		t.Errorf("expected ERROR severity, got %v", issue.Rule.Severity)
+	}
+	if issue.Location.ElementID != "TEST:Line:1" || issue.Location.FileName != "line.xml" {
+		t.Errorf("unexpected location: %+v", issue.Location)
+	}
+	want := "Line 'TEST:Line:1' references itself through RepresentedByGroupRef"
+	if issue.Message != want {
+		t.Errorf("expected message %q, got %q", want, issue.Message)
+	}
+}
+
+// failingExtractor fails every extraction, from content and from parsed documents
+type failingExtractor struct{}
+
+var errExtraction = errors.New("extraction failed")
+
+func (failingExtractor) ExtractIds(string, []byte) ([]types.IdVersion, error) {
+	return nil, errExtraction
+}
+
+func (failingExtractor) ExtractReferences(string, []byte) ([]types.IdVersion, error) {
+	return nil, errExtraction
+}
+
+func (failingExtractor) ExtractIdsFromDocument(string, *xmlquery.Node) ([]types.IdVersion, error) {
+	return nil, errExtraction
+}
+
+func (failingExtractor) ExtractReferencesFromDocument(string, *xmlquery.Node) ([]types.IdVersion, error) {
+	return nil, errExtraction
+}
+
+// contentOnlyExtractor hides the document methods of the wrapped extractor
+type contentOnlyExtractor struct {
+	interfaces.IdExtractor
+}
+
+func TestNetexIdValidatorDocumentExtractionErrors(t *testing.T) {
+	doc, err := xmlquery.Parse(strings.NewReader(`<PublicationDelivery><Line id="TEST:Line:1" version="1"/></PublicationDelivery>`))
+	if err != nil {
+		t.Fatalf("Failed to parse document: %v", err)
+	}
+
+	extractors := map[string]interfaces.IdExtractor{
+		"document extractor": failingExtractor{},
+		"content extractor":  contentOnlyExtractor{failingExtractor{}},
+	}
+	for name, extractor := range extractors {
+		t.Run(name, func(t *testing.T) {
+			validator, ok := NewNetexIdValidator(NewNetexIdRepository(), extractor).(interfaces.DocumentIdValidator)
+			if !ok {
+				t.Fatal("Expected NetexIdValidator to implement DocumentIdValidator")
+			}
+			if err := validator.ExtractIdsFromDocument("test.xml", doc); !errors.Is(err, errExtraction) {
+				t.Errorf("ExtractIdsFromDocument() error = %v, want %v", err, errExtraction)
+			}
+			if err := validator.ExtractReferencesFromDocument("test.xml", doc); !errors.Is(err, errExtraction) {
+				t.Errorf("ExtractReferencesFromDocument() error = %v, want %v", err, errExtraction)
+			}
+		})
+	}
+}
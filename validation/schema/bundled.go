@@ -0,0 +1,93 @@
+package schema
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+	"time"
+)
+
+// bundledFiles holds the schema trees compiled into the binary, see bundled/README.md
+//
+//go:embed all:bundled
+var bundledFiles embed.FS
+
+// bundledSchemas is the schema set looked up before the disk cache and the network.
+// Tests replace it with an in-memory file system.
+var bundledSchemas fs.FS = mustSubFS(bundledFiles, "bundled")
+
+// bundledRootSchema is the root schema of each bundled version directory; the
+// schemas it includes and imports lie next to it as in the published tree
+const bundledRootSchema = "NeTEx_publication.xsd"
+
+func mustSubFS(fsys fs.FS, dir string) fs.FS {
+	sub, err := fs.Sub(fsys, dir)
+	if err != nil {
+		panic(fmt.Sprintf("bundled schemas: %v", err))
+	}
+	return sub
+}
+
+// BundledSchemaVersions returns the NetEX versions whose schema tree is compiled into
+// the binary, in ascending order
+func BundledSchemaVersions() []string {
+	entries, err := fs.ReadDir(bundledSchemas, ".")
+	if err != nil {
+		return nil
+	}
+	var versions []string
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		if _, err := fs.Stat(bundledSchemas, path.Join(entry.Name(), bundledRootSchema)); err == nil {
+			versions = append(versions, entry.Name())
+		}
+	}
+	sort.Strings(versions)
+	return versions
+}
+
+// isBundledVersion reports whether the schema tree of version is compiled into the binary
+func isBundledVersion(version string) bool {
+	for _, bundled := range BundledSchemaVersions() {
+		if bundled == sanitizeVersion(version) {
+			return true
+		}
+	}
+	return false
+}
+
+// bundledVersionList formats the bundled versions for error messages
+func bundledVersionList() string {
+	versions := BundledSchemaVersions()
+	if len(versions) == 0 {
+		return "none"
+	}
+	return strings.Join(versions, ", ")
+}
+
+// loadBundledSchema returns the bundled schema tree of a version
+func loadBundledSchema(version string) (*CachedSchema, error) {
+	dir := sanitizeVersion(version)
+	tree, err := fs.Sub(bundledSchemas, dir)
+	if err != nil {
+		return nil, fmt.Errorf("no bundled schema for version %s", version)
+	}
+	content, err := fs.ReadFile(tree, bundledRootSchema)
+	if err != nil {
+		return nil, fmt.Errorf("no bundled schema for version %s", version)
+	}
+	now := time.Now()
+	return &CachedSchema{
+		Version:  version,
+		URL:      "bundled:" + path.Join(dir, bundledRootSchema),
+		Content:  content,
+		Tree:     tree,
+		CachedAt: now,
+		LastUsed: now,
+	}, nil
+}
@@ -0,0 +1,203 @@
+package schema
+
+import (
+	"io/fs"
+	"os"
+	"path"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+const bundledTestDocument = `<?xml version="1.0" encoding="UTF-8"?>
+<PublicationDelivery xmlns="http://www.netex.org.uk/netex" version="1.15">
+	<PublicationTimestamp>2024-01-01T00:00:00</PublicationTimestamp>
+	<ParticipantRef>TEST</ParticipantRef>
+	<dataObjects/>
+</PublicationDelivery>`
+
+const bundledTestRootSchema = `<?xml version="1.0" encoding="UTF-8"?>
+<xsd:schema xmlns:xsd="http://www.w3.org/2001/XMLSchema">
+	<xsd:include schemaLocation="netex_framework/netex_all.xsd"/>
+</xsd:schema>`
+
+// useBundledTestSchemas replaces the bundled schema set with schemas of the given
+// versions for the duration of the test
+func useBundledTestSchemas(t *testing.T, versions ...string) {
+	t.Helper()
+	fsys := fstest.MapFS{"README.md": {Data: []byte("bundled schemas")}}
+	for _, version := range versions {
+		fsys[version+"/"+bundledRootSchema] = &fstest.MapFile{Data: []byte(bundledTestRootSchema)}
+		fsys[version+"/netex_framework/netex_all.xsd"] = &fstest.MapFile{Data: []byte(testSchemaContent)}
+	}
+	// A directory without a root schema is not a bundled version
+	fsys["1.0/netex_framework/netex_all.xsd"] = &fstest.MapFile{Data: []byte(testSchemaContent)}
+	previous := bundledSchemas
+	bundledSchemas = fsys
+	t.Cleanup(func() { bundledSchemas = previous })
+}
+
+// newOfflineXSDValidator creates a validator without network access and with an empty cache directory
+func newOfflineXSDValidator(t *testing.T, useBundled bool, bundledVersion string) (*XSDValidator, string) {
+	t.Helper()
+	options := DefaultXSDValidationOptions()
+	options.AllowNetworkDownload = false
+	options.CacheDirectory = t.TempDir()
+	options.UseBundledSchemas = useBundled
+	options.BundledSchemaVersion = bundledVersion
+	validator, err := NewXSDValidator(options)
+	if err != nil {
+		t.Fatalf("NewXSDValidator() error = %v", err)
+	}
+	return validator, options.CacheDirectory
+}
+
+func TestBundledSchemaVersions(t *testing.T) {
+	useBundledTestSchemas(t, "1.16", "1.15")
+
+	if got, want := BundledSchemaVersions(), []string{"1.15", "1.16"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("BundledSchemaVersions() = %v, want %v", got, want)
+	}
+}
+
+func TestValidateXML_BundledSchemas(t *testing.T) {
+	useBundledTestSchemas(t, "1.15", "1.16")
+
+	tests := []struct {
+		name           string
+		useBundled     bool
+		bundledVersion string
+		wantVersion    string
+	}{
+		{name: "declared version", useBundled: true, wantVersion: "1.15"},
+		{name: "pinned version", useBundled: true, bundledVersion: "1.16", wantVersion: "1.16"},
+		{name: "disabled", useBundled: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			validator, cacheDir := newOfflineXSDValidator(t, tt.useBundled, tt.bundledVersion)
+
+			if _, err := validator.ValidateXML([]byte(bundledTestDocument), "bundled.xml"); err != nil {
+				t.Fatalf("ValidateXML() error = %v", err)
+			}
+
+			cache := validator.schemaManager.schemaCache
+			if tt.wantVersion == "" {
+				if len(cache) != 0 {
+					t.Errorf("Expected no schema to be loaded, got %v", cache)
+				}
+				return
+			}
+			schema, ok := cache["netex_"+tt.wantVersion]
+			if !ok {
+				t.Fatalf("Expected the %s schema to be loaded, got %v", tt.wantVersion, cache)
+			}
+			if want := "bundled:" + tt.wantVersion + "/NeTEx_publication.xsd"; schema.URL != want {
+				t.Errorf("Expected schema from %s, got %s", want, schema.URL)
+			}
+			if schema.Tree == nil {
+				t.Fatal("Expected the bundled schema tree to be loaded")
+			}
+			if _, err := fs.Stat(schema.Tree, "netex_framework/netex_all.xsd"); err != nil {
+				t.Errorf("Expected the included schema in the tree: %v", err)
+			}
+			if entries, _ := os.ReadDir(cacheDir); len(entries) != 0 {
+				t.Errorf("Expected the cache directory to stay empty, got %d entries", len(entries))
+			}
+		})
+	}
+}
+
+func TestGetSchema_BundledBeforeNetwork(t *testing.T) {
+	useBundledTestSchemas(t, "1.15")
+	server, requests := newFlakyServer(t, 0)
+	registerTestSchemaVersion(t, "1.15", server.URL+"/NeTEx_publication.xsd")
+
+	sm := NewSchemaManager(t.TempDir())
+	sm.SetBundledSchemas(true)
+
+	schema, err := sm.GetSchema("1.15")
+	if err != nil {
+		t.Fatalf("GetSchema() error = %v", err)
+	}
+	if schema.Tree == nil {
+		t.Errorf("Expected the bundled schema, got one from %s", schema.URL)
+	}
+	if got := requests.Load(); got != 0 {
+		t.Errorf("Expected no download, got %d requests", got)
+	}
+}
+
+func TestNewXSDValidator_UnbundledVersion(t *testing.T) {
+	useBundledTestSchemas(t, "1.15")
+
+	options := DefaultXSDValidationOptions()
+	options.AllowNetworkDownload = false
+	options.CacheDirectory = t.TempDir()
+	options.BundledSchemaVersion = "1.16"
+
+	_, err := NewXSDValidator(options)
+	if err == nil || !strings.Contains(err.Error(), "1.16") || !strings.Contains(err.Error(), "1.15") {
+		t.Errorf("Expected an error naming the missing and the bundled versions, got %v", err)
+	}
+
+	// Without bundled schemas the version is not used, so it is not checked either
+	options.UseBundledSchemas = false
+	if _, err := NewXSDValidator(options); err != nil {
+		t.Errorf("NewXSDValidator() error = %v", err)
+	}
+}
+
+// requireBundledSchemasEnv makes TestBundledSchemas_Embedded fail instead of skip
+// when no schema tree is compiled in, as release builds must bundle them
+const requireBundledSchemasEnv = "NETEX_REQUIRE_BUNDLED_SCHEMAS"
+
+var schemaLocationPattern = regexp.MustCompile(`schemaLocation="([^"]+)"`)
+
+func TestBundledSchemas_Embedded(t *testing.T) {
+	embedded := mustSubFS(bundledFiles, "bundled")
+	previous := bundledSchemas
+	bundledSchemas = embedded
+	t.Cleanup(func() { bundledSchemas = previous })
+
+	versions := BundledSchemaVersions()
+	if len(versions) == 0 {
+		if os.Getenv(requireBundledSchemasEnv) != "" {
+			t.Fatal("No schema tree is bundled; run make bundle-schemas")
+		}
+		t.Skipf("No schema tree is bundled; set %s to require one", requireBundledSchemasEnv)
+	}
+
+	for _, version := range versions {
+		tree, err := fs.Sub(embedded, version)
+		if err != nil {
+			t.Fatalf("%s: %v", version, err)
+		}
+		// Every relative include and import must resolve inside the version's tree
+		err = fs.WalkDir(tree, ".", func(name string, entry fs.DirEntry, err error) error {
+			if err != nil || entry.IsDir() || path.Ext(name) != ".xsd" {
+				return err
+			}
+			content, err := fs.ReadFile(tree, name)
+			if err != nil {
+				return err
+			}
+			for _, match := range schemaLocationPattern.FindAllSubmatch(content, -1) {
+				location := string(match[1])
+				if strings.Contains(location, "://") {
+					continue
+				}
+				target := path.Join(path.Dir(name), location)
+				if _, err := fs.Stat(tree, target); err != nil {
+					t.Errorf("%s: %s includes %s, which is not bundled", version, name, location)
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("%s: %v", version, err)
+		}
+	}
+}
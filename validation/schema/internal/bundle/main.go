@@ -0,0 +1,157 @@
+// Command bundle downloads the NetEX schema trees compiled into the validator.
+//
+// Each version is fetched from its published xsd directory, starting at
+// NeTEx_publication.xsd and following every xsd:include, xsd:import and
+// xsd:redefine, and written to <dir>/<version> with the published layout:
+//
+//	go run ./validation/schema/internal/bundle -dir validation/schema/bundled 1.15 1.16
+package main
+
+import (
+	"bytes"
+	"encoding/xml"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	rootSchema   = "NeTEx_publication.xsd"
+	xsdNamespace = "http://www.w3.org/2001/XMLSchema"
+)
+
+func main() {
+	dir := flag.String("dir", filepath.Join("validation", "schema", "bundled"), "directory the schema trees are written to")
+	base := flag.String("base", "http://www.netex.org.uk/schema", "URL below which each version publishes its xsd directory")
+	flag.Parse()
+	if flag.NArg() == 0 {
+		fmt.Fprintln(os.Stderr, "usage: bundle [-dir dir] [-base url] version...")
+		os.Exit(2)
+	}
+
+	client := &http.Client{Timeout: 60 * time.Second}
+	for _, version := range flag.Args() {
+		root := strings.TrimSuffix(*base, "/") + "/" + version + "/xsd/"
+		files, skipped, err := fetchTree(client, root)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", version, err)
+			os.Exit(1)
+		}
+		if err := writeTree(filepath.Join(*dir, version), files); err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", version, err)
+			os.Exit(1)
+		}
+		fmt.Printf("%s: %d schemas\n", version, len(files))
+		for _, location := range skipped {
+			fmt.Printf("%s: not bundled, outside the schema tree: %s\n", version, location)
+		}
+	}
+}
+
+// fetchTree downloads the root schema below root and every schema it references,
+// keyed by their path relative to root. Schemas outside root are returned as skipped.
+func fetchTree(client *http.Client, root string) (map[string][]byte, []string, error) {
+	files := make(map[string][]byte)
+	var skipped []string
+	seen := map[string]bool{root + rootSchema: true}
+	queue := []string{root + rootSchema}
+	for len(queue) > 0 {
+		location := queue[0]
+		queue = queue[1:]
+
+		content, err := fetch(client, location)
+		if err != nil {
+			return nil, nil, err
+		}
+		files[strings.TrimPrefix(location, root)] = content
+
+		references, err := schemaLocations(content)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s: %w", location, err)
+		}
+		base, err := url.Parse(location)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, reference := range references {
+			resolved, err := base.Parse(reference)
+			if err != nil {
+				return nil, nil, fmt.Errorf("%s: invalid schemaLocation %q: %w", location, reference, err)
+			}
+			next := resolved.String()
+			if seen[next] {
+				continue
+			}
+			seen[next] = true
+			if !strings.HasPrefix(next, root) {
+				skipped = append(skipped, next)
+				continue
+			}
+			queue = append(queue, next)
+		}
+	}
+	return files, skipped, nil
+}
+
+// fetch downloads one schema
+func fetch(client *http.Client, location string) ([]byte, error) {
+	resp, err := client.Get(location) //nolint:gosec // Locations come from the published schemas
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %s", location, resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// schemaLocations returns the schemaLocation of every include, import and redefine of a schema
+func schemaLocations(content []byte) ([]string, error) {
+	var locations []string
+	decoder := xml.NewDecoder(bytes.NewReader(content))
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return locations, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		start, ok := token.(xml.StartElement)
+		if !ok || start.Name.Space != xsdNamespace {
+			continue
+		}
+		switch start.Name.Local {
+		case "include", "import", "redefine":
+			for _, attr := range start.Attr {
+				if attr.Name.Local == "schemaLocation" && attr.Value != "" {
+					locations = append(locations, attr.Value)
+				}
+			}
+		}
+	}
+}
+
+// writeTree replaces dir with the downloaded schemas
+func writeTree(dir string, files map[string][]byte) error {
+	if err := os.RemoveAll(dir); err != nil {
+		return err
+	}
+	for name, content := range files {
+		target := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(target), 0o750); err != nil {
+			return err
+		}
+		if err := os.WriteFile(target, content, 0o600); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestFetchTree(t *testing.T) {
+	schemas := map[string]string{
+		"/1.15/xsd/NeTEx_publication.xsd": `<xsd:schema xmlns:xsd="http://www.w3.org/2001/XMLSchema">
+	<xsd:import namespace="http://www.w3.org/XML/1998/namespace" schemaLocation="http://www.w3.org/2001/xml.xsd"/>
+	<xsd:include schemaLocation="netex_framework/netex_all.xsd"/>
+</xsd:schema>`,
+		"/1.15/xsd/netex_framework/netex_all.xsd": `<xsd:schema xmlns:xsd="http://www.w3.org/2001/XMLSchema">
+	<xsd:include schemaLocation="../netex_service/netex_line.xsd"/>
+	<xsd:include schemaLocation="netex_types.xsd"/>
+</xsd:schema>`,
+		"/1.15/xsd/netex_framework/netex_types.xsd": `<xsd:schema xmlns:xsd="http://www.w3.org/2001/XMLSchema"/>`,
+		"/1.15/xsd/netex_service/netex_line.xsd": `<xsd:schema xmlns:xsd="http://www.w3.org/2001/XMLSchema">
+	<xsd:include schemaLocation="../netex_framework/netex_types.xsd"/>
+</xsd:schema>`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		content, ok := schemas[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(content))
+	}))
+	defer server.Close()
+
+	files, skipped, err := fetchTree(server.Client(), server.URL+"/1.15/xsd/")
+	if err != nil {
+		t.Fatalf("fetchTree() error = %v", err)
+	}
+
+	var names []string
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	want := []string{"NeTEx_publication.xsd", "netex_framework/netex_all.xsd", "netex_framework/netex_types.xsd", "netex_service/netex_line.xsd"}
+	if !reflect.DeepEqual(names, want) {
+		t.Errorf("Expected schemas %v, got %v", want, names)
+	}
+	if want := []string{"http://www.w3.org/2001/xml.xsd"}; !reflect.DeepEqual(skipped, want) {
+		t.Errorf("Expected skipped %v, got %v", want, skipped)
+	}
+
+	dir := filepath.Join(t.TempDir(), "1.15")
+	if err := writeTree(dir, files); err != nil {
+		t.Fatalf("writeTree() error = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "netex_service", "netex_line.xsd")); err != nil {
+		t.Errorf("Expected the tree to be written: %v", err)
+	}
+}
+
+func TestFetchTree_MissingSchema(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+
+	if _, _, err := fetchTree(server.Client(), server.URL+"/1.15/xsd/"); err == nil {
+		t.Error("Expected an error for a missing root schema")
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"regexp"
@@ -29,7 +30,45 @@ type SchemaManager struct {
 	schemaMutex   sync.RWMutex
 	schemaCache   map[string]*CachedSchema
 	enableNetwork bool
-	maxCacheAge   time.Duration
+	// useBundled looks schemas up in the set compiled into the binary first
+	useBundled  bool
+	maxCacheAge time.Duration
+	// downloadRetries is how many times a failed schema download is retried
+	downloadRetries int
+	// retryDelay is the wait before the first retry; it doubles on each retry
+	retryDelay time.Duration
+}
+
+// downloadSlots limits concurrent schema downloads across every SchemaManager
+// in the process. A nil channel means downloads are not limited.
+var (
+	downloadSlotsMu sync.Mutex
+	downloadSlots   chan struct{}
+)
+
+// SetMaxConcurrentDownloads limits how many schema downloads may run at the
+// same time within the process. Zero or a negative value removes the limit.
+func SetMaxConcurrentDownloads(n int) {
+	downloadSlotsMu.Lock()
+	defer downloadSlotsMu.Unlock()
+	if n <= 0 {
+		downloadSlots = nil
+		return
+	}
+	downloadSlots = make(chan struct{}, n)
+}
+
+// acquireDownloadSlot blocks until a download slot is free and returns the
+// function that releases it.
+func acquireDownloadSlot() func() {
+	downloadSlotsMu.Lock()
+	slots := downloadSlots
+	downloadSlotsMu.Unlock()
+	if slots == nil {
+		return func() {}
+	}
+	slots <- struct{}{}
+	return func() { <-slots }
 }
 
 // CachedSchema represents a cached XSD schema
@@ -38,6 +77,9 @@ type CachedSchema struct {
 	Version  string
 	URL      string
 	Content  []byte
+	// Tree holds the schemas Content includes and imports, at their paths relative
+	// to it. Only bundled schemas have one.
+	Tree     fs.FS
 	CachedAt time.Time
 	LastUsed time.Time
 }
@@ -100,6 +142,7 @@ func NewSchemaManager(cacheDir string) *SchemaManager {
 		schemaCache:   make(map[string]*CachedSchema),
 		enableNetwork: true,
 		maxCacheAge:   24 * time.Hour, // Cache schemas for 24 hours
+		retryDelay:    500 * time.Millisecond,
 	}
 }
 
@@ -108,6 +151,11 @@ func (sm *SchemaManager) SetNetworkEnabled(enabled bool) {
 	sm.enableNetwork = enabled
 }
 
+// SetBundledSchemas enables or disables the schemas compiled into the binary
+func (sm *SchemaManager) SetBundledSchemas(enabled bool) {
+	sm.useBundled = enabled
+}
+
 // SetMaxCacheAge sets the maximum age for cached schemas
 func (sm *SchemaManager) SetMaxCacheAge(maxAge time.Duration) {
 	sm.maxCacheAge = maxAge
@@ -121,6 +169,16 @@ func (sm *SchemaManager) SetHttpTimeout(timeout time.Duration) {
 	sm.httpClient = utils.NewOptimizedHTTPClient(opts)
 }
 
+// SetDownloadRetries sets how many times a failed schema download is retried
+// and the delay before the first retry. The delay doubles after each attempt.
+func (sm *SchemaManager) SetDownloadRetries(retries int, baseDelay time.Duration) {
+	if retries < 0 {
+		retries = 0
+	}
+	sm.downloadRetries = retries
+	sm.retryDelay = baseDelay
+}
+
 // DetectSchemaVersion detects the NetEX schema version from XML content
 func (sm *SchemaManager) DetectSchemaVersion(xmlContent []byte) (string, error) {
 	// Parse XML to detect schema version
@@ -180,6 +238,14 @@ func (sm *SchemaManager) GetSchema(version string) (*CachedSchema, error) {
 		}
 	}
 
+	// Bundled schemas need neither the disk cache nor the network
+	if sm.useBundled {
+		if schema, err := loadBundledSchema(version); err == nil {
+			sm.schemaCache[cacheKey] = schema
+			return schema, nil
+		}
+	}
+
 	// Try to load from disk cache
 	if schema, err := sm.loadFromDiskCache(version); err == nil {
 		sm.schemaCache[cacheKey] = schema
@@ -246,6 +312,34 @@ func (sm *SchemaManager) downloadSchema(version string) (*CachedSchema, error) {
 		}
 	}
 
+	var lastErr error
+	for attempt := 0; attempt <= sm.downloadRetries; attempt++ {
+		if attempt > 0 {
+			time.Sleep(sm.retryBackoff(attempt))
+		}
+
+		schema, err := sm.downloadFromSchemaURLs(version, schemaInfo)
+		if err == nil {
+			return schema, nil
+		}
+		lastErr = err
+	}
+
+	return nil, fmt.Errorf("failed to download schema for version %s after %d attempts: %w", version, sm.downloadRetries+1, lastErr)
+}
+
+// retryBackoff returns the wait before the given retry attempt (1-based)
+func (sm *SchemaManager) retryBackoff(attempt int) time.Duration {
+	// #nosec G115: attempt is small and positive; shift is bounded by downloadRetries
+	backoff := sm.retryDelay * time.Duration(1<<uint64(attempt-1))
+	if backoff > 30*time.Second || backoff < 0 {
+		backoff = 30 * time.Second // Cap at 30 seconds
+	}
+	return backoff
+}
+
+// downloadFromSchemaURLs tries each known URL for a schema version once
+func (sm *SchemaManager) downloadFromSchemaURLs(version string, schemaInfo *NetEXSchemaInfo) (*CachedSchema, error) {
 	// Try different schema URLs
 	var lastErr error
 	for _, schemaURL := range schemaInfo.SchemaURLs {
@@ -274,7 +368,7 @@ func (sm *SchemaManager) downloadSchema(version string) (*CachedSchema, error) {
 		}, nil
 	}
 
-	return nil, fmt.Errorf("failed to download schema for version %s: %w", version, lastErr)
+	return nil, lastErr
 }
 
 // downloadFromURL downloads content from a URL using the optimized HTTP client
@@ -283,6 +377,9 @@ func (sm *SchemaManager) downloadFromURL(url string) ([]byte, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
 	defer cancel()
 
+	release := acquireDownloadSlot()
+	defer release()
+
 	// Use optimized HTTP client with retry logic
 	resp, err := sm.httpClient.Get(ctx, url)
 	if err != nil {
@@ -364,6 +461,7 @@ func (sm *SchemaManager) GetCacheStats() map[string]interface{} {
 		"cacheDir":       sm.cacheDir,
 		"cachedSchemas":  len(sm.schemaCache),
 		"networkEnabled": sm.enableNetwork,
+		"bundledEnabled": sm.useBundled,
 		"maxCacheAge":    sm.maxCacheAge.String(),
 	}
 
@@ -0,0 +1,113 @@
+package schema
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+const testSchemaContent = `<?xml version="1.0" encoding="UTF-8"?>
+<xsd:schema xmlns:xsd="http://www.w3.org/2001/XMLSchema"/>`
+
+// registerTestSchemaVersion points a schema version at url for the duration of the test
+func registerTestSchemaVersion(t *testing.T, version, url string) {
+	t.Helper()
+	DefaultSchemaVersions[version] = &NetEXSchemaInfo{
+		Version:    version,
+		SchemaURLs: map[string]string{"NeTEx_publication": url},
+	}
+	t.Cleanup(func() { delete(DefaultSchemaVersions, version) })
+}
+
+// newFlakyServer drops the connection for the first failures requests and serves a schema afterwards
+func newFlakyServer(t *testing.T, failures int32) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) <= failures {
+			conn, _, err := w.(http.Hijacker).Hijack()
+			if err == nil {
+				_ = conn.Close()
+			}
+			return
+		}
+		_, _ = w.Write([]byte(testSchemaContent))
+	}))
+	t.Cleanup(server.Close)
+	return server, &requests
+}
+
+func TestSchemaManager_DownloadRetry(t *testing.T) {
+	server, requests := newFlakyServer(t, 1)
+	registerTestSchemaVersion(t, "test-flaky", server.URL+"/NeTEx_publication.xsd")
+
+	sm := NewSchemaManager(t.TempDir())
+	sm.SetDownloadRetries(2, time.Millisecond)
+
+	schema, err := sm.GetSchema("test-flaky")
+	if err != nil {
+		t.Fatalf("GetSchema() error = %v", err)
+	}
+	if string(schema.Content) != testSchemaContent {
+		t.Errorf("Unexpected schema content: %q", schema.Content)
+	}
+	if got := requests.Load(); got != 2 {
+		t.Errorf("Expected 2 download attempts, got %d", got)
+	}
+}
+
+func TestSchemaManager_DownloadWithoutRetry(t *testing.T) {
+	server, requests := newFlakyServer(t, 1)
+	registerTestSchemaVersion(t, "test-flaky", server.URL+"/NeTEx_publication.xsd")
+
+	sm := NewSchemaManager(t.TempDir())
+	sm.SetDownloadRetries(0, time.Millisecond)
+
+	if _, err := sm.GetSchema("test-flaky"); err == nil {
+		t.Fatal("Expected GetSchema() to fail without retries")
+	}
+	if got := requests.Load(); got != 1 {
+		t.Errorf("Expected 1 download attempt, got %d", got)
+	}
+}
+
+func TestSetMaxConcurrentDownloads(t *testing.T) {
+	SetMaxConcurrentDownloads(1)
+	t.Cleanup(func() { SetMaxConcurrentDownloads(0) })
+
+	var inFlight, maxInFlight atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			current := maxInFlight.Load()
+			if n <= current || maxInFlight.CompareAndSwap(current, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		_, _ = w.Write([]byte(testSchemaContent))
+	}))
+	t.Cleanup(server.Close)
+	registerTestSchemaVersion(t, "test-limited", server.URL+"/NeTEx_publication.xsd")
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sm := NewSchemaManager(t.TempDir())
+			if _, err := sm.GetSchema("test-limited"); err != nil {
+				t.Errorf("GetSchema() error = %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := maxInFlight.Load(); got != 1 {
+		t.Errorf("Expected at most 1 concurrent download, got %d", got)
+	}
+}
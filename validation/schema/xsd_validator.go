@@ -5,6 +5,7 @@ import (
 	"encoding/xml"
 	"fmt"
 	"io"
+	"io/fs"
 	"net/http"
 	"os"
 	"path/filepath"
@@ -14,6 +15,7 @@ import (
 
 	"github.com/theoremus-urban-solutions/netex-validator/logging"
 	errors "github.com/theoremus-urban-solutions/netex-validator/reporting"
+	"github.com/theoremus-urban-solutions/netex-validator/types"
 )
 
 const (
@@ -21,6 +23,18 @@ const (
 	xsdLatestVersion = "1.16"
 )
 
+// NetEX namespace URIs
+const (
+	// NetexNamespace is the current NetEX namespace
+	NetexNamespace = "http://www.netex.org.uk/netex"
+	// LegacyNetexNamespace is the deprecated CEN namespace, still found in older exports
+	LegacyNetexNamespace = "http://netex-cen.eu/netex"
+)
+
+// NamespaceRuleCode is the rule of the warnings about a root element in the legacy
+// or an unknown namespace
+const NamespaceRuleCode = "SCHEMA_NAMESPACE"
+
 // XSDValidator provides XML Schema (XSD) validation capabilities for NetEX files.
 type XSDValidator struct {
 	schemaCache      map[string]*XSDSchema
@@ -30,6 +44,8 @@ type XSDValidator struct {
 	mutex            sync.RWMutex
 	logger           *logging.Logger
 	allowNetwork     bool
+	useBundled       bool
+	bundledVersion   string
 	cacheExpiryHours int
 	// useLibxml2 controls whether to attempt libxml2-backed validation when available
 	useLibxml2 bool
@@ -37,9 +53,11 @@ type XSDValidator struct {
 
 // XSDSchema represents a cached XSD schema with metadata.
 type XSDSchema struct {
-	Version   string
-	Content   []byte
-	URL       string
+	Version string
+	Content []byte
+	URL     string
+	// Tree holds the included and imported schemas of a bundled schema
+	Tree      fs.FS
 	CachedAt  time.Time
 	ExpiresAt time.Time
 }
@@ -50,6 +68,12 @@ type XSDValidationOptions struct {
 	AllowNetworkDownload bool
 	// CacheDirectory specifies where to cache downloaded schemas
 	CacheDirectory string
+	// UseBundledSchemas looks schemas up in the set compiled into the binary before
+	// the cache and the network
+	UseBundledSchemas bool
+	// BundledSchemaVersion validates every document against the schema of this
+	// version instead of the version the document declares. Empty uses the declared version.
+	BundledSchemaVersion string
 	// CacheExpiryHours sets how long cached schemas remain valid
 	CacheExpiryHours int
 	// StrictMode fails validation on any schema-related errors
@@ -58,6 +82,13 @@ type XSDValidationOptions struct {
 	MaxSchemaSize int64
 	// HttpTimeoutSeconds controls the schema download timeout in seconds
 	HttpTimeoutSeconds int
+	// DownloadRetries sets how many times a failed schema download is retried
+	DownloadRetries int
+	// DownloadRetryDelay is the wait before the first retry; it doubles on each retry
+	DownloadRetryDelay time.Duration
+	// MaxConcurrentDownloads limits simultaneous schema downloads across the whole
+	// process. 0 leaves the current process-wide limit unchanged.
+	MaxConcurrentDownloads int
 	// UseLibxml2 enables libxml2-backed XSD validation when the build has libxml2 bindings
 	UseLibxml2 bool
 }
@@ -66,11 +97,13 @@ type XSDValidationOptions struct {
 func DefaultXSDValidationOptions() *XSDValidationOptions {
 	return &XSDValidationOptions{
 		AllowNetworkDownload: true,
+		UseBundledSchemas:    true,
 		CacheDirectory:       filepath.Join(os.TempDir(), "netex-schemas"),
 		CacheExpiryHours:     24 * 7, // 1 week
 		StrictMode:           false,
 		MaxSchemaSize:        50 * 1024 * 1024, // 50MB
 		HttpTimeoutSeconds:   30,
+		DownloadRetryDelay:   500 * time.Millisecond,
 		UseLibxml2:           false,
 	}
 }
@@ -80,6 +113,10 @@ func NewXSDValidator(options *XSDValidationOptions) (*XSDValidator, error) {
 	if options == nil {
 		options = DefaultXSDValidationOptions()
 	}
+	if options.UseBundledSchemas && options.BundledSchemaVersion != "" && !isBundledVersion(options.BundledSchemaVersion) {
+		return nil, fmt.Errorf("no bundled schema for NetEX version %s (bundled versions: %s)",
+			options.BundledSchemaVersion, bundledVersionList())
+	}
 
 	// Create cache directory if it doesn't exist
 	if err := os.MkdirAll(options.CacheDirectory, 0750); err != nil {
@@ -89,6 +126,7 @@ func NewXSDValidator(options *XSDValidationOptions) (*XSDValidator, error) {
 	// Create schema manager
 	schemaManager := NewSchemaManager(options.CacheDirectory)
 	schemaManager.SetNetworkEnabled(options.AllowNetworkDownload)
+	schemaManager.SetBundledSchemas(options.UseBundledSchemas)
 	schemaManager.SetMaxCacheAge(time.Duration(options.CacheExpiryHours) * time.Hour)
 
 	// Set timeout for schema downloads - use the configured timeout or default to 10s
@@ -98,6 +136,15 @@ func NewXSDValidator(options *XSDValidationOptions) (*XSDValidator, error) {
 	}
 	schemaManager.SetHttpTimeout(schemaTimeout)
 
+	retryDelay := options.DownloadRetryDelay
+	if retryDelay <= 0 {
+		retryDelay = 500 * time.Millisecond
+	}
+	schemaManager.SetDownloadRetries(options.DownloadRetries, retryDelay)
+	if options.MaxConcurrentDownloads > 0 {
+		SetMaxConcurrentDownloads(options.MaxConcurrentDownloads)
+	}
+
 	timeout := time.Duration(options.HttpTimeoutSeconds) * time.Second
 	if options.HttpTimeoutSeconds <= 0 {
 		timeout = 30 * time.Second
@@ -111,6 +158,8 @@ func NewXSDValidator(options *XSDValidationOptions) (*XSDValidator, error) {
 		},
 		logger:           logging.GetDefaultLogger(),
 		allowNetwork:     options.AllowNetworkDownload,
+		useBundled:       options.UseBundledSchemas,
+		bundledVersion:   options.BundledSchemaVersion,
 		cacheExpiryHours: options.CacheExpiryHours,
 		useLibxml2:       options.UseLibxml2,
 	}
@@ -146,26 +195,32 @@ func (v *XSDValidator) ValidateXML(xmlContent []byte, filename string) ([]*error
 	}
 
 	logger.Debug("Detected NetEX version", "version", version)
+	if v.useBundled && v.bundledVersion != "" {
+		version = v.bundledVersion
+	}
 
 	// Get schema using the schema manager
 	var cachedSchema *CachedSchema
 	var schema *XSDSchema
-	if v.allowNetwork {
+	if v.allowNetwork || v.useBundled {
 		cachedSchema, err = v.schemaManager.GetSchema(version)
-		if err != nil {
+		if err != nil && v.allowNetwork {
 			logger.Warn("Failed to get schema from schema manager; continuing with basic checks", "error", err.Error())
+		} else if err != nil {
+			logger.Debug("No bundled or cached schema; performing basic schema checks only", "error", err.Error())
 		} else {
 			// Convert CachedSchema to XSDSchema for compatibility
 			schema = &XSDSchema{
 				Version:   cachedSchema.Version,
 				Content:   cachedSchema.Content,
 				URL:       cachedSchema.URL,
+				Tree:      cachedSchema.Tree,
 				CachedAt:  cachedSchema.CachedAt,
 				ExpiresAt: cachedSchema.LastUsed.Add(24 * time.Hour), // Simple expiry logic
 			}
 		}
 	} else {
-		logger.Debug("Network download disabled; performing basic schema checks only")
+		logger.Debug("Network download and bundled schemas disabled; performing basic schema checks only")
 	}
 
 	// Perform XSD validation
@@ -275,6 +330,7 @@ func (v *XSDValidator) validateAgainstSchema(xmlContent []byte, schema *XSDSchem
 			Version:  schema.Version,
 			Content:  schema.Content,
 			URL:      schema.URL,
+			Tree:     schema.Tree,
 			CachedAt: schema.CachedAt,
 			LastUsed: time.Now(),
 		}
@@ -311,8 +367,8 @@ func (v *XSDValidator) performBasicValidation(xmlContent []byte, filename string
 	var validationErrors []*errors.ValidationError
 
 	// 1. Check for required root element - only when a NetEX namespace is present
-	hasNetexNs := bytes.Contains(xmlContent, []byte("http://www.netex.org.uk/netex")) ||
-		bytes.Contains(xmlContent, []byte("http://netex-cen.eu/netex"))
+	hasNetexNs := bytes.Contains(xmlContent, []byte(NetexNamespace)) ||
+		bytes.Contains(xmlContent, []byte(LegacyNetexNamespace))
 	missingRoot := hasNetexNs && !bytes.Contains(xmlContent, []byte("PublicationDelivery"))
 	if missingRoot {
 		validationErrors = append(validationErrors,
@@ -329,7 +385,12 @@ func (v *XSDValidator) performBasicValidation(xmlContent []byte, filename string
 				"Missing required NetEX namespace (expected http://www.netex.org.uk/netex or http://netex-cen.eu/netex)"))
 	}
 
-	// 3. Check for basic required elements
+	// 3. Check that the root element uses the current namespace
+	if namespaceErr := checkRootNamespace(xmlContent, filename); namespaceErr != nil {
+		validationErrors = append(validationErrors, namespaceErr)
+	}
+
+	// 4. Check for basic required elements
 	requiredElements := []string{
 		"PublicationTimestamp",
 		"ParticipantRef",
@@ -347,6 +408,42 @@ func (v *XSDValidator) performBasicValidation(xmlContent []byte, filename string
 	return validationErrors
 }
 
+// rootNamespace returns the namespace of the root element, or "" when it has none
+// or the content cannot be parsed
+func rootNamespace(xmlContent []byte) string {
+	decoder := xml.NewDecoder(bytes.NewReader(xmlContent))
+	for {
+		tok, err := decoder.Token()
+		if err != nil {
+			return ""
+		}
+		if start, ok := tok.(xml.StartElement); ok {
+			return start.Name.Space
+		}
+	}
+}
+
+// checkRootNamespace warns about a root element in the legacy or an unknown
+// namespace, naming the namespace found. Files without a namespace are reported as
+// missing the NetEX namespace instead.
+func checkRootNamespace(xmlContent []byte, filename string) *errors.ValidationError {
+	var message string
+	switch namespace := rootNamespace(xmlContent); namespace {
+	case "", NetexNamespace:
+		return nil
+	case LegacyNetexNamespace:
+		message = fmt.Sprintf("Root element uses the deprecated NetEX namespace %s; migrate to %s", namespace, NetexNamespace)
+	default:
+		message = fmt.Sprintf("Root element uses the unknown namespace %s; expected %s", namespace, NetexNamespace)
+	}
+	return errors.NewValidationError(NamespaceRuleCode, message).
+		WithFile(filename).
+		WithLocation(1, 0).
+		WithRule(NamespaceRuleCode).
+		WithSeverity(types.WARNING).
+		WithSuggestion("Declare xmlns=\"" + NetexNamespace + "\" on PublicationDelivery")
+}
+
 // loadCachedSchemas loads previously cached schemas from disk.
 func (v *XSDValidator) loadCachedSchemas() error {
 	v.mutex.Lock()
@@ -1,8 +1,11 @@
 package schema
 
 import (
+	"strings"
 	"testing"
 	"time"
+
+	"github.com/theoremus-urban-solutions/netex-validator/types"
 )
 
 const (
@@ -293,6 +296,62 @@ func TestValidateXML_BasicValidation(t *testing.T) {
 	}
 }
 
+func TestValidateXML_Namespace(t *testing.T) {
+	validator, err := NewXSDValidator(&XSDValidationOptions{
+		AllowNetworkDownload: false,
+		CacheDirectory:       t.TempDir(),
+	})
+	if err != nil {
+		t.Fatalf("NewXSDValidator() failed: %v", err)
+	}
+
+	document := func(namespace string) []byte {
+		return []byte(`<?xml version="1.0" encoding="UTF-8"?>
+<PublicationDelivery xmlns="` + namespace + `" version="1.16">
+	<PublicationTimestamp>2023-01-01T12:00:00</PublicationTimestamp>
+	<ParticipantRef>TEST</ParticipantRef>
+	<dataObjects/>
+</PublicationDelivery>`)
+	}
+
+	tests := []struct {
+		name      string
+		namespace string
+		want      string // expected namespace warning, empty for none
+	}{
+		{"current", NetexNamespace, ""},
+		{"legacy", LegacyNetexNamespace, "deprecated NetEX namespace http://netex-cen.eu/netex"},
+		{"unknown", "http://www.netex.org.uk/netex/v2", "unknown namespace http://www.netex.org.uk/netex/v2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			validationErrors, err := validator.ValidateXML(document(tt.namespace), "test.xml")
+			if err != nil {
+				t.Fatalf("ValidateXML() failed: %v", err)
+			}
+
+			var warnings []string
+			for _, verr := range validationErrors {
+				if verr.Rule != NamespaceRuleCode {
+					t.Errorf("Unexpected error %v", verr)
+					continue
+				}
+				if verr.Severity != types.WARNING {
+					t.Errorf("Expected a WARNING, got %v", verr.Severity)
+				}
+				warnings = append(warnings, verr.Message)
+			}
+
+			switch {
+			case tt.want == "" && len(warnings) > 0:
+				t.Errorf("Expected no namespace warning, got %v", warnings)
+			case tt.want != "" && (len(warnings) != 1 || !strings.Contains(warnings[0], tt.want)):
+				t.Errorf("Expected one warning containing %q, got %v", tt.want, warnings)
+			}
+		})
+	}
+}
+
 func TestXSDSchema(t *testing.T) {
 	schema := &XSDSchema{
 		Version:   "1.16",
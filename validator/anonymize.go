@@ -0,0 +1,151 @@
+package validator
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"regexp"
+	"strings"
+)
+
+const (
+	// anonymizedIdPrefix marks pseudonyms so they are not mistaken for real ids
+	anonymizedIdPrefix = "ANON"
+	// anonymizedHashLength is the number of hex characters of the hash kept in a pseudonym
+	anonymizedHashLength = 12
+	// anonymizationKeyLength is the size in bytes of a generated anonymization key
+	anonymizationKeyLength = 32
+)
+
+var (
+	// quotedIdPattern matches NeTEx ids quoted in finding messages, e.g. 'NSR:Quay:1'
+	quotedIdPattern = regexp.MustCompile(`'([A-Za-z0-9_\-]+:[A-Za-z0-9_\-]+:[^'\s]+)'`)
+	// idAttributePattern matches id and ref attributes in matched element snippets
+	idAttributePattern = regexp.MustCompile(`\b(id|ref)="([^"]+)"`)
+)
+
+// idAnonymizer replaces element ids with pseudonyms. The pseudonym keeps the
+// element type of a NeTEx id (CODESPACE:Type:Local becomes ANON:Type:<hash>) so
+// findings stay readable, and remembers the original id for de-anonymization.
+// The hash is an HMAC of the id, so pseudonyms of guessable ids such as
+// NSR:Quay:1 cannot be reversed without the key.
+type idAnonymizer struct {
+	key        []byte
+	pseudonyms map[string]string // original id -> pseudonym
+	originals  map[string]string // pseudonym -> original id
+}
+
+// newIdAnonymizer creates an anonymizer hashing with key, or with a random key
+// of its own when key is empty
+func newIdAnonymizer(key []byte) *idAnonymizer {
+	if len(key) == 0 {
+		key = make([]byte, anonymizationKeyLength)
+		if _, err := rand.Read(key); err != nil {
+			panic("failed to generate anonymization key: " + err.Error())
+		}
+	}
+	return &idAnonymizer{
+		key:        key,
+		pseudonyms: make(map[string]string),
+		originals:  make(map[string]string),
+	}
+}
+
+// pseudonym returns the pseudonym for id, creating it on first use
+func (a *idAnonymizer) pseudonym(id string) string {
+	if id == "" {
+		return ""
+	}
+	if p, ok := a.pseudonyms[id]; ok {
+		return p
+	}
+
+	hash := a.hash(id)
+	prefix := anonymizedIdPrefix
+	if parts := strings.Split(id, ":"); len(parts) == 3 && parts[1] != "" {
+		prefix += ":" + parts[1]
+	}
+
+	// Lengthen the hash on the (unlikely) collision with another id's pseudonym
+	var p string
+	for n := anonymizedHashLength; n <= len(hash); n += 4 {
+		p = prefix + ":" + hash[:n]
+		if _, taken := a.originals[p]; !taken {
+			break
+		}
+	}
+
+	a.pseudonyms[id] = p
+	a.originals[p] = id
+	return p
+}
+
+// hash returns the hex encoded HMAC of value
+func (a *idAnonymizer) hash(value string) string {
+	mac := hmac.New(sha256.New, a.key)
+	mac.Write([]byte(value))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// anonymizeEntry replaces the ids in an entry's location, message and snippet.
+// The fingerprint was hashed from the original ids, so it is hashed again with the
+// key; otherwise hashing candidate ids would reveal the original.
+func (a *idAnonymizer) anonymizeEntry(entry *ValidationReportEntry) {
+	if entry.Fingerprint != "" {
+		entry.Fingerprint = a.hash(entry.Fingerprint)[:len(entry.Fingerprint)]
+	}
+
+	elementID := entry.Location.ElementID
+	entry.Location.ElementID = a.pseudonym(elementID)
+
+	entry.Message = quotedIdPattern.ReplaceAllStringFunc(entry.Message, func(match string) string {
+		return "'" + a.pseudonym(match[1:len(match)-1]) + "'"
+	})
+	if elementID != "" {
+		entry.Message = replaceId(entry.Message, elementID, entry.Location.ElementID)
+	}
+
+	entry.MatchedSnippet = idAttributePattern.ReplaceAllStringFunc(entry.MatchedSnippet, func(match string) string {
+		groups := idAttributePattern.FindStringSubmatch(match)
+		return groups[1] + `="` + a.pseudonym(groups[2]) + `"`
+	})
+}
+
+// replaceId replaces the occurrences of id in message that are not part of a longer
+// id, so NO:Line:1 is replaced in "Line NO:Line:1." but not in "NO:Line:10"
+func replaceId(message, id, replacement string) string {
+	var b strings.Builder
+	for {
+		i := strings.Index(message, id)
+		if i < 0 {
+			b.WriteString(message)
+			return b.String()
+		}
+		end := i + len(id)
+		whole := (i == 0 || !isIdByte(message[i-1])) && (end == len(message) || !isIdByte(message[end]))
+		b.WriteString(message[:i])
+		if whole {
+			b.WriteString(replacement)
+		} else {
+			b.WriteString(id)
+		}
+		message = message[end:]
+	}
+}
+
+// isIdByte reports whether c can be part of a NeTEx id
+func isIdByte(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == ':' || c == '_' || c == '-'
+}
+
+// AnonymizedIds returns the pseudonyms used in the result's findings mapped to the
+// original element ids. It is empty unless the result was produced with
+// WithAnonymizeIds(true).
+func (r *ValidationResult) AnonymizedIds() map[string]string {
+	mapping := make(map[string]string, len(r.anonymizedIds))
+	for pseudonym, id := range r.anonymizedIds {
+		mapping[pseudonym] = id
+	}
+	return mapping
+}
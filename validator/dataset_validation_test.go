@@ -0,0 +1,1086 @@
+package validator
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/theoremus-urban-solutions/netex-validator/testutil"
+	"github.com/theoremus-urban-solutions/netex-validator/types"
+)
+
+// netexDocument wraps the given frames in a minimal PublicationDelivery
+func netexDocument(frames string) string {
+	return `<?xml version="1.0" encoding="UTF-8"?>
+<PublicationDelivery xmlns="http://www.netex.org.uk/netex" version="1.15">
+	<PublicationTimestamp>2023-01-01T00:00:00</PublicationTimestamp>
+	<ParticipantRef>TEST</ParticipantRef>
+	<dataObjects>
+` + frames + `
+	</dataObjects>
+</PublicationDelivery>`
+}
+
+// entriesNamed returns the report entries produced by the rule with the given name
+func entriesNamed(result *ValidationResult, name string) []ValidationReportEntry {
+	var entries []ValidationReportEntry
+	for _, entry := range result.ValidationReportEntries {
+		if entry.Name == name {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+// validateDataset validates the given files as a ZIP dataset without schema validation
+func validateDataset(t *testing.T, files map[string]string) *ValidationResult {
+	t.Helper()
+	tm := testutil.NewTestDataManager(t)
+	zipFile := tm.CreateTestZipFile(t, "dataset.zip", files)
+
+	options := DefaultValidationOptions().
+		WithCodespace(testutil.TestCodespace).
+		WithSkipSchema(true)
+
+	result, err := ValidateZip(zipFile, options)
+	if err != nil {
+		t.Fatalf("Dataset validation failed: %v", err)
+	}
+	return result
+}
+
+const routesFrame = `		<ServiceFrame id="TEST:ServiceFrame:Routes" version="1">
+			<lines>
+				<Line id="TEST:Line:1" version="1">
+					<Name>Line 1</Name>
+					<PublicCode>1</PublicCode>
+					<TransportMode>bus</TransportMode>
+					<OperatorRef ref="TEST:Operator:1" version="1"/>
+				</Line>
+			</lines>
+			<routes>
+				<Route id="TEST:Route:Used" version="1">
+					<Name>Used route</Name>
+					<LineRef ref="TEST:Line:1" version="1"/>
+					<DirectionType>outbound</DirectionType>
+					<pointsInSequence>
+						<PointOnRoute id="TEST:PointOnRoute:U1" version="1" order="1"/>
+						<PointOnRoute id="TEST:PointOnRoute:U2" version="1" order="2"/>
+					</pointsInSequence>
+				</Route>
+				<Route id="TEST:Route:Orphan" version="1">
+					<Name>Orphaned route</Name>
+					<LineRef ref="TEST:Line:1" version="1"/>
+					<DirectionType>inbound</DirectionType>
+					<pointsInSequence>
+						<PointOnRoute id="TEST:PointOnRoute:O1" version="1" order="1"/>
+						<PointOnRoute id="TEST:PointOnRoute:O2" version="1" order="2"/>
+					</pointsInSequence>
+				</Route>
+			</routes>
+		</ServiceFrame>`
+
+const journeyPatternsFrame = `		<ServiceFrame id="TEST:ServiceFrame:Patterns" version="1">
+			<scheduledStopPoints>
+				<ScheduledStopPoint id="TEST:ScheduledStopPoint:1" version="1">
+					<Name>Stop 1</Name>
+				</ScheduledStopPoint>
+				<ScheduledStopPoint id="TEST:ScheduledStopPoint:2" version="1">
+					<Name>Stop 2</Name>
+				</ScheduledStopPoint>
+			</scheduledStopPoints>
+			<journeyPatterns>
+				<JourneyPattern id="TEST:JourneyPattern:1" version="1">
+					<Name>Pattern 1</Name>
+					<RouteRef ref="TEST:Route:Used" version="1"/>
+					<pointsInSequence>
+						<StopPointInJourneyPattern id="TEST:StopPointInJourneyPattern:1" version="1" order="1">
+							<ScheduledStopPointRef ref="TEST:ScheduledStopPoint:1" version="1"/>
+						</StopPointInJourneyPattern>
+						<StopPointInJourneyPattern id="TEST:StopPointInJourneyPattern:2" version="1" order="2">
+							<ScheduledStopPointRef ref="TEST:ScheduledStopPoint:2" version="1"/>
+						</StopPointInJourneyPattern>
+					</pointsInSequence>
+				</JourneyPattern>
+			</journeyPatterns>
+		</ServiceFrame>`
+
+func TestDatasetValidation_OrphanedRoutes(t *testing.T) {
+	result := validateDataset(t, map[string]string{
+		"routes.xml":   netexDocument(routesFrame),
+		"patterns.xml": netexDocument(journeyPatternsFrame),
+	})
+
+	orphans := entriesNamed(result, "Orphaned route")
+	if len(orphans) != 1 {
+		t.Fatalf("Expected exactly 1 orphaned route, got %d: %+v", len(orphans), orphans)
+	}
+
+	orphan := orphans[0]
+	if orphan.Location.ElementID != "TEST:Route:Orphan" {
+		t.Errorf("Expected orphaned route TEST:Route:Orphan, got %s", orphan.Location.ElementID)
+	}
+	if orphan.FileName != "routes.xml" {
+		t.Errorf("Expected orphan to be reported in routes.xml, got %s", orphan.FileName)
+	}
+	if !strings.Contains(orphan.Message, "TEST:Line:1") {
+		t.Errorf("Expected message to name the route's line, got %q", orphan.Message)
+	}
+}
+
+func TestDatasetValidation_FileWithErrors(t *testing.T) {
+	// The missing order is an ERROR that stops the XPath validation of patterns.xml,
+	// but its JourneyPattern still uses TEST:Route:Used
+	patterns := strings.Replace(journeyPatternsFrame, `version="1" order="2"`, `version="1"`, 1)
+	result := validateDataset(t, map[string]string{
+		"routes.xml":   netexDocument(routesFrame),
+		"patterns.xml": netexDocument(patterns),
+	})
+
+	if entries := entriesNamed(result, "StopPointInJourneyPattern missing order"); len(entries) != 1 {
+		t.Fatalf("Expected patterns.xml to have 1 missing order error, got %+v", entries)
+	}
+	orphans := entriesNamed(result, "Orphaned route")
+	if len(orphans) != 1 || orphans[0].Location.ElementID != "TEST:Route:Orphan" {
+		t.Errorf("Expected only TEST:Route:Orphan to be orphaned, got %+v", orphans)
+	}
+	if entries := unresolvedReferencesTo(result, "TEST:ScheduledStopPoint:1"); len(entries) != 0 {
+		t.Errorf("Expected the stop points of patterns.xml to resolve, got %+v", entries)
+	}
+}
+
+func TestDatasetValidation_LinesWithoutRoutes(t *testing.T) {
+	// TEST:Line:1 in routes.xml has Routes; TEST:Line:2 is declared alone in lines.xml
+	lines := netexDocument(`		<ServiceFrame id="TEST:ServiceFrame:Lines" version="1">
+			<lines>
+				<Line id="TEST:Line:2" version="1">
+					<Name>Line 2</Name>
+					<PublicCode>2</PublicCode>
+					<TransportMode>bus</TransportMode>
+					<OperatorRef ref="TEST:Operator:1" version="1"/>
+				</Line>
+			</lines>
+		</ServiceFrame>`)
+	result := validateDataset(t, map[string]string{
+		"lines.xml":  lines,
+		"routes.xml": netexDocument(routesFrame),
+	})
+
+	routeless := entriesNamed(result, "Line without Route")
+	if len(routeless) != 1 {
+		t.Fatalf("Expected exactly 1 line without route, got %d: %+v", len(routeless), routeless)
+	}
+	if routeless[0].Location.ElementID != "TEST:Line:2" {
+		t.Errorf("Expected TEST:Line:2 to be reported, got %s", routeless[0].Location.ElementID)
+	}
+	if routeless[0].FileName != "lines.xml" {
+		t.Errorf("Expected the line to be reported in lines.xml, got %s", routeless[0].FileName)
+	}
+}
+
+func TestDatasetValidation_ChangedFiles(t *testing.T) {
+	// The unchanged file breaks LINE_2 (Line missing Name) and holds an unresolved
+	// reference; the changed file references the operator declared in the unchanged one.
+	changed := netexDocument(`		<ServiceFrame id="TEST:ServiceFrame:Changed" version="1">
+			<lines>
+				<Line id="TEST:Line:Changed" version="1">
+					<Name>Changed line</Name>
+					<PublicCode>1</PublicCode>
+					<TransportMode>bus</TransportMode>
+					<OperatorRef ref="TEST:Operator:1" version="1"/>
+				</Line>
+			</lines>
+		</ServiceFrame>`)
+	unchanged := netexDocument(`		<ResourceFrame id="TEST:ResourceFrame:1" version="1">
+			<organisations>
+				<Operator id="TEST:Operator:1" version="1">
+					<Name>Operator</Name>
+				</Operator>
+			</organisations>
+		</ResourceFrame>
+		<ServiceFrame id="TEST:ServiceFrame:Unchanged" version="1">
+			<lines>
+				<Line id="TEST:Line:Unchanged" version="1">
+					<TransportMode>bus</TransportMode>
+					<OperatorRef ref="TEST:Operator:Missing" version="1"/>
+				</Line>
+			</lines>
+		</ServiceFrame>`)
+
+	tm := testutil.NewTestDataManager(t)
+	zipFile := tm.CreateTestZipFile(t, "changed.zip", map[string]string{
+		"changed.xml":   changed,
+		"unchanged.xml": unchanged,
+	})
+
+	options := DefaultValidationOptions().
+		WithCodespace(testutil.TestCodespace).
+		WithSkipSchema(true).
+		WithChangedFiles([]string{"netex/changed.xml"})
+
+	result, err := ValidateZip(zipFile, options)
+	if err != nil {
+		t.Fatalf("Dataset validation failed: %v", err)
+	}
+
+	if len(result.ValidationReportEntries) == 0 {
+		t.Fatal("Expected findings for the changed file")
+	}
+	for _, entry := range result.ValidationReportEntries {
+		if entry.FileName != "changed.xml" {
+			t.Errorf("Expected findings only for changed.xml, got %s: %s", entry.FileName, entry.Message)
+		}
+		if strings.Contains(entry.Message, "TEST:Operator:1") {
+			t.Errorf("Expected reference to the unchanged file's operator to resolve, got %q", entry.Message)
+		}
+	}
+
+	changedLineIssues := 0
+	for _, entry := range result.ValidationReportEntries {
+		if strings.Contains(entry.Message, "TEST:Line:Changed") {
+			changedLineIssues++
+		}
+	}
+	if changedLineIssues == 0 {
+		t.Error("Expected business rules to run on the changed file")
+	}
+}
+
+func TestDatasetValidation_OperatingDayRefs(t *testing.T) {
+	calendar := netexDocument(`		<ServiceCalendarFrame id="TEST:ServiceCalendarFrame:1" version="1">
+			<operatingDays>
+				<OperatingDay id="TEST:OperatingDay:2024-01-01" version="1">
+					<CalendarDate>2024-01-01</CalendarDate>
+				</OperatingDay>
+			</operatingDays>
+		</ServiceCalendarFrame>`)
+
+	timetable := netexDocument(`		<TimetableFrame id="TEST:TimetableFrame:1" version="1">
+			<vehicleJourneys>
+				<DatedServiceJourney id="TEST:DatedServiceJourney:Valid" version="1">
+					<OperatingDayRef ref="TEST:OperatingDay:2024-01-01" version="1"/>
+				</DatedServiceJourney>
+				<DatedServiceJourney id="TEST:DatedServiceJourney:Dangling" version="1">
+					<OperatingDayRef ref="TEST:OperatingDay:2024-02-30" version="1"/>
+				</DatedServiceJourney>
+			</vehicleJourneys>
+		</TimetableFrame>`)
+
+	result := validateDataset(t, map[string]string{
+		"_calendar_shared_data.xml": calendar,
+		"timetable.xml":             timetable,
+	})
+
+	entries := entriesNamed(result, "DatedServiceJourney invalid OperatingDayRef")
+	if len(entries) != 1 {
+		t.Fatalf("Expected 1 dangling OperatingDayRef, got %d: %+v", len(entries), entries)
+	}
+
+	entry := entries[0]
+	if entry.Location.ElementID != "TEST:DatedServiceJourney:Dangling" {
+		t.Errorf("Expected the dangling journey to be reported, got %s", entry.Location.ElementID)
+	}
+	if entry.FileName != "timetable.xml" {
+		t.Errorf("Expected timetable.xml, got %s", entry.FileName)
+	}
+	if !strings.Contains(entry.Message, "TEST:OperatingDay:2024-02-30") {
+		t.Errorf("Expected message to name the missing OperatingDay, got %q", entry.Message)
+	}
+}
+
+func TestDatasetValidation_BaselineDataset(t *testing.T) {
+	baseline := netexDocument(`		<ResourceFrame id="TEST:ResourceFrame:1" version="1">
+			<organisations>
+				<Operator id="TEST:Operator:1" version="1">
+					<Name>Baseline operator</Name>
+				</Operator>
+			</organisations>
+		</ResourceFrame>
+		<ServiceCalendarFrame id="TEST:ServiceCalendarFrame:1" version="1">
+			<operatingDays>
+				<OperatingDay id="TEST:OperatingDay:2024-01-01" version="1">
+					<CalendarDate>2024-01-01</CalendarDate>
+				</OperatingDay>
+			</operatingDays>
+		</ServiceCalendarFrame>`)
+
+	delta := netexDocument(`		<ServiceFrame id="TEST:ServiceFrame:Delta" version="2">
+			<lines>
+				<Line id="TEST:Line:1" version="2">
+					<Name>Line 1</Name>
+					<PublicCode>1</PublicCode>
+					<TransportMode>bus</TransportMode>
+					<OperatorRef ref="TEST:Operator:1" version="1"/>
+				</Line>
+				<Line id="TEST:Line:2" version="2">
+					<Name>Line 2</Name>
+					<PublicCode>2</PublicCode>
+					<TransportMode>bus</TransportMode>
+					<OperatorRef ref="TEST:Operator:Missing" version="1"/>
+				</Line>
+			</lines>
+		</ServiceFrame>
+		<TimetableFrame id="TEST:TimetableFrame:Delta" version="2">
+			<vehicleJourneys>
+				<DatedServiceJourney id="TEST:DatedServiceJourney:1" version="2">
+					<OperatingDayRef ref="TEST:OperatingDay:2024-01-01" version="1"/>
+				</DatedServiceJourney>
+			</vehicleJourneys>
+		</TimetableFrame>`)
+
+	tm := testutil.NewTestDataManager(t)
+	baselineZip := tm.CreateTestZipFile(t, "baseline.zip", map[string]string{"full.xml": baseline})
+	deltaZip := tm.CreateTestZipFile(t, "delta.zip", map[string]string{"delta.xml": delta})
+
+	validateDelta := func(options *ValidationOptions) *ValidationResult {
+		t.Helper()
+		result, err := ValidateZip(deltaZip, options.WithCodespace(testutil.TestCodespace).WithSkipSchema(true))
+		if err != nil {
+			t.Fatalf("Dataset validation failed: %v", err)
+		}
+		if result.Error != nil {
+			t.Fatalf("Dataset validation failed: %s", result.Error)
+		}
+		return result
+	}
+
+	unresolvedIds := func(result *ValidationResult) map[string]bool {
+		ids := make(map[string]bool)
+		for _, entry := range entriesNamed(result, "NeTEx ID unresolved reference") {
+			ids[entry.Location.ElementID] = true
+		}
+		return ids
+	}
+
+	// Without the baseline every reference into it is dangling
+	result := validateDelta(DefaultValidationOptions())
+	if got := unresolvedIds(result); len(got) != 3 {
+		t.Errorf("Expected 3 unresolved references without a baseline, got %v", got)
+	}
+	if got := len(entriesNamed(result, "DatedServiceJourney invalid OperatingDayRef")); got != 1 {
+		t.Errorf("Expected the OperatingDayRef to be dangling without a baseline, got %d", got)
+	}
+
+	result = validateDelta(DefaultValidationOptions().WithBaselineDataset(baselineZip))
+	if got := unresolvedIds(result); len(got) != 1 || !got["TEST:Operator:Missing"] {
+		t.Errorf("Expected only TEST:Operator:Missing to be unresolved, got %v", got)
+	}
+	if got := entriesNamed(result, "DatedServiceJourney invalid OperatingDayRef"); len(got) != 0 {
+		t.Errorf("Expected the OperatingDayRef to resolve into the baseline, got %+v", got)
+	}
+	for _, entry := range result.ValidationReportEntries {
+		if strings.HasPrefix(entry.FileName, "baseline/") {
+			t.Errorf("Expected no findings in the baseline, got %s: %s", entry.FileName, entry.Message)
+		}
+	}
+}
+
+func TestDatasetValidation_Deterministic(t *testing.T) {
+	tm := testutil.NewTestDataManager(t)
+	zipFile := tm.CreateTestZipFile(t, "dataset.zip", map[string]string{
+		"routes.xml":   netexDocument(routesFrame),
+		"patterns.xml": netexDocument(journeyPatternsFrame),
+		"broken.xml": netexDocument(`		<ServiceFrame id="TEST:ServiceFrame:Broken" version="1">
+			<lines>
+				<Line id="TEST:Line:Broken" version="1">
+					<OperatorRef ref="TEST:Operator:Missing"/>
+				</Line>
+			</lines>
+		</ServiceFrame>`),
+	})
+
+	validate := func() (optimized, flat []byte) {
+		t.Helper()
+		options := DefaultValidationOptions().
+			WithCodespace(testutil.TestCodespace).
+			WithSkipSchema(true).
+			WithConcurrentFiles(4).
+			WithDeterministic(true)
+
+		result, err := ValidateZip(zipFile, options)
+		if err != nil {
+			t.Fatalf("Dataset validation failed: %v", err)
+		}
+		if len(result.ValidationReportEntries) < 2 {
+			t.Fatalf("Expected findings in several files, got %d", len(result.ValidationReportEntries))
+		}
+
+		optimized, err = result.ToJSON()
+		if err != nil {
+			t.Fatalf("ToJSON() error = %v", err)
+		}
+		flat, err = result.ToFlatJSON()
+		if err != nil {
+			t.Fatalf("ToFlatJSON() error = %v", err)
+		}
+		return optimized, flat
+	}
+
+	firstOptimized, firstFlat := validate()
+	secondOptimized, secondFlat := validate()
+	if string(firstOptimized) != string(secondOptimized) {
+		t.Errorf("Expected byte-identical JSON across deterministic runs:\n%s\n---\n%s", firstOptimized, secondOptimized)
+	}
+	if string(firstFlat) != string(secondFlat) {
+		t.Errorf("Expected byte-identical flat JSON across deterministic runs:\n%s\n---\n%s", firstFlat, secondFlat)
+	}
+}
+
+func TestDatasetValidation_FilePlacement(t *testing.T) {
+	organisations := `		<ResourceFrame id="TEST:ResourceFrame:%s" version="1">
+			<organisations>
+				<Operator id="TEST:Operator:%s" version="1">
+					<Name>Operator</Name>
+				</Operator>
+			</organisations>
+		</ResourceFrame>`
+	lines := `		<ServiceFrame id="TEST:ServiceFrame:%s" version="1">
+			<lines>
+				<Line id="TEST:Line:%s" version="1">
+					<Name>Line</Name>
+					<PublicCode>1</PublicCode>
+					<TransportMode>bus</TransportMode>
+					<OperatorRef ref="TEST:Operator:1" version="1"/>
+				</Line>
+			</lines>
+		</ServiceFrame>`
+	frame := func(template, name string) string {
+		return strings.ReplaceAll(template, "%s", name)
+	}
+
+	result := validateDataset(t, map[string]string{
+		"_common.xml": netexDocument(frame(organisations, "Shared") + frame(lines, "InCommon")),
+		"line_1.xml":  netexDocument(frame(organisations, "InLine") + frame(lines, "1")),
+	})
+
+	inLineFile := entriesNamed(result, "Organisation declared in line file")
+	if len(inLineFile) != 1 || inLineFile[0].Location.ElementID != "TEST:Operator:InLine" || inLineFile[0].FileName != "line_1.xml" {
+		t.Errorf("Expected TEST:Operator:InLine to be reported in line_1.xml, got %+v", inLineFile)
+	}
+	inCommonFile := entriesNamed(result, "Line declared in common file")
+	if len(inCommonFile) != 1 || inCommonFile[0].Location.ElementID != "TEST:Line:InCommon" || inCommonFile[0].FileName != "_common.xml" {
+		t.Errorf("Expected TEST:Line:InCommon to be reported in _common.xml, got %+v", inCommonFile)
+	}
+	for _, entry := range append(inLineFile, inCommonFile...) {
+		if entry.Severity != types.WARNING {
+			t.Errorf("Expected WARNING severity, got %v", entry.Severity)
+		}
+	}
+
+	// Without a common file there is nowhere else to declare the organisations
+	result = validateDataset(t, map[string]string{
+		"line_1.xml": netexDocument(frame(organisations, "InLine") + frame(lines, "1")),
+	})
+	if entries := entriesNamed(result, "Organisation declared in line file"); len(entries) != 0 {
+		t.Errorf("Expected no findings for a dataset without common files, got %+v", entries)
+	}
+}
+
+func TestDatasetValidation_CodespacePrefix(t *testing.T) {
+	files := map[string]string{
+		"_common.xml": netexDocument(`		<ResourceFrame id="TEST:ResourceFrame:1" version="1">
+			<codespaces>
+				<Codespace id="TEST:Codespace:PART" version="1">
+					<Xmlns>PART</Xmlns>
+					<XmlnsUrl>http://www.example.com/part</XmlnsUrl>
+				</Codespace>
+			</codespaces>
+			<organisations>
+				<Operator id="PART:Operator:1" version="1">
+					<Name>Partner operator</Name>
+				</Operator>
+				<Operator id="OTHER:Operator:2" version="1">
+					<Name>Foreign operator</Name>
+				</Operator>
+			</organisations>
+		</ResourceFrame>`),
+	}
+
+	tm := testutil.NewTestDataManager(t)
+	zipFile := tm.CreateTestZipFile(t, "dataset.zip", files)
+	validate := func(enforce bool) *ValidationResult {
+		options := DefaultValidationOptions().
+			WithCodespace(testutil.TestCodespace).
+			WithSkipSchema(true).
+			WithEnforceCodespacePrefix(enforce)
+		result, err := ValidateZip(zipFile, options)
+		if err != nil {
+			t.Fatalf("Dataset validation failed: %v", err)
+		}
+		return result
+	}
+
+	if entries := entriesNamed(validate(false), "Id without codespace prefix"); len(entries) != 0 {
+		t.Errorf("Expected no findings unless enforced, got %+v", entries)
+	}
+
+	entries := entriesNamed(validate(true), "Id without codespace prefix")
+	if len(entries) != 1 {
+		t.Fatalf("Expected exactly 1 finding, got %d: %+v", len(entries), entries)
+	}
+	entry := entries[0]
+	if entry.Location.ElementID != "OTHER:Operator:2" || entry.Severity != types.WARNING {
+		t.Errorf("Expected WARNING for OTHER:Operator:2, got %+v", entry)
+	}
+	for _, want := range []string{"'OTHER'", "PART:", testutil.TestCodespace + ":"} {
+		if !strings.Contains(entry.Message, want) {
+			t.Errorf("Expected message to contain %q, got %q", want, entry.Message)
+		}
+	}
+}
+
+func TestDatasetValidation_StrictDeadRuns(t *testing.T) {
+	files := map[string]string{
+		"routes.xml":   netexDocument(routesFrame),
+		"patterns.xml": netexDocument(journeyPatternsFrame),
+		"timetable.xml": netexDocument(`		<TimetableFrame id="TEST:TimetableFrame:1" version="1">
+			<vehicleJourneys>
+				<ServiceJourney id="TEST:ServiceJourney:1" version="1">
+					<JourneyPatternRef ref="TEST:JourneyPattern:1"/>
+				</ServiceJourney>
+				<DeadRun id="TEST:DeadRun:Shared" version="1">
+					<RouteRef ref="TEST:Route:Used"/>
+				</DeadRun>
+				<DeadRun id="TEST:DeadRun:Depot" version="1">
+					<RouteRef ref="TEST:Route:Orphan"/>
+				</DeadRun>
+			</vehicleJourneys>
+		</TimetableFrame>`),
+	}
+
+	tm := testutil.NewTestDataManager(t)
+	zipFile := tm.CreateTestZipFile(t, "dataset.zip", files)
+	validate := func(strict bool) *ValidationResult {
+		options := DefaultValidationOptions().
+			WithCodespace(testutil.TestCodespace).
+			WithSkipSchema(true).
+			WithStrictDeadRuns(strict)
+		result, err := ValidateZip(zipFile, options)
+		if err != nil {
+			t.Fatalf("Dataset validation failed: %v", err)
+		}
+		return result
+	}
+
+	if entries := entriesNamed(validate(false), "DeadRun on passenger route"); len(entries) != 0 {
+		t.Errorf("Expected no findings unless strict, got %+v", entries)
+	}
+
+	entries := entriesNamed(validate(true), "DeadRun on passenger route")
+	if len(entries) != 1 {
+		t.Fatalf("Expected exactly 1 finding, got %d: %+v", len(entries), entries)
+	}
+	entry := entries[0]
+	if entry.Location.ElementID != "TEST:DeadRun:Shared" || entry.FileName != "timetable.xml" || entry.Severity != types.WARNING {
+		t.Errorf("Expected WARNING for TEST:DeadRun:Shared in timetable.xml, got %+v", entry)
+	}
+	for _, want := range []string{"TEST:Route:Used", "TEST:ServiceJourney:1"} {
+		if !strings.Contains(entry.Message, want) {
+			t.Errorf("Expected message to contain %q, got %q", want, entry.Message)
+		}
+	}
+}
+
+func TestDatasetValidation_StrictCalendar(t *testing.T) {
+	files := map[string]string{
+		"calendar.xml": netexDocument(`		<ServiceCalendarFrame id="TEST:ServiceCalendarFrame:1" version="1">
+			<ServiceCalendar id="TEST:ServiceCalendar:1" version="1">
+				<dayTypes>
+					<DayType id="TEST:DayType:1" version="1"/>
+				</dayTypes>
+				<operatingPeriods>
+					<OperatingPeriod id="TEST:OperatingPeriod:1" version="1">
+						<FromDate>2024-01-01T00:00:00</FromDate>
+						<ToDate>2024-01-31T00:00:00</ToDate>
+					</OperatingPeriod>
+				</operatingPeriods>
+			</ServiceCalendar>
+		</ServiceCalendarFrame>`),
+		"days.xml": netexDocument(`		<ServiceCalendarFrame id="TEST:ServiceCalendarFrame:2" version="1">
+			<operatingDays>
+				<OperatingDay id="TEST:OperatingDay:Covered" version="1"><CalendarDate>2024-01-15</CalendarDate></OperatingDay>
+				<OperatingDay id="TEST:OperatingDay:Uncovered" version="1"><CalendarDate>2024-02-01</CalendarDate></OperatingDay>
+			</operatingDays>
+		</ServiceCalendarFrame>`),
+	}
+
+	tm := testutil.NewTestDataManager(t)
+	zipFile := tm.CreateTestZipFile(t, "dataset.zip", files)
+	validate := func(strict bool) *ValidationResult {
+		options := DefaultValidationOptions().
+			WithCodespace(testutil.TestCodespace).
+			WithSkipSchema(true).
+			WithStrictCalendar(strict)
+		result, err := ValidateZip(zipFile, options)
+		if err != nil {
+			t.Fatalf("Dataset validation failed: %v", err)
+		}
+		return result
+	}
+
+	if entries := entriesNamed(validate(false), "OperatingDay outside OperatingPeriods"); len(entries) != 0 {
+		t.Errorf("Expected no findings unless strict, got %+v", entries)
+	}
+
+	entries := entriesNamed(validate(true), "OperatingDay outside OperatingPeriods")
+	if len(entries) != 1 {
+		t.Fatalf("Expected exactly 1 finding, got %d: %+v", len(entries), entries)
+	}
+	entry := entries[0]
+	if entry.Location.ElementID != "TEST:OperatingDay:Uncovered" || entry.FileName != "days.xml" || entry.Severity != types.WARNING {
+		t.Errorf("Expected WARNING for TEST:OperatingDay:Uncovered in days.xml, got %+v", entry)
+	}
+	if !strings.Contains(entry.Message, "2024-02-01") {
+		t.Errorf("Expected message to contain the date, got %q", entry.Message)
+	}
+}
+
+func TestDatasetValidation_CodespacePattern(t *testing.T) {
+	files := map[string]string{
+		"line.xml": netexDocument(`		<ServiceFrame id="TEST:ServiceFrame:1" version="1">
+			<lines>
+				<Line id="TEST:Line:1" version="1">
+					<Name>Line 1</Name>
+					<PublicCode>1</PublicCode>
+					<TransportMode>bus</TransportMode>
+					<OperatorRef ref="TEST:Operator:1" version="1"/>
+				</Line>
+			</lines>
+		</ServiceFrame>`),
+	}
+
+	tm := testutil.NewTestDataManager(t)
+	zipFile := tm.CreateTestZipFile(t, "dataset.zip", files)
+	validate := func(pattern string) *ValidationResult {
+		options := DefaultValidationOptions().
+			WithCodespace(testutil.TestCodespace).
+			WithSkipSchema(true).
+			WithCodespacePattern(pattern)
+		result, err := ValidateZip(zipFile, options)
+		if err != nil {
+			t.Fatalf("Dataset validation failed: %v", err)
+		}
+		return result
+	}
+
+	if entries := entriesNamed(validate(""), "NeTEx ID invalid value"); len(entries) != 0 {
+		t.Errorf("Expected any codespace to be accepted by default, got %+v", entries)
+	}
+	if entries := entriesNamed(validate("[A-Z]{4}"), "NeTEx ID invalid value"); len(entries) != 0 {
+		t.Errorf("Expected TEST to match [A-Z]{4}, got %+v", entries)
+	}
+
+	var lineEntries []ValidationReportEntry
+	for _, entry := range entriesNamed(validate("[A-Z]{2}"), "NeTEx ID invalid value") {
+		if entry.Location.ElementID == "TEST:Line:1" {
+			lineEntries = append(lineEntries, entry)
+		}
+	}
+	if len(lineEntries) != 1 || lineEntries[0].Severity != types.ERROR {
+		t.Errorf("Expected an ERROR for TEST:Line:1 under [A-Z]{2}, got %+v", lineEntries)
+	}
+
+	if _, err := NewWithOptions(DefaultValidationOptions().WithCodespacePattern("[A-Z")); err == nil {
+		t.Error("Expected an invalid codespace pattern to be rejected")
+	}
+}
+
+func TestDatasetValidation_VersionConsistency(t *testing.T) {
+	operator := func(version string) string {
+		return netexDocument(`		<ResourceFrame id="TEST:ResourceFrame:` + version + `" version="1">
+			<organisations>
+				<Operator id="TEST:Operator:1" version="` + version + `">
+					<Name>Operator</Name>
+				</Operator>
+			</organisations>
+		</ResourceFrame>`)
+	}
+	files := map[string]string{
+		"operators_v1.xml": operator("1"),
+		"operators_v2.xml": operator("2"),
+	}
+	options := DefaultValidationOptions().
+		WithCodespace(testutil.TestCodespace).
+		WithSkipSchema(true).
+		WithVersionReport(true)
+
+	tm := testutil.NewTestDataManager(t)
+	zipResult, err := ValidateZip(tm.CreateTestZipFile(t, "dataset.zip", files), options)
+	if err != nil {
+		t.Fatalf("Dataset validation failed: %v", err)
+	}
+
+	v, err := NewWithOptions(options)
+	if err != nil {
+		t.Fatalf("NewWithOptions failed: %v", err)
+	}
+	v.BeginDataset()
+	for name, content := range files {
+		if _, err := v.ValidateContent([]byte(content), name); err != nil {
+			t.Fatalf("ValidateContent(%s) failed: %v", name, err)
+		}
+	}
+	datasetResult, err := v.EndDataset()
+	if err != nil {
+		t.Fatalf("EndDataset failed: %v", err)
+	}
+
+	for path, result := range map[string]*ValidationResult{"ZIP": zipResult, "dataset": datasetResult} {
+		mismatches := entriesNamed(result, "NeTEx ID version mismatch across files")
+		if len(mismatches) != 1 || mismatches[0].Location.ElementID != "TEST:Operator:1" {
+			t.Fatalf("%s: expected one version mismatch for TEST:Operator:1, got %+v", path, mismatches)
+		}
+		if !strings.Contains(mismatches[0].Message, "version '1' in operators_v1.xml, version '2' in operators_v2.xml") {
+			t.Errorf("%s: expected the version of each file in the message, got %q", path, mismatches[0].Message)
+		}
+
+		reports := entriesNamed(result, "NeTEx ID versions inconsistent across dataset")
+		if len(reports) != 1 {
+			t.Fatalf("%s: expected one dataset version report, got %+v", path, reports)
+		}
+		if !strings.Contains(reports[0].Message, "1 ID(s)") || !strings.Contains(reports[0].Message, "TEST:Operator:1 (version '1' in operators_v1.xml") {
+			t.Errorf("%s: expected the report to list TEST:Operator:1, got %q", path, reports[0].Message)
+		}
+	}
+
+	if reports := entriesNamed(validateDataset(t, files), "NeTEx ID versions inconsistent across dataset"); len(reports) != 0 {
+		t.Errorf("Expected no dataset version report by default, got %+v", reports)
+	}
+}
+
+// entriesWithCode returns the report entries produced by the rule with the given code
+func entriesWithCode(result *ValidationResult, code string) []ValidationReportEntry {
+	var entries []ValidationReportEntry
+	for _, entry := range result.ValidationReportEntries {
+		if entry.RuleCode == code {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+// authorityDocument declares TEST:Authority:1 in a ResourceFrame with the given id
+func authorityDocument(frameID string) string {
+	return netexDocument(`		<ResourceFrame id="TEST:ResourceFrame:` + frameID + `" version="1">
+			<organisations>
+				<Authority id="TEST:Authority:1" version="1">
+					<Name>Authority</Name>
+				</Authority>
+			</organisations>
+		</ResourceFrame>`)
+}
+
+func TestDatasetValidation_DuplicateIds(t *testing.T) {
+	result := validateDataset(t, map[string]string{
+		"a.xml": authorityDocument("A"),
+		"b.xml": authorityDocument("B"),
+	})
+
+	duplicates := entriesWithCode(result, "NETEX_ID_1")
+	if len(duplicates) != 1 || duplicates[0].Location.ElementID != "TEST:Authority:1" {
+		t.Fatalf("Expected one NETEX_ID_1 for TEST:Authority:1, got %+v", duplicates)
+	}
+	if !strings.Contains(duplicates[0].Message, "a.xml, b.xml") {
+		t.Errorf("Expected both files in the message, got %q", duplicates[0].Message)
+	}
+}
+
+func TestDatasetValidation_DuplicateIdsInCommonFiles(t *testing.T) {
+	result := validateDataset(t, map[string]string{
+		"_common.xml": authorityDocument("Common"),
+		"_shared.xml": authorityDocument("Shared"),
+	})
+
+	duplicates := entriesWithCode(result, "NETEX_ID_10")
+	if len(duplicates) != 1 || duplicates[0].Location.ElementID != "TEST:Authority:1" {
+		t.Fatalf("Expected one NETEX_ID_10 for TEST:Authority:1, got %+v", duplicates)
+	}
+	if duplicates[0].Severity != types.WARNING {
+		t.Errorf("Expected WARNING severity, got %v", duplicates[0].Severity)
+	}
+	if entries := entriesWithCode(result, "NETEX_ID_1"); len(entries) != 0 {
+		t.Errorf("Expected no NETEX_ID_1 for common files, got %+v", entries)
+	}
+}
+
+// unresolvedReferencesTo returns the unresolved reference findings naming id
+func unresolvedReferencesTo(result *ValidationResult, id string) []ValidationReportEntry {
+	var entries []ValidationReportEntry
+	for _, entry := range entriesNamed(result, "NeTEx ID unresolved reference") {
+		if strings.Contains(entry.Message, "'"+id+"'") {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+func TestNetexValidator_NoStateBetweenValidations(t *testing.T) {
+	v, err := NewWithOptions(DefaultValidationOptions().
+		WithCodespace(testutil.TestCodespace).
+		WithSkipSchema(true))
+	if err != nil {
+		t.Fatalf("NewWithOptions failed: %v", err)
+	}
+
+	// routes.xml declares the Route that patterns.xml in the ZIP refers to
+	if _, err := v.ValidateContent([]byte(netexDocument(routesFrame)), "routes.xml"); err != nil {
+		t.Fatalf("ValidateContent failed: %v", err)
+	}
+
+	tm := testutil.NewTestDataManager(t)
+	zipFile := tm.CreateTestZipFile(t, "patterns.zip", map[string]string{
+		"patterns.xml": netexDocument(journeyPatternsFrame),
+	})
+	for run := 1; run <= 2; run++ {
+		result, err := v.ValidateZip(zipFile)
+		if err != nil {
+			t.Fatalf("ValidateZip failed: %v", err)
+		}
+		if result.Error != nil {
+			t.Fatalf("ValidateZip reported an error: %s", result.Error)
+		}
+		if entries := unresolvedReferencesTo(result, "TEST:Route:Used"); len(entries) == 0 {
+			t.Errorf("Run %d: expected TEST:Route:Used to be unresolved within the ZIP, got %+v", run, result.ValidationReportEntries)
+		}
+		if entries := entriesNamed(result, "NeTEx ID duplicate"); len(entries) != 0 {
+			t.Errorf("Run %d: expected no duplicates from earlier runs, got %+v", run, entries)
+		}
+	}
+}
+
+func TestNetexValidator_Dataset(t *testing.T) {
+	v, err := NewWithOptions(DefaultValidationOptions().
+		WithCodespace(testutil.TestCodespace).
+		WithSkipSchema(true))
+	if err != nil {
+		t.Fatalf("NewWithOptions failed: %v", err)
+	}
+
+	if _, err := v.EndDataset(); err == nil {
+		t.Error("Expected EndDataset without BeginDataset to fail")
+	}
+
+	v.BeginDataset()
+	for name, content := range map[string]string{
+		"routes.xml":   netexDocument(routesFrame),
+		"patterns.xml": netexDocument(journeyPatternsFrame),
+	} {
+		if _, err := v.ValidateContent([]byte(content), name); err != nil {
+			t.Fatalf("ValidateContent(%s) failed: %v", name, err)
+		}
+	}
+	if result, err := v.ValidateZip("unused.zip"); err != nil || result.Error == nil {
+		t.Errorf("Expected ValidateZip to be refused while a dataset is open, got %+v, %v", result, err)
+	}
+
+	result, err := v.EndDataset()
+	if err != nil {
+		t.Fatalf("EndDataset failed: %v", err)
+	}
+	if result.FilesProcessed != 2 {
+		t.Errorf("Expected 2 files in the dataset, got %d", result.FilesProcessed)
+	}
+	if entries := unresolvedReferencesTo(result, "TEST:Route:Used"); len(entries) != 0 {
+		t.Errorf("Expected TEST:Route:Used to resolve across the dataset, got %+v", entries)
+	}
+	if orphans := entriesNamed(result, "Orphaned route"); len(orphans) != 1 || orphans[0].Location.ElementID != "TEST:Route:Orphan" {
+		t.Errorf("Expected dataset validators to report TEST:Route:Orphan, got %+v", orphans)
+	}
+
+	// A new dataset starts empty
+	v.BeginDataset()
+	if _, err := v.ValidateContent([]byte(netexDocument(journeyPatternsFrame)), "patterns.xml"); err != nil {
+		t.Fatalf("ValidateContent failed: %v", err)
+	}
+	result, err = v.EndDataset()
+	if err != nil {
+		t.Fatalf("EndDataset failed: %v", err)
+	}
+	if entries := unresolvedReferencesTo(result, "TEST:Route:Used"); len(entries) == 0 {
+		t.Errorf("Expected TEST:Route:Used to be unresolved in the second dataset, got %+v", result.ValidationReportEntries)
+	}
+}
+
+func TestDatasetValidation_StrictRouteDirections(t *testing.T) {
+	files := map[string]string{
+		"lines.xml": netexDocument(`		<ServiceFrame id="TEST:ServiceFrame:1" version="1">
+			<lines>
+				<Line id="TEST:Line:1" version="1">
+					<Name>Line 1</Name>
+					<TransportMode>bus</TransportMode>
+					<OperatorRef ref="TEST:Operator:1"/>
+				</Line>
+			</lines>
+		</ServiceFrame>`),
+		"routes.xml": netexDocument(`		<ServiceFrame id="TEST:ServiceFrame:2" version="1">
+			<routes>
+				<Route id="TEST:Route:1" version="1">
+					<Name>Route 1</Name>
+					<LineRef ref="TEST:Line:1"/>
+					<DirectionType>outbound</DirectionType>
+					<pointsInSequence>
+						<PointOnRoute id="TEST:PointOnRoute:1-1" version="1" order="1"/>
+						<PointOnRoute id="TEST:PointOnRoute:1-2" version="1" order="2"/>
+					</pointsInSequence>
+				</Route>
+				<Route id="TEST:Route:2" version="1">
+					<Name>Route 2</Name>
+					<LineRef ref="TEST:Line:1"/>
+					<DirectionType>outbound</DirectionType>
+					<pointsInSequence>
+						<PointOnRoute id="TEST:PointOnRoute:2-1" version="1" order="1"/>
+						<PointOnRoute id="TEST:PointOnRoute:2-2" version="1" order="2"/>
+					</pointsInSequence>
+				</Route>
+			</routes>
+		</ServiceFrame>`),
+	}
+
+	tm := testutil.NewTestDataManager(t)
+	zipFile := tm.CreateTestZipFile(t, "dataset.zip", files)
+	validate := func(strict bool) *ValidationResult {
+		options := DefaultValidationOptions().
+			WithCodespace(testutil.TestCodespace).
+			WithSkipSchema(true).
+			WithStrictRouteDirections(strict)
+		result, err := ValidateZip(zipFile, options)
+		if err != nil {
+			t.Fatalf("Dataset validation failed: %v", err)
+		}
+		return result
+	}
+
+	if entries := entriesNamed(validate(false), "Line Routes in a single direction"); len(entries) != 0 {
+		t.Errorf("Expected no findings unless strict, got %+v", entries)
+	}
+
+	entries := entriesNamed(validate(true), "Line Routes in a single direction")
+	if len(entries) != 1 {
+		t.Fatalf("Expected exactly 1 finding, got %d: %+v", len(entries), entries)
+	}
+	entry := entries[0]
+	if entry.Location.ElementID != "TEST:Line:1" || entry.FileName != "lines.xml" || entry.Severity != types.WARNING {
+		t.Errorf("Expected WARNING for TEST:Line:1 in lines.xml, got %+v", entry)
+	}
+	if !strings.Contains(entry.Message, "outbound") {
+		t.Errorf("Expected message to name the direction, got %q", entry.Message)
+	}
+}
+
+func TestDatasetValidation_FlagModeOutliers(t *testing.T) {
+	var lines strings.Builder
+	for i, mode := range []string{"rail", "rail", "rail", "bus"} {
+		fmt.Fprintf(&lines, `				<Line id="TEST:Line:%d" version="1"><Name>Line %d</Name><TransportMode>%s</TransportMode><OperatorRef ref="TEST:Operator:1"/><RepresentedByGroupRef ref="TEST:Network:1"/></Line>
+`, i+1, i+1, mode)
+	}
+	files := map[string]string{
+		"_common.xml": netexDocument(`		<ServiceFrame id="TEST:ServiceFrame:1" version="1">
+			<Network id="TEST:Network:1" version="1"><Name>Rail</Name></Network>
+		</ServiceFrame>`),
+		"lines.xml": netexDocument(`		<ServiceFrame id="TEST:ServiceFrame:2" version="1">
+			<lines>
+` + lines.String() + `			</lines>
+		</ServiceFrame>`),
+	}
+
+	tm := testutil.NewTestDataManager(t)
+	zipFile := tm.CreateTestZipFile(t, "dataset.zip", files)
+	validate := func(flag bool) *ValidationResult {
+		options := DefaultValidationOptions().
+			WithCodespace(testutil.TestCodespace).
+			WithSkipSchema(true).
+			WithFlagModeOutliers(flag)
+		result, err := ValidateZip(zipFile, options)
+		if err != nil {
+			t.Fatalf("Dataset validation failed: %v", err)
+		}
+		return result
+	}
+
+	if entries := entriesNamed(validate(false), "Line mode differs from its Network"); len(entries) != 0 {
+		t.Errorf("Expected no findings unless flagged, got %+v", entries)
+	}
+
+	entries := entriesNamed(validate(true), "Line mode differs from its Network")
+	if len(entries) != 1 {
+		t.Fatalf("Expected exactly 1 finding, got %d: %+v", len(entries), entries)
+	}
+	entry := entries[0]
+	if entry.Location.ElementID != "TEST:Line:4" || entry.FileName != "lines.xml" || entry.Severity != types.INFO {
+		t.Errorf("Expected INFO for TEST:Line:4 in lines.xml, got %+v", entry)
+	}
+}
+
+func TestDatasetValidation_AmbiguousNameThreshold(t *testing.T) {
+	var stops strings.Builder
+	for i := 1; i <= 12; i++ {
+		fmt.Fprintf(&stops, `				<ScheduledStopPoint id="TEST:ScheduledStopPoint:%d" version="1"><Name>School</Name></ScheduledStopPoint>
+`, i)
+	}
+	files := map[string]string{
+		"stops.xml": netexDocument(`		<ServiceFrame id="TEST:ServiceFrame:1" version="1">
+			<scheduledStopPoints>
+` + stops.String() + `			</scheduledStopPoints>
+		</ServiceFrame>`),
+	}
+
+	tm := testutil.NewTestDataManager(t)
+	zipFile := tm.CreateTestZipFile(t, "dataset.zip", files)
+	validate := func(threshold int) []ValidationReportEntry {
+		options := DefaultValidationOptions().
+			WithCodespace(testutil.TestCodespace).
+			WithSkipSchema(true).
+			WithAmbiguousNameThreshold(threshold)
+		result, err := ValidateZip(zipFile, options)
+		if err != nil {
+			t.Fatalf("Dataset validation failed: %v", err)
+		}
+		return entriesNamed(result, "ScheduledStopPoint ambiguous Name")
+	}
+
+	if entries := validate(0); len(entries) != 0 {
+		t.Errorf("Expected no findings without a threshold, got %+v", entries)
+	}
+	if entries := validate(12); len(entries) != 0 {
+		t.Errorf("Expected no findings when the count equals the threshold, got %+v", entries)
+	}
+
+	entries := validate(10)
+	if len(entries) != 1 {
+		t.Fatalf("Expected exactly 1 finding, got %d: %+v", len(entries), entries)
+	}
+	if entries[0].Severity != types.INFO || !strings.Contains(entries[0].Message, "'School' is shared by 12") {
+		t.Errorf("Expected INFO naming 'School' and its count, got %+v", entries[0])
+	}
+}
+
+func TestDatasetValidation_ExpectedCounts(t *testing.T) {
+	files := map[string]string{
+		"lines.xml": netexDocument(routesFrame),
+	}
+	tm := testutil.NewTestDataManager(t)
+	zipFile := tm.CreateTestZipFile(t, "dataset.zip", files)
+
+	options := DefaultValidationOptions().
+		WithCodespace(testutil.TestCodespace).
+		WithSkipSchema(true).
+		WithExpectedCounts(map[string]types.ExpectedCount{
+			"Line":  {Min: 42, Max: 42},
+			"Route": {Min: 1, Max: 1},
+		})
+	result, err := ValidateZip(zipFile, options)
+	if err != nil {
+		t.Fatalf("Dataset validation failed: %v", err)
+	}
+
+	entries := entriesNamed(result, "Element count far from manifest")
+	if len(entries) != 1 {
+		t.Fatalf("Expected exactly 1 finding, got %d: %+v", len(entries), entries)
+	}
+	if entries[0].Severity != types.ERROR || entries[0].Message != "Dataset has 1 Line elements, expected 42" {
+		t.Errorf("Expected an ERROR naming the Line count, got %+v", entries[0])
+	}
+	if entries := entriesNamed(result, "Element count differs from manifest"); len(entries) != 1 {
+		t.Errorf("Expected the surplus Route to be reported, got %+v", entries)
+	}
+}
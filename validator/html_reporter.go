@@ -11,6 +11,9 @@ import (
 
 const (
 	unknownSeverityClass = "unknown"
+
+	// DefaultReportTitle is the title of reports without a custom title
+	DefaultReportTitle = "NetEX Validation Report"
 )
 
 // HTMLReporter generates professional HTML reports for validation results
@@ -20,17 +23,71 @@ type HTMLReporter struct {
 
 // NewHTMLReporter creates a new HTML reporter
 func NewHTMLReporter() *HTMLReporter {
-	tmpl := template.Must(template.New("validation_report").Funcs(template.FuncMap{
+	tmpl := template.Must(template.New("validation_report").Funcs(htmlTemplateFuncs()).Parse(htmlTemplate))
+
+	return &HTMLReporter{
+		template: tmpl,
+	}
+}
+
+// WithCustomHTMLTemplate replaces the built-in report template, e.g. to add
+// agency branding. The template is parsed immediately so syntax errors surface
+// here rather than at render time; on error the reporter keeps its current
+// template. An empty string restores the default template.
+//
+// The template is executed with *HTMLTemplateData as its root value:
+//
+//	.Result            *ValidationResult (Codespace, ValidationReportID, CreationDate, ...)
+//	.Title             string, the report title or DefaultReportTitle
+//	.Metadata          map[string]string set through WithReportMetadata
+//	.Summary           ValidationSummary (IsValid, TotalIssues, IssuesBySeverity, ...)
+//	.Statistics        *ValidationStatistics (TotalIssues, FilesProcessed, SeverityCounts, ...)
+//	.IssuesByFile      map[string][]ValidationReportEntry
+//	.IssuesBySeverity  map[string][]ValidationReportEntry keyed by "Critical", "Error", "Warning", "Info"
+//	.IssuesByRule      map[string][]ValidationReportEntry keyed by rule name
+//	.SeverityKeys      []string of severities present, most severe first
+//	.TopRules          []RuleCount of the rules with the most findings, most first
+//	.GeneratedAt       time.Time
+//
+// The following functions are available in addition to the standard ones:
+//
+//	severityClass  types.Severity -> CSS class ("critical", "error", "warning", "info")
+//	severityIcon   types.Severity -> emoji icon
+//	severityText   types.Severity -> display text ("Critical", "Error", ...)
+//	formatTime     time.Time -> "2006-01-02 15:04:05"
+//	percentage     (part, total int) -> float64
+//	lower          string -> lower-cased string
+//
+// Example:
+//
+//	reporter, err := NewHTMLReporter().WithCustomHTMLTemplate(brandedTemplate)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	html, err := reporter.GenerateHTML(result)
+func (r *HTMLReporter) WithCustomHTMLTemplate(tmpl string) (*HTMLReporter, error) {
+	if tmpl == "" {
+		tmpl = htmlTemplate
+	}
+
+	parsed, err := template.New("validation_report").Funcs(htmlTemplateFuncs()).Parse(tmpl)
+	if err != nil {
+		return r, fmt.Errorf("failed to parse custom HTML template: %w", err)
+	}
+
+	r.template = parsed
+	return r, nil
+}
+
+// htmlTemplateFuncs returns the helper functions available to report templates
+func htmlTemplateFuncs() template.FuncMap {
+	return template.FuncMap{
 		"severityClass": severityClass,
 		"severityIcon":  severityIcon,
 		"severityText":  severityText,
 		"formatTime":    formatTime,
 		"percentage":    percentage,
 		"lower":         strings.ToLower,
-	}).Parse(htmlTemplate))
-
-	return &HTMLReporter{
-		template: tmpl,
 	}
 }
 
@@ -101,14 +158,27 @@ func (r *HTMLReporter) prepareTemplateData(result *ValidationResult) *HTMLTempla
 		}
 	}
 
+	var topRules []RuleCount
+	if limit := result.topRulesLimit(); limit > 0 {
+		topRules = result.TopRules(limit)
+	}
+
+	title := result.ReportTitle
+	if title == "" {
+		title = DefaultReportTitle
+	}
+
 	return &HTMLTemplateData{
 		Result:           result,
+		Title:            title,
+		Metadata:         result.Metadata,
 		Summary:          summary,
 		Statistics:       stats,
 		IssuesByFile:     issuesByFile,
 		IssuesBySeverity: issuesBySeverity,
 		IssuesByRule:     issuesByRule,
 		SeverityKeys:     filteredSeverityKeys,
+		TopRules:         topRules,
 		GeneratedAt:      time.Now(),
 	}
 }
@@ -116,12 +186,15 @@ func (r *HTMLReporter) prepareTemplateData(result *ValidationResult) *HTMLTempla
 // HTMLTemplateData contains all data needed for HTML template
 type HTMLTemplateData struct {
 	Result           *ValidationResult
+	Title            string
+	Metadata         map[string]string
 	Summary          ValidationSummary
 	Statistics       *ValidationStatistics
 	IssuesByFile     map[string][]ValidationReportEntry
 	IssuesBySeverity map[string][]ValidationReportEntry
 	IssuesByRule     map[string][]ValidationReportEntry
 	SeverityKeys     []string
+	TopRules         []RuleCount
 	GeneratedAt      time.Time
 }
 
@@ -198,7 +271,7 @@ const htmlTemplate = `<!DOCTYPE html>
 <head>
     <meta charset="UTF-8"/>
     <meta name="viewport" content="width=device-width, initial-scale=1.0"/>
-    <title>NetEX Validation Report - {{.Result.ValidationReportID}}</title>
+    <title>{{.Title}} - {{.Result.ValidationReportID}}</title>
     <style>
         * {
             margin: 0;
@@ -238,6 +311,22 @@ const htmlTemplate = `<!DOCTYPE html>
             opacity: 0.9;
         }
 
+        .header .metadata {
+            display: grid;
+            grid-template-columns: max-content auto;
+            gap: 4px 12px;
+            margin-top: 15px;
+            opacity: 0.9;
+        }
+
+        .header .metadata dt {
+            font-weight: bold;
+        }
+
+        .header .metadata dd {
+            margin: 0;
+        }
+
         .summary-cards {
             display: grid;
             grid-template-columns: repeat(auto-fit, minmax(250px, 1fr));
@@ -262,6 +351,35 @@ const htmlTemplate = `<!DOCTYPE html>
         .summary-card.files { border-left: 5px solid #51cf66; }
         .summary-card.time { border-left: 5px solid #ff8cc8; }
         .summary-card.status { border-left: 5px solid #ffa502; }
+        .summary-card.score { border-left: 5px solid #20c997; }
+
+        .top-rules, .category-scores {
+            background: white;
+            padding: 25px;
+            border-radius: 10px;
+            box-shadow: 0 2px 10px rgba(0,0,0,0.1);
+            margin-bottom: 30px;
+        }
+
+        .top-rules h2, .category-scores h2 {
+            margin-bottom: 15px;
+        }
+
+        .top-rules table, .category-scores table {
+            width: 100%;
+            border-collapse: collapse;
+        }
+
+        .top-rules th, .top-rules td, .category-scores th, .category-scores td {
+            padding: 8px 12px;
+            border-bottom: 1px solid #eee;
+            text-align: left;
+        }
+
+        .top-rules .count, .category-scores .count {
+            text-align: right;
+            font-weight: bold;
+        }
 
         .tabs {
             background: white;
@@ -367,6 +485,12 @@ const htmlTemplate = `<!DOCTYPE html>
             flex: 1;
         }
 
+        .issue-doc {
+            font-size: 13px;
+            color: #667eea;
+            white-space: nowrap;
+        }
+
         .issue-details {
             color: #666;
             font-size: 14px;
@@ -381,6 +505,17 @@ const htmlTemplate = `<!DOCTYPE html>
             color: #888;
         }
 
+        .issue-snippet {
+            margin-top: 10px;
+            padding: 8px;
+            background: #f8f9fa;
+            border-radius: 4px;
+            font-family: monospace;
+            font-size: 12px;
+            white-space: pre-wrap;
+            word-break: break-all;
+        }
+
         .file-group {
             margin-bottom: 30px;
         }
@@ -467,8 +602,14 @@ const htmlTemplate = `<!DOCTYPE html>
 <body>
     <div class="container">
         <div class="header">
-            <h1>NetEX Validation Report</h1>
+            <h1>{{.Title}}</h1>
             <div class="subtitle">{{.Result.ValidationReportID}} - {{formatTime .GeneratedAt}}</div>
+            {{if .Metadata}}
+            <dl class="metadata">
+                {{range $key, $value := .Metadata}}<dt>{{$key}}</dt><dd>{{$value}}</dd>
+                {{end}}
+            </dl>
+            {{end}}
         </div>
 
         <div class="summary-cards">
@@ -488,7 +629,41 @@ const htmlTemplate = `<!DOCTYPE html>
                 <h3>{{if .Statistics.HasErrors}}❌{{else}}✅{{end}}</h3>
                 <p>{{if .Statistics.HasErrors}}Failed{{else}}Passed{{end}}</p>
             </div>
+            {{with .Result.QualityScore}}
+            <div class="summary-card score">
+                <h3>{{printf "%.1f" .Score}}</h3>
+                <p>Quality Score (model {{.Version}})</p>
+            </div>
+            {{end}}
+        </div>
+
+        {{if .TopRules}}
+        <div class="top-rules">
+            <h2>Top Issues</h2>
+            <table>
+                <thead><tr><th>Rule</th><th class="count">Findings</th></tr></thead>
+                <tbody>
+                    {{range .TopRules}}
+                    <tr><td>{{.Name}}</td><td class="count">{{.Count}}</td></tr>
+                    {{end}}
+                </tbody>
+            </table>
+        </div>
+        {{end}}
+
+        {{with .Result.QualityScore}}{{if .Categories}}
+        <div class="category-scores">
+            <h2>Quality by Category</h2>
+            <table>
+                <thead><tr><th>Category</th><th class="count">Findings</th><th class="count">Penalty</th><th class="count">Score</th></tr></thead>
+                <tbody>
+                    {{range $category, $score := .Categories}}
+                    <tr><td>{{$category}}</td><td class="count">{{$score.Findings}}</td><td class="count">{{printf "%.1f" $score.Penalty}}</td><td class="count">{{printf "%.1f" $score.Score}}</td></tr>
+                    {{end}}
+                </tbody>
+            </table>
         </div>
+        {{end}}{{end}}
 
         <div class="tabs">
             <div class="tab-buttons">
@@ -508,13 +683,16 @@ const htmlTemplate = `<!DOCTYPE html>
                                 {{severityIcon .Severity}} {{severityText .Severity}}
                             </span>
                             <span class="issue-title">{{.Name}}</span>
+                            {{if .DocURL}}<a class="issue-doc" href="{{.DocURL}}" target="_blank" rel="noopener">Rule documentation</a>{{end}}
                         </div>
                         <div class="issue-details">{{.Message}}</div>
                         <div class="issue-meta">
                             File: {{.FileName}} 
                             {{if .Location.ElementID}}| Element: {{.Location.ElementID}}{{end}}
                             {{if .Location.XPath}}| XPath: {{.Location.XPath}}{{end}}
+                            {{if .RuleXPath}}| Rule XPath: <code>{{.RuleXPath}}</code>{{end}}
                         </div>
+                        {{if .MatchedSnippet}}<pre class="issue-snippet">{{.MatchedSnippet}}</pre>{{end}}
                     </li>
                     {{end}}
                 </ul>
@@ -533,9 +711,11 @@ const htmlTemplate = `<!DOCTYPE html>
                                     {{severityIcon .Severity}} {{severityText .Severity}}
                                 </span>
                                 <span class="issue-title">{{.Name}}</span>
+                                {{if .DocURL}}<a class="issue-doc" href="{{.DocURL}}" target="_blank" rel="noopener">Rule documentation</a>{{end}}
                             </div>
                             <div class="issue-details">{{.Message}}</div>
                             {{if .Location.ElementID}}<div class="issue-meta">Element: {{.Location.ElementID}}</div>{{end}}
+                            {{if .MatchedSnippet}}<pre class="issue-snippet">{{.MatchedSnippet}}</pre>{{end}}
                         </li>
                         {{end}}
                     </ul>
@@ -569,6 +749,7 @@ const htmlTemplate = `<!DOCTYPE html>
                         <li class="issue-item {{severityClass .Severity}}">
                             <div class="issue-header">
                                 <span class="issue-title">{{.Name}}</span>
+                                {{if .DocURL}}<a class="issue-doc" href="{{.DocURL}}" target="_blank" rel="noopener">Rule documentation</a>{{end}}
                             </div>
                             <div class="issue-details">{{.Message}}</div>
                             <div class="issue-meta">File: {{.FileName}}</div>
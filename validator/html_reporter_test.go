@@ -1,6 +1,8 @@
 package validator
 
 import (
+	"fmt"
+	"reflect"
 	"strings"
 	"testing"
 	"time"
@@ -177,6 +179,98 @@ func TestHTMLReporter_EmptyResult(t *testing.T) {
 	})
 }
 
+func TestHTMLReporter_CustomTemplate(t *testing.T) {
+	result := &ValidationResult{
+		Codespace:                        testutil.TestCodespace,
+		ValidationReportID:               testutil.TestReportID,
+		CreationDate:                     time.Now(),
+		ValidationReportEntries:          createTestReportEntries(),
+		FilesProcessed:                   1,
+		NumberOfValidationEntriesPerRule: make(map[string]int),
+	}
+
+	t.Run("Render custom template", func(t *testing.T) {
+		custom := `<html><body><h1>Acme Transit - {{.Result.Codespace}}</h1>` +
+			`<p>{{.Statistics.TotalIssues}} issues</p>` +
+			`{{range .SeverityKeys}}<span class="{{lower .}}">{{.}}</span>{{end}}` +
+			`{{range .Result.ValidationReportEntries}}<i class="{{severityClass .Severity}}"></i>{{end}}</body></html>`
+
+		reporter, err := NewHTMLReporter().WithCustomHTMLTemplate(custom)
+		if err != nil {
+			t.Fatalf("WithCustomHTMLTemplate() error = %v", err)
+		}
+
+		html, err := reporter.GenerateHTML(result)
+		if err != nil {
+			t.Fatalf("GenerateHTML() error = %v", err)
+		}
+
+		if !strings.Contains(html, "<h1>Acme Transit - "+testutil.TestCodespace+"</h1>") {
+			t.Errorf("Expected custom heading in output, got: %s", html)
+		}
+		if !strings.Contains(html, "<p>3 issues</p>") {
+			t.Errorf("Expected issue count in output, got: %s", html)
+		}
+		if !strings.Contains(html, `<i class="error"></i>`) {
+			t.Errorf("Expected severityClass helper output, got: %s", html)
+		}
+	})
+
+	t.Run("Invalid template is rejected", func(t *testing.T) {
+		reporter := NewHTMLReporter()
+		if _, err := reporter.WithCustomHTMLTemplate("{{.Result.Codespace"); err == nil {
+			t.Fatal("Expected parse error for malformed template")
+		}
+
+		// The reporter keeps working with the default template
+		html, err := reporter.GenerateHTML(result)
+		if err != nil {
+			t.Fatalf("GenerateHTML() error = %v", err)
+		}
+		if !strings.Contains(html, "NetEX Validation Report") {
+			t.Error("Expected default template to remain in use")
+		}
+	})
+
+	t.Run("Template from the validation options", func(t *testing.T) {
+		options := DefaultValidationOptions().
+			WithCodespace(testutil.TestCodespace).
+			WithSkipSchema(true).
+			WithHTMLTemplate(`<html><body><h1>Acme Transit - {{.Result.Codespace}}</h1></body></html>`)
+
+		validated, err := ValidateContent([]byte(testutil.NetEXTestFragment), "test.xml", options)
+		if err != nil {
+			t.Fatalf("ValidateContent() error = %v", err)
+		}
+		html, err := validated.ToHTML()
+		if err != nil {
+			t.Fatalf("ToHTML() error = %v", err)
+		}
+		if !strings.Contains(string(html), "<h1>Acme Transit - "+testutil.TestCodespace+"</h1>") {
+			t.Errorf("Expected the options template in ToHTML() output, got: %s", html)
+		}
+
+		if _, err := NewWithOptions(DefaultValidationOptions().WithHTMLTemplate("{{.Result.Codespace")); err == nil {
+			t.Error("Expected NewWithOptions() to reject a malformed template")
+		}
+	})
+
+	t.Run("Empty template falls back to default", func(t *testing.T) {
+		reporter, err := NewHTMLReporter().WithCustomHTMLTemplate("")
+		if err != nil {
+			t.Fatalf("WithCustomHTMLTemplate() error = %v", err)
+		}
+
+		html, err := reporter.GenerateHTML(result)
+		if err != nil {
+			t.Fatalf("GenerateHTML() error = %v", err)
+		}
+		if !strings.Contains(html, "NetEX Validation Report") {
+			t.Error("Expected default template output")
+		}
+	})
+}
+
 func TestHTMLReporter_LargeResult(t *testing.T) {
 	reporter := NewHTMLReporter()
 
@@ -536,3 +630,65 @@ func BenchmarkHTMLReporter_GenerateHTML_Large(b *testing.B) {
 		}
 	}
 }
+
+func TestValidationResult_TopRules(t *testing.T) {
+	result := &ValidationResult{
+		NumberOfValidationEntriesPerRule: map[string]int{
+			"Rule B": 3,
+			"Rule A": 3,
+			"Rule C": 12,
+			"Rule D": 1,
+			"Rule E": 0,
+		},
+	}
+
+	want := []RuleCount{{"Rule C", 12}, {"Rule A", 3}, {"Rule B", 3}, {"Rule D", 1}}
+	if got := result.TopRules(0); !reflect.DeepEqual(got, want) {
+		t.Errorf("TopRules(0) = %v, want %v", got, want)
+	}
+	if got := result.TopRules(2); !reflect.DeepEqual(got, want[:2]) {
+		t.Errorf("TopRules(2) = %v, want %v", got, want[:2])
+	}
+	if got := (&ValidationResult{}).TopRules(5); len(got) != 0 {
+		t.Errorf("Expected no top rules without findings, got %v", got)
+	}
+}
+
+func TestHTMLReporter_TopRules(t *testing.T) {
+	counts := make(map[string]int)
+	for i := 1; i <= 12; i++ {
+		counts[fmt.Sprintf("Rule %02d", i)] = i
+	}
+	result := &ValidationResult{
+		Codespace:                        testutil.TestCodespace,
+		ValidationReportID:               testutil.TestReportID,
+		NumberOfValidationEntriesPerRule: counts,
+	}
+
+	html, err := NewHTMLReporter().GenerateHTML(result)
+	if err != nil {
+		t.Fatalf("GenerateHTML() error = %v", err)
+	}
+	if !strings.Contains(html, "Top Issues") {
+		t.Fatal("Expected a top issues section")
+	}
+	if !strings.Contains(html, "<td>Rule 12</td>") || !strings.Contains(html, "<td>Rule 03</td>") {
+		t.Error("Expected the 10 rules with the most findings to be listed")
+	}
+	if strings.Contains(html, "<td>Rule 02</td>") {
+		t.Error("Expected rules beyond the default 10 to be left out")
+	}
+	if strings.Index(html, "<td>Rule 12</td>") > strings.Index(html, "<td>Rule 11</td>") {
+		t.Error("Expected rules to be listed by descending count")
+	}
+
+	result.topRules = 3
+	if html, _ = NewHTMLReporter().GenerateHTML(result); strings.Contains(html, "<td>Rule 09</td>") {
+		t.Error("Expected only 3 rules with a configured limit of 3")
+	}
+
+	result.topRules = -1
+	if html, _ = NewHTMLReporter().GenerateHTML(result); strings.Contains(html, "Top Issues") {
+		t.Error("Expected no top issues section with a negative limit")
+	}
+}
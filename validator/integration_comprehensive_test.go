@@ -191,7 +191,7 @@ func TestValidator_ComprehensiveIntegration(t *testing.T) {
 		if err != nil {
 			t.Errorf("Unexpected Go error for non-existent file: %v", err)
 		}
-		if result == nil || result.Error == "" {
+		if result == nil || result.Error == nil {
 			t.Error("Expected validation result with error message for non-existent file")
 		}
 
@@ -21,7 +21,7 @@ func TestIntegration_ValidMinimal(t *testing.T) {
 		t.Fatal("result is nil")
 	}
 
-	if result != nil && result.Error != "" {
+	if result != nil && result.Error != nil {
 		t.Errorf("unexpected error in result: %s", result.Error)
 	}
 
@@ -143,7 +143,7 @@ func TestIntegration_MalformedXML(t *testing.T) {
 	// Check if validation handled malformed XML gracefully
 	// The validator might report issues or might detect parsing problems early and stop
 	switch {
-	case result.Error != "":
+	case result.Error != nil:
 		t.Logf("Validation error detected: %s", result.Error)
 	case summary.TotalIssues > 0:
 		t.Logf("Validation found %d issues (expected for malformed XML)", summary.TotalIssues)
@@ -183,7 +183,7 @@ func TestIntegration_EmptyFile(t *testing.T) {
 	}
 
 	// Empty file should be handled gracefully
-	if result.Error == "" {
+	if result.Error == nil {
 		t.Log("Empty file handled without error")
 	}
 
@@ -1,11 +1,18 @@
 package validator
 
 import (
+	"bytes"
+	"encoding/json"
+	"errors"
 	"os"
+	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
+	"testing/iotest"
 	"time"
 
+	"github.com/theoremus-urban-solutions/netex-validator/testutil"
 	"github.com/theoremus-urban-solutions/netex-validator/types"
 )
 
@@ -212,11 +219,192 @@ func TestValidateFile_NonExistent(t *testing.T) {
 	}
 
 	// Should have an error in the result
-	if result != nil && result.Error == "" {
+	if result != nil && result.Error == nil {
 		t.Error("expected error in result for non-existent file")
 	}
 }
 
+func TestValidationResult_CompactJSON(t *testing.T) {
+	validate := func(compact bool) *ValidationResult {
+		t.Helper()
+		options := DefaultValidationOptions().
+			WithCodespace("TEST").
+			WithSkipSchema(true).
+			WithDeterministic(true).
+			WithCompactJSON(compact)
+
+		result, err := ValidateContent([]byte(invalidNetexXML), "invalid.xml", options)
+		if err != nil {
+			t.Fatalf("ValidateContent() error = %v", err)
+		}
+		return result
+	}
+	pretty, compact := validate(false), validate(true)
+
+	for name, serialize := range map[string]func(*ValidationResult) ([]byte, error){
+		"ToJSON":     (*ValidationResult).ToJSON,
+		"ToFlatJSON": (*ValidationResult).ToFlatJSON,
+	} {
+		prettyData, err := serialize(pretty)
+		if err != nil {
+			t.Fatalf("%s() pretty error = %v", name, err)
+		}
+		compactData, err := serialize(compact)
+		if err != nil {
+			t.Fatalf("%s() compact error = %v", name, err)
+		}
+
+		if !strings.Contains(string(prettyData), "\n  ") {
+			t.Errorf("Expected %s to indent by default", name)
+		}
+		if strings.Contains(string(compactData), "\n") {
+			t.Errorf("Expected %s to write a single line in compact mode", name)
+		}
+
+		var prettyValue, compactValue interface{}
+		if err := json.Unmarshal(prettyData, &prettyValue); err != nil {
+			t.Fatalf("%s pretty output is not valid JSON: %v", name, err)
+		}
+		if err := json.Unmarshal(compactData, &compactValue); err != nil {
+			t.Fatalf("%s compact output is not valid JSON: %v", name, err)
+		}
+		prettyNormalized, _ := json.Marshal(prettyValue)
+		compactNormalized, _ := json.Marshal(compactValue)
+		if string(prettyNormalized) != string(compactNormalized) {
+			t.Errorf("Expected %s pretty and compact output to hold the same JSON", name)
+		}
+	}
+}
+
+func TestValidationResult_ErrorCodes(t *testing.T) {
+	dir := t.TempDir()
+	notZip := filepath.Join(dir, "broken.zip")
+	if err := os.WriteFile(notZip, []byte("not a zip archive"), 0o600); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+	zipFile := testutil.NewTestDataManager(t).CreateTestZipFile(t, "dataset.zip", map[string]string{
+		"line.xml": validNetexXML,
+	})
+
+	newValidator := func(options *ValidationOptions) *NetexValidator {
+		v, err := NewWithOptions(options.WithCodespace("TEST").WithSkipSchema(true))
+		if err != nil {
+			t.Fatalf("NewWithOptions failed: %v", err)
+		}
+		return v
+	}
+
+	tests := []struct {
+		name     string
+		validate func() (*ValidationResult, error)
+		code     ResultErrorCode
+	}{
+		{
+			name: "missing file",
+			validate: func() (*ValidationResult, error) {
+				return newValidator(DefaultValidationOptions()).ValidateFile(filepath.Join(dir, "missing.xml"))
+			},
+			code: ErrorCodeFileNotFound,
+		},
+		{
+			name: "missing ZIP",
+			validate: func() (*ValidationResult, error) {
+				return newValidator(DefaultValidationOptions()).ValidateZip(filepath.Join(dir, "missing.zip"))
+			},
+			code: ErrorCodeFileNotFound,
+		},
+		{
+			name: "unreadable file",
+			validate: func() (*ValidationResult, error) {
+				return newValidator(DefaultValidationOptions()).ValidateFile(dir)
+			},
+			code: ErrorCodeReadError,
+		},
+		{
+			name: "failing reader",
+			validate: func() (*ValidationResult, error) {
+				return newValidator(DefaultValidationOptions()).ValidateReader(iotest.ErrReader(errors.New("connection reset")), "line.xml")
+			},
+			code: ErrorCodeReadError,
+		},
+		{
+			name: "malformed XML",
+			validate: func() (*ValidationResult, error) {
+				return newValidator(DefaultValidationOptions()).ValidateContent([]byte("<PublicationDelivery><dataObjects></PublicationDelivery>"), "broken.xml")
+			},
+			code: ErrorCodeParseError,
+		},
+		{
+			name: "not a ZIP archive",
+			validate: func() (*ValidationResult, error) {
+				return newValidator(DefaultValidationOptions()).ValidateZip(notZip)
+			},
+			code: ErrorCodeParseError,
+		},
+		{
+			name: "missing baseline dataset",
+			validate: func() (*ValidationResult, error) {
+				return newValidator(DefaultValidationOptions().WithBaselineDataset(filepath.Join(dir, "baseline.zip"))).ValidateZip(zipFile)
+			},
+			code: ErrorCodeConfigError,
+		},
+		{
+			name: "ZIP while a dataset is open",
+			validate: func() (*ValidationResult, error) {
+				v := newValidator(DefaultValidationOptions())
+				v.BeginDataset()
+				return v.ValidateZip(zipFile)
+			},
+			code: ErrorCodeInvalidState,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := tt.validate()
+			if err != nil {
+				t.Fatalf("expected the error in the result, got %v", err)
+			}
+			if result.Error == nil {
+				t.Fatalf("expected a %s error, got none", tt.code)
+			}
+			if result.Error.Code != tt.code || result.Error.Message == "" {
+				t.Errorf("expected a %s error with a message, got %+v", tt.code, result.Error)
+			}
+			if result.IsValid() {
+				t.Error("expected a result with an error to be invalid")
+			}
+		})
+	}
+
+	// Runner failures that are neither parse nor configuration problems
+	if code := runErrorCode(errors.New("XPath validation error")); code != ErrorCodeValidationError {
+		t.Errorf("expected %s for other runner errors, got %s", ErrorCodeValidationError, code)
+	}
+}
+
+func TestResultError_JSON(t *testing.T) {
+	result := &ValidationResult{
+		Error: &ResultError{Code: ErrorCodeFileNotFound, Message: "file does not exist: line.xml"},
+	}
+
+	data, err := result.ToFlatJSON()
+	if err != nil {
+		t.Fatalf("ToFlatJSON failed: %v", err)
+	}
+	if !strings.Contains(string(data), `"error": "file does not exist: line.xml"`) {
+		t.Errorf("expected the error to be serialized as its message, got %s", data)
+	}
+
+	var decoded ValidationResult
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if decoded.Error == nil || decoded.Error.Message != result.Error.Message {
+		t.Errorf("expected the message to round-trip, got %+v", decoded.Error)
+	}
+}
+
 func TestValidationResult_IsValid(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -227,7 +415,6 @@ func TestValidationResult_IsValid(t *testing.T) {
 			name: "no issues",
 			result: &ValidationResult{
 				ValidationReportEntries: []ValidationReportEntry{},
-				Error:                   "",
 			},
 			expected: true,
 		},
@@ -237,7 +424,6 @@ func TestValidationResult_IsValid(t *testing.T) {
 				ValidationReportEntries: []ValidationReportEntry{
 					{Severity: types.INFO},
 				},
-				Error: "",
 			},
 			expected: true,
 		},
@@ -247,7 +433,6 @@ func TestValidationResult_IsValid(t *testing.T) {
 				ValidationReportEntries: []ValidationReportEntry{
 					{Severity: types.WARNING},
 				},
-				Error: "",
 			},
 			expected: true,
 		},
@@ -257,7 +442,6 @@ func TestValidationResult_IsValid(t *testing.T) {
 				ValidationReportEntries: []ValidationReportEntry{
 					{Severity: types.ERROR},
 				},
-				Error: "",
 			},
 			expected: false,
 		},
@@ -267,7 +451,6 @@ func TestValidationResult_IsValid(t *testing.T) {
 				ValidationReportEntries: []ValidationReportEntry{
 					{Severity: types.CRITICAL},
 				},
-				Error: "",
 			},
 			expected: false,
 		},
@@ -275,7 +458,7 @@ func TestValidationResult_IsValid(t *testing.T) {
 			name: "has error string",
 			result: &ValidationResult{
 				ValidationReportEntries: []ValidationReportEntry{},
-				Error:                   "validation failed",
+				Error:                   &ResultError{Code: ErrorCodeValidationError, Message: "validation failed"},
 			},
 			expected: false,
 		},
@@ -336,6 +519,312 @@ func TestValidationResult_Summary(t *testing.T) {
 	}
 }
 
+func TestValidationResult_Merge(t *testing.T) {
+	first := &ValidationResult{
+		Codespace:          "TEST",
+		ValidationReportID: "shard-1",
+		CreationDate:       time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC),
+		ValidationReportEntries: []ValidationReportEntry{
+			{Name: "Line missing Name", Severity: types.ERROR, FileName: "line_1.xml"},
+		},
+		NumberOfValidationEntriesPerRule: map[string]int{"Line missing Name": 1},
+		FilesProcessed:                   2,
+		ProcessingTime:                   3 * time.Second,
+	}
+	second := &ValidationResult{
+		ValidationReportID: "shard-2",
+		CreationDate:       time.Date(2023, 1, 1, 12, 5, 0, 0, time.UTC),
+		ValidationReportEntries: []ValidationReportEntry{
+			{Name: "Line missing Name", Severity: types.ERROR, FileName: "line_2.xml"},
+			{Name: "Route missing Name", Severity: types.WARNING, FileName: "line_2.xml"},
+		},
+		NumberOfValidationEntriesPerRule: map[string]int{"Line missing Name": 1, "Route missing Name": 1},
+		FilesProcessed:                   3,
+		ProcessingTime:                   time.Second,
+		Error:                            &ResultError{Code: ErrorCodeReadError, Message: "failed to read line_3.xml"},
+	}
+
+	merged := first.Merge(second, nil)
+
+	if merged.Codespace != "TEST" || merged.ValidationReportID != "shard-1" {
+		t.Errorf("expected metadata of the receiver, got %q/%q", merged.Codespace, merged.ValidationReportID)
+	}
+	if len(merged.ValidationReportEntries) != 3 || merged.ValidationReportEntries[1].FileName != "line_2.xml" {
+		t.Errorf("expected entries to be concatenated in order, got %+v", merged.ValidationReportEntries)
+	}
+	if merged.NumberOfValidationEntriesPerRule["Line missing Name"] != 2 || merged.NumberOfValidationEntriesPerRule["Route missing Name"] != 1 {
+		t.Errorf("expected per-rule counts to be summed, got %v", merged.NumberOfValidationEntriesPerRule)
+	}
+	if merged.FilesProcessed != 5 {
+		t.Errorf("expected 5 files processed, got %d", merged.FilesProcessed)
+	}
+	if merged.ProcessingTime != 3*time.Second {
+		t.Errorf("expected the longest processing time, got %v", merged.ProcessingTime)
+	}
+	if !merged.CreationDate.Equal(second.CreationDate) {
+		t.Errorf("expected the latest creation date, got %v", merged.CreationDate)
+	}
+	if merged.Error == nil || *merged.Error != *second.Error || merged.IsValid() {
+		t.Errorf("expected the shard error to be kept, got %+v", merged.Error)
+	}
+
+	// The inputs are left untouched
+	if len(first.ValidationReportEntries) != 1 || first.NumberOfValidationEntriesPerRule["Line missing Name"] != 1 {
+		t.Error("expected Merge not to modify the receiver")
+	}
+}
+
+func TestValidationResult_MarshalBinary(t *testing.T) {
+	result := &ValidationResult{
+		Codespace:          "TEST",
+		ValidationReportID: "report-1",
+		CreationDate:       time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC),
+		ReportTitle:        "Regional Feed Report",
+		Metadata:           map[string]string{"dataset": "regional"},
+		ValidationReportEntries: []ValidationReportEntry{
+			{
+				Name:     "Line missing Name",
+				Message:  "Line 'TEST:Line:1' has no Name",
+				Severity: types.ERROR,
+				FileName: "line.xml",
+				Location: ValidationReportLocation{
+					FileName:   "line.xml",
+					LineNumber: 12,
+					XPath:      "/PublicationDelivery/dataObjects/ServiceFrame/lines/Line",
+					ElementID:  "TEST:Line:1",
+				},
+				MatchedSnippet: `<Line id="TEST:Line:1">`,
+				RuleXPath:      "//lines/Line[not(Name)]",
+				Fingerprint:    "abc123",
+			},
+		},
+		NumberOfValidationEntriesPerRule: map[string]int{"Line missing Name": 1},
+		FilesProcessed:                   2,
+		ProcessingTime:                   1500 * time.Millisecond,
+		Error:                            &ResultError{Code: ErrorCodeReadError, Message: "failed to read stops.xml"},
+		CacheHit:                         true,
+		FileHash:                         "deadbeef",
+		compactJSON:                      true,
+	}
+	result.SetRawContent("line.xml", []byte("<PublicationDelivery/>"))
+
+	data, err := result.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary() error = %v", err)
+	}
+
+	var decoded ValidationResult
+	if err := decoded.UnmarshalBinary(data); err != nil {
+		t.Fatalf("UnmarshalBinary() error = %v", err)
+	}
+	if !reflect.DeepEqual(&decoded, result) {
+		t.Errorf("Expected the result to round-trip\ngot  %+v\nwant %+v", decoded, *result)
+	}
+
+	if err := decoded.UnmarshalBinary([]byte("not gob")); err == nil {
+		t.Error("Expected invalid data to be rejected")
+	}
+
+	// Neither the pseudonyms nor the original content of an anonymized result are encoded
+	result.anonymizedIds = map[string]string{"ANON:Line:abc": "TEST:Line:1"}
+	data, err = result.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary() error = %v", err)
+	}
+	if bytes.Contains(data, []byte("<PublicationDelivery/>")) || bytes.Contains(data, []byte("ANON:Line:abc")) {
+		t.Error("Expected the encoding of an anonymized result to leave out its raw content and pseudonyms")
+	}
+	decoded = ValidationResult{}
+	if err := decoded.UnmarshalBinary(data); err != nil {
+		t.Fatalf("UnmarshalBinary() error = %v", err)
+	}
+	if len(decoded.AnonymizedIds()) != 0 || len(decoded.rawContent) != 0 {
+		t.Errorf("Expected no pseudonyms or raw content after decoding, got %v and %d files", decoded.AnonymizedIds(), len(decoded.rawContent))
+	}
+}
+
+func TestValidateContent_MaxFindingsPerRule(t *testing.T) {
+	var lines strings.Builder
+	for _, id := range []string{"1", "2", "3", "4", "5"} {
+		lines.WriteString(`<Line id="TEST:Line:` + id + `" version="1"><Name>Line</Name><TransportMode>bus</TransportMode></Line>`)
+	}
+	content := `<?xml version="1.0" encoding="UTF-8"?>
+<PublicationDelivery xmlns="http://www.netex.org.uk/netex" version="1.15">
+	<PublicationTimestamp>2023-01-01T12:00:00</PublicationTimestamp>
+	<ParticipantRef>TEST</ParticipantRef>
+	<dataObjects>
+		<ServiceFrame id="TEST:ServiceFrame:1" version="1">
+			<lines>` + lines.String() + `</lines>
+		</ServiceFrame>
+	</dataObjects>
+</PublicationDelivery>`
+
+	options := DefaultValidationOptions().
+		WithCodespace("TEST").
+		WithSkipSchema(true).
+		WithMaxFindingsPerRule(2)
+
+	result, err := ValidateContent([]byte(content), "lines.xml", options)
+	if err != nil {
+		t.Fatalf("ValidateContent failed: %v", err)
+	}
+
+	var findings []ValidationReportEntry
+	for _, entry := range result.ValidationReportEntries {
+		if entry.Name == "Line missing PublicCode" {
+			findings = append(findings, entry)
+		}
+	}
+	if len(findings) != 3 {
+		t.Fatalf("expected 2 findings and a summary entry, got %d: %+v", len(findings), findings)
+	}
+	if summary := findings[2]; summary.Message != "... and 3 more" || summary.Severity != types.WARNING {
+		t.Errorf("expected a WARNING summary of 3 more findings, got %+v", summary)
+	}
+	if count := result.NumberOfValidationEntriesPerRule["Line missing PublicCode"]; count != 5 {
+		t.Errorf("expected the per-rule count to include every finding, got %d", count)
+	}
+
+	// Rules under the cap are left alone
+	counts := make(map[string]int)
+	for _, entry := range result.ValidationReportEntries {
+		counts[entry.Name]++
+	}
+	for name, count := range counts {
+		if count > 3 {
+			t.Errorf("expected at most 3 entries for %s, got %d", name, count)
+		}
+	}
+}
+
+func TestLimitFindingsPerRule_KeysOnRuleCode(t *testing.T) {
+	var entries []ValidationReportEntry
+	for _, code := range []string{"CUSTOM_1", "CUSTOM_2", "CUSTOM_1", "CUSTOM_2", "CUSTOM_1"} {
+		entries = append(entries, ValidationReportEntry{RuleCode: code, Name: "Shared name", Severity: types.ERROR})
+	}
+
+	limited := limitFindingsPerRule(entries, 2)
+
+	kept := make(map[string]int)
+	var summaries []ValidationReportEntry
+	for _, entry := range limited {
+		if strings.HasPrefix(entry.Message, "... and") {
+			summaries = append(summaries, entry)
+			continue
+		}
+		kept[entry.RuleCode]++
+	}
+	if kept["CUSTOM_1"] != 2 || kept["CUSTOM_2"] != 2 {
+		t.Errorf("expected 2 findings of each rule, got %v", kept)
+	}
+	if len(summaries) != 1 || summaries[0].RuleCode != "CUSTOM_1" || summaries[0].Message != "... and 1 more" {
+		t.Errorf("expected one summary of 1 more CUSTOM_1 finding, got %+v", summaries)
+	}
+}
+
+func TestValidateContent_FindingTransform(t *testing.T) {
+	options := DefaultValidationOptions().
+		WithCodespace("TEST").
+		WithSkipSchema(true).
+		WithMaxFindingsPerRule(1).
+		WithFindingTransform(func(entry ValidationReportEntry) ValidationReportEntry {
+			entry.Message += " [team:timetables]"
+			entry.Annotations = map[string]string{"rule": entry.Name}
+			return entry
+		})
+
+	result, err := ValidateContent([]byte(validNetexXML), "line.xml", options)
+	if err != nil {
+		t.Fatalf("ValidateContent failed: %v", err)
+	}
+	if len(result.ValidationReportEntries) == 0 {
+		t.Fatal("expected findings to transform")
+	}
+	for _, entry := range result.ValidationReportEntries {
+		if !strings.HasSuffix(entry.Message, " [team:timetables]") {
+			t.Errorf("expected every message to be tagged, got %q", entry.Message)
+		}
+		if entry.Annotations["rule"] != entry.Name {
+			t.Errorf("expected an annotation naming the rule, got %v for %s", entry.Annotations, entry.Name)
+		}
+	}
+
+	output, err := result.ToFlatJSON()
+	if err != nil {
+		t.Fatalf("ToFlatJSON failed: %v", err)
+	}
+	if !strings.Contains(string(output), `"annotations"`) {
+		t.Error("expected annotations in the flat JSON output")
+	}
+}
+
+func TestValidateContent_ReportTitleAndMetadata(t *testing.T) {
+	metadata := map[string]string{"dataset": "Regional buses", "portal": "north-7"}
+	options := DefaultValidationOptions().
+		WithCodespace("TEST").
+		WithSkipSchema(true).
+		WithReportTitle("Northern Transit Data Quality").
+		WithReportMetadata(metadata)
+	metadata["portal"] = "changed after the option was set"
+
+	result, err := ValidateContent([]byte(validNetexXML), "line.xml", options)
+	if err != nil {
+		t.Fatalf("ValidateContent failed: %v", err)
+	}
+
+	html, err := result.ToHTML()
+	if err != nil {
+		t.Fatalf("ToHTML failed: %v", err)
+	}
+	for _, want := range []string{"<h1>Northern Transit Data Quality</h1>", "<dt>dataset</dt><dd>Regional buses</dd>", "<dt>portal</dt><dd>north-7</dd>"} {
+		if !strings.Contains(string(html), want) {
+			t.Errorf("expected HTML report to contain %q", want)
+		}
+	}
+	if strings.Contains(string(html), DefaultReportTitle) {
+		t.Error("expected the custom title to replace the default title")
+	}
+
+	for name, toJSON := range map[string]func() ([]byte, error){"grouped": result.ToJSON, "flat": result.ToFlatJSON} {
+		output, err := toJSON()
+		if err != nil {
+			t.Fatalf("%s JSON failed: %v", name, err)
+		}
+		var decoded struct {
+			ReportTitle string            `json:"reportTitle"`
+			Metadata    map[string]string `json:"metadata"`
+		}
+		if err := json.Unmarshal(output, &decoded); err != nil {
+			t.Fatalf("%s JSON is invalid: %v", name, err)
+		}
+		if decoded.ReportTitle != "Northern Transit Data Quality" {
+			t.Errorf("expected the title in %s JSON, got %q", name, decoded.ReportTitle)
+		}
+		if !reflect.DeepEqual(decoded.Metadata, map[string]string{"dataset": "Regional buses", "portal": "north-7"}) {
+			t.Errorf("expected the metadata in %s JSON, got %v", name, decoded.Metadata)
+		}
+	}
+
+	plain, err := ValidateContent([]byte(validNetexXML), "line.xml", DefaultValidationOptions().WithCodespace("TEST").WithSkipSchema(true))
+	if err != nil {
+		t.Fatalf("ValidateContent failed: %v", err)
+	}
+	html, err = plain.ToHTML()
+	if err != nil {
+		t.Fatalf("ToHTML failed: %v", err)
+	}
+	if !strings.Contains(string(html), "<h1>"+DefaultReportTitle+"</h1>") || strings.Contains(string(html), `class="metadata"`) {
+		t.Error("expected the default title and no metadata without report options")
+	}
+	output, err := plain.ToFlatJSON()
+	if err != nil {
+		t.Fatalf("ToFlatJSON failed: %v", err)
+	}
+	if strings.Contains(string(output), `"reportTitle"`) || strings.Contains(string(output), `"metadata"`) {
+		t.Error("expected no title or metadata in JSON without report options")
+	}
+}
+
 func TestValidationResult_GetIssuesByFile(t *testing.T) {
 	result := &ValidationResult{
 		ValidationReportEntries: []ValidationReportEntry{
@@ -475,7 +964,7 @@ func TestValidationResult_String(t *testing.T) {
 		{
 			name: "validation error",
 			result: &ValidationResult{
-				Error: "test error",
+				Error: &ResultError{Code: ErrorCodeValidationError, Message: "test error"},
 			},
 			contains: "Validation failed: test error",
 		},
@@ -508,3 +997,353 @@ func TestValidationResult_String(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateContent_Explain(t *testing.T) {
+	validate := func(explain bool) *ValidationResult {
+		t.Helper()
+		options := DefaultValidationOptions().
+			WithCodespace("TEST").
+			WithSkipSchema(true).
+			WithExplain(explain)
+
+		result, err := ValidateContent([]byte(invalidNetexXML), "invalid.xml", options)
+		if err != nil {
+			t.Fatalf("ValidateContent() error = %v", err)
+		}
+		if len(result.ValidationReportEntries) == 0 {
+			t.Fatal("Expected findings for invalid content")
+		}
+		return result
+	}
+
+	t.Run("Snippets are omitted by default", func(t *testing.T) {
+		for _, entry := range validate(false).ValidationReportEntries {
+			if entry.MatchedSnippet != "" {
+				t.Errorf("Expected no snippet without explain mode, got %q", entry.MatchedSnippet)
+			}
+		}
+	})
+
+	t.Run("Snippets show the matched element", func(t *testing.T) {
+		result := validate(true)
+
+		var lineEntry *ValidationReportEntry
+		for i, entry := range result.ValidationReportEntries {
+			if entry.Name == "Line missing Name" {
+				lineEntry = &result.ValidationReportEntries[i]
+			}
+		}
+		if lineEntry == nil {
+			t.Fatal("Expected a 'Line missing Name' finding")
+		}
+		if !strings.HasPrefix(lineEntry.MatchedSnippet, `<Line id="TEST:Line:1"`) {
+			t.Errorf("Expected snippet of the matched Line, got %q", lineEntry.MatchedSnippet)
+		}
+		if len([]rune(lineEntry.MatchedSnippet)) > 203 {
+			t.Errorf("Expected snippet to be truncated to 200 characters, got %d", len(lineEntry.MatchedSnippet))
+		}
+
+		jsonData, err := result.ToJSON()
+		if err != nil {
+			t.Fatalf("ToJSON() error = %v", err)
+		}
+		if !strings.Contains(string(jsonData), `"matchedSnippets"`) {
+			t.Error("Expected JSON output to include matched snippets")
+		}
+
+		htmlData, err := result.ToHTML()
+		if err != nil {
+			t.Fatalf("ToHTML() error = %v", err)
+		}
+		if !strings.Contains(string(htmlData), `class="issue-snippet"`) {
+			t.Error("Expected HTML output to include matched snippets")
+		}
+	})
+}
+
+func TestValidateContent_IncludeRuleXPath(t *testing.T) {
+	validate := func(include bool) *ValidationResult {
+		t.Helper()
+		options := DefaultValidationOptions().
+			WithCodespace("TEST").
+			WithSkipSchema(true).
+			WithIncludeRuleXPath(include)
+
+		result, err := ValidateContent([]byte(invalidNetexXML), "invalid.xml", options)
+		if err != nil {
+			t.Fatalf("ValidateContent() error = %v", err)
+		}
+		return result
+	}
+
+	for _, entry := range validate(false).ValidationReportEntries {
+		if entry.RuleXPath != "" {
+			t.Errorf("Expected no rule XPath by default, got %q", entry.RuleXPath)
+		}
+	}
+
+	result := validate(true)
+	var lineEntry *ValidationReportEntry
+	for i, entry := range result.ValidationReportEntries {
+		if entry.Name == "Line missing AuthorityRef" {
+			lineEntry = &result.ValidationReportEntries[i]
+			break
+		}
+	}
+	if lineEntry == nil {
+		t.Fatal("Expected a Line missing AuthorityRef finding")
+	}
+	if want := "//lines/*[self::Line or self::FlexibleLine][not(AuthorityRef)]"; lineEntry.RuleXPath != want {
+		t.Errorf("Expected rule XPath %q, got %q", want, lineEntry.RuleXPath)
+	}
+
+	jsonData, err := result.ToJSON()
+	if err != nil {
+		t.Fatalf("ToJSON() error = %v", err)
+	}
+	if !strings.Contains(string(jsonData), `"ruleXPaths"`) {
+		t.Error("Expected JSON output to include rule XPaths")
+	}
+	flatJSON, err := result.ToFlatJSON()
+	if err != nil {
+		t.Fatalf("ToFlatJSON() error = %v", err)
+	}
+	if !strings.Contains(string(flatJSON), `"ruleXPath"`) {
+		t.Error("Expected flat JSON output to include rule XPaths")
+	}
+	htmlData, err := result.ToHTML()
+	if err != nil {
+		t.Fatalf("ToHTML() error = %v", err)
+	}
+	if !strings.Contains(string(htmlData), "Rule XPath:") {
+		t.Error("Expected HTML output to include rule XPaths")
+	}
+}
+
+func TestValidateContent_AnonymizeIds(t *testing.T) {
+	options := DefaultValidationOptions().
+		WithCodespace("TEST").
+		WithSkipSchema(true).
+		WithExplain(true).
+		WithAnonymizeIds(true)
+
+	result, err := ValidateContent([]byte(invalidNetexXML), "invalid.xml", options)
+	if err != nil {
+		t.Fatalf("ValidateContent() error = %v", err)
+	}
+
+	var pseudonyms []string
+	for _, entry := range result.ValidationReportEntries {
+		if strings.Contains(entry.Location.ElementID+entry.Message+entry.MatchedSnippet, "TEST:Line:1") {
+			t.Errorf("Expected Line id to be anonymized, got entry %+v", entry)
+		}
+		if strings.HasPrefix(entry.Location.ElementID, "ANON:Line:") {
+			pseudonyms = append(pseudonyms, entry.Location.ElementID)
+		}
+	}
+	if len(pseudonyms) < 2 {
+		t.Fatalf("Expected several findings on the Line, got %d", len(pseudonyms))
+	}
+	for _, p := range pseudonyms[1:] {
+		if p != pseudonyms[0] {
+			t.Errorf("Expected the same pseudonym for the same id, got %s and %s", pseudonyms[0], p)
+		}
+	}
+
+	plain, err := ValidateContent([]byte(invalidNetexXML), "invalid.xml", DefaultValidationOptions().WithCodespace("TEST").WithSkipSchema(true))
+	if err != nil {
+		t.Fatalf("ValidateContent() error = %v", err)
+	}
+
+	// Fingerprints are keyed too, or hashing candidate ids would reveal TEST:Line:1
+	plainFingerprints := make(map[string]bool)
+	for _, entry := range plain.ValidationReportEntries {
+		plainFingerprints[entry.Fingerprint] = true
+	}
+	for _, entry := range result.ValidationReportEntries {
+		if entry.Fingerprint == "" || plainFingerprints[entry.Fingerprint] {
+			t.Errorf("Expected a keyed fingerprint for %q, got %q", entry.Name, entry.Fingerprint)
+		}
+	}
+
+	mapping := result.AnonymizedIds()
+	if mapping[pseudonyms[0]] != "TEST:Line:1" {
+		t.Errorf("Expected %s to map back to TEST:Line:1, got %q", pseudonyms[0], mapping[pseudonyms[0]])
+	}
+
+	if len(plain.AnonymizedIds()) != 0 {
+		t.Error("Expected no pseudonyms without anonymization")
+	}
+}
+
+func TestIdAnonymizer_DistinctIds(t *testing.T) {
+	anonymizer := newIdAnonymizer([]byte("report key"))
+
+	first := anonymizer.pseudonym("TEST:Quay:1")
+	second := anonymizer.pseudonym("TEST:Quay:2")
+	if first == second {
+		t.Errorf("Expected distinct pseudonyms for distinct ids, got %s twice", first)
+	}
+	if again := anonymizer.pseudonym("TEST:Quay:1"); again != first {
+		t.Errorf("Expected a stable pseudonym, got %s and %s", first, again)
+	}
+	if !strings.HasPrefix(first, "ANON:Quay:") {
+		t.Errorf("Expected pseudonym to keep the element type, got %s", first)
+	}
+	if other := newIdAnonymizer([]byte("report key")).pseudonym("TEST:Quay:1"); other != first {
+		t.Errorf("Expected the same key to give the same pseudonym, got %s and %s", first, other)
+	}
+	if other := newIdAnonymizer([]byte("another key")).pseudonym("TEST:Quay:1"); other == first {
+		t.Errorf("Expected another key to give another pseudonym, got %s twice", first)
+	}
+	if random, again := newIdAnonymizer(nil).pseudonym("TEST:Quay:1"), newIdAnonymizer(nil).pseudonym("TEST:Quay:1"); random == again {
+		t.Errorf("Expected reports without a key to use random keys, got %s twice", random)
+	}
+}
+
+func TestIdAnonymizer_PrefixIds(t *testing.T) {
+	anonymizer := newIdAnonymizer([]byte("report key"))
+	entry := ValidationReportEntry{
+		Location: ValidationReportLocation{ElementID: "NO:Line:1"},
+		Message:  "Line NO:Line:1 shares its PublicCode with NO:Line:10 and 'NO:Line:1'.",
+	}
+	anonymizer.anonymizeEntry(&entry)
+
+	short := anonymizer.pseudonym("NO:Line:1")
+	want := "Line " + short + " shares its PublicCode with NO:Line:10 and '" + short + "'."
+	if entry.Message != want {
+		t.Errorf("Expected only whole ids to be replaced:\n got %q\nwant %q", entry.Message, want)
+	}
+
+	// A quoted longer id gets a pseudonym of its own rather than a corrupted one
+	entry = ValidationReportEntry{
+		Location: ValidationReportLocation{ElementID: "NO:Line:1"},
+		Message:  "Line 'NO:Line:1' and Line 'NO:Line:10' share a PublicCode",
+	}
+	anonymizer.anonymizeEntry(&entry)
+	want = "Line '" + short + "' and Line '" + anonymizer.pseudonym("NO:Line:10") + "' share a PublicCode"
+	if entry.Message != want {
+		t.Errorf("Expected each quoted id to get its own pseudonym:\n got %q\nwant %q", entry.Message, want)
+	}
+}
+
+func TestValidateContent_RuleDocsBaseURL(t *testing.T) {
+	options := DefaultValidationOptions().
+		WithCodespace("TEST").
+		WithSkipSchema(true).
+		WithRuleDocsBaseURL("https://docs.example.org/rules/")
+
+	result, err := ValidateContent([]byte(invalidNetexXML), "invalid.xml", options)
+	if err != nil {
+		t.Fatalf("ValidateContent() error = %v", err)
+	}
+	if len(result.ValidationReportEntries) == 0 {
+		t.Fatal("Expected findings in the invalid document")
+	}
+
+	for _, entry := range result.ValidationReportEntries {
+		code := strings.TrimPrefix(entry.DocURL, "https://docs.example.org/rules/")
+		if code == "" || code == entry.DocURL || strings.ContainsAny(code, " /") {
+			t.Errorf("Expected docs base URL followed by a rule code for %q, got %q", entry.Name, entry.DocURL)
+		}
+	}
+	docURL := result.ValidationReportEntries[0].DocURL
+
+	flat, err := result.ToFlatJSON()
+	if err != nil {
+		t.Fatalf("ToFlatJSON() error = %v", err)
+	}
+	grouped, err := result.ToJSON()
+	if err != nil {
+		t.Fatalf("ToJSON() error = %v", err)
+	}
+	for name, data := range map[string][]byte{"flat": flat, "grouped": grouped} {
+		if !strings.Contains(string(data), `"docUrl": "`+docURL+`"`) {
+			t.Errorf("Expected %s JSON to contain docUrl %s", name, docURL)
+		}
+	}
+
+	html, err := result.ToHTML()
+	if err != nil {
+		t.Fatalf("ToHTML() error = %v", err)
+	}
+	if !strings.Contains(string(html), `href="`+docURL+`"`) {
+		t.Errorf("Expected HTML report to link to %s", docURL)
+	}
+
+	plain, err := ValidateContent([]byte(invalidNetexXML), "invalid.xml", DefaultValidationOptions().WithCodespace("TEST").WithSkipSchema(true))
+	if err != nil {
+		t.Fatalf("ValidateContent() error = %v", err)
+	}
+	for _, entry := range plain.ValidationReportEntries {
+		if entry.DocURL != "" {
+			t.Errorf("Expected no docUrl without a docs base URL, got %q", entry.DocURL)
+		}
+	}
+}
+
+func TestValidateContent_FlagExpiredData(t *testing.T) {
+	content := []byte(netexDocument(`		<ServiceCalendarFrame id="TEST:ServiceCalendarFrame:1" version="1">
+			<validityConditions>
+				<AvailabilityCondition id="TEST:AvailabilityCondition:Past" version="1">
+					<FromDate>2022-01-01T00:00:00</FromDate>
+					<ToDate>2022-12-31T23:59:59</ToDate>
+				</AvailabilityCondition>
+				<AvailabilityCondition id="TEST:AvailabilityCondition:Current" version="1">
+					<FromDate>2022-06-01T00:00:00</FromDate>
+					<ToDate>2023-06-30T23:59:59</ToDate>
+				</AvailabilityCondition>
+			</validityConditions>
+		</ServiceCalendarFrame>`))
+
+	for _, flag := range []bool{false, true} {
+		options := DefaultValidationOptions().
+			WithCodespace(testutil.TestCodespace).
+			WithSkipSchema(true).
+			WithFlagExpiredData(flag)
+		result, err := ValidateContent(content, "calendar.xml", options)
+		if err != nil {
+			t.Fatalf("ValidateContent() error = %v", err)
+		}
+
+		expired := entriesNamed(result, "Possibly expired data")
+		if !flag {
+			if len(expired) != 0 {
+				t.Errorf("Expected no findings unless enabled, got %+v", expired)
+			}
+			continue
+		}
+		if len(expired) != 1 || expired[0].Location.ElementID != "TEST:AvailabilityCondition:Past" || expired[0].Severity != types.INFO {
+			t.Fatalf("Expected one INFO finding for TEST:AvailabilityCondition:Past, got %+v", expired)
+		}
+		if !strings.Contains(expired[0].Message, "1 day before the PublicationTimestamp 2023-01-01") {
+			t.Errorf("Expected the message to give the expiry gap, got %q", expired[0].Message)
+		}
+	}
+}
+
+func TestValidateContent_CheckParticipantRef(t *testing.T) {
+	content := []byte(netexDocument(""))
+
+	for _, tt := range []struct {
+		codespace string
+		check     bool
+		want      int
+	}{
+		{codespace: "TEST", check: true, want: 0},
+		{codespace: "OTHER", check: false, want: 0},
+		{codespace: "OTHER", check: true, want: 1},
+	} {
+		options := DefaultValidationOptions().
+			WithCodespace(tt.codespace).
+			WithSkipSchema(true).
+			WithCheckParticipantRef(tt.check)
+		result, err := ValidateContent(content, "delivery.xml", options)
+		if err != nil {
+			t.Fatalf("ValidateContent() error = %v", err)
+		}
+		if got := entriesNamed(result, "ParticipantRef does not match codespace"); len(got) != tt.want {
+			t.Errorf("codespace %s, check %v: expected %d findings, got %+v", tt.codespace, tt.check, tt.want, got)
+		}
+	}
+}
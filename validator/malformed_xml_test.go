@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/theoremus-urban-solutions/netex-validator/testutil"
+	"github.com/theoremus-urban-solutions/netex-validator/types"
 )
 
 func TestMalformedXML_Recovery(t *testing.T) {
@@ -243,7 +244,7 @@ func TestMalformedXML_Recovery(t *testing.T) {
 
 			// If we got a result (no error), check for issues
 			if result != nil {
-				hasIssues := len(result.ValidationReportEntries) > 0 || result.Error != ""
+				hasIssues := len(result.ValidationReportEntries) > 0 || result.Error != nil
 				// For malformed XML, errors during validation count as issues too
 				hasIssuesOrError := hasIssues || err != nil
 				if tt.expectIssues && !hasIssuesOrError {
@@ -251,7 +252,7 @@ func TestMalformedXML_Recovery(t *testing.T) {
 				}
 				if hasIssues {
 					t.Logf("%s: Found %d validation issues", tt.description, len(result.ValidationReportEntries))
-					if result.Error != "" {
+					if result.Error != nil {
 						t.Logf("%s: Validation error: %s", tt.description, result.Error)
 					}
 				}
@@ -306,7 +307,7 @@ func TestMalformedXML_LargeFiles(t *testing.T) {
 			t.Logf("Large malformed XML handling result: validation error (expected)")
 		}
 
-		if result != nil && result.Error != "" {
+		if result != nil && result.Error != nil {
 			t.Logf("Large malformed XML handled with validation error: %s", result.Error)
 		}
 
@@ -370,7 +371,7 @@ func TestMalformedXML_EncodingIssues(t *testing.T) {
 			}
 
 			if result != nil {
-				if result.Error != "" {
+				if result.Error != nil {
 					t.Logf("%s: Validation result error: %s", tt.description, result.Error)
 				}
 				t.Logf("%s: Found %d validation issues", tt.description, len(result.ValidationReportEntries))
@@ -422,7 +423,7 @@ func TestMalformedXML_RecoveryStrategies(t *testing.T) {
 			t.Logf("Partial parsing handled with error: %v", err)
 		}
 
-		if result != nil && result.Error != "" {
+		if result != nil && result.Error != nil {
 			t.Logf("Partial parsing result: %s", result.Error)
 		}
 
@@ -456,6 +457,48 @@ func TestMalformedXML_RecoveryStrategies(t *testing.T) {
 	})
 }
 
+func TestMalformedXML_NestingDepth(t *testing.T) {
+	// 300 nested elements inside an otherwise ordinary document
+	deep := strings.Repeat("<Extension>", 300) + strings.Repeat("</Extension>", 300)
+	content := fmt.Sprintf(`<?xml version="1.0" encoding="UTF-8"?>
+<PublicationDelivery xmlns="http://www.netex.org.uk/netex" version="1.15">
+	<PublicationTimestamp>2023-01-01T00:00:00</PublicationTimestamp>
+	<ParticipantRef>TEST</ParticipantRef>
+	<Extensions>%s</Extensions>
+</PublicationDelivery>`, deep)
+
+	validate := func(maxDepth int) *ValidationResult {
+		t.Helper()
+		options := DefaultValidationOptions().
+			WithCodespace(testutil.TestCodespace).
+			WithSkipSchema(true).
+			WithMaxXMLDepth(maxDepth)
+		result, err := ValidateContent([]byte(content), "deep.xml", options)
+		if err != nil {
+			t.Fatalf("ValidateContent() error = %v", err)
+		}
+		return result
+	}
+
+	result := validate(DefaultMaxXMLDepth)
+	if len(result.ValidationReportEntries) != 1 {
+		t.Fatalf("Expected only the nesting finding, got %d: %+v", len(result.ValidationReportEntries), result.ValidationReportEntries)
+	}
+	entry := result.ValidationReportEntries[0]
+	if entry.Name != "XML nesting too deep" || entry.Severity != types.CRITICAL {
+		t.Errorf("Expected CRITICAL XML nesting too deep, got %s %q", entry.Severity, entry.Name)
+	}
+	if entry.Location.LineNumber != 5 || !strings.Contains(entry.Message, "256") {
+		t.Errorf("Expected finding on line 5 naming the limit, got line %d: %q", entry.Location.LineNumber, entry.Message)
+	}
+
+	for _, entry := range validate(0).ValidationReportEntries {
+		if entry.Name == "XML nesting too deep" {
+			t.Error("Expected no nesting finding with the check disabled")
+		}
+	}
+}
+
 func BenchmarkMalformedXML_HandlingPerformance(b *testing.B) {
 	// Test performance impact of malformed XML handling
 	malformedContent := `<?xml version="1.0" encoding="UTF-8"?>
@@ -39,12 +39,17 @@ package validator
 
 import (
 	"archive/zip"
+	"bytes"
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
+	"regexp"
+	"sort"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/antchfx/xmlquery"
@@ -55,12 +60,21 @@ import (
 	"github.com/theoremus-urban-solutions/netex-validator/rules"
 	"github.com/theoremus-urban-solutions/netex-validator/types"
 	"github.com/theoremus-urban-solutions/netex-validator/utils"
+	"github.com/theoremus-urban-solutions/netex-validator/validation/business"
 	"github.com/theoremus-urban-solutions/netex-validator/validation/context"
 	"github.com/theoremus-urban-solutions/netex-validator/validation/engine"
 	"github.com/theoremus-urban-solutions/netex-validator/validation/ids"
 	xsdpkg "github.com/theoremus-urban-solutions/netex-validator/validation/schema"
 )
 
+// schemaNamespaceRule reports a root element in the legacy or an unknown NetEX namespace
+var schemaNamespaceRule = types.ValidationRule{
+	Code:     xsdpkg.NamespaceRuleCode,
+	Name:     "Unexpected NetEX namespace",
+	Message:  "The root element does not use the current NetEX namespace",
+	Severity: types.WARNING,
+}
+
 // NetexSchemaValidatorAdapter adapts XSDValidator to SchemaValidator interface
 type NetexSchemaValidatorAdapter struct {
 	xsdValidator *xsdpkg.XSDValidator
@@ -89,13 +103,17 @@ func (a *NetexSchemaValidatorAdapter) Validate(ctx context.SchemaValidationConte
 			break
 		}
 
+		rule := types.ValidationRule{
+			Code:     "SCHEMA_ERROR",
+			Name:     "Schema validation error",
+			Message:  verr.Message,
+			Severity: types.ERROR,
+		}
+		if verr.Rule == xsdpkg.NamespaceRuleCode {
+			rule = schemaNamespaceRule
+		}
 		issue := types.ValidationIssue{
-			Rule: types.ValidationRule{
-				Code:     "SCHEMA_ERROR",
-				Name:     "Schema validation error",
-				Message:  verr.Message,
-				Severity: types.ERROR,
-			},
+			Rule: rule,
 			Location: types.DataLocation{
 				FileName:   ctx.FileName,
 				LineNumber: verr.Line,
@@ -118,6 +136,7 @@ func (a *NetexSchemaValidatorAdapter) GetRules() []types.ValidationRule {
 			Message:  "XML content does not conform to NetEX schema",
 			Severity: types.ERROR,
 		},
+		schemaNamespaceRule,
 	}
 }
 
@@ -127,12 +146,34 @@ func (a *NetexSchemaValidatorAdapter) GetRules() []types.ValidationRule {
 //
 // Use New() or NewWithOptions() to create a validator instance, then call
 // ValidateFile(), ValidateContent(), or ValidateZip() to perform validation.
+//
+// A validator collects the IDs, references and dataset data of the files it
+// validates for cross-file checks. Each ValidateFile, ValidateContent,
+// ValidateReader and ValidateZip call starts from a clean state, so unrelated files
+// validated with the same instance, also from several goroutines, never see each
+// other's IDs. To validate loose files as one dataset, call BeginDataset, validate
+// the files and call EndDataset for the cross-file findings.
 type NetexValidator struct {
 	config          *config.ValidatorConfig
 	runner          *engine.EnhancedNetexValidatorsRunner
 	codespace       string
 	validationCache utils.ValidationCache
 	options         *ValidationOptions
+	skippedRules    *skippedRules // XPath rules that could not be evaluated, when reported
+
+	// Streaming mode runs the element-local XPath rules only
+	streamingValidators []interfaces.XPathValidator
+	streamingRuleCount  int
+	streamingSkipped    []string // codes of the enabled rules streaming mode skips
+
+	// stateMu is held shared while a file is validated and exclusively while a ZIP
+	// dataset is validated or the collected state is reset, so the runner's cross-file
+	// state is never cleared under a running dataset
+	stateMu      sync.RWMutex
+	datasetOpen  bool         // set between BeginDataset and EndDataset
+	datasetFiles atomic.Int64 // files validated since BeginDataset
+	// datasetProfile is the NeTEx profile of the ZIP dataset being validated
+	datasetProfile string
 }
 
 // New creates a new NetexValidator instance with default configuration.
@@ -204,6 +245,22 @@ func NewWithOptions(opts *ValidationOptions) (*NetexValidator, error) {
 		cfg = config.DefaultConfig()
 	}
 
+	// Parse a custom HTML template now rather than when the first report is written
+	if opts.HTMLTemplate != "" {
+		if _, err := NewHTMLReporter().WithCustomHTMLTemplate(opts.HTMLTemplate); err != nil {
+			return nil, err
+		}
+	}
+
+	// An empty codespace falls back to the default of the options, then of the configuration
+	codespace := opts.Codespace
+	if codespace == "" {
+		codespace = opts.DefaultCodespace
+	}
+	if codespace == "" {
+		codespace = cfg.Validator.DefaultCodespace
+	}
+
 	// Apply option overrides
 	if opts.MaxSchemaErrors > 0 {
 		cfg.Validator.MaxSchemaErrors = opts.MaxSchemaErrors
@@ -234,7 +291,7 @@ func NewWithOptions(opts *ValidationOptions) (*NetexValidator, error) {
 	// Create validator
 	validator := &NetexValidator{
 		config:          cfg,
-		codespace:       opts.Codespace,
+		codespace:       codespace,
 		validationCache: validationCache,
 		options:         opts,
 	}
@@ -341,6 +398,12 @@ func ValidateZip(zipPath string, options *ValidationOptions) (*ValidationResult,
 	return validator.ValidateZip(zipPath)
 }
 
+// Codespace returns the codespace the validator validates with, after falling back
+// to the default codespace of the options or the configuration file
+func (v *NetexValidator) Codespace() string {
+	return v.codespace
+}
+
 // ValidateFile validates a single NetEX file using this validator instance
 func (v *NetexValidator) ValidateFile(filePath string) (*ValidationResult, error) {
 	startTime := time.Now()
@@ -348,7 +411,7 @@ func (v *NetexValidator) ValidateFile(filePath string) (*ValidationResult, error
 	// Check if file exists
 	if _, err := os.Stat(filePath); os.IsNotExist(err) {
 		return &ValidationResult{
-			Error:        fmt.Sprintf("file does not exist: %s", filePath),
+			Error:        newResultError(ErrorCodeFileNotFound, "file does not exist: %s", filePath),
 			CreationDate: time.Now(),
 		}, nil
 	}
@@ -361,17 +424,21 @@ func (v *NetexValidator) ValidateFile(filePath string) (*ValidationResult, error
 		cleanPath, err = filepath.Abs(cleanPath)
 		if err != nil {
 			return &ValidationResult{
-				Error:        fmt.Sprintf("failed to resolve file path %s: %v", filePath, err),
+				Error:        newResultError(ErrorCodeReadError, "failed to resolve file path %s: %v", filePath, err),
 				CreationDate: time.Now(),
 			}, nil
 		}
 	}
 
+	if v.options.StreamingMode {
+		return v.validateFileStream(cleanPath, filepath.Base(filePath), startTime)
+	}
+
 	// Read file content using the cleaned path
 	content, err := os.ReadFile(cleanPath)
 	if err != nil {
 		return &ValidationResult{
-			Error:        fmt.Sprintf("failed to read file: %v", err),
+			Error:        newResultError(ErrorCodeReadError, "failed to read file: %v", err),
 			CreationDate: time.Now(),
 		}, nil
 	}
@@ -380,7 +447,9 @@ func (v *NetexValidator) ValidateFile(filePath string) (*ValidationResult, error
 	if err != nil {
 		return nil, err
 	}
-	result.ProcessingTime = time.Since(startTime)
+	if !result.deterministic {
+		result.ProcessingTime = time.Since(startTime)
+	}
 	result.FilesProcessed = 1
 
 	return result, nil
@@ -389,17 +458,32 @@ func (v *NetexValidator) ValidateFile(filePath string) (*ValidationResult, error
 // ValidateContent validates NetEX content from memory using this validator instance
 func (v *NetexValidator) ValidateContent(content []byte, filename string) (*ValidationResult, error) {
 	startTime := time.Now()
+	if v.options.StreamingMode {
+		return v.validateStream(bytes.NewReader(content), filename, startTime)
+	}
 	return v.validateContentWithCaching(content, filename, startTime)
 }
 
-// ValidateZip validates a ZIP dataset using this validator instance
+// ValidateZip validates a ZIP dataset using this validator instance. The ZIP is
+// validated as a dataset of its own and cannot be part of a dataset opened with
+// BeginDataset.
 func (v *NetexValidator) ValidateZip(zipPath string) (*ValidationResult, error) {
 	startTime := time.Now()
 
+	v.stateMu.Lock()
+	defer v.stateMu.Unlock()
+	if v.datasetOpen {
+		return &ValidationResult{
+			Error:        newResultError(ErrorCodeInvalidState, "cannot validate a ZIP dataset while a dataset is open; call EndDataset first"),
+			CreationDate: time.Now(),
+		}, nil
+	}
+	v.runner.Reset()
+
 	// Check if ZIP file exists
 	if _, err := os.Stat(zipPath); os.IsNotExist(err) {
 		return &ValidationResult{
-			Error:        fmt.Sprintf("ZIP file does not exist: %s", zipPath),
+			Error:        newResultError(ErrorCodeFileNotFound, "ZIP file does not exist: %s", zipPath),
 			CreationDate: time.Now(),
 		}, nil
 	}
@@ -408,22 +492,24 @@ func (v *NetexValidator) ValidateZip(zipPath string) (*ValidationResult, error)
 	rawContents, err := v.extractZipContents(zipPath)
 	if err != nil {
 		return &ValidationResult{
-			Error:        fmt.Sprintf("failed to extract ZIP contents: %v", err),
+			Error:        newResultError(zipErrorCode(err), "failed to extract ZIP contents: %v", err),
 			CreationDate: time.Now(),
 		}, nil
 	}
+	v.datasetProfile = v.logProfile(filepath.Base(zipPath), func() string { return detectDatasetProfile(rawContents) })
+	defer func() { v.datasetProfile = "" }()
 
 	// Use the validator's built-in ZIP support
 	report, err := v.runner.ValidateFile(zipPath, v.codespace, false, false)
 	if err != nil {
 		return &ValidationResult{
-			Error:        fmt.Sprintf("ZIP validation failed: %v", err),
+			Error:        newResultError(runErrorCode(err), "ZIP validation failed: %v", err),
 			CreationDate: time.Now(),
 		}, nil
 	}
 
 	// Convert to result format
-	result := v.createValidationResultFromReport(report, filepath.Base(zipPath), startTime)
+	result := v.createValidationResultFromReport(report, filepath.Base(zipPath), startTime, len(rawContents))
 
 	// Store raw content for statistics extraction
 	for fileName, content := range rawContents {
@@ -433,6 +519,28 @@ func (v *NetexValidator) ValidateZip(zipPath string) (*ValidationResult, error)
 	return result, nil
 }
 
+// zipErrorCode categorizes an error reading a ZIP dataset
+func zipErrorCode(err error) ResultErrorCode {
+	if errors.Is(err, zip.ErrFormat) {
+		return ErrorCodeParseError
+	}
+	return ErrorCodeReadError
+}
+
+// runErrorCode categorizes an error returned by the validation runner
+func runErrorCode(err error) ResultErrorCode {
+	switch {
+	case errors.Is(err, engine.ErrParse):
+		return ErrorCodeParseError
+	case errors.Is(err, engine.ErrBaselineDataset):
+		return ErrorCodeConfigError
+	case errors.Is(err, zip.ErrFormat):
+		return ErrorCodeParseError
+	default:
+		return ErrorCodeValidationError
+	}
+}
+
 // extractZipContents extracts raw XML content from ZIP files for statistics
 func (v *NetexValidator) extractZipContents(zipPath string) (map[string][]byte, error) {
 	zr, err := zip.OpenReader(zipPath)
@@ -469,12 +577,15 @@ func (v *NetexValidator) extractZipContents(zipPath string) (map[string][]byte,
 // ValidateReader validates NetEX content from an io.Reader
 func (v *NetexValidator) ValidateReader(reader io.Reader, filename string) (*ValidationResult, error) {
 	startTime := time.Now()
+	if v.options.StreamingMode {
+		return v.validateStream(reader, filename, startTime)
+	}
 
 	// Read all content for validation
 	content, err := io.ReadAll(reader)
 	if err != nil {
 		return &ValidationResult{
-			Error:        fmt.Sprintf("failed to read content: %v", err),
+			Error:        newResultError(ErrorCodeReadError, "failed to read content: %v", err),
 			CreationDate: time.Now(),
 		}, nil
 	}
@@ -482,13 +593,75 @@ func (v *NetexValidator) ValidateReader(reader io.Reader, filename string) (*Val
 	return v.validateContentWithCaching(content, filename, startTime)
 }
 
+// Reset clears the IDs, references and dataset data collected from earlier files and
+// closes a dataset opened with BeginDataset without reporting its cross-file findings
+func (v *NetexValidator) Reset() {
+	v.stateMu.Lock()
+	defer v.stateMu.Unlock()
+	v.datasetOpen = false
+	v.datasetFiles.Store(0)
+	v.runner.Reset()
+}
+
+// BeginDataset starts collecting the files validated with ValidateFile,
+// ValidateContent and ValidateReader into one dataset, discarding any earlier state.
+// Each call still reports the file's own findings; the cross-file ID and dataset
+// findings are reported once by EndDataset. Files of a dataset may be validated
+// from several goroutines.
+func (v *NetexValidator) BeginDataset() {
+	v.stateMu.Lock()
+	defer v.stateMu.Unlock()
+	v.datasetOpen = true
+	v.datasetFiles.Store(0)
+	v.runner.Reset()
+}
+
+// EndDataset runs the cross-file ID and dataset validation over the files validated
+// since BeginDataset and returns their findings. The validator then goes back to
+// validating each file on its own.
+//
+// Example:
+//
+//	validator.BeginDataset()
+//	for _, path := range paths {
+//		result, err := validator.ValidateFile(path)
+//		...
+//	}
+//	datasetResult, err := validator.EndDataset()
+func (v *NetexValidator) EndDataset() (*ValidationResult, error) {
+	startTime := time.Now()
+
+	v.stateMu.Lock()
+	defer v.stateMu.Unlock()
+	if !v.datasetOpen {
+		return nil, fmt.Errorf("no dataset is open; call BeginDataset first")
+	}
+
+	report := v.runner.FinalizeDataset(v.codespace, "dataset")
+	result := v.createValidationResultFromReport(report, "dataset", startTime, int(v.datasetFiles.Swap(0)))
+
+	v.datasetOpen = false
+	return result, nil
+}
+
 // validateContentWithCaching validates content with caching support
 func (v *NetexValidator) validateContentWithCaching(content []byte, filename string, startTime time.Time) (*ValidationResult, error) {
+	// Files of an open dataset accumulate; any other file is validated on its own
+	// and collects nothing, so concurrent files share no state under the read lock
+	v.stateMu.RLock()
+	defer v.stateMu.RUnlock()
+	datasetOpen := v.datasetOpen
+	if datasetOpen {
+		v.datasetFiles.Add(1)
+	}
+	v.logProfile(filename, func() string { return DetectProfile(content) })
+
 	// Calculate file hash for caching
 	var fileHash string
 	var cacheHit bool
 
-	if v.validationCache != nil {
+	// A cached result would skip collecting the file into the open dataset
+	if v.validationCache != nil && !datasetOpen {
 		fileHash = utils.CalculateFileHash(content)
 
 		// Check cache first
@@ -506,18 +679,21 @@ func (v *NetexValidator) validateContentWithCaching(content []byte, filename str
 	}
 
 	// Perform validation
-	report, err := v.runner.ValidateContent(filename, v.codespace, content, v.options.SkipSchema, v.options.SkipValidators)
+	validate := v.runner.ValidateStandaloneContent
+	if datasetOpen {
+		validate = v.runner.ValidateContent
+	}
+	report, err := validate(filename, v.codespace, content, v.options.SkipSchema, v.options.SkipValidators)
 	if err != nil {
 		return &ValidationResult{
-			Error:        fmt.Sprintf("validation failed: %v", err),
+			Error:        newResultError(runErrorCode(err), "validation failed: %v", err),
 			CreationDate: time.Now(),
 			FileHash:     fileHash,
 		}, nil
 	}
 
 	// Convert to result format
-	result := v.createValidationResultFromReport(report, filename, startTime)
-	result.FilesProcessed = 1
+	result := v.createValidationResultFromReport(report, filename, startTime, 1)
 	result.CacheHit = cacheHit
 	result.FileHash = fileHash
 
@@ -546,12 +722,16 @@ func (v *NetexValidator) initializeRunner(opts *ValidationOptions) error {
 		// Initialize schema validator honoring options
 		xsdOpts := xsdpkg.DefaultXSDValidationOptions()
 		xsdOpts.AllowNetworkDownload = opts.AllowSchemaNetwork
+		xsdOpts.UseBundledSchemas = opts.BundledSchemas
+		xsdOpts.BundledSchemaVersion = opts.BundledSchemaVersion
 		if opts.SchemaCacheDir != "" {
 			xsdOpts.CacheDirectory = opts.SchemaCacheDir
 		}
 		if opts.SchemaTimeoutSeconds > 0 {
 			xsdOpts.HttpTimeoutSeconds = opts.SchemaTimeoutSeconds
 		}
+		xsdOpts.DownloadRetries = opts.SchemaDownloadRetries
+		xsdOpts.MaxConcurrentDownloads = opts.SchemaDownloadConcurrency
 		// experimental libxml2 backend
 		if opts.UseLibxml2XSD {
 			xsdOpts.UseLibxml2 = true
@@ -568,9 +748,20 @@ func (v *NetexValidator) initializeRunner(opts *ValidationOptions) error {
 	if !opts.SkipValidators {
 		// Create rule registry and get enabled rules
 		ruleRegistry := rules.NewRuleRegistry(v.config)
-		// Force EU profile regardless of options
+		// Only the EU rule set exists, so every profile is validated with it
 		ruleRegistry = ruleRegistry.WithProfile("eu")
 		enabled := ruleRegistry.GetEnabledRules()
+		// Opt-in rules only run when their option asks for them
+		if !opts.FlagUnknownModes {
+			enabled = withoutRule(enabled, "TRANSPORT_MODE_UNKNOWN")
+		}
+		// Rules of the EU profile itself are left out for a national profile; a
+		// detected profile is only known per file, see euProfileValidator
+		if opts.Profile != "" && opts.Profile != ProfileEU {
+			for code := range euProfileRules {
+				enabled = withoutRule(enabled, code)
+			}
+		}
 		// Apply in-memory rule overrides from options (in addition to config)
 		if len(opts.RuleOverrides) > 0 {
 			filtered := make([]rules.Rule, 0, len(enabled))
@@ -591,23 +782,153 @@ func (v *NetexValidator) initializeRunner(opts *ValidationOptions) error {
 				}
 			}
 		}
+		if opts.ReportSkippedRules {
+			v.skippedRules = newSkippedRules()
+		}
 		// Wrap rules as XPathValidationRule implementations
 		xrules := make([]utils.XPathValidationRule, 0, len(enabled))
+		var euRules []utils.XPathValidationRule
+		simpleRules := make([]*SimpleXPathRule, 0, len(enabled))
 		for _, r := range enabled {
-			xrules = append(xrules, NewSimpleXPathRule(r))
+			xrule := NewSimpleXPathRule(r)
+			xrule.explain = opts.Explain
+			xrule.includeXPath = opts.IncludeRuleXPath
+			xrule.skipped = v.skippedRules
+			if euProfileRules[r.Code] {
+				euRules = append(euRules, xrule)
+			} else {
+				xrules = append(xrules, xrule)
+			}
+			simpleRules = append(simpleRules, xrule)
 		}
+		if opts.StreamingMode {
+			local, skipped := splitStreamingRules(simpleRules)
+			v.streamingValidators = []interfaces.XPathValidator{newStreamingRuleValidator(local)}
+			v.streamingRuleCount = len(local)
+			v.streamingSkipped = skipped
+		}
+		xpathValidators := make([]interfaces.XPathValidator, 0, 15)
 		if len(xrules) > 0 {
-			xpathValidator := utils.NewXPathRuleValidator(xrules)
-			builder = builder.WithXPathValidators([]interfaces.XPathValidator{xpathValidator})
+			xpathValidators = append(xpathValidators, utils.NewXPathRuleValidator(xrules))
+		}
+		if len(euRules) > 0 {
+			xpathValidators = append(xpathValidators, newEUProfileValidator(utils.NewXPathRuleValidator(euRules), v.documentProfile))
+		}
+		// Business validators need more than a single XPath expression per rule
+		overrides := newRuleOverrides(v.config, opts)
+		xpathValidators = append(xpathValidators,
+			newRuleOverrideValidator(business.NewInterchangeTransferTimeValidator(opts.MaxTransferTime), overrides),
+			newRuleOverrideValidator(business.NewOperatorLegalDetailsValidator(), overrides),
+			newRuleOverrideValidator(business.NewPublicationTimestampValidator(), overrides),
+			newRuleOverrideValidator(business.NewStopPointAccessValidator(), overrides),
+			newRuleOverrideValidator(business.NewJourneyPatternStopsValidator(), overrides),
+			newRuleOverrideValidator(business.NewJourneyPatternOrderValidator(), overrides),
+			newRuleOverrideValidator(business.NewPassingTimeFormatValidator(), overrides),
+			newRuleOverrideValidator(business.NewPassingTimeStopPointValidator(), overrides),
+			newRuleOverrideValidator(business.NewPassingTimeCountValidator(), overrides),
+			newRuleOverrideValidator(business.NewFlexibleTimeWindowValidator(), overrides),
+			newRuleOverrideValidator(business.NewLinkDistanceValidator(), overrides),
+			newRuleOverrideValidator(business.NewBookingContactValidator(), overrides),
+			newRuleOverrideValidator(business.NewCalendarDateFormatValidator(), overrides))
+		if opts.FlagExpiredData {
+			xpathValidators = append(xpathValidators,
+				newRuleOverrideValidator(business.NewExpiredDataValidator(), overrides))
+		}
+		if opts.CheckParticipantRef {
+			xpathValidators = append(xpathValidators,
+				newRuleOverrideValidator(business.NewParticipantRefValidator(), overrides))
+		}
+		var pluginDatasetValidators []interfaces.DatasetValidator
+		for _, path := range opts.Plugins {
+			loaded, err := loadRulePlugin(path)
+			if err != nil {
+				return err
+			}
+			for _, validator := range loaded.validators {
+				xpathValidators = append(xpathValidators, newRuleOverrideValidator(validator, overrides))
+			}
+			for _, validator := range loaded.datasetValidators {
+				pluginDatasetValidators = append(pluginDatasetValidators, newRuleOverrideDatasetValidator(validator, overrides))
+			}
+		}
+		builder = builder.WithXPathValidators(xpathValidators)
+
+		// Dataset validators see every file before reporting
+		datasetValidators := []interfaces.DatasetValidator{
+			newRuleOverrideDatasetValidator(business.NewOrphanedRouteValidator(), overrides),
+			newRuleOverrideDatasetValidator(business.NewRoutelessLineValidator(), overrides),
+			newRuleOverrideDatasetValidator(business.NewUndirectedRouteValidator(), overrides),
+			newRuleOverrideDatasetValidator(business.NewPassingTimePatternValidator(), overrides),
+			newRuleOverrideDatasetValidator(business.NewTypedReferenceValidator(), overrides),
+			newRuleOverrideDatasetValidator(business.NewServiceJourneyCalendarValidator(), overrides),
+			newRuleOverrideDatasetValidator(business.NewDayTypeConflictValidator(), overrides),
+			newRuleOverrideDatasetValidator(business.NewCoincidentStopPointValidator(), overrides),
+			newRuleOverrideDatasetValidator(business.NewFilePlacementValidator(), overrides),
+			newRuleOverrideDatasetValidator(business.NewInterchangeStopPointValidator(), overrides),
+			newRuleOverrideDatasetValidator(business.NewJourneyTransportModeValidator(), overrides),
+			newRuleOverrideDatasetValidator(business.NewJourneyPatternTypeValidator(), overrides),
+			newRuleOverrideDatasetValidator(business.NewQuayStopPlaceValidator(), overrides),
+		}
+		if opts.EnforceCodespacePrefix {
+			datasetValidators = append(datasetValidators,
+				newRuleOverrideDatasetValidator(business.NewCodespacePrefixValidator(v.codespace), overrides))
+		}
+		if opts.StrictDeadRuns {
+			datasetValidators = append(datasetValidators,
+				newRuleOverrideDatasetValidator(business.NewDeadRunRouteValidator(), overrides))
+		}
+		if opts.StrictCalendar {
+			datasetValidators = append(datasetValidators,
+				newRuleOverrideDatasetValidator(business.NewOperatingDayPeriodValidator(), overrides))
+		}
+		if opts.StrictRouteDirections {
+			datasetValidators = append(datasetValidators,
+				newRuleOverrideDatasetValidator(business.NewRouteDirectionValidator(), overrides))
 		}
+		if opts.FlagModeOutliers {
+			datasetValidators = append(datasetValidators,
+				newRuleOverrideDatasetValidator(business.NewNetworkModeValidator(), overrides))
+		}
+		if opts.AmbiguousNameThreshold > 0 {
+			datasetValidators = append(datasetValidators,
+				newRuleOverrideDatasetValidator(business.NewAmbiguousStopNameValidator(opts.AmbiguousNameThreshold), overrides))
+		}
+		if len(opts.ExpectedCounts) > 0 {
+			datasetValidators = append(datasetValidators,
+				newRuleOverrideDatasetValidator(business.NewExpectedCountValidator(opts.ExpectedCounts), overrides))
+		}
+		datasetValidators = append(datasetValidators, pluginDatasetValidators...)
+		builder = builder.WithDatasetValidators(datasetValidators)
 	}
 
 	// Add ID validator
 	idRepo := ids.NewNetexIdRepository()
+	idRepo.SetFinalizeWorkers(opts.IdValidationWorkers)
+	if opts.Deterministic {
+		idRepo.SetFinalizeWorkers(1)
+	}
+	if opts.CodespacePattern != "" {
+		pattern, err := regexp.Compile(`^(?:` + opts.CodespacePattern + `)$`)
+		if err != nil {
+			return fmt.Errorf("invalid codespace pattern: %w", err)
+		}
+		idRepo.SetCodespacePattern(pattern)
+	}
+	idRepo.SetVersionReport(opts.VersionReport)
 	idExtractor := ids.NewNetexIdExtractor()
 	idValidator := ids.NewNetexIdValidator(idRepo, idExtractor)
 	builder = builder.WithIdValidator(idValidator)
 
+	if len(opts.ChangedFiles) > 0 {
+		builder = builder.WithChangedFiles(opts.ChangedFiles)
+	}
+
+	if opts.BaselineDataset != "" {
+		builder = builder.WithBaselineDataset(opts.BaselineDataset)
+	}
+
+	builder = builder.WithMaxDepth(opts.MaxXMLDepth)
+
 	// Apply max findings if set
 	if opts.MaxFindings > 0 {
 		builder = builder.WithMaxFindings(opts.MaxFindings)
@@ -621,9 +942,27 @@ func (v *NetexValidator) initializeRunner(opts *ValidationOptions) error {
 	if concurrent > 0 {
 		builder = builder.WithConcurrentFiles(concurrent)
 	}
+	builder = builder.WithDeterministic(opts.Deterministic)
+
+	if opts.ResultSpillDir != "" {
+		if opts.Deterministic {
+			return fmt.Errorf("result spilling cannot be combined with deterministic mode")
+		}
+		if info, err := os.Stat(opts.ResultSpillDir); err != nil || !info.IsDir() {
+			return fmt.Errorf("result spill directory %s is not a directory", opts.ResultSpillDir)
+		}
+		threshold := opts.ResultSpillThreshold
+		if threshold <= 0 {
+			threshold = DefaultResultSpillThreshold
+		}
+		builder = builder.WithResultSpill(opts.ResultSpillDir, threshold)
+	}
 
 	// Set validation report entry factory
-	builder = builder.WithValidationReportEntryFactory(engine.NewDefaultValidationReportEntryFactory())
+	builder = builder.WithValidationReportEntryFactory(&engine.DefaultValidationReportEntryFactory{
+		FingerprintLineNumbers: opts.FingerprintLineNumbers,
+		RuleDocsBaseURL:        opts.RuleDocsBaseURL,
+	})
 
 	// Build runner
 	runner, err := builder.Build()
@@ -635,23 +974,30 @@ func (v *NetexValidator) initializeRunner(opts *ValidationOptions) error {
 	return nil
 }
 
-// createValidationResultFromReport converts a validation report to library result format
-func (v *NetexValidator) createValidationResultFromReport(report *types.ValidationReport, reportID string, startTime time.Time) *ValidationResult {
-	// Convert entries to library format
-	var resultEntries []ValidationReportEntry
-	for _, entry := range report.ValidationReportEntries {
-		resultEntries = append(resultEntries, ValidationReportEntry{
-			Name:     entry.Name,
-			Message:  entry.Message,
-			Severity: entry.Severity,
-			FileName: entry.FileName,
-			Location: ValidationReportLocation{
-				FileName:   entry.Location.FileName,
-				LineNumber: entry.Location.LineNumber,
-				XPath:      entry.Location.XPath,
-				ElementID:  entry.Location.ElementID,
-			},
-		})
+// createValidationResultFromReport converts a validation report of filesProcessed
+// files to library result format
+func (v *NetexValidator) createValidationResultFromReport(report *types.ValidationReport, reportID string, startTime time.Time, filesProcessed int) *ValidationResult {
+	if report.SpilledEntryCount() > 0 {
+		return v.createSpilledResult(report, startTime, filesProcessed)
+	}
+
+	resultEntries := convertReportEntries(report.ValidationReportEntries)
+	// Scored before findings are limited or transformed
+	qualityScore := computeQualityScore(resultEntries, filesProcessed, v.config.Scoring)
+	var skipped []ValidationReportEntry
+	if v.skippedRules != nil {
+		skipped = v.skippedRules.entries()
+		resultEntries = append(resultEntries, skipped...)
+	}
+
+	// Replace element ids with pseudonyms when the report is to be shared
+	var anonymizedIds map[string]string
+	if v.options != nil && v.options.AnonymizeIds {
+		anonymizer := newIdAnonymizer(v.options.AnonymizationKey)
+		for i := range resultEntries {
+			anonymizer.anonymizeEntry(&resultEntries[i])
+		}
+		anonymizedIds = anonymizer.originals
 	}
 
 	// Convert int64 map to int map
@@ -659,24 +1005,317 @@ func (v *NetexValidator) createValidationResultFromReport(report *types.Validati
 	for k, v := range report.NumberOfValidationEntriesPerRule {
 		entriesPerRule[k] = int(v)
 	}
+	if len(skipped) > 0 {
+		entriesPerRule[skippedRuleName] += len(skipped)
+	}
 
-	return &ValidationResult{
+	result := &ValidationResult{
 		Codespace:                        report.Codespace,
 		ValidationReportID:               report.ValidationReportID,
 		CreationDate:                     report.CreationDate,
 		ValidationReportEntries:          resultEntries,
 		NumberOfValidationEntriesPerRule: entriesPerRule,
+		FilesProcessed:                   filesProcessed,
 		ProcessingTime:                   time.Since(startTime),
+		QualityScore:                     qualityScore,
+		anonymizedIds:                    anonymizedIds,
+	}
+
+	// Leave out everything that varies between runs over the same input
+	if v.options != nil && v.options.Deterministic {
+		sortReportEntries(result.ValidationReportEntries)
+		result.CreationDate = time.Time{}
+		result.ProcessingTime = 0
+		result.deterministic = true
+	}
+
+	v.applyResultOptions(result)
+
+	if v.options != nil && v.options.MaxFindingsPerRule > 0 {
+		result.ValidationReportEntries = limitFindingsPerRule(result.ValidationReportEntries, v.options.MaxFindingsPerRule)
+	}
+
+	// Integrator hook, last so it sees the entries as they are output
+	if v.options != nil && v.options.FindingTransform != nil {
+		for i := range result.ValidationReportEntries {
+			result.ValidationReportEntries[i] = v.options.FindingTransform(result.ValidationReportEntries[i])
+		}
+	}
+
+	return result
+}
+
+// applyResultOptions sets the output options and report metadata of a result
+func (v *NetexValidator) applyResultOptions(result *ValidationResult) {
+	if v.options != nil {
+		result.compactJSON = v.options.CompactJSON
+		result.topRules = v.options.TopRules
+		result.htmlTemplate = v.options.HTMLTemplate
+		result.ReportTitle = v.options.ReportTitle
+		result.Metadata = copyMetadata(v.options.ReportMetadata)
+	}
+}
+
+// withoutRule returns the rules other than the one with the given code
+func withoutRule(enabled []rules.Rule, code string) []rules.Rule {
+	filtered := enabled[:0]
+	for _, r := range enabled {
+		if r.Code != code {
+			filtered = append(filtered, r)
+		}
+	}
+	return filtered
+}
+
+// copyMetadata returns a copy of metadata, so results do not share the options' map
+func copyMetadata(metadata map[string]string) map[string]string {
+	if len(metadata) == 0 {
+		return nil
+	}
+	copied := make(map[string]string, len(metadata))
+	for key, value := range metadata {
+		copied[key] = value
+	}
+	return copied
+}
+
+// limitFindingsPerRule keeps the first limit entries of each rule and appends one
+// summary entry per rule that had more, in the order the rules first appeared
+func limitFindingsPerRule(entries []ValidationReportEntry, limit int) []ValidationReportEntry {
+	limiter := newFindingLimiter(limit)
+	limited := entries[:0]
+	for _, entry := range entries {
+		if limiter.keep(entry) {
+			limited = append(limited, entry)
+		}
+	}
+	return append(limited, limiter.summaries()...)
+}
+
+// findingLimiter counts the findings of each rule against a limit, one at a time
+type findingLimiter struct {
+	limit       int
+	kept        map[string]int
+	dropped     map[string]int
+	overflowing []ValidationReportEntry
+}
+
+func newFindingLimiter(limit int) *findingLimiter {
+	return &findingLimiter{limit: limit, kept: make(map[string]int), dropped: make(map[string]int)}
+}
+
+// keep reports whether entry is within the limit of its rule
+func (l *findingLimiter) keep(entry ValidationReportEntry) bool {
+	key := limiterKey(entry)
+	if l.kept[key] < l.limit {
+		l.kept[key]++
+		return true
+	}
+	if l.dropped[key] == 0 {
+		l.overflowing = append(l.overflowing, entry)
+	}
+	l.dropped[key]++
+	return false
+}
+
+// summaries returns one entry per rule with dropped findings, saying how many
+func (l *findingLimiter) summaries() []ValidationReportEntry {
+	summaries := make([]ValidationReportEntry, 0, len(l.overflowing))
+	for _, entry := range l.overflowing {
+		summaries = append(summaries, ValidationReportEntry{
+			RuleCode: entry.RuleCode,
+			Name:     entry.Name,
+			Message:  fmt.Sprintf("... and %d more", l.dropped[limiterKey(entry)]),
+			Severity: entry.Severity,
+		})
+	}
+	return summaries
+}
+
+// limiterKey identifies the rule of an entry by its code, since rules may share
+// a name. Entries without a code, such as those of custom integrations, fall
+// back to the name.
+func limiterKey(entry ValidationReportEntry) string {
+	if entry.RuleCode != "" {
+		return entry.RuleCode
+	}
+	return entry.Name
+}
+
+// convertReportEntries converts engine report entries to library format
+func convertReportEntries(entries []types.ValidationReportEntry) []ValidationReportEntry {
+	var resultEntries []ValidationReportEntry
+	for _, entry := range entries {
+		resultEntries = append(resultEntries, convertReportEntry(entry))
+	}
+	return resultEntries
+}
+
+// convertReportEntry converts an engine report entry to library format
+func convertReportEntry(entry types.ValidationReportEntry) ValidationReportEntry {
+	return ValidationReportEntry{
+		RuleCode: entry.RuleCode,
+		Name:     entry.Name,
+		Message:  entry.Message,
+		Severity: entry.Severity,
+		FileName: entry.FileName,
+		Location: ValidationReportLocation{
+			FileName:   entry.Location.FileName,
+			LineNumber: entry.Location.LineNumber,
+			XPath:      entry.Location.XPath,
+			ElementID:  entry.Location.ElementID,
+		},
+		MatchedSnippet: entry.MatchedSnippet,
+		RuleXPath:      entry.RuleXPath,
+		Fingerprint:    entry.Fingerprint,
+		DocURL:         entry.DocURL,
+	}
+}
+
+// sortReportEntries orders entries by location, then rule and message
+func sortReportEntries(entries []ValidationReportEntry) {
+	sort.SliceStable(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		if a.FileName != b.FileName {
+			return a.FileName < b.FileName
+		}
+		if a.Location.LineNumber != b.Location.LineNumber {
+			return a.Location.LineNumber < b.Location.LineNumber
+		}
+		if a.Location.XPath != b.Location.XPath {
+			return a.Location.XPath < b.Location.XPath
+		}
+		if a.Location.ElementID != b.Location.ElementID {
+			return a.Location.ElementID < b.Location.ElementID
+		}
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		return a.Message < b.Message
+	})
+}
+
+// ruleOverrides applies the configured rule categories and severities and the rule
+// and severity overrides from ValidationOptions to validators whose rules are not
+// managed by the rule registry. The options take precedence over the configuration.
+type ruleOverrides struct {
+	config     *config.ValidatorConfig
+	enabled    map[string]bool
+	severities map[string]types.Severity
+}
+
+func newRuleOverrides(cfg *config.ValidatorConfig, opts *ValidationOptions) ruleOverrides {
+	return ruleOverrides{config: cfg, enabled: opts.RuleOverrides, severities: opts.SeverityOverrides}
+}
+
+// isEnabled reports whether a rule runs
+func (o ruleOverrides) isEnabled(code string) bool {
+	if enabled, ok := o.enabled[code]; ok {
+		return enabled
+	}
+	return o.config == nil || o.config.IsRuleEnabled(code)
+}
+
+// severity returns the effective severity of a rule
+func (o ruleOverrides) severity(code string, severity types.Severity) types.Severity {
+	if sev, ok := o.severities[code]; ok {
+		return sev
+	}
+	if o.config != nil {
+		return o.config.GetRuleSeverity(code, severity)
+	}
+	return severity
+}
+
+// filterIssues drops issues of disabled rules and remaps overridden severities
+func (o ruleOverrides) filterIssues(issues []types.ValidationIssue) []types.ValidationIssue {
+	filtered := issues[:0]
+	for _, issue := range issues {
+		if !o.isEnabled(issue.Rule.Code) {
+			continue
+		}
+		issue.Rule.Severity = o.severity(issue.Rule.Code, issue.Rule.Severity)
+		filtered = append(filtered, issue)
+	}
+	return filtered
+}
+
+// filterRules returns the enabled rules with severity overrides applied
+func (o ruleOverrides) filterRules(rules []types.ValidationRule) []types.ValidationRule {
+	var filtered []types.ValidationRule
+	for _, rule := range rules {
+		if !o.isEnabled(rule.Code) {
+			continue
+		}
+		rule.Severity = o.severity(rule.Code, rule.Severity)
+		filtered = append(filtered, rule)
+	}
+	return filtered
+}
+
+// ruleOverrideValidator wraps a per-file validator with rule overrides
+type ruleOverrideValidator struct {
+	inner     interfaces.XPathValidator
+	overrides ruleOverrides
+}
+
+func newRuleOverrideValidator(inner interfaces.XPathValidator, overrides ruleOverrides) *ruleOverrideValidator {
+	return &ruleOverrideValidator{inner: inner, overrides: overrides}
+}
+
+// Validate runs the wrapped validator and applies the overrides to its issues
+func (v *ruleOverrideValidator) Validate(ctx context.XPathValidationContext) ([]types.ValidationIssue, error) {
+	issues, err := v.inner.Validate(ctx)
+	if err != nil {
+		return issues, err
+	}
+	return v.overrides.filterIssues(issues), nil
+}
+
+// GetRules returns the wrapped validator's rules with overrides applied
+func (v *ruleOverrideValidator) GetRules() []types.ValidationRule {
+	return v.overrides.filterRules(v.inner.GetRules())
+}
+
+// ruleOverrideDatasetValidator wraps a dataset validator with rule overrides
+type ruleOverrideDatasetValidator struct {
+	interfaces.DatasetValidator
+	overrides ruleOverrides
+}
+
+func newRuleOverrideDatasetValidator(inner interfaces.DatasetValidator, overrides ruleOverrides) *ruleOverrideDatasetValidator {
+	return &ruleOverrideDatasetValidator{DatasetValidator: inner, overrides: overrides}
+}
+
+// Validate runs the wrapped validator and applies the overrides to its issues
+func (v *ruleOverrideDatasetValidator) Validate(repository interfaces.IdRepository) ([]types.ValidationIssue, error) {
+	issues, err := v.DatasetValidator.Validate(repository)
+	if err != nil {
+		return issues, err
 	}
+	return v.overrides.filterIssues(issues), nil
+}
+
+// GetRules returns the wrapped validator's rules with overrides applied
+func (v *ruleOverrideDatasetValidator) GetRules() []types.ValidationRule {
+	return v.overrides.filterRules(v.DatasetValidator.GetRules())
 }
 
 // SimpleXPathRule is a minimal adapter to execute a rule's XPath and produce issues
 type SimpleXPathRule struct {
 	rule     rules.Rule
 	compiled *antxpath.Expr
+	explain  bool       // Attach a snippet of each matched element to its issue
 	mu       sync.Mutex // Protects compiled XPath expression
+
+	includeXPath bool // Attach the rule's XPath to each issue
+
+	skipped *skippedRules // Records the rule when its XPath cannot be evaluated (nil = not recorded)
 }
 
+// matchedSnippetLength is the number of characters of a matched element kept in explain mode
+const matchedSnippetLength = 200
+
 // NewSimpleXPathRule creates a new adapter from a rules.Rule
 func NewSimpleXPathRule(rule rules.Rule) *SimpleXPathRule {
 	r := &SimpleXPathRule{rule: rule}
@@ -707,6 +1346,7 @@ func (r *SimpleXPathRule) Validate(ctx context.XPathValidationContext) ([]types.
 		if rec := recover(); rec != nil {
 			// Log the unsupported XPath function and skip this rule
 			evalErr = fmt.Errorf("unsupported XPath function in rule %s: %v", r.rule.Code, rec)
+			r.recordSkip(fmt.Sprintf("evaluating its XPath failed: %v", rec))
 		}
 	}()
 
@@ -757,14 +1397,21 @@ func (r *SimpleXPathRule) Validate(ctx context.XPathValidationContext) ([]types.
 				Name:     r.rule.Name,
 				Message:  r.rule.Message,
 				Severity: r.rule.Severity,
+				DocURL:   r.rule.DocURL,
 			},
 			Location: types.DataLocation{
 				FileName:  ctx.GetFileName(),
-				XPath:     computeNodeXPath(node),
+				XPath:     utils.NodeXPath(node),
 				ElementID: elementID,
 			},
 			Message: baseMsg,
 		}
+		if r.explain {
+			issue.MatchedSnippet = utils.NodeSnippet(node, matchedSnippetLength)
+		}
+		if r.includeXPath {
+			issue.RuleXPath = r.rule.XPath
+		}
 		issues = append(issues, issue)
 	}
 
@@ -812,37 +1459,23 @@ func (r *SimpleXPathRule) safeXPathFind(doc *xmlquery.Node, xpath string) []*xml
 		if rec := recover(); rec != nil {
 			// Log the error but don't crash
 			fmt.Printf("Warning: Skipping rule %s due to unsupported XPath function: %v\n", r.rule.Code, rec)
+			r.recordSkip(fmt.Sprintf("evaluating its XPath failed: %v", rec))
 		}
 	}()
 
 	// Check for unsupported functions before executing
 	if r.hasUnsupportedFunctions(xpath) {
 		fmt.Printf("Warning: Skipping rule %s - contains unsupported XPath function\n", r.rule.Code)
+		r.recordSkip("its XPath uses a function the XPath engine does not support")
 		return nil
 	}
 
 	return xmlquery.Find(doc, xpath)
 }
 
-// computeNodeXPath builds a simple XPath-like location for a node
-func computeNodeXPath(n *xmlquery.Node) string {
-	if n == nil {
-		return ""
-	}
-	var parts []string
-	for cur := n; cur != nil && cur.Type != xmlquery.DocumentNode; cur = cur.Parent {
-		// position among siblings with same name
-		pos := 1
-		for sib := cur.PrevSibling; sib != nil; sib = sib.PrevSibling {
-			if sib.Type == xmlquery.ElementNode && sib.Data == cur.Data {
-				pos++
-			}
-		}
-		parts = append(parts, fmt.Sprintf("/%s[%d]", cur.Data, pos))
-	}
-	// reverse
-	for i, j := 0, len(parts)-1; i < j; i, j = i+1, j-1 {
-		parts[i], parts[j] = parts[j], parts[i]
+// recordSkip records that the rule was skipped when skipped rules are reported
+func (r *SimpleXPathRule) recordSkip(reason string) {
+	if r.skipped != nil {
+		r.skipped.add(r.rule, reason)
 	}
-	return strings.Join(parts, "")
 }
@@ -1,7 +1,6 @@
 package validator
 
 import (
-	"encoding/json"
 	"sort"
 	"strings"
 	"time"
@@ -23,12 +22,19 @@ type OptimizedGroupedResult struct {
 	CreationDate       time.Time `json:"creationDate"`
 	GeneratedAt        time.Time `json:"generatedAt"`
 
+	// Report title and integrator metadata, when set
+	ReportTitle string            `json:"reportTitle,omitempty"`
+	Metadata    map[string]string `json:"metadata,omitempty"`
+
 	// High-level summary
 	Summary OptimizedSummary `json:"summary"`
 
 	// Optimized grouped notices
 	Notices OptimizedNotices `json:"notices"`
 
+	// Dataset quality score, when the validation completed
+	QualityScore *QualityScore `json:"qualityScore,omitempty"`
+
 	// Processing info
 	FilesProcessed int           `json:"filesProcessed"`
 	ProcessingTime time.Duration `json:"processingTimeMs"`
@@ -74,6 +80,12 @@ type OptimizedNoticeGroup struct {
 	// For ID-related issues, group by the problematic ID
 	IDGroups map[string]IDIssueGroup `json:"idGroups,omitempty"`
 
+	// XPaths of the rules behind the group, only populated when rule XPaths are included
+	RuleXPaths []string `json:"ruleXPaths,omitempty"`
+
+	// Documentation of the rule behind the group, when rule docs are linked
+	DocURL string `json:"docUrl,omitempty"`
+
 	// Sample occurrences (for very large groups, show just a few examples)
 	SampleOccurrences []OptimizedOccurrence `json:"sampleOccurrences,omitempty"`
 
@@ -83,9 +95,10 @@ type OptimizedNoticeGroup struct {
 
 // FileIssueDetail contains issue details for a specific file
 type FileIssueDetail struct {
-	Count       int      `json:"count"`
-	ElementIDs  []string `json:"elementIds,omitempty"`
-	LineNumbers []int    `json:"lineNumbers,omitempty"`
+	Count           int      `json:"count"`
+	ElementIDs      []string `json:"elementIds,omitempty"`
+	LineNumbers     []int    `json:"lineNumbers,omitempty"`
+	MatchedSnippets []string `json:"matchedSnippets,omitempty"` // Only populated in explain mode
 }
 
 // IDIssueGroup contains issues grouped by problematic NetEX ID
@@ -100,8 +113,11 @@ type IDIssueGroup struct {
 // This method intelligently groups similar validation issues to reduce output size
 // and improve readability, especially for large datasets with many repetitive notices.
 func (r *ValidationResult) ToOptimizedJSON() ([]byte, error) {
+	if r.spilled != nil {
+		return nil, ErrSpilledResult
+	}
 	optimized := r.createOptimizedGrouping()
-	return json.MarshalIndent(optimized, "", "  ")
+	return r.marshalJSON(optimized)
 }
 
 // createOptimizedGrouping creates optimized grouped structure with smart aggregation
@@ -156,17 +172,25 @@ func (r *ValidationResult) createOptimizedGrouping() *OptimizedGroupedResult {
 	// Calculate NetEX element statistics
 	statistics := r.calculateNetEXStatistics()
 
+	generatedAt := time.Now()
+	if r.deterministic {
+		generatedAt = r.CreationDate
+	}
+
 	return &OptimizedGroupedResult{
 		Codespace:          r.Codespace,
 		ValidationReportID: r.ValidationReportID,
 		CreationDate:       r.CreationDate,
-		GeneratedAt:        time.Now(),
+		GeneratedAt:        generatedAt,
+		ReportTitle:        r.ReportTitle,
+		Metadata:           r.Metadata,
 		Summary:            summary,
 		Notices: OptimizedNotices{
 			Errors:   errors,
 			Warnings: warnings,
 			Info:     info,
 		},
+		QualityScore:   r.QualityScore,
 		FilesProcessed: r.FilesProcessed,
 		ProcessingTime: r.ProcessingTime,
 		CacheHit:       r.CacheHit,
@@ -197,6 +221,8 @@ func (r *ValidationResult) createOptimizedGroup(ruleName string, entries []Valid
 		Count:          len(entries),
 		Severity:       firstEntry.Severity,
 		AffectedFiles:  affectedFiles,
+		RuleXPaths:     ruleXPaths(entries),
+		DocURL:         firstEntry.DocURL,
 		ShowingDetails: true,
 	}
 
@@ -296,6 +322,9 @@ func (r *ValidationResult) createFileDetails(filesMap map[string][]ValidationRep
 			if entry.Location.LineNumber > 0 {
 				lineNumberSet[entry.Location.LineNumber] = true
 			}
+			if entry.MatchedSnippet != "" {
+				detail.MatchedSnippets = append(detail.MatchedSnippets, entry.MatchedSnippet)
+			}
 		}
 
 		// Convert to sorted slices
@@ -317,11 +346,30 @@ func (r *ValidationResult) createFileDetails(filesMap map[string][]ValidationRep
 
 // OptimizedOccurrence represents a single occurrence in optimized format
 type OptimizedOccurrence struct {
-	FileName   string `json:"fileName"`
-	LineNumber int    `json:"lineNumber,omitempty"`
-	XPath      string `json:"xpath,omitempty"`
-	ElementID  string `json:"elementId,omitempty"`
-	Message    string `json:"message,omitempty"`
+	FileName       string            `json:"fileName"`
+	LineNumber     int               `json:"lineNumber,omitempty"`
+	XPath          string            `json:"xpath,omitempty"`
+	ElementID      string            `json:"elementId,omitempty"`
+	Message        string            `json:"message,omitempty"`
+	MatchedSnippet string            `json:"matchedSnippet,omitempty"`
+	RuleXPath      string            `json:"ruleXPath,omitempty"`
+	Fingerprint    string            `json:"fingerprint,omitempty"`
+	Annotations    map[string]string `json:"annotations,omitempty"`
+}
+
+// ruleXPaths returns the distinct rule XPaths of entries in sorted order. Several
+// rules can share a name, so a group may have more than one.
+func ruleXPaths(entries []ValidationReportEntry) []string {
+	seen := make(map[string]bool)
+	var xpaths []string
+	for _, entry := range entries {
+		if entry.RuleXPath != "" && !seen[entry.RuleXPath] {
+			seen[entry.RuleXPath] = true
+			xpaths = append(xpaths, entry.RuleXPath)
+		}
+	}
+	sort.Strings(xpaths)
+	return xpaths
 }
 
 // createSampleOccurrences creates sample occurrences for large groups
@@ -339,11 +387,15 @@ func (r *ValidationResult) createSampleOccurrences(entries []ValidationReportEnt
 		// Prefer samples from different files
 		if !filesSeen[entry.FileName] || len(samples) < maxSamples/2 {
 			samples = append(samples, OptimizedOccurrence{
-				FileName:   entry.FileName,
-				LineNumber: entry.Location.LineNumber,
-				XPath:      entry.Location.XPath,
-				ElementID:  entry.Location.ElementID,
-				Message:    entry.Message,
+				FileName:       entry.FileName,
+				LineNumber:     entry.Location.LineNumber,
+				XPath:          entry.Location.XPath,
+				ElementID:      entry.Location.ElementID,
+				Message:        entry.Message,
+				MatchedSnippet: entry.MatchedSnippet,
+				RuleXPath:      entry.RuleXPath,
+				Fingerprint:    entry.Fingerprint,
+				Annotations:    entry.Annotations,
 			})
 			filesSeen[entry.FileName] = true
 		}
@@ -1,8 +1,11 @@
 package validator
 
 import (
+	"time"
+
 	"github.com/theoremus-urban-solutions/netex-validator/logging"
 	"github.com/theoremus-urban-solutions/netex-validator/types"
+	"github.com/theoremus-urban-solutions/netex-validator/validation/engine"
 )
 
 // ValidationOptions configures NetEX validation behavior.
@@ -25,6 +28,10 @@ type ValidationOptions struct {
 	// identifier (e.g., "NO" for Norway, "SE" for Sweden, "DK" for Denmark).
 	Codespace string
 
+	// DefaultCodespace is used when Codespace is empty. The defaultCodespace entry
+	// of the configuration file is used when both are empty.
+	DefaultCodespace string
+
 	// ConfigFile specifies the path to a YAML configuration file for rule customization.
 	// If empty, built-in default rules are used. The config file can enable/disable
 	// specific rules and override their severity levels.
@@ -45,6 +52,11 @@ type ValidationOptions struct {
 	// Higher values provide more comprehensive error reporting but may impact performance.
 	MaxSchemaErrors int
 
+	// MaxXMLDepth rejects documents nesting elements deeper than this with a
+	// CRITICAL XML_TOO_DEEP finding before they are parsed, guarding against
+	// maliciously nested input. Set to 0 to disable the check.
+	MaxXMLDepth int
+
 	// Verbose enables detailed logging during validation processing.
 	// When true, validation progress, rule execution, and detailed error
 	// information is logged to help with debugging and monitoring.
@@ -77,21 +89,54 @@ type ValidationOptions struct {
 	// based on LogLevel and LogFormat settings.
 	Logger *logging.Logger
 
-	// Profile is deprecated; EU is the default and only supported profile.
+	// Profile names the NeTEx profile of the data ("eu", "nordic", "fr") instead of
+	// detecting it with DetectProfile. The configured or detected profile is logged
+	// and selects the rules: the EU rules are used for every profile, as no national
+	// rule sets exist, except that rules specific to the EU profile such as
+	// FRAME_OUTSIDE_COMPOSITE_FRAME are left out for national profiles.
 	Profile string
 
 	// MaxFindings limits the total number of validation findings to collect (0 = unlimited).
 	MaxFindings int
 
+	// MaxFindingsPerRule limits the findings reported for any single rule (0 = unlimited).
+	// Findings beyond the limit are replaced by one summary entry per rule.
+	MaxFindingsPerRule int
+
 	// AllowSchemaNetwork enables downloading schemas from network for XSD validation.
 	AllowSchemaNetwork bool
 
 	// SchemaCacheDir specifies where downloaded schemas are cached.
 	SchemaCacheDir string
 
+	// BundledSchemas looks schemas up in the set compiled into the binary before the
+	// schema cache and the network. Default true.
+	BundledSchemas bool
+
+	// BundledSchemaVersion validates every document against the bundled schema of this
+	// version instead of the version the document declares. Empty uses the declared version.
+	BundledSchemaVersion string
+
 	// SchemaTimeoutSeconds sets HTTP timeout for schema downloads.
 	SchemaTimeoutSeconds int
 
+	// SchemaDownloadRetries sets how many times a failed schema download is retried
+	// with exponential backoff before falling back to basic validation. Datasets
+	// downloaded by ValidateURL are retried as often. Default 0.
+	SchemaDownloadRetries int
+
+	// SchemaDownloadConcurrency limits how many schema downloads may run at once
+	// within the process, shared by all validators. 0 means no limit.
+	SchemaDownloadConcurrency int
+
+	// URLTimeoutSeconds bounds the download of a dataset validated with ValidateURL,
+	// including reading the body. Default 120.
+	URLTimeoutSeconds int
+
+	// MaxURLBytes rejects datasets larger than this many bytes in ValidateURL.
+	// Default 512 MiB.
+	MaxURLBytes int64
+
 	// UseLibxml2XSD enables real XSD validation using libxml2 bindings when available.
 	// Default is false; when true, the validator will attempt libxml2 and fall back on failure.
 	UseLibxml2XSD bool
@@ -100,6 +145,10 @@ type ValidationOptions struct {
 	// 0 means use configuration default.
 	ConcurrentFiles int
 
+	// IdValidationWorkers sets the number of goroutines used to validate cross-file
+	// references and duplicate IDs once all files are loaded. 0 means GOMAXPROCS.
+	IdValidationWorkers int
+
 	// EnableValidationCache enables in-memory caching of validation results by file hash
 	EnableValidationCache bool
 
@@ -111,8 +160,136 @@ type ValidationOptions struct {
 
 	// CacheTTLHours sets how long cached results remain valid (default: 24 hours)
 	CacheTTLHours int
+
+	// MaxTransferTime is the StandardTransferTime above which a ServiceJourneyInterchange
+	// is reported as implausible (default: 2 hours)
+	MaxTransferTime time.Duration
+
+	// ChangedFiles lists the dataset entries that get full rule execution when validating
+	// a ZIP dataset. Other entries are only loaded so cross-file references resolve.
+	// Empty means every file is validated.
+	ChangedFiles []string
+
+	// BaselineDataset is the path of a full dataset ZIP that a delta ZIP dataset is
+	// validated against. References into the baseline resolve; the baseline itself
+	// is not reported on.
+	BaselineDataset string
+
+	// Explain attaches a snippet of the matched element's XML to each XPath rule
+	// finding, which helps when authoring and debugging rules
+	Explain bool
+
+	// IncludeRuleXPath attaches the XPath of the matching rule to each XPath rule
+	// finding. Off by default so reports do not expose rule internals.
+	IncludeRuleXPath bool
+
+	// AnonymizeIds replaces element ids in findings with keyed hash-based pseudonyms,
+	// for reports shared outside the organisation
+	AnonymizeIds bool
+	// AnonymizationKey keys the pseudonyms of AnonymizeIds. Without a key every
+	// report uses a random one, so its pseudonyms cannot be matched across reports.
+	AnonymizationKey []byte
+
+	// Deterministic runs validation single-threaded and sorts findings so the same input
+	// always produces identical output, at the cost of parallel speed-up
+	Deterministic bool
+
+	// CompactJSON writes the JSON of validation results on a single line instead of
+	// indented. Default false.
+	CompactJSON bool
+
+	// EnforceCodespacePrefix reports declared element ids whose prefix is neither the
+	// validation codespace nor a codespace declared in the dataset
+	EnforceCodespacePrefix bool
+
+	// FingerprintLineNumbers includes line numbers in finding fingerprints
+	FingerprintLineNumbers bool
+
+	// StrictDeadRuns reports DeadRuns that share a Route with passenger ServiceJourneys
+	StrictDeadRuns bool
+
+	// CodespacePattern is a regular expression the codespace of every structured id
+	// must match in full (empty = any codespace)
+	CodespacePattern string
+
+	// FlagUnknownModes reports TransportMode unknown on Lines and ServiceJourneys
+	FlagUnknownModes bool
+
+	// FindingTransform, when set, rewrites each finding before it is returned
+	FindingTransform func(ValidationReportEntry) ValidationReportEntry
+
+	// ReportSkippedRules adds an INFO finding for every XPath rule whose expression
+	// could not be evaluated
+	ReportSkippedRules bool
+
+	// VersionReport adds one finding listing every id declared or referenced with
+	// conflicting versions across the dataset
+	VersionReport bool
+
+	// StrictCalendar reports OperatingDays not covered by any OperatingPeriod
+	StrictCalendar bool
+
+	// StreamingMode validates single files token by token with the element-local
+	// XPath rules only, for files too large to parse into memory
+	StreamingMode bool
+
+	// ReportTitle replaces the default title of HTML reports (empty = default)
+	ReportTitle string
+
+	// ReportMetadata is copied into every result, e.g. a dataset name or portal id
+	ReportMetadata map[string]string
+
+	// Plugins are paths of Go plugins whose validators run alongside the built-in ones
+	Plugins []string
+
+	// StrictRouteDirections reports Lines whose Routes all share one DirectionType
+	StrictRouteDirections bool
+
+	// FlagModeOutliers reports Lines whose TransportMode differs from the dominant
+	// mode of their Network
+	FlagModeOutliers bool
+
+	// AmbiguousNameThreshold reports ScheduledStopPoint Names shared by more stop
+	// points than this (0 = not checked)
+	AmbiguousNameThreshold int
+
+	// RuleDocsBaseURL links each finding to base + rule code (empty = no links)
+	RuleDocsBaseURL string
+
+	// TopRules is the number of rules in the top rules summary of HTML reports
+	// (0 = DefaultTopRules, negative = no summary)
+	TopRules int
+
+	// HTMLTemplate replaces the built-in template of HTML reports when set
+	HTMLTemplate string
+
+	// FlagExpiredData reports AvailabilityConditions and ServiceCalendars whose
+	// ToDate is before the PublicationTimestamp
+	FlagExpiredData bool
+
+	// CheckParticipantRef reports a PublicationDelivery ParticipantRef that matches
+	// neither the codespace nor a declared Codespace
+	CheckParticipantRef bool
+
+	// ResultSpillDir enables spilling the findings of ZIP and dataset validation to
+	// NDJSON files in this directory once there are more than ResultSpillThreshold
+	ResultSpillDir string
+
+	// ResultSpillThreshold is the number of findings held in memory before they are
+	// spilled (0 = DefaultResultSpillThreshold)
+	ResultSpillThreshold int
+
+	// ExpectedCounts maps element names, such as Line, to the number of them a
+	// dataset should declare (nil = not checked)
+	ExpectedCounts map[string]types.ExpectedCount
 }
 
+// DefaultMaxXMLDepth is the default MaxXMLDepth
+const DefaultMaxXMLDepth = engine.DefaultMaxXMLDepth
+
+// DefaultResultSpillThreshold is the default ResultSpillThreshold
+const DefaultResultSpillThreshold = 100000
+
 // DefaultValidationOptions returns a ValidationOptions instance with sensible defaults.
 //
 // Default configuration:
@@ -120,6 +297,7 @@ type ValidationOptions struct {
 //   - Schema validation: enabled
 //   - Business rule validation: enabled
 //   - Maximum schema errors: 100
+//   - Maximum XML nesting depth: 256
 //   - Verbose logging: disabled
 //   - Output format: JSON
 //   - No rule or severity overrides
@@ -135,6 +313,7 @@ func DefaultValidationOptions() *ValidationOptions {
 		SkipSchema:            false,
 		SkipValidators:        false,
 		MaxSchemaErrors:       100,
+		MaxXMLDepth:           DefaultMaxXMLDepth,
 		Verbose:               false,
 		RuleOverrides:         make(map[string]bool),
 		SeverityOverrides:     make(map[string]types.Severity),
@@ -146,13 +325,17 @@ func DefaultValidationOptions() *ValidationOptions {
 		MaxFindings:           0,
 		AllowSchemaNetwork:    true,
 		SchemaCacheDir:        "",
+		BundledSchemas:        true,
 		SchemaTimeoutSeconds:  30,
+		URLTimeoutSeconds:     120,
+		MaxURLBytes:           512 << 20,
 		UseLibxml2XSD:         false,
 		ConcurrentFiles:       0,
 		EnableValidationCache: false,
 		CacheMaxEntries:       1000,
 		CacheMaxMemoryMB:      50,
 		CacheTTLHours:         24, // 1 day default
+		MaxTransferTime:       2 * time.Hour,
 	}
 }
 
@@ -170,6 +353,13 @@ func (o *ValidationOptions) WithCodespace(codespace string) *ValidationOptions {
 	return o
 }
 
+// WithDefaultCodespace sets the codespace used when WithCodespace leaves it empty,
+// taking precedence over the defaultCodespace entry of the configuration file.
+func (o *ValidationOptions) WithDefaultCodespace(codespace string) *ValidationOptions {
+	o.DefaultCodespace = codespace
+	return o
+}
+
 // WithConfigFile sets the path to a YAML configuration file and returns the options for chaining.
 //
 // The configuration file allows customizing validation rules, their severity levels,
@@ -297,7 +487,8 @@ func (o *ValidationOptions) WithLogger(logger *logging.Logger) *ValidationOption
 	return o
 }
 
-// WithProfile sets the validation profile (e.g., "eu", "custom").
+// WithProfile sets the NeTEx profile of the data (e.g., "eu", "nordic", "fr")
+// instead of detecting it from the content.
 func (o *ValidationOptions) WithProfile(profile string) *ValidationOptions {
 	o.Profile = profile
 	return o
@@ -309,6 +500,27 @@ func (o *ValidationOptions) WithMaxFindings(n int) *ValidationOptions {
 	return o
 }
 
+// WithMaxFindingsPerRule caps the findings reported for each rule (0 = unlimited), so a
+// single rule matching everywhere cannot drown out the rest of the report. The first n
+// findings of a rule are kept and the others are replaced by one "... and N more"
+// entry for that rule; NumberOfValidationEntriesPerRule still counts every finding.
+//
+// The cap is applied when the report is assembled, after MaxFindings has limited the
+// findings collected during validation. With both set, MaxFindings bounds the work and
+// memory of a run while MaxFindingsPerRule keeps the report balanced; summary entries
+// do not count towards MaxFindings.
+func (o *ValidationOptions) WithMaxFindingsPerRule(n int) *ValidationOptions {
+	o.MaxFindingsPerRule = n
+	return o
+}
+
+// WithMaxXMLDepth sets the element nesting depth above which a document is
+// rejected without further validation (0 = no limit)
+func (o *ValidationOptions) WithMaxXMLDepth(depth int) *ValidationOptions {
+	o.MaxXMLDepth = depth
+	return o
+}
+
 // WithAllowSchemaNetwork toggles schema network download
 func (o *ValidationOptions) WithAllowSchemaNetwork(allow bool) *ValidationOptions {
 	o.AllowSchemaNetwork = allow
@@ -321,12 +533,50 @@ func (o *ValidationOptions) WithSchemaCacheDir(dir string) *ValidationOptions {
 	return o
 }
 
+// WithBundledSchemas validates against the schemas compiled into the binary, which
+// needs neither network access nor a schema cache. A non-empty version validates every
+// document against that version; empty uses the version each document declares.
+// schema.BundledSchemaVersions lists the versions bundled in the binary; creating a
+// validator fails when version is not one of them.
+func (o *ValidationOptions) WithBundledSchemas(version string) *ValidationOptions {
+	o.BundledSchemas = true
+	o.BundledSchemaVersion = version
+	return o
+}
+
 // WithSchemaTimeoutSeconds sets schema HTTP timeout
 func (o *ValidationOptions) WithSchemaTimeoutSeconds(seconds int) *ValidationOptions {
 	o.SchemaTimeoutSeconds = seconds
 	return o
 }
 
+// WithSchemaDownloadRetries sets how many times a failed schema download is retried
+func (o *ValidationOptions) WithSchemaDownloadRetries(retries int) *ValidationOptions {
+	o.SchemaDownloadRetries = retries
+	return o
+}
+
+// WithSchemaDownloadConcurrency limits concurrent schema downloads within the process.
+//
+// The limit is process-wide: it applies to every validator, so bursts of
+// validators starting together do not overload the schema server.
+func (o *ValidationOptions) WithSchemaDownloadConcurrency(n int) *ValidationOptions {
+	o.SchemaDownloadConcurrency = n
+	return o
+}
+
+// WithURLTimeoutSeconds sets how long ValidateURL may take to download a dataset
+func (o *ValidationOptions) WithURLTimeoutSeconds(seconds int) *ValidationOptions {
+	o.URLTimeoutSeconds = seconds
+	return o
+}
+
+// WithMaxURLBytes sets the largest dataset ValidateURL downloads, in bytes
+func (o *ValidationOptions) WithMaxURLBytes(n int64) *ValidationOptions {
+	o.MaxURLBytes = n
+	return o
+}
+
 // WithUseLibxml2XSD toggles libxml2-backed XSD validation (experimental)
 func (o *ValidationOptions) WithUseLibxml2XSD(use bool) *ValidationOptions {
 	o.UseLibxml2XSD = use
@@ -339,6 +589,12 @@ func (o *ValidationOptions) WithConcurrentFiles(n int) *ValidationOptions {
 	return o
 }
 
+// WithIdValidationWorkers sets the parallelism for cross-file ID validation
+func (o *ValidationOptions) WithIdValidationWorkers(n int) *ValidationOptions {
+	o.IdValidationWorkers = n
+	return o
+}
+
 // WithValidationCache enables caching of validation results by file hash with memory limits
 func (o *ValidationOptions) WithValidationCache(enabled bool, maxEntries int, maxMemoryMB int, ttlHours int) *ValidationOptions {
 	o.EnableValidationCache = enabled
@@ -348,6 +604,173 @@ func (o *ValidationOptions) WithValidationCache(enabled bool, maxEntries int, ma
 	return o
 }
 
+// WithMaxTransferTime sets the threshold for implausibly large interchange transfer times
+func (o *ValidationOptions) WithMaxTransferTime(d time.Duration) *ValidationOptions {
+	o.MaxTransferTime = d
+	return o
+}
+
+// WithChangedFiles limits business rule validation of ZIP datasets to the given paths,
+// e.g. the files touched by a pull request. Cross-file ID validation still loads every file.
+func (o *ValidationOptions) WithChangedFiles(paths []string) *ValidationOptions {
+	o.ChangedFiles = paths
+	return o
+}
+
+// WithBaselineDataset validates ZIP datasets as deltas of the full dataset at path, e.g.
+// a nightly update validated against the last full export. IDs declared in the
+// baseline resolve references from the delta, so only genuinely dangling references
+// are reported.
+func (o *ValidationOptions) WithBaselineDataset(path string) *ValidationOptions {
+	o.BaselineDataset = path
+	return o
+}
+
+// WithExplain enables or disables explain mode. Each XPath rule finding then carries
+// the first 200 characters of the matched element's XML in MatchedSnippet.
+func (o *ValidationOptions) WithExplain(explain bool) *ValidationOptions {
+	o.Explain = explain
+	return o
+}
+
+// WithIncludeRuleXPath enables or disables including rule XPaths. Each XPath rule
+// finding then carries the expression that matched it in RuleXPath, so the match
+// can be understood and reproduced.
+func (o *ValidationOptions) WithIncludeRuleXPath(include bool) *ValidationOptions {
+	o.IncludeRuleXPath = include
+	return o
+}
+
+// WithAnonymizeIds enables or disables id anonymization. Element ids in the findings
+// of a report are replaced with pseudonyms derived from an HMAC of the id, so the same
+// id gets the same pseudonym throughout a report. Use ValidationResult.AnonymizedIds
+// to map the pseudonyms back to the original ids.
+func (o *ValidationOptions) WithAnonymizeIds(anonymize bool) *ValidationOptions {
+	o.AnonymizeIds = anonymize
+	return o
+}
+
+// WithAnonymizationKey sets the secret key of the anonymization HMAC, so ids keep
+// their pseudonyms across reports anonymized with the same key. Keep the key
+// private: anyone holding it can test guessed ids against the pseudonyms.
+func (o *ValidationOptions) WithAnonymizationKey(key []byte) *ValidationOptions {
+	o.AnonymizationKey = key
+	return o
+}
+
+// WithDeterministic enables or disables deterministic mode for reproducible output.
+//
+// Validators run one after another, ZIP entries and cross-file ID checks run on a
+// single goroutine, findings are sorted, and the creation date and processing time
+// are left zero, so two runs over the same input serialize byte for byte identically.
+// This is meant for golden-file tests and audit artifacts: large ZIP datasets take
+// roughly as many times longer as the number of files that would otherwise be
+// validated in parallel.
+func (o *ValidationOptions) WithDeterministic(deterministic bool) *ValidationOptions {
+	o.Deterministic = deterministic
+	return o
+}
+
+// WithCompactJSON selects single-line JSON output for results, which suits
+// machines and log pipelines; the default indented JSON is easier to read.
+func (o *ValidationOptions) WithCompactJSON(compact bool) *ValidationOptions {
+	o.CompactJSON = compact
+	return o
+}
+
+// WithEnforceCodespacePrefix enables or disables the ID_CODESPACE_PREFIX check. Ids
+// such as NO:Line:1 must then start with the codespace passed to WithCodespace or one
+// whose Xmlns is declared in a Codespace element of the dataset. Like the other
+// cross-file checks, it runs when validating ZIP datasets.
+func (o *ValidationOptions) WithEnforceCodespacePrefix(enforce bool) *ValidationOptions {
+	o.EnforceCodespacePrefix = enforce
+	return o
+}
+
+// WithFingerprintLineNumbers controls whether the line number of a finding is part of
+// its Fingerprint. By default it is left out, so a finding keeps its fingerprint when
+// edits elsewhere in the file shift it to another line; include it when findings of
+// the same rule on the same element must be told apart by position.
+func (o *ValidationOptions) WithFingerprintLineNumbers(include bool) *ValidationOptions {
+	o.FingerprintLineNumbers = include
+	return o
+}
+
+// WithStrictDeadRuns enables or disables the DEAD_RUN_3 check, which warns about
+// DeadRuns running on a Route that passenger ServiceJourneys also use. Reusing a
+// passenger Route is legal but often a modeling mistake, so the check is opt-in.
+// Like the other cross-file checks, it runs when validating ZIP datasets.
+func (o *ValidationOptions) WithStrictDeadRuns(strict bool) *ValidationOptions {
+	o.StrictDeadRuns = strict
+	return o
+}
+
+// WithCodespacePattern restricts the codespace token of ids, such as NO in
+// NO:Line:1, to values matching the regular expression pattern in full. Ids with
+// another codespace are reported as NETEX_ID_7. Without a pattern any codespace is
+// accepted. Examples:
+//
+//	[A-Z]{2}   ISO 3166 country codes, e.g. NO, SE or FR
+//	[A-Z]{3}   Nordic operator codespaces, e.g. RUT or ATB
+//	FR         French national data
+//	\d+        numeric codespaces
+//
+// An invalid pattern makes NewWithOptions return an error.
+func (o *ValidationOptions) WithCodespacePattern(pattern string) *ValidationOptions {
+	o.CodespacePattern = pattern
+	return o
+}
+
+// WithFlagUnknownModes enables or disables the TRANSPORT_MODE_UNKNOWN rule, which
+// warns about Lines and ServiceJourneys whose TransportMode is 'unknown'. The value
+// is valid NeTEx, so the rule is off by default to keep datasets that use it on
+// purpose free of noise.
+func (o *ValidationOptions) WithFlagUnknownModes(flag bool) *ValidationOptions {
+	o.FlagUnknownModes = flag
+	return o
+}
+
+// WithVersionReport enables or disables the NETEX_ID_14 dataset version report.
+// Ids declared with different versions in different files are always reported
+// per id as NETEX_ID_10; the report also takes the versions on references into
+// account and lists every id whose versions disagree, with the version found in
+// each file, in a single finding. It is added when validating ZIP datasets and
+// datasets opened with BeginDataset.
+func (o *ValidationOptions) WithVersionReport(enabled bool) *ValidationOptions {
+	o.VersionReport = enabled
+	return o
+}
+
+// WithFindingTransform sets a function applied to every finding of a result, e.g.
+// to attach Annotations such as ticket links or owner teams based on the rule or
+// file, or to rewrite messages. The transform runs when the result is assembled,
+// after findings are anonymized, sorted in deterministic mode and limited by
+// MaxFindingsPerRule, so it sees the entries exactly as they will be output.
+// NumberOfValidationEntriesPerRule keeps counting findings under the names the
+// rules reported them with. A nil transform leaves the findings unchanged.
+//
+// Example:
+//
+//	options := netexvalidator.DefaultValidationOptions().
+//		WithFindingTransform(func(entry netexvalidator.ValidationReportEntry) netexvalidator.ValidationReportEntry {
+//			entry.Annotations = map[string]string{"owner": "timetables"}
+//			return entry
+//		})
+func (o *ValidationOptions) WithFindingTransform(transform func(ValidationReportEntry) ValidationReportEntry) *ValidationOptions {
+	o.FindingTransform = transform
+	return o
+}
+
+// WithReportSkippedRules enables or disables reporting XPath rules that were skipped.
+// A rule whose XPath uses a function the XPath engine does not support, such as
+// current() or key(), or whose evaluation fails, matches nothing and would look like
+// a check that passed. With reporting enabled, each such rule is listed once in an
+// INFO finding named "XPath rule skipped" with the reason it was skipped.
+func (o *ValidationOptions) WithReportSkippedRules(report bool) *ValidationOptions {
+	o.ReportSkippedRules = report
+	return o
+}
+
 // GetLogger returns the logger instance to use for validation operations.
 //
 // If a custom logger was set via WithLogger(), it is returned directly.
@@ -367,3 +790,172 @@ func (o *ValidationOptions) GetLogger() *logging.Logger {
 
 	return logging.NewLogger(config)
 }
+
+// WithStrictCalendar enables or disables the OPERATING_DAY_OUTSIDE_PERIODS check,
+// which warns about OperatingDays whose CalendarDate lies outside every
+// OperatingPeriod of the dataset. Some profiles use OperatingDays on their own, so
+// the check is opt-in, and datasets without OperatingPeriods are not checked.
+func (o *ValidationOptions) WithStrictCalendar(strict bool) *ValidationOptions {
+	o.StrictCalendar = strict
+	return o
+}
+
+// WithStreamingMode enables or disables streaming validation of single files.
+// ValidateFile, ValidateReader and ValidateContent then read the document token by
+// token and build one object at a time, such as a StopPlace with its quays, instead
+// of the whole tree, so multi-gigabyte files validate in bounded memory.
+//
+// Only XPath rules that look at a single element and its descendants run, e.g.
+// missing Names, missing references and invalid TransportMode values. Schema
+// validation, ID and reference validation, business validators, cross-file checks
+// and rules that need other parts of the document are skipped, and each result
+// carries an INFO finding named "Streaming mode" listing the skipped rules. Results
+// are not cached. ZIP datasets are always validated in full.
+func (o *ValidationOptions) WithStreamingMode(enabled bool) *ValidationOptions {
+	o.StreamingMode = enabled
+	return o
+}
+
+// WithReportTitle sets the title shown in the header of HTML reports and written as
+// reportTitle in JSON output, e.g. to brand reports embedded in a portal. An empty
+// title keeps the default "NetEX Validation Report".
+func (o *ValidationOptions) WithReportTitle(title string) *ValidationOptions {
+	o.ReportTitle = title
+	return o
+}
+
+// WithReportMetadata attaches key-value metadata, such as a dataset name or the
+// publishing agency, to every result. It is listed in the header of HTML reports and
+// written as metadata in JSON output. The map is copied.
+func (o *ValidationOptions) WithReportMetadata(metadata map[string]string) *ValidationOptions {
+	o.ReportMetadata = copyMetadata(metadata)
+	return o
+}
+
+// WithPlugins loads rule plugins, Go shared objects built with
+// go build -buildmode=plugin, and runs their validators alongside the built-in
+// ones. A plugin exports a func() []interfaces.XPathValidator named
+// NetexValidators, a func() []interfaces.DatasetValidator named
+// NetexDatasetValidators, or both. Rule and severity overrides apply to plugin
+// rules as to built-in ones.
+//
+// Plugin loading is only compiled in with the plugins build tag and cgo, see
+// PluginsSupported. Go plugins are only supported on Linux, macOS and FreeBSD, and
+// must be built with the same Go toolchain, build tags, build flags and
+// netex-validator version as the program loading them. Creating a validator fails
+// when a plugin cannot be loaded.
+func (o *ValidationOptions) WithPlugins(paths ...string) *ValidationOptions {
+	o.Plugins = append(o.Plugins, paths...)
+	return o
+}
+
+// WithStrictRouteDirections enables or disables the ROUTE_SINGLE_DIRECTION check,
+// which warns about Lines with several Routes that all have the same DirectionType,
+// usually a bidirectional service missing its return direction. Circular and
+// one-way services legitimately run in one direction, so the check is opt-in. It
+// runs when validating ZIP datasets, as Routes and Lines may be in different files.
+func (o *ValidationOptions) WithStrictRouteDirections(strict bool) *ValidationOptions {
+	o.StrictRouteDirections = strict
+	return o
+}
+
+// WithFlagModeOutliers enables or disables the TRANSPORT_MODE_NETWORK_OUTLIER check,
+// an INFO finding for every Line whose TransportMode differs from the mode of at
+// least three quarters of the Lines of its Network, such as a lone bus Line in a
+// rail Network. Many networks legitimately mix modes, so the check is opt-in. It
+// runs when validating ZIP datasets, as Networks and Lines may be in different files.
+func (o *ValidationOptions) WithFlagModeOutliers(flag bool) *ValidationOptions {
+	o.FlagModeOutliers = flag
+	return o
+}
+
+// WithAmbiguousNameThreshold enables the SCHEDULED_STOP_POINT_AMBIGUOUS_NAME check,
+// an INFO finding for every ScheduledStopPoint Name shared by more than n stop
+// points across the dataset, listing their ids. Passengers cannot tell dozens of
+// stops with the same Name apart, while a few repeats are normal, so the threshold
+// is left to the data owner. 0 disables the check.
+func (o *ValidationOptions) WithAmbiguousNameThreshold(n int) *ValidationOptions {
+	o.AmbiguousNameThreshold = n
+	return o
+}
+
+// WithRuleDocsBaseURL links every finding to the documentation of its rule at base
+// followed by the rule code, e.g. "https://docs.example.org/rules/" gives
+// "https://docs.example.org/rules/LINE_2". The link is written as docUrl in JSON
+// output and shown next to each finding in HTML reports. Rules with a DocURL of
+// their own keep it.
+func (o *ValidationOptions) WithRuleDocsBaseURL(base string) *ValidationOptions {
+	o.RuleDocsBaseURL = base
+	return o
+}
+
+// WithTopRules sets how many of the rules with the most findings are listed in the
+// top rules summary of HTML reports, 10 by default. A negative n leaves the summary
+// out. See ValidationResult.TopRules.
+func (o *ValidationOptions) WithTopRules(n int) *ValidationOptions {
+	o.TopRules = n
+	return o
+}
+
+// WithHTMLTemplate renders the HTML reports of ValidationResult.ToHTML with tmpl
+// instead of the built-in template, e.g. to add agency branding. See
+// HTMLReporter.WithCustomHTMLTemplate for the data and functions available to the
+// template. Creating a validator fails when the template does not parse.
+func (o *ValidationOptions) WithHTMLTemplate(tmpl string) *ValidationOptions {
+	o.HTMLTemplate = tmpl
+	return o
+}
+
+// WithFlagExpiredData enables or disables the VALIDITY_CONDITIONS_EXPIRED check, an
+// INFO finding for every AvailabilityCondition and ServiceCalendar whose ToDate lies
+// before the date of its file's PublicationTimestamp, with the number of days
+// between them. Such data is often a stale export, but archives publish past
+// validity on purpose, so the check is opt-in.
+func (o *ValidationOptions) WithFlagExpiredData(flag bool) *ValidationOptions {
+	o.FlagExpiredData = flag
+	return o
+}
+
+// WithCheckParticipantRef enables or disables the PUBLICATION_3 check, a warning
+// for every file whose PublicationDelivery ParticipantRef matches neither the
+// validation codespace nor the Xmlns of a Codespace declared in the file, ignoring
+// case. A mismatch often means the wrong codespace was supplied, but publishers
+// may identify themselves differently from the codespace of their data, so the
+// check is opt-in.
+func (o *ValidationOptions) WithCheckParticipantRef(check bool) *ValidationOptions {
+	o.CheckParticipantRef = check
+	return o
+}
+
+// WithResultSpillDir keeps the memory used by the findings of ZIP and dataset
+// validation bounded: whenever more than ResultSpillThreshold findings are held in
+// memory, they are moved to an NDJSON file in dir and streamed back when the result
+// is output. Such a spilled result holds only its latest findings in
+// ValidationReportEntries; ForEachEntry, Summary, WriteFlatJSON, ToFlatJSON and
+// ToSQLite cover all of them. ToJSON and ToHTML group or render every finding at
+// once and return an error for spilled results. Spilling cannot be combined with
+// deterministic mode, which sorts all findings in memory. Call Close on the result
+// to remove its spill file.
+func (o *ValidationOptions) WithResultSpillDir(dir string) *ValidationOptions {
+	o.ResultSpillDir = dir
+	return o
+}
+
+// WithResultSpillThreshold sets the number of findings held in memory before they
+// are spilled to the directory of WithResultSpillDir, 100000 by default
+func (o *ValidationOptions) WithResultSpillThreshold(threshold int) *ValidationOptions {
+	o.ResultSpillThreshold = threshold
+	return o
+}
+
+// WithExpectedCounts enables the DATASET_COUNT_DEVIATION and
+// DATASET_COUNT_SEVERE_DEVIATION checks of ZIP and dataset validation, which
+// compare the number of distinct ids declared per element name with a manifest,
+// e.g. {"Line": {Min: 42, Max: 42}, "ScheduledStopPoint": {Min: 1100, Max: 1300}}.
+// A count outside its range is a warning, and an error when it is below half the
+// minimum or above twice the maximum, which usually means a truncated or bloated
+// export. Element names missing from the manifest are not checked.
+func (o *ValidationOptions) WithExpectedCounts(manifest map[string]types.ExpectedCount) *ValidationOptions {
+	o.ExpectedCounts = manifest
+	return o
+}
@@ -1,6 +1,9 @@
 package validator
 
 import (
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/theoremus-urban-solutions/netex-validator/types"
@@ -66,6 +69,35 @@ func TestValidationOptions_WithCodespace(t *testing.T) {
 	}
 }
 
+func TestValidationOptions_DefaultCodespace(t *testing.T) {
+	configFile := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(configFile, []byte("validator:\n  defaultCodespace: CFG\n"), 0o600); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		options *ValidationOptions
+		want    string
+	}{
+		{"explicit codespace", DefaultValidationOptions().WithCodespace("NO").WithDefaultCodespace("OPT").WithConfigFile(configFile), "NO"},
+		{"options default", DefaultValidationOptions().WithCodespace("").WithDefaultCodespace("OPT").WithConfigFile(configFile), "OPT"},
+		{"configuration default", DefaultValidationOptions().WithCodespace("").WithConfigFile(configFile), "CFG"},
+		{"none", DefaultValidationOptions().WithCodespace(""), ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := NewWithOptions(tt.options)
+			if err != nil {
+				t.Fatalf("NewWithOptions() error = %v", err)
+			}
+			if got := v.Codespace(); got != tt.want {
+				t.Errorf("Codespace() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestValidationOptions_WithConfigFile(t *testing.T) {
 	options := DefaultValidationOptions()
 
@@ -100,6 +132,38 @@ func TestValidationOptions_WithSkipSchema(t *testing.T) {
 	}
 }
 
+func TestValidationOptions_WithBundledSchemas(t *testing.T) {
+	options := DefaultValidationOptions()
+	if !options.BundledSchemas {
+		t.Error("expected BundledSchemas to be true by default")
+	}
+
+	options.BundledSchemas = false
+	result := options.WithBundledSchemas("1.15")
+
+	if result != options {
+		t.Error("WithBundledSchemas should return the same instance for chaining")
+	}
+	if !options.BundledSchemas {
+		t.Error("expected BundledSchemas to be true")
+	}
+	if options.BundledSchemaVersion != "1.15" {
+		t.Errorf("expected bundled schema version '1.15', got %q", options.BundledSchemaVersion)
+	}
+}
+
+func TestNewWithOptions_UnbundledSchemaVersion(t *testing.T) {
+	options := DefaultValidationOptions().
+		WithCodespace("TEST").
+		WithAllowSchemaNetwork(false).
+		WithSchemaCacheDir(t.TempDir()).
+		WithBundledSchemas("0.9")
+
+	if _, err := NewWithOptions(options); err == nil || !strings.Contains(err.Error(), "no bundled schema for NetEX version 0.9") {
+		t.Errorf("Expected an error for a version that is not bundled, got %v", err)
+	}
+}
+
 func TestValidationOptions_WithVerbose(t *testing.T) {
 	options := DefaultValidationOptions()
 
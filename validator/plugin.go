@@ -0,0 +1,22 @@
+package validator
+
+import (
+	"github.com/theoremus-urban-solutions/netex-validator/interfaces"
+)
+
+// Symbols a rule plugin exports. A plugin exports either or both.
+const (
+	// PluginValidatorsSymbol names a func() []interfaces.XPathValidator returning
+	// validators run on every file
+	PluginValidatorsSymbol = "NetexValidators"
+
+	// PluginDatasetValidatorsSymbol names a func() []interfaces.DatasetValidator
+	// returning validators run once the whole dataset is collected
+	PluginDatasetValidatorsSymbol = "NetexDatasetValidators"
+)
+
+// rulePlugin holds the validators a plugin returned
+type rulePlugin struct {
+	validators        []interfaces.XPathValidator
+	datasetValidators []interfaces.DatasetValidator
+}
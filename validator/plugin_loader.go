@@ -0,0 +1,47 @@
+//go:build plugins && cgo
+// +build plugins,cgo
+
+package validator
+
+import (
+	"fmt"
+	"plugin"
+
+	"github.com/theoremus-urban-solutions/netex-validator/interfaces"
+)
+
+// PluginsSupported reports whether this binary can load rule plugins
+const PluginsSupported = true
+
+// loadRulePlugin opens the Go plugin at path and calls the validator functions it
+// exports. Go caches opened plugins, so validators created with the same plugin
+// share its package state but get validators of their own.
+func loadRulePlugin(path string) (*rulePlugin, error) {
+	p, err := plugin.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open plugin %s: %w", path, err)
+	}
+
+	loaded := &rulePlugin{}
+	found := false
+	if sym, err := p.Lookup(PluginValidatorsSymbol); err == nil {
+		validators, ok := sym.(func() []interfaces.XPathValidator)
+		if !ok {
+			return nil, fmt.Errorf("plugin %s: %s is %T, want func() []interfaces.XPathValidator", path, PluginValidatorsSymbol, sym)
+		}
+		loaded.validators = validators()
+		found = true
+	}
+	if sym, err := p.Lookup(PluginDatasetValidatorsSymbol); err == nil {
+		validators, ok := sym.(func() []interfaces.DatasetValidator)
+		if !ok {
+			return nil, fmt.Errorf("plugin %s: %s is %T, want func() []interfaces.DatasetValidator", path, PluginDatasetValidatorsSymbol, sym)
+		}
+		loaded.datasetValidators = validators()
+		found = true
+	}
+	if !found {
+		return nil, fmt.Errorf("plugin %s exports neither %s nor %s", path, PluginValidatorsSymbol, PluginDatasetValidatorsSymbol)
+	}
+	return loaded, nil
+}
@@ -0,0 +1,16 @@
+//go:build !plugins || !cgo
+// +build !plugins !cgo
+
+package validator
+
+import (
+	"fmt"
+)
+
+// PluginsSupported reports whether this binary can load rule plugins
+const PluginsSupported = false
+
+// loadRulePlugin is a stub when the plugins build tag or cgo is not enabled
+func loadRulePlugin(path string) (*rulePlugin, error) {
+	return nil, fmt.Errorf("failed to open plugin %s: rule plugins require a binary built with cgo and -tags plugins", path)
+}
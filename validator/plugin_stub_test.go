@@ -0,0 +1,24 @@
+//go:build !plugins || !cgo
+// +build !plugins !cgo
+
+package validator
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNewWithOptions_PluginsNotCompiledIn(t *testing.T) {
+	if PluginsSupported {
+		t.Fatal("Expected plugins to be unsupported without the plugins build tag")
+	}
+	options := DefaultValidationOptions().
+		WithCodespace("TEST").
+		WithSkipSchema(true).
+		WithPlugins("rules.so")
+
+	_, err := NewWithOptions(options)
+	if err == nil || !strings.Contains(err.Error(), "-tags plugins") {
+		t.Errorf("Expected an error naming the plugins build tag, got %v", err)
+	}
+}
@@ -0,0 +1,102 @@
+//go:build plugins && cgo
+// +build plugins,cgo
+
+package validator
+
+import (
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/theoremus-urban-solutions/netex-validator/testutil"
+	"github.com/theoremus-urban-solutions/netex-validator/types"
+)
+
+// buildRulePlugin builds testdata/rulesplugin as a Go plugin and returns its path
+func buildRulePlugin(t *testing.T) string {
+	t.Helper()
+	if testing.Short() {
+		t.Skip("Skipping plugin build in short mode")
+	}
+	if runtime.GOOS != "linux" && runtime.GOOS != "darwin" {
+		t.Skipf("Go plugins are not supported on %s", runtime.GOOS)
+	}
+	goTool, err := exec.LookPath(filepath.Join(runtime.GOROOT(), "bin", "go"))
+	if err != nil {
+		t.Skipf("Go toolchain not available: %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "rules.so")
+	// A plugin only loads into a binary built with the same flags
+	args := []string{"build", "-buildmode=plugin", "-tags", "plugins", "-o", path}
+	if raceEnabled {
+		args = append(args, "-race")
+	}
+	cmd := exec.Command(goTool, append(args, "./testdata/rulesplugin")...)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		if strings.Contains(string(output), "-buildmode=plugin not supported") || strings.Contains(string(output), "cgo") {
+			t.Skipf("Go plugins cannot be built here: %s", output)
+		}
+		t.Fatalf("Failed to build plugin: %v\n%s", err, output)
+	}
+	return path
+}
+
+func TestValidateZip_Plugins(t *testing.T) {
+	pluginPath := buildRulePlugin(t)
+
+	lines := func(code string) string {
+		return netexDocument(`		<ServiceFrame id="TEST:ServiceFrame:1" version="1">
+			<lines>
+				<Line id="TEST:Line:` + code + `" version="1">
+					<Name>Line ` + code + `</Name>
+					<PublicCode>` + code + `</PublicCode>
+					<TransportMode>bus</TransportMode>
+					<OperatorRef ref="TEST:Operator:1"/>
+				</Line>
+			</lines>
+		</ServiceFrame>`)
+	}
+	tm := testutil.NewTestDataManager(t)
+	zipFile := tm.CreateTestZipFile(t, "dataset.zip", map[string]string{
+		"line_x.xml": lines("X"),
+		"line_y.xml": lines("Y"),
+	})
+
+	options := DefaultValidationOptions().
+		WithCodespace(testutil.TestCodespace).
+		WithSkipSchema(true).
+		WithSeverityOverride("PLUGIN_DATASET_LINE_X", types.WARNING).
+		WithPlugins(pluginPath)
+	result, err := ValidateZip(zipFile, options)
+	if err != nil {
+		t.Fatalf("ValidateZip() error = %v", err)
+	}
+	if result.Error != nil {
+		t.Fatalf("Unexpected result error: %v", result.Error)
+	}
+
+	perFile := entriesNamed(result, "Plugin Line X")
+	if len(perFile) != 1 {
+		t.Fatalf("Expected 1 finding from the plugin's file validator, got %+v", perFile)
+	}
+	if perFile[0].Location.ElementID != "TEST:Line:X" || perFile[0].Severity != types.WARNING {
+		t.Errorf("Expected WARNING on TEST:Line:X, got %v on %s", perFile[0].Severity, perFile[0].Location.ElementID)
+	}
+	dataset := entriesNamed(result, "Plugin dataset Line X")
+	if len(dataset) != 1 || dataset[0].Severity != types.WARNING {
+		t.Errorf("Expected 1 overridden WARNING from the plugin's dataset validator, got %+v", dataset)
+	}
+}
+
+func TestNewWithOptions_PluginNotFound(t *testing.T) {
+	options := DefaultValidationOptions().
+		WithCodespace(testutil.TestCodespace).
+		WithSkipSchema(true).
+		WithPlugins(filepath.Join(t.TempDir(), "missing.so"))
+	if _, err := NewWithOptions(options); err == nil || !strings.Contains(err.Error(), "missing.so") {
+		t.Errorf("Expected an error naming the missing plugin, got %v", err)
+	}
+}
@@ -0,0 +1,148 @@
+package validator
+
+import (
+	"bytes"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/antchfx/xmlquery"
+	"github.com/theoremus-urban-solutions/netex-validator/interfaces"
+	"github.com/theoremus-urban-solutions/netex-validator/types"
+	"github.com/theoremus-urban-solutions/netex-validator/validation/context"
+)
+
+// NeTEx profiles recognized by DetectProfile
+const (
+	ProfileEU     = "eu"
+	ProfileNordic = "nordic"
+	ProfileFrench = "fr"
+)
+
+// euProfileRules are the rules that only hold for the EU profile itself, which
+// the national profiles relax. The EU profile expects frames inside a
+// CompositeFrame; national profiles may not.
+var euProfileRules = map[string]bool{
+	"FRAME_OUTSIDE_COMPOSITE_FRAME": true,
+}
+
+var typeOfFrameRefPattern = regexp.MustCompile(`<TypeOfFrameRef\b[^>]*\bref="([^"]+)"`)
+
+// frenchFrameTypes are the TypeOfFrame names of the French NeTEx profile
+var frenchFrameTypes = []string{
+	"NETEX_FRANCE", "NETEX_COMMUN", "NETEX_LIGNE", "NETEX_RESEAU",
+	"NETEX_ARRET", "NETEX_HORAIRE", "NETEX_CALENDRIER",
+}
+
+// nordicMarkers are strings only Nordic profile data contains: the Rutebanken
+// codespace namespace and references into the national stop place register
+var nordicMarkers = [][]byte{
+	[]byte("rutebanken.org/ns/"),
+	[]byte(`ref="NSR:`),
+}
+
+// DetectProfile guesses the NeTEx profile a document was written for. The
+// TypeOfFrameRef values are the strongest hint: French frames use NETEX_*
+// frame types and EU frames EU_PI_* ones. Otherwise Rutebanken namespaces and
+// NSR stop place references mark Nordic data. Anything else is reported as EU.
+func DetectProfile(content []byte) string {
+	for _, match := range typeOfFrameRefPattern.FindAllSubmatch(content, -1) {
+		ref := string(match[1])
+		for _, frameType := range frenchFrameTypes {
+			if strings.Contains(ref, frameType) {
+				return ProfileFrench
+			}
+		}
+		if strings.Contains(ref, "EU_PI_") {
+			return ProfileEU
+		}
+	}
+
+	for _, marker := range nordicMarkers {
+		if bytes.Contains(content, marker) {
+			return ProfileNordic
+		}
+	}
+
+	return ProfileEU
+}
+
+// detectDatasetProfile guesses the profile of a ZIP dataset. Shared files named
+// with a leading underscore, such as _common.xml, are a Nordic convention;
+// otherwise the first file that does not look like EU data decides.
+func detectDatasetProfile(contents map[string][]byte) string {
+	names := make([]string, 0, len(contents))
+	for name := range contents {
+		if strings.HasPrefix(path.Base(name), "_") {
+			return ProfileNordic
+		}
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+	for _, name := range names {
+		if profile := DetectProfile(contents[name]); profile != ProfileEU {
+			return profile
+		}
+	}
+	return ProfileEU
+}
+
+// logProfile logs and returns the NeTEx profile of the validated data. An
+// explicit Profile option takes precedence over the detected one. The national
+// profiles extend the EU profile and have no rule sets of their own, so every
+// profile is validated with the EU rules, minus euProfileRules for national ones.
+func (v *NetexValidator) logProfile(name string, detect func() string) string {
+	profile, source := v.options.Profile, "option"
+	if profile == "" {
+		profile, source = detect(), "detected"
+	}
+	v.options.GetLogger().Info("NeTEx profile", "file", name, "profile", profile, "source", source, "rules", ProfileEU)
+	return profile
+}
+
+// documentProfile returns the NeTEx profile a document is validated for: the
+// Profile option, else the profile detected for the ZIP dataset being validated,
+// else the one detected from the document itself
+func (v *NetexValidator) documentProfile(document *xmlquery.Node) string {
+	if v.options.Profile != "" {
+		return v.options.Profile
+	}
+	if v.datasetProfile != "" {
+		return v.datasetProfile
+	}
+	if document == nil {
+		return ProfileEU
+	}
+	return DetectProfile([]byte(document.OutputXML(true)))
+}
+
+// euProfileValidator runs the euProfileRules, keeping their findings only for
+// documents validated for the EU profile
+type euProfileValidator struct {
+	inner   interfaces.XPathValidator
+	profile func(document *xmlquery.Node) string
+}
+
+func newEUProfileValidator(inner interfaces.XPathValidator, profile func(document *xmlquery.Node) string) *euProfileValidator {
+	return &euProfileValidator{inner: inner, profile: profile}
+}
+
+// Validate runs the wrapped rules. The profile is only looked up once a rule
+// matched, as detection serializes the document.
+func (v *euProfileValidator) Validate(ctx context.XPathValidationContext) ([]types.ValidationIssue, error) {
+	issues, err := v.inner.Validate(ctx)
+	if err != nil || len(issues) == 0 {
+		return issues, err
+	}
+	if v.profile(ctx.Document) != ProfileEU {
+		return nil, nil
+	}
+	return issues, nil
+}
+
+// GetRules returns the wrapped rules
+func (v *euProfileValidator) GetRules() []types.ValidationRule {
+	return v.inner.GetRules()
+}
@@ -0,0 +1,91 @@
+package validator
+
+import (
+	"testing"
+)
+
+func TestDetectProfile(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{
+			name: "French frame types",
+			content: `<PublicationDelivery xmlns="http://www.netex.org.uk/netex" version="1.09:FR-NETEX-2.1-1.0">
+	<dataObjects>
+		<GeneralFrame id="FR:GeneralFrame:NETEX_LIGNE-20240101:LOC" version="any">
+			<TypeOfFrameRef ref="FR:TypeOfFrame:NETEX_LIGNE:"/>
+		</GeneralFrame>
+	</dataObjects>
+</PublicationDelivery>`,
+			want: ProfileFrench,
+		},
+		{
+			name: "Nordic codespace and stop place references",
+			content: `<PublicationDelivery xmlns="http://www.netex.org.uk/netex" version="1.15:NO-NeTEx-networktimetable:1.5">
+	<dataObjects>
+		<CompositeFrame id="RUT:CompositeFrame:1" version="1">
+			<codespaces>
+				<Codespace id="rut">
+					<Xmlns>RUT</Xmlns>
+					<XmlnsUrl>http://www.rutebanken.org/ns/rut</XmlnsUrl>
+				</Codespace>
+			</codespaces>
+		</CompositeFrame>
+		<SiteFrame id="RUT:SiteFrame:1" version="1">
+			<StopPlaceRef ref="NSR:StopPlace:337"/>
+		</SiteFrame>
+	</dataObjects>
+</PublicationDelivery>`,
+			want: ProfileNordic,
+		},
+		{
+			name: "EU frame types win over Nordic references",
+			content: `<PublicationDelivery xmlns="http://www.netex.org.uk/netex" version="1.15">
+	<dataObjects>
+		<CompositeFrame id="NO:CompositeFrame:1" version="1">
+			<TypeOfFrameRef ref="epip:EU_PI_LINE_OFFER"/>
+			<StopPlaceRef ref="NSR:StopPlace:337"/>
+		</CompositeFrame>
+	</dataObjects>
+</PublicationDelivery>`,
+			want: ProfileEU,
+		},
+		{
+			name:    "no hints",
+			content: `<PublicationDelivery xmlns="http://www.netex.org.uk/netex" version="1.15"/>`,
+			want:    ProfileEU,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DetectProfile([]byte(tt.content)); got != tt.want {
+				t.Errorf("DetectProfile() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDetectDatasetProfile(t *testing.T) {
+	line := []byte(`<PublicationDelivery xmlns="http://www.netex.org.uk/netex" version="1.15"/>`)
+
+	nordic := map[string][]byte{"_RUT_shared_data.xml": line, "RUT_RUT-Line-1_1.xml": line}
+	if got := detectDatasetProfile(nordic); got != ProfileNordic {
+		t.Errorf("Expected %q for a dataset with shared _ files, got %q", ProfileNordic, got)
+	}
+
+	french := map[string][]byte{
+		"lines.xml":  line,
+		"offre.xml":  []byte(`<GeneralFrame><TypeOfFrameRef ref="FR:TypeOfFrame:NETEX_HORAIRE:"/></GeneralFrame>`),
+		"common.xml": line,
+	}
+	if got := detectDatasetProfile(french); got != ProfileFrench {
+		t.Errorf("Expected %q for a dataset with a French frame, got %q", ProfileFrench, got)
+	}
+
+	if got := detectDatasetProfile(map[string][]byte{"lines.xml": line}); got != ProfileEU {
+		t.Errorf("Expected %q without hints, got %q", ProfileEU, got)
+	}
+}
@@ -0,0 +1,139 @@
+package validator
+
+import (
+	"math"
+
+	"github.com/theoremus-urban-solutions/netex-validator/config"
+)
+
+// QualityScoreVersion identifies the scoring model, i.e. the formula and the default
+// severity weights. It changes whenever either does. Scores are comparable between
+// runs with the same model version, the same configured weights and the same rule
+// set; a release that adds rules can lower the score of unchanged data.
+const QualityScoreVersion = "1"
+
+// QualityScoreScale is the penalty per file at which the quality score drops to 50
+const QualityScoreScale = 100.0
+
+// QualityScore rates a validated dataset from 0 to 100, computed as
+//
+//	penalty = sum over findings of severityWeight(severity) * ruleWeight(ruleCode)
+//	score   = 100 * QualityScoreScale / (QualityScoreScale + penalty / max(files, 1))
+//
+// rounded to one decimal. Data without findings scores 100; every finding with a
+// positive weight lowers the score. Findings without a rule code, such as the notes
+// of streaming mode and skipped rules, are not scored. The weights come from the
+// scoring section of the configuration, see config.ScoringConfig.
+type QualityScore struct {
+	Score   float64 `json:"score"`
+	Version string  `json:"version"`
+	Penalty float64 `json:"penalty"`
+	Files   int     `json:"files"`
+
+	// Categories breaks the score down by rule category, each scored with the same
+	// formula from the penalty of its findings alone
+	Categories map[string]CategoryScore `json:"categories,omitempty"`
+}
+
+// CategoryScore is the share of a rule category in the quality score
+type CategoryScore struct {
+	Findings int     `json:"findings"`
+	Penalty  float64 `json:"penalty"`
+	Score    float64 `json:"score"`
+}
+
+// computeQualityScore scores findings of a dataset of the given number of files
+func computeQualityScore(entries []ValidationReportEntry, files int, weights config.ScoringConfig) *QualityScore {
+	scorer := newQualityScorer(files, weights)
+	for _, entry := range entries {
+		scorer.add(entry)
+	}
+	return scorer.score()
+}
+
+// qualityScorer adds up the penalties of findings one at a time, for results whose
+// findings are streamed rather than held in memory
+type qualityScorer struct {
+	weights config.ScoringConfig
+	result  *QualityScore
+}
+
+func newQualityScorer(files int, weights config.ScoringConfig) *qualityScorer {
+	return &qualityScorer{weights: weights, result: &QualityScore{Version: QualityScoreVersion, Files: files}}
+}
+
+// add adds the penalty of a finding
+func (s *qualityScorer) add(entry ValidationReportEntry) {
+	if entry.RuleCode == "" {
+		return
+	}
+	ruleWeight, ok := s.weights.RuleWeights[entry.RuleCode]
+	if !ok {
+		ruleWeight = 1
+	}
+	penalty := s.weights.SeverityWeights[entry.Severity.String()] * ruleWeight
+
+	score := s.result
+	if score.Categories == nil {
+		score.Categories = make(map[string]CategoryScore)
+	}
+	category := config.RuleCategory(entry.RuleCode)
+	categoryScore := score.Categories[category]
+	categoryScore.Findings++
+	categoryScore.Penalty += penalty
+	score.Categories[category] = categoryScore
+	score.Penalty += penalty
+}
+
+// score returns the score of the findings added so far
+func (s *qualityScorer) score() *QualityScore {
+	s.result.rescore()
+	return s.result
+}
+
+// rescore derives the scores from the penalties and the number of files
+func (s *QualityScore) rescore() {
+	s.Score = scoreFromPenalty(s.Penalty, s.Files)
+	for category, categoryScore := range s.Categories {
+		categoryScore.Score = scoreFromPenalty(categoryScore.Penalty, s.Files)
+		s.Categories[category] = categoryScore
+	}
+}
+
+// scoreFromPenalty applies the quality score formula
+func scoreFromPenalty(penalty float64, files int) float64 {
+	if files < 1 {
+		files = 1
+	}
+	perFile := penalty / float64(files)
+	return math.Round(10*100*QualityScoreScale/(QualityScoreScale+perFile)) / 10
+}
+
+// mergeQualityScores combines the scores of shards of one dataset. Penalties and
+// files add up, so the merged score equals the score of validating all shards at
+// once. Nil is returned unless every shard has a score of the same model version.
+func mergeQualityScores(scores []*QualityScore) *QualityScore {
+	if len(scores) == 0 || scores[0] == nil {
+		return nil
+	}
+	merged := &QualityScore{Version: scores[0].Version}
+	for _, score := range scores {
+		if score == nil || score.Version != merged.Version {
+			return nil
+		}
+		merged.Penalty += score.Penalty
+		merged.Files += score.Files
+		for category, categoryScore := range score.Categories {
+			if merged.Categories == nil {
+				merged.Categories = make(map[string]CategoryScore)
+			}
+			total := merged.Categories[category]
+			total.Findings += categoryScore.Findings
+			total.Penalty += categoryScore.Penalty
+			merged.Categories[category] = total
+		}
+	}
+
+	merged.rescore()
+	return merged
+}
@@ -0,0 +1,128 @@
+package validator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/theoremus-urban-solutions/netex-validator/config"
+	"github.com/theoremus-urban-solutions/netex-validator/types"
+)
+
+func TestComputeQualityScore_DecreasesWithFindings(t *testing.T) {
+	weights := config.DefaultConfig().Scoring
+	findings := []ValidationReportEntry{
+		{RuleCode: "LINE_4", Severity: types.INFO},
+		{RuleCode: "LINE_8", Severity: types.WARNING},
+		{RuleCode: "LINE_2", Severity: types.ERROR},
+		{RuleCode: "ROUTE_1", Severity: types.ERROR},
+		{RuleCode: "SCHEMA_ERROR", Severity: types.CRITICAL},
+	}
+
+	previous := computeQualityScore(nil, 1, weights)
+	if previous.Score != 100 || previous.Version != QualityScoreVersion {
+		t.Fatalf("Expected 100 for data without findings, got %+v", previous)
+	}
+	for i := range findings {
+		score := computeQualityScore(findings[:i+1], 1, weights)
+		if score.Score >= previous.Score {
+			t.Errorf("Expected score to decrease with finding %d, got %.1f after %.1f", i+1, score.Score, previous.Score)
+		}
+		previous = score
+	}
+
+	// 0.5 + 2 + 10 + 10 + 25 = 47.5 penalty in one file: 100 * 100 / 147.5
+	if previous.Penalty != 47.5 || previous.Score != 67.8 {
+		t.Errorf("Expected penalty 47.5 and score 67.8, got %v and %v", previous.Penalty, previous.Score)
+	}
+	if line := previous.Categories["line"]; line.Findings != 3 || line.Penalty != 12.5 || line.Score != 88.9 {
+		t.Errorf("Expected 3 line findings with penalty 12.5 and score 88.9, got %+v", line)
+	}
+	if schema := previous.Categories["schema"]; schema.Findings != 1 || schema.Penalty != 25 {
+		t.Errorf("Expected 1 schema finding with penalty 25, got %+v", schema)
+	}
+
+	// The same findings spread over more files weigh less
+	if spread := computeQualityScore(findings, 10, weights); spread.Score <= previous.Score {
+		t.Errorf("Expected a higher score over 10 files, got %.1f", spread.Score)
+	}
+}
+
+func TestComputeQualityScore_Weights(t *testing.T) {
+	entries := []ValidationReportEntry{
+		{RuleCode: "LINE_2", Severity: types.ERROR},
+		{RuleCode: "LINE_8", Severity: types.WARNING},
+		{Name: "Streaming validation", Severity: types.INFO},
+	}
+
+	score := computeQualityScore(entries, 1, config.ScoringConfig{
+		SeverityWeights: map[string]float64{"ERROR": 4, "WARNING": 1},
+		RuleWeights:     map[string]float64{"LINE_2": 2.5},
+	})
+	if score.Penalty != 11 {
+		t.Errorf("Expected penalty 4*2.5 + 1 = 11, got %v", score.Penalty)
+	}
+	if score.Categories["line"].Findings != 2 || len(score.Categories) != 1 {
+		t.Errorf("Expected findings without a rule code not to be scored, got %+v", score.Categories)
+	}
+}
+
+func TestMergeQualityScores(t *testing.T) {
+	weights := config.DefaultConfig().Scoring
+	first := []ValidationReportEntry{{RuleCode: "LINE_2", Severity: types.ERROR}}
+	second := []ValidationReportEntry{{RuleCode: "ROUTE_1", Severity: types.WARNING}, {RuleCode: "LINE_2", Severity: types.ERROR}}
+
+	merged := (&ValidationResult{QualityScore: computeQualityScore(first, 2, weights)}).
+		Merge(&ValidationResult{QualityScore: computeQualityScore(second, 3, weights)})
+	whole := computeQualityScore(append(append([]ValidationReportEntry{}, first...), second...), 5, weights)
+	if merged.QualityScore == nil || merged.QualityScore.Score != whole.Score || merged.QualityScore.Categories["line"] != whole.Categories["line"] {
+		t.Errorf("Expected merged score %+v to equal the score of the whole dataset %+v", merged.QualityScore, whole)
+	}
+
+	if partial := (&ValidationResult{QualityScore: whole}).Merge(&ValidationResult{}); partial.QualityScore != nil {
+		t.Errorf("Expected no score when a merged run has none, got %+v", partial.QualityScore)
+	}
+}
+
+func TestValidateContent_QualityScore(t *testing.T) {
+	options := DefaultValidationOptions().WithCodespace("TEST").WithSkipSchema(true)
+
+	result, err := ValidateContent([]byte(invalidNetexXML), "invalid.xml", options)
+	if err != nil {
+		t.Fatalf("ValidateContent() error = %v", err)
+	}
+	if result.QualityScore == nil || result.QualityScore.Score >= 100 || result.QualityScore.Files != 1 {
+		t.Fatalf("Expected a score below 100 for the invalid document, got %+v", result.QualityScore)
+	}
+
+	for name, output := range map[string]func() ([]byte, error){"flat": result.ToFlatJSON, "grouped": result.ToJSON} {
+		data, err := output()
+		if err != nil {
+			t.Fatalf("%s JSON error = %v", name, err)
+		}
+		if !strings.Contains(string(data), `"qualityScore"`) {
+			t.Errorf("Expected %s JSON to contain qualityScore", name)
+		}
+	}
+	html, err := result.ToHTML()
+	if err != nil {
+		t.Fatalf("ToHTML() error = %v", err)
+	}
+	if !strings.Contains(string(html), "Quality Score") || !strings.Contains(string(html), "Quality by Category") {
+		t.Error("Expected the HTML report to show the quality score and its categories")
+	}
+
+	// Weights from the configuration file replace the defaults they name
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(configPath, []byte("scoring:\n  severityWeights:\n    ERROR: 0\n    WARNING: 0\n    INFO: 0\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	weighted, err := ValidateContent([]byte(invalidNetexXML), "invalid.xml", options.WithConfigFile(configPath))
+	if err != nil {
+		t.Fatalf("ValidateContent() error = %v", err)
+	}
+	if weighted.QualityScore == nil || weighted.QualityScore.Score != 100 {
+		t.Errorf("Expected 100 with zero weights for every reported severity, got %+v", weighted.QualityScore)
+	}
+}
@@ -1,8 +1,11 @@
 package validator
 
 import (
+	"bytes"
+	"encoding/gob"
 	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
@@ -17,7 +20,13 @@ type ValidationResult struct {
 	ValidationReportID string    `json:"validationReportId"`
 	CreationDate       time.Time `json:"creationDate"`
 
-	// Validation entries
+	// Report title and integrator metadata, such as a dataset name, set through
+	// WithReportTitle and WithReportMetadata
+	ReportTitle string            `json:"reportTitle,omitempty"`
+	Metadata    map[string]string `json:"metadata,omitempty"`
+
+	// Validation entries. Of a result spilled to disk, only the latest findings; see
+	// ForEachEntry.
 	ValidationReportEntries []ValidationReportEntry `json:"validationReportEntries"`
 
 	// Summary statistics
@@ -27,8 +36,11 @@ type ValidationResult struct {
 	FilesProcessed int           `json:"filesProcessed"`
 	ProcessingTime time.Duration `json:"processingTimeMs"`
 
-	// Error information (if validation failed)
-	Error string `json:"error,omitempty"`
+	// Error information (if validation failed), serialized as its message
+	Error *ResultError `json:"error,omitempty"`
+
+	// Dataset quality score, set when the validation completed
+	QualityScore *QualityScore `json:"qualityScore,omitempty"`
 
 	// Cache information
 	CacheHit bool   `json:"cacheHit,omitempty"`
@@ -36,15 +48,107 @@ type ValidationResult struct {
 
 	// Raw content for statistics (not serialized to JSON)
 	rawContent map[string][]byte `json:"-"`
+
+	// Pseudonym -> original id when ids are anonymized (not serialized to JSON)
+	anonymizedIds map[string]string `json:"-"`
+
+	// Set in deterministic mode so serialization leaves out run-dependent values
+	deterministic bool `json:"-"`
+
+	// Set when JSON output is written on a single line instead of indented
+	compactJSON bool `json:"-"`
+
+	// Number of rules in the top rules summary of HTML reports (0 = DefaultTopRules,
+	// negative = no summary)
+	topRules int `json:"-"`
+
+	// Template of HTML reports, empty for the built-in one
+	htmlTemplate string `json:"-"`
+
+	// Findings moved to disk, with their number per severity, when the result is
+	// spilled; see ValidationOptions.WithResultSpillDir
+	spilled           *types.Spill[ValidationReportEntry] `json:"-"`
+	spilledSeverities map[types.Severity]int              `json:"-"`
+	spillThreshold    int                                 `json:"-"`
+}
+
+// ResultErrorCode categorizes why a validation could not be completed
+type ResultErrorCode string
+
+const (
+	// ErrorCodeFileNotFound means the file or ZIP to validate does not exist
+	ErrorCodeFileNotFound ResultErrorCode = "FILE_NOT_FOUND"
+	// ErrorCodeReadError means the input exists but could not be read
+	ErrorCodeReadError ResultErrorCode = "READ_ERROR"
+	// ErrorCodeParseError means the input is not well-formed XML or not a ZIP archive
+	ErrorCodeParseError ResultErrorCode = "PARSE_ERROR"
+	// ErrorCodeConfigError means an option, such as the baseline dataset, is unusable
+	ErrorCodeConfigError ResultErrorCode = "CONFIG_ERROR"
+	// ErrorCodeInvalidState means the call is not allowed in the validator's current
+	// state, e.g. ValidateZip while a dataset is open
+	ErrorCodeInvalidState ResultErrorCode = "INVALID_STATE"
+	// ErrorCodeValidationError means a validation stage failed while running
+	ErrorCodeValidationError ResultErrorCode = "VALIDATION_ERROR"
+	// ErrorCodeDownloadError means the dataset could not be downloaded from its URL,
+	// e.g. because of an HTTP error, a timeout or the download size limit
+	ErrorCodeDownloadError ResultErrorCode = "DOWNLOAD_ERROR"
+)
+
+// ResultError describes why a validation could not be completed. In JSON it is
+// written as its message, as the string error of earlier versions was.
+type ResultError struct {
+	Code    ResultErrorCode
+	Message string
+}
+
+// newResultError creates a ResultError with a formatted message
+func newResultError(code ResultErrorCode, format string, args ...interface{}) *ResultError {
+	return &ResultError{Code: code, Message: fmt.Sprintf(format, args...)}
+}
+
+// Error returns the error message
+func (e *ResultError) Error() string {
+	return e.Message
+}
+
+// String returns the error message
+func (e *ResultError) String() string {
+	return e.Message
+}
+
+// MarshalJSON encodes the error as its message
+func (e *ResultError) MarshalJSON() ([]byte, error) {
+	return json.Marshal(e.Message)
+}
+
+// UnmarshalJSON decodes an error from its message. The code is not serialized, so it
+// is left empty.
+func (e *ResultError) UnmarshalJSON(b []byte) error {
+	return json.Unmarshal(b, &e.Message)
 }
 
 // ValidationReportEntry represents a single validation issue
 type ValidationReportEntry struct {
+	// RuleCode is the code of the rule that produced the finding, e.g. LINE_2
+	RuleCode string                   `json:"ruleCode,omitempty"`
 	Name     string                   `json:"name"`
 	Message  string                   `json:"message"`
 	Severity types.Severity           `json:"severity"`
 	FileName string                   `json:"fileName"`
 	Location ValidationReportLocation `json:"location"`
+	// MatchedSnippet holds the start of the matched element's XML when explain mode is enabled
+	MatchedSnippet string `json:"matchedSnippet,omitempty"`
+	// RuleXPath is the XPath of the rule that produced the finding, set when rule
+	// XPaths are included
+	RuleXPath string `json:"ruleXPath,omitempty"`
+	// Fingerprint identifies the finding across runs, e.g. for baselines and suppressions
+	Fingerprint string `json:"fingerprint,omitempty"`
+	// Annotations holds metadata attached by integrators, e.g. through a finding
+	// transform, such as a ticket link or the team owning a rule
+	Annotations map[string]string `json:"annotations,omitempty"`
+	// DocURL links to the documentation of the rule, set when a rule docs base URL is
+	// configured or the rule has a documentation link of its own
+	DocURL string `json:"docUrl,omitempty"`
 }
 
 // ValidationReportLocation provides location information for a validation issue
@@ -58,13 +162,20 @@ type ValidationReportLocation struct {
 // Summary returns a summary of validation results
 func (r *ValidationResult) Summary() ValidationSummary {
 	summary := ValidationSummary{
-		TotalIssues:      len(r.ValidationReportEntries),
+		TotalIssues:      len(r.ValidationReportEntries) + r.SpilledEntryCount(),
 		FilesProcessed:   r.FilesProcessed,
 		ProcessingTime:   r.ProcessingTime,
 		HasErrors:        false,
 		IssuesBySeverity: make(map[types.Severity]int),
 	}
 
+	for severity, count := range r.spilledSeverities {
+		summary.IssuesBySeverity[severity] += count
+		if severity >= types.ERROR && count > 0 {
+			summary.HasErrors = true
+		}
+	}
+
 	for _, entry := range r.ValidationReportEntries {
 		summary.IssuesBySeverity[entry.Severity]++
 		if entry.Severity >= types.ERROR {
@@ -84,14 +195,60 @@ type ValidationSummary struct {
 	IssuesBySeverity map[types.Severity]int `json:"issuesBySeverity"`
 }
 
+// DefaultTopRules is the number of rules listed in the top rules summary of HTML
+// reports and verbose CLI output unless configured otherwise
+const DefaultTopRules = 10
+
+// RuleCount is the number of findings of a rule
+type RuleCount struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+// TopRules returns the n rules with the most findings according to
+// NumberOfValidationEntriesPerRule, by descending count and then by name. All
+// rules are returned when n is zero or negative.
+func (r *ValidationResult) TopRules(n int) []RuleCount {
+	counts := make([]RuleCount, 0, len(r.NumberOfValidationEntriesPerRule))
+	for name, count := range r.NumberOfValidationEntriesPerRule {
+		if count > 0 {
+			counts = append(counts, RuleCount{Name: name, Count: count})
+		}
+	}
+	sort.Slice(counts, func(i, j int) bool {
+		if counts[i].Count != counts[j].Count {
+			return counts[i].Count > counts[j].Count
+		}
+		return counts[i].Name < counts[j].Name
+	})
+
+	if n > 0 && len(counts) > n {
+		counts = counts[:n]
+	}
+	return counts
+}
+
+// topRulesLimit returns the number of rules in the top rules summary of HTML reports
+func (r *ValidationResult) topRulesLimit() int {
+	if r.topRules == 0 {
+		return DefaultTopRules
+	}
+	return r.topRules
+}
+
 // IsValid returns true if validation passed (no errors or critical issues)
 func (r *ValidationResult) IsValid() bool {
+	for severity, count := range r.spilledSeverities {
+		if severity >= types.ERROR && count > 0 {
+			return false
+		}
+	}
 	for _, entry := range r.ValidationReportEntries {
 		if entry.Severity >= types.ERROR {
 			return false
 		}
 	}
-	return r.Error == ""
+	return r.Error == nil
 }
 
 // GetIssuesByFile returns validation issues grouped by filename
@@ -128,13 +285,34 @@ func (r *ValidationResult) ToJSON() ([]byte, error) {
 
 // ToFlatJSON converts the validation result to flat JSON format (original format)
 func (r *ValidationResult) ToFlatJSON() ([]byte, error) {
-	return json.MarshalIndent(r, "", "  ")
+	if r.spilled != nil {
+		var buf bytes.Buffer
+		if err := r.WriteFlatJSON(&buf); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	}
+	return r.marshalJSON(r)
+}
+
+// SetCompactJSON selects single-line JSON (true) or indented JSON (false) for
+// ToJSON, ToOptimizedJSON and ToFlatJSON
+func (r *ValidationResult) SetCompactJSON(compact bool) {
+	r.compactJSON = compact
+}
+
+// marshalJSON encodes v compactly or indented, depending on the result's JSON style
+func (r *ValidationResult) marshalJSON(v interface{}) ([]byte, error) {
+	if r.compactJSON {
+		return json.Marshal(v)
+	}
+	return json.MarshalIndent(v, "", "  ")
 }
 
 // String returns a human-readable string representation
 func (r *ValidationResult) String() string {
-	if r.Error != "" {
-		return "Validation failed: " + r.Error
+	if r.Error != nil {
+		return "Validation failed: " + r.Error.Message
 	}
 
 	summary := r.Summary()
@@ -146,13 +324,20 @@ func (r *ValidationResult) String() string {
 		summary.TotalIssues, summary.FilesProcessed)
 }
 
-// ToHTML converts the validation result to HTML format
+// ToHTML converts the validation result to HTML format, using the template set
+// with ValidationOptions.WithHTMLTemplate if any
 func (r *ValidationResult) ToHTML() ([]byte, error) {
-	if r.Error != "" {
-		return []byte(fmt.Sprintf("<html><body><h1>Validation Error</h1><p>%s</p></body></html>", r.Error)), nil
+	if r.spilled != nil {
+		return nil, ErrSpilledResult
+	}
+	if r.Error != nil {
+		return []byte(fmt.Sprintf("<html><body><h1>Validation Error</h1><p>%s</p></body></html>", r.Error.Message)), nil
 	}
 
-	reporter := NewHTMLReporter()
+	reporter, err := NewHTMLReporter().WithCustomHTMLTemplate(r.htmlTemplate)
+	if err != nil {
+		return nil, err
+	}
 	html, err := reporter.GenerateHTML(r)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate HTML report: %w", err)
@@ -360,6 +545,90 @@ func (r *ValidationResult) GetErrorsBySeverity() map[types.Severity][]*errors.Va
 	return result
 }
 
+// Merge combines this result with results from separate runs, e.g. shards of a dataset
+// validated in parallel, into a new result. Neither this result nor others are modified.
+//
+// Entries are concatenated in order, FilesProcessed and NumberOfValidationEntriesPerRule
+// are summed, and ProcessingTime is the longest of the merged runs, which is the wall
+// time when the shards ran in parallel. CreationDate is the latest of the merged runs
+// and error messages are joined under the code of the first failed run. Codespace,
+// ValidationReportID, ReportTitle and Metadata are taken from this result. Quality
+// scores are recomputed from the summed penalties and files, and left out unless
+// every merged run has one.
+//
+// Cross-file ID validation cannot be reconstructed from merged results: each run only
+// saw its own files, so references resolved in another shard are still reported as
+// unresolved and ids duplicated across shards are not reported. Validate the complete
+// dataset in one run when those checks matter.
+func (r *ValidationResult) Merge(others ...*ValidationResult) *ValidationResult {
+	merged := &ValidationResult{
+		Codespace:                        r.Codespace,
+		ValidationReportID:               r.ValidationReportID,
+		ReportTitle:                      r.ReportTitle,
+		Metadata:                         copyMetadata(r.Metadata),
+		NumberOfValidationEntriesPerRule: make(map[string]int),
+		deterministic:                    r.deterministic,
+		compactJSON:                      r.compactJSON,
+		topRules:                         r.topRules,
+		htmlTemplate:                     r.htmlTemplate,
+	}
+
+	var errs []string
+	var scores []*QualityScore
+	for _, result := range append([]*ValidationResult{r}, others...) {
+		if result == nil {
+			continue
+		}
+		scores = append(scores, result.QualityScore)
+
+		entries, err := result.allEntries()
+		if err != nil {
+			if merged.Error == nil {
+				merged.Error = &ResultError{Code: ErrorCodeValidationError}
+			}
+			errs = append(errs, err.Error())
+		}
+		merged.ValidationReportEntries = append(merged.ValidationReportEntries, entries...)
+		for rule, count := range result.NumberOfValidationEntriesPerRule {
+			merged.NumberOfValidationEntriesPerRule[rule] += count
+		}
+		merged.FilesProcessed += result.FilesProcessed
+		if result.ProcessingTime > merged.ProcessingTime {
+			merged.ProcessingTime = result.ProcessingTime
+		}
+		if result.CreationDate.After(merged.CreationDate) {
+			merged.CreationDate = result.CreationDate
+		}
+		if result.Error != nil {
+			if merged.Error == nil {
+				merged.Error = &ResultError{Code: result.Error.Code}
+			}
+			errs = append(errs, result.Error.Message)
+		}
+		for fileName, content := range result.rawContent {
+			merged.SetRawContent(fileName, content)
+		}
+		for pseudonym, id := range result.anonymizedIds {
+			if merged.anonymizedIds == nil {
+				merged.anonymizedIds = make(map[string]string)
+			}
+			merged.anonymizedIds[pseudonym] = id
+		}
+		merged.deterministic = merged.deterministic && result.deterministic
+	}
+	if merged.Error != nil {
+		merged.Error.Message = strings.Join(errs, "; ")
+	}
+	merged.QualityScore = mergeQualityScores(scores)
+
+	// Keep deterministic output independent of the order shards finished in
+	if merged.deterministic {
+		sortReportEntries(merged.ValidationReportEntries)
+	}
+
+	return merged
+}
+
 // SetRawContent stores raw XML content for statistics extraction
 func (r *ValidationResult) SetRawContent(fileName string, content []byte) {
 	if r.rawContent == nil {
@@ -368,6 +637,112 @@ func (r *ValidationResult) SetRawContent(fileName string, content []byte) {
 	r.rawContent[fileName] = content
 }
 
+// binaryResult is the gob encoding of a ValidationResult, including the unexported
+// state that JSON leaves out
+type binaryResult struct {
+	Codespace                        string
+	ValidationReportID               string
+	CreationDate                     time.Time
+	ReportTitle                      string
+	Metadata                         map[string]string
+	ValidationReportEntries          []ValidationReportEntry
+	NumberOfValidationEntriesPerRule map[string]int
+	FilesProcessed                   int
+	ProcessingTime                   time.Duration
+	Error                            *ResultError
+	QualityScore                     *QualityScore
+	CacheHit                         bool
+	FileHash                         string
+	RawContent                       map[string][]byte
+	Deterministic                    bool
+	CompactJSON                      bool
+	TopRules                         int
+	HTMLTemplate                     string
+}
+
+// MarshalBinary encodes the result with encoding/gob, e.g. to pass it from a
+// validation worker to a reporting worker. Unlike JSON, the encoding keeps the error
+// code and the raw content used for statistics, so UnmarshalBinary restores an
+// identical result. The mapping of pseudonyms to the original ids is never encoded,
+// and neither is the raw content of an anonymized result, as either would undo the
+// anonymization wherever the encoding is stored or sent.
+func (r *ValidationResult) MarshalBinary() ([]byte, error) {
+	entries, err := r.allEntries()
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode validation result: %w", err)
+	}
+	rawContent := r.rawContent
+	if r.anonymizedIds != nil {
+		rawContent = nil
+	}
+
+	var buf bytes.Buffer
+	err = gob.NewEncoder(&buf).Encode(binaryResult{
+		Codespace:                        r.Codespace,
+		ValidationReportID:               r.ValidationReportID,
+		CreationDate:                     r.CreationDate,
+		ReportTitle:                      r.ReportTitle,
+		Metadata:                         r.Metadata,
+		ValidationReportEntries:          entries,
+		NumberOfValidationEntriesPerRule: r.NumberOfValidationEntriesPerRule,
+		FilesProcessed:                   r.FilesProcessed,
+		ProcessingTime:                   r.ProcessingTime,
+		Error:                            r.Error,
+		QualityScore:                     r.QualityScore,
+		CacheHit:                         r.CacheHit,
+		FileHash:                         r.FileHash,
+		RawContent:                       rawContent,
+		Deterministic:                    r.deterministic,
+		CompactJSON:                      r.compactJSON,
+		TopRules:                         r.topRules,
+		HTMLTemplate:                     r.htmlTemplate,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode validation result: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary decodes a result encoded by MarshalBinary, replacing the
+// contents of r
+func (r *ValidationResult) UnmarshalBinary(data []byte) error {
+	var decoded binaryResult
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&decoded); err != nil {
+		return fmt.Errorf("failed to decode validation result: %w", err)
+	}
+
+	// gob leaves out empty slices and maps; keep them empty rather than nil, as the
+	// validator returns them, so JSON output does not change to null
+	if decoded.ValidationReportEntries == nil {
+		decoded.ValidationReportEntries = []ValidationReportEntry{}
+	}
+	if decoded.NumberOfValidationEntriesPerRule == nil {
+		decoded.NumberOfValidationEntriesPerRule = make(map[string]int)
+	}
+
+	*r = ValidationResult{
+		Codespace:                        decoded.Codespace,
+		ValidationReportID:               decoded.ValidationReportID,
+		CreationDate:                     decoded.CreationDate,
+		ReportTitle:                      decoded.ReportTitle,
+		Metadata:                         decoded.Metadata,
+		ValidationReportEntries:          decoded.ValidationReportEntries,
+		NumberOfValidationEntriesPerRule: decoded.NumberOfValidationEntriesPerRule,
+		FilesProcessed:                   decoded.FilesProcessed,
+		ProcessingTime:                   decoded.ProcessingTime,
+		Error:                            decoded.Error,
+		QualityScore:                     decoded.QualityScore,
+		CacheHit:                         decoded.CacheHit,
+		FileHash:                         decoded.FileHash,
+		rawContent:                       decoded.RawContent,
+		deterministic:                    decoded.Deterministic,
+		compactJSON:                      decoded.CompactJSON,
+		topRules:                         decoded.TopRules,
+		htmlTemplate:                     decoded.HTMLTemplate,
+	}
+	return nil
+}
+
 // contains is a helper function to check if a string contains a substring (case-insensitive).
 func contains(s, substr string) bool {
 	return len(s) >= len(substr) &&
@@ -0,0 +1,17 @@
+package validator
+
+import (
+	// Embeds the JSON Schema of ValidationResult
+	_ "embed"
+)
+
+//go:embed result.schema.json
+var validationResultJSONSchema []byte
+
+// ValidationResultJSONSchema returns a JSON Schema (draft 2020-12) describing a
+// ValidationResult serialized with ToFlatJSON or encoding/json, so consumers can
+// validate reports in their pipelines or generate typed bindings. The grouped
+// format written by ToJSON and the CLI is not covered. The returned slice is a copy.
+func ValidationResultJSONSchema() []byte {
+	return append([]byte(nil), validationResultJSONSchema...)
+}
@@ -0,0 +1,232 @@
+package validator
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/theoremus-urban-solutions/netex-validator/types"
+)
+
+// checkJSONSchema validates value against the subset of JSON Schema the result
+// schema uses: type, enum, minimum, properties, required, additionalProperties,
+// items and local $refs
+func checkJSONSchema(root, schema map[string]interface{}, value interface{}, path string) []string {
+	if ref, ok := schema["$ref"].(string); ok {
+		def := root["$defs"].(map[string]interface{})[strings.TrimPrefix(ref, "#/$defs/")]
+		return checkJSONSchema(root, def.(map[string]interface{}), value, path)
+	}
+
+	if allowed, ok := schema["type"]; ok {
+		var typeNames []string
+		switch t := allowed.(type) {
+		case string:
+			typeNames = []string{t}
+		case []interface{}:
+			for _, name := range t {
+				typeNames = append(typeNames, name.(string))
+			}
+		}
+		if !slices.Contains(typeNames, jsonTypeName(value)) &&
+			!(jsonTypeName(value) == "integer" && slices.Contains(typeNames, "number")) {
+			return []string{fmt.Sprintf("%s: %s is not of type %v", path, jsonTypeName(value), typeNames)}
+		}
+	}
+	if enum, ok := schema["enum"].([]interface{}); ok && !slices.Contains(enum, value) {
+		return []string{fmt.Sprintf("%s: %v is not one of %v", path, value, enum)}
+	}
+	if minimum, ok := schema["minimum"].(float64); ok {
+		if number, ok := value.(float64); ok && number < minimum {
+			return []string{fmt.Sprintf("%s: %v is below %v", path, number, minimum)}
+		}
+	}
+
+	var problems []string
+	switch v := value.(type) {
+	case map[string]interface{}:
+		properties, _ := schema["properties"].(map[string]interface{})
+		required, _ := schema["required"].([]interface{})
+		for _, name := range required {
+			if _, ok := v[name.(string)]; !ok {
+				problems = append(problems, fmt.Sprintf("%s: missing required property %s", path, name))
+			}
+		}
+		for name, child := range v {
+			if property, ok := properties[name]; ok {
+				problems = append(problems, checkJSONSchema(root, property.(map[string]interface{}), child, path+"."+name)...)
+				continue
+			}
+			switch additional := schema["additionalProperties"].(type) {
+			case bool:
+				if !additional {
+					problems = append(problems, fmt.Sprintf("%s: unexpected property %s", path, name))
+				}
+			case map[string]interface{}:
+				problems = append(problems, checkJSONSchema(root, additional, child, path+"."+name)...)
+			}
+		}
+	case []interface{}:
+		if items, ok := schema["items"].(map[string]interface{}); ok {
+			for i, item := range v {
+				problems = append(problems, checkJSONSchema(root, items, item, fmt.Sprintf("%s[%d]", path, i))...)
+			}
+		}
+	}
+	return problems
+}
+
+// jsonTypeName returns the JSON Schema type of a value decoded by encoding/json
+func jsonTypeName(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case float64:
+		if v == float64(int64(v)) {
+			return "integer"
+		}
+		return "number"
+	case []interface{}:
+		return "array"
+	default:
+		return "object"
+	}
+}
+
+func parseResultSchema(t *testing.T) map[string]interface{} {
+	t.Helper()
+	var schema map[string]interface{}
+	if err := json.Unmarshal(ValidationResultJSONSchema(), &schema); err != nil {
+		t.Fatalf("Embedded schema is not valid JSON: %v", err)
+	}
+	return schema
+}
+
+func TestValidationResultJSONSchema_ValidatesResults(t *testing.T) {
+	schema := parseResultSchema(t)
+
+	score := &QualityScore{Score: 90.9, Version: QualityScoreVersion, Penalty: 10, Files: 1,
+		Categories: map[string]CategoryScore{"line": {Findings: 1, Penalty: 10, Score: 90.9}}}
+	full := &ValidationResult{
+		Codespace:          "TEST",
+		ValidationReportID: "report-1",
+		CreationDate:       time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC),
+		ReportTitle:        "Regional data quality",
+		Metadata:           map[string]string{"dataset": "regional"},
+		ValidationReportEntries: []ValidationReportEntry{{
+			Name:           "Line missing Name",
+			Message:        "Line is missing Name",
+			Severity:       types.ERROR,
+			FileName:       "lines.xml",
+			Location:       ValidationReportLocation{FileName: "lines.xml", LineNumber: 12, XPath: "/PublicationDelivery[1]", ElementID: "TEST:Line:1"},
+			MatchedSnippet: `<Line id="TEST:Line:1"/>`,
+			RuleCode:       "LINE_2",
+			RuleXPath:      "//lines/Line[not(Name)]",
+			Fingerprint:    "abc123",
+			Annotations:    map[string]string{"owner": "lines"},
+		}},
+		NumberOfValidationEntriesPerRule: map[string]int{"Line missing Name": 1},
+		FilesProcessed:                   1,
+		ProcessingTime:                   1500 * time.Millisecond,
+		QualityScore:                     score,
+		CacheHit:                         true,
+		FileHash:                         "deadbeef",
+	}
+
+	options := DefaultValidationOptions().WithCodespace("TEST").WithSkipSchema(true)
+	validated, err := ValidateContent([]byte(netexDocument(`		<ServiceFrame id="TEST:ServiceFrame:1" version="1">
+			<lines>
+				<Line id="TEST:Line:1" version="1"/>
+			</lines>
+		</ServiceFrame>`)), "lines.xml", options)
+	if err != nil {
+		t.Fatalf("ValidateContent() error = %v", err)
+	}
+	failed, err := ValidateContent([]byte("<PublicationDelivery>"), "broken.xml", options)
+	if err != nil {
+		t.Fatalf("ValidateContent() error = %v", err)
+	}
+	if failed.Error == nil {
+		t.Fatal("Expected the broken document to fail")
+	}
+
+	for name, result := range map[string]*ValidationResult{"full": full, "validated": validated, "failed": failed, "empty": {}} {
+		data, err := result.ToFlatJSON()
+		if err != nil {
+			t.Fatalf("%s: ToFlatJSON() error = %v", name, err)
+		}
+		var decoded interface{}
+		if err := json.Unmarshal(data, &decoded); err != nil {
+			t.Fatalf("%s: invalid JSON: %v", name, err)
+		}
+		for _, problem := range checkJSONSchema(schema, schema, decoded, "$") {
+			t.Errorf("%s: %s", name, problem)
+		}
+	}
+
+	var invalid interface{}
+	if err := json.Unmarshal([]byte(`{"codespace": "TEST", "severity": "BAD"}`), &invalid); err != nil {
+		t.Fatal(err)
+	}
+	if problems := checkJSONSchema(schema, schema, invalid, "$"); len(problems) == 0 {
+		t.Error("Expected a document with missing and unknown properties to be rejected")
+	}
+}
+
+// TestValidationResultJSONSchema_MatchesStructTags fails when a serialized field is
+// added to or removed from a result type without updating result.schema.json
+func TestValidationResultJSONSchema_MatchesStructTags(t *testing.T) {
+	schema := parseResultSchema(t)
+	defs := schema["$defs"].(map[string]interface{})
+
+	for _, tt := range []struct {
+		name   string
+		typ    reflect.Type
+		schema map[string]interface{}
+	}{
+		{"ValidationResult", reflect.TypeOf(ValidationResult{}), schema},
+		{"ValidationReportEntry", reflect.TypeOf(ValidationReportEntry{}), defs["validationReportEntry"].(map[string]interface{})},
+		{"ValidationReportLocation", reflect.TypeOf(ValidationReportLocation{}), defs["validationReportLocation"].(map[string]interface{})},
+		{"QualityScore", reflect.TypeOf(QualityScore{}), defs["qualityScore"].(map[string]interface{})},
+		{"CategoryScore", reflect.TypeOf(CategoryScore{}), defs["categoryScore"].(map[string]interface{})},
+	} {
+		var fields, required []string
+		for i := 0; i < tt.typ.NumField(); i++ {
+			tag := tt.typ.Field(i).Tag.Get("json")
+			name, options, _ := strings.Cut(tag, ",")
+			if !tt.typ.Field(i).IsExported() || name == "-" {
+				continue
+			}
+			fields = append(fields, name)
+			if options != "omitempty" {
+				required = append(required, name)
+			}
+		}
+
+		var properties, schemaRequired []string
+		for name := range tt.schema["properties"].(map[string]interface{}) {
+			properties = append(properties, name)
+		}
+		for _, name := range tt.schema["required"].([]interface{}) {
+			schemaRequired = append(schemaRequired, name.(string))
+		}
+
+		for _, list := range [][]string{fields, required, properties, schemaRequired} {
+			sort.Strings(list)
+		}
+		if !slices.Equal(fields, properties) {
+			t.Errorf("%s: JSON fields %v, schema properties %v", tt.name, fields, properties)
+		}
+		if !slices.Equal(required, schemaRequired) {
+			t.Errorf("%s: fields without omitempty %v, schema required %v", tt.name, required, schemaRequired)
+		}
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/theoremus-urban-solutions/netex-validator/testutil"
+	"github.com/theoremus-urban-solutions/netex-validator/types"
 )
 
 func TestSchemaValidation_NetworkTimeout(t *testing.T) {
@@ -261,6 +262,37 @@ func TestSchemaValidation_ErrorTypes(t *testing.T) {
 	}
 }
 
+func TestSchemaValidation_LegacyNamespace(t *testing.T) {
+	content := `<?xml version="1.0" encoding="UTF-8"?>
+<PublicationDelivery xmlns="http://netex-cen.eu/netex" version="1.15">
+    <PublicationTimestamp>2023-01-01T00:00:00</PublicationTimestamp>
+    <ParticipantRef>TEST</ParticipantRef>
+    <dataObjects/>
+</PublicationDelivery>`
+
+	options := DefaultValidationOptions().WithCodespace(testutil.TestCodespace)
+	options.SkipValidators = true
+	validator, err := NewWithOptions(options)
+	if err != nil {
+		t.Fatalf("Failed to create validator: %v", err)
+	}
+	result, err := validator.ValidateContent([]byte(content), "legacy.xml")
+	if err != nil {
+		t.Fatalf("Validation failed: %v", err)
+	}
+
+	entries := entriesNamed(result, "Unexpected NetEX namespace")
+	if len(entries) != 1 {
+		t.Fatalf("Expected 1 namespace warning, got %d: %+v", len(entries), result.ValidationReportEntries)
+	}
+	if entries[0].RuleCode != "SCHEMA_NAMESPACE" || entries[0].Severity != types.WARNING {
+		t.Errorf("Expected a SCHEMA_NAMESPACE warning, got %s (%v)", entries[0].RuleCode, entries[0].Severity)
+	}
+	if !strings.Contains(entries[0].Message, "http://netex-cen.eu/netex") {
+		t.Errorf("Expected the message to name the namespace found, got %q", entries[0].Message)
+	}
+}
+
 func TestSchemaValidation_Versions(t *testing.T) {
 	tm := testutil.NewTestDataManager(t)
 
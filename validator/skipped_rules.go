@@ -0,0 +1,62 @@
+package validator
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/theoremus-urban-solutions/netex-validator/rules"
+	"github.com/theoremus-urban-solutions/netex-validator/types"
+)
+
+// skippedRuleName is the name of the INFO findings listing skipped XPath rules
+const skippedRuleName = "XPath rule skipped"
+
+// skippedRule is an XPath rule that could not be evaluated and why
+type skippedRule struct {
+	code   string
+	name   string
+	reason string
+}
+
+// skippedRules collects the XPath rules skipped because their expression could not
+// be evaluated, so they are reported instead of looking like checks that passed
+type skippedRules struct {
+	mu    sync.Mutex
+	rules map[string]skippedRule // by rule code, keeping the first reason
+}
+
+func newSkippedRules() *skippedRules {
+	return &skippedRules{rules: make(map[string]skippedRule)}
+}
+
+// add records that rule was skipped
+func (s *skippedRules) add(rule rules.Rule, reason string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, seen := s.rules[rule.Code]; !seen {
+		s.rules[rule.Code] = skippedRule{code: rule.Code, name: rule.Name, reason: reason}
+	}
+}
+
+// entries returns one INFO finding per skipped rule, ordered by rule code
+func (s *skippedRules) entries() []ValidationReportEntry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	skipped := make([]skippedRule, 0, len(s.rules))
+	for _, rule := range s.rules {
+		skipped = append(skipped, rule)
+	}
+	sort.Slice(skipped, func(i, j int) bool { return skipped[i].code < skipped[j].code })
+
+	entries := make([]ValidationReportEntry, 0, len(skipped))
+	for _, rule := range skipped {
+		entries = append(entries, ValidationReportEntry{
+			Name:     skippedRuleName,
+			Message:  fmt.Sprintf("Rule %s (%s) was not evaluated: %s", rule.code, rule.name, rule.reason),
+			Severity: types.INFO,
+		})
+	}
+	return entries
+}
@@ -0,0 +1,53 @@
+package validator
+
+import (
+	"fmt"
+
+	"github.com/theoremus-urban-solutions/netex-validator/config"
+	"github.com/theoremus-urban-solutions/netex-validator/interfaces"
+	"github.com/theoremus-urban-solutions/netex-validator/rules"
+	"github.com/theoremus-urban-solutions/netex-validator/utils"
+	"github.com/theoremus-urban-solutions/netex-validator/validation/engine"
+)
+
+// snippetFileName is the logical filename reported for findings from ValidateSnippet
+const snippetFileName = "snippet.xml"
+
+// ValidateSnippet runs a single XPath rule against an XML snippet and returns its findings.
+//
+// Schema validation and every other rule are skipped, which keeps rule regression
+// tests and documentation examples short: the snippet only needs to contain the
+// elements the rule looks at. The rule is looked up by code among all built-in
+// XPath rules, regardless of profile; rules implemented by business validators
+// are not available here.
+//
+// Example:
+//
+//	findings, err := netexvalidator.ValidateSnippet(`<lines><Line id="NO:Line:1" version="1"/></lines>`, "LINE_2")
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	fmt.Printf("LINE_2 reported %d findings\n", len(findings))
+func ValidateSnippet(xml string, ruleCode string) ([]ValidationReportEntry, error) {
+	rule, ok := rules.NewRuleRegistry(config.DefaultConfig()).GetRuleByCode(ruleCode)
+	if !ok {
+		return nil, fmt.Errorf("unknown XPath rule: %s", ruleCode)
+	}
+
+	runner, err := engine.NewEnhancedNetexValidatorsRunnerBuilder().
+		WithXPathValidators([]interfaces.XPathValidator{
+			utils.NewXPathRuleValidator([]utils.XPathValidationRule{NewSimpleXPathRule(rule)}),
+		}).
+		WithValidationReportEntryFactory(engine.NewDefaultValidationReportEntryFactory()).
+		WithDeterministic(true).
+		Build()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build validator runner: %w", err)
+	}
+
+	report, err := runner.ValidateContent(snippetFileName, "", []byte(xml), true, false)
+	if err != nil {
+		return nil, err
+	}
+	return convertReportEntries(report.ValidationReportEntries), nil
+}
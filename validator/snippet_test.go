@@ -0,0 +1,83 @@
+package validator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/theoremus-urban-solutions/netex-validator/types"
+)
+
+func TestValidateSnippet(t *testing.T) {
+	tests := []struct {
+		name     string
+		xml      string
+		ruleCode string
+		expected []string // ElementIDs of the expected findings
+		severity types.Severity
+	}{
+		{
+			name: "LINE_2 reports only the unnamed line",
+			xml: `<lines>
+				<Line id="TEST:Line:Named" version="1"><Name>Named</Name></Line>
+				<Line id="TEST:Line:Unnamed" version="1"/>
+			</lines>`,
+			ruleCode: "LINE_2",
+			expected: []string{"TEST:Line:Unnamed"},
+			severity: types.ERROR,
+		},
+		{
+			name: "LINE_3 ignores other missing fields",
+			xml: `<lines>
+				<Line id="TEST:Line:1" version="1"><PublicCode>1</PublicCode></Line>
+			</lines>`,
+			ruleCode: "LINE_3",
+			expected: nil,
+		},
+		{
+			name: "FARE_5 on a namespaced document",
+			xml: `<PublicationDelivery xmlns="http://www.netex.org.uk/netex" version="1.15">
+				<fareZones>
+					<FareZone id="TEST:FareZone:Empty" version="1"/>
+					<FareZone id="TEST:FareZone:Covered" version="1">
+						<members><ScheduledStopPointRef ref="TEST:ScheduledStopPoint:1"/></members>
+					</FareZone>
+				</fareZones>
+			</PublicationDelivery>`,
+			ruleCode: "FARE_5",
+			expected: []string{"TEST:FareZone:Empty"},
+			severity: types.WARNING,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			findings, err := ValidateSnippet(tt.xml, tt.ruleCode)
+			if err != nil {
+				t.Fatalf("ValidateSnippet() error = %v", err)
+			}
+			if len(findings) != len(tt.expected) {
+				t.Fatalf("Expected %d findings, got %d: %+v", len(tt.expected), len(findings), findings)
+			}
+			for i, finding := range findings {
+				if finding.Location.ElementID != tt.expected[i] {
+					t.Errorf("Expected finding on %s, got %s", tt.expected[i], finding.Location.ElementID)
+				}
+				if finding.Severity != tt.severity {
+					t.Errorf("Expected severity %v, got %v", tt.severity, finding.Severity)
+				}
+				if finding.FileName != snippetFileName {
+					t.Errorf("Expected file name %s, got %s", snippetFileName, finding.FileName)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateSnippet_Errors(t *testing.T) {
+	if _, err := ValidateSnippet(`<lines/>`, "NO_SUCH_RULE"); err == nil || !strings.Contains(err.Error(), "NO_SUCH_RULE") {
+		t.Errorf("Expected unknown rule error, got %v", err)
+	}
+	if _, err := ValidateSnippet(`<lines><Line>`, "LINE_2"); err == nil {
+		t.Error("Expected error for malformed XML")
+	}
+}
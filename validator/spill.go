@@ -0,0 +1,248 @@
+package validator
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/theoremus-urban-solutions/netex-validator/types"
+)
+
+// ErrSpilledResult is returned by the output formats that need every finding in
+// memory at once, grouped JSON and HTML, for results spilled to disk. See
+// ValidationOptions.WithResultSpillDir.
+var ErrSpilledResult = errors.New("result findings were spilled to disk; use flat JSON, SQLite or ForEachEntry")
+
+// createSpilledResult converts a report that spilled findings to disk without
+// loading them: each finding is read back, scored, anonymized, limited and
+// transformed in turn, then spilled again by the result once it holds more than
+// the spill threshold. The report's spill file is removed afterwards.
+func (v *NetexValidator) createSpilledResult(report *types.ValidationReport, startTime time.Time, filesProcessed int) *ValidationResult {
+	defer func() { _ = report.Close() }()
+
+	entriesPerRule := make(map[string]int)
+	for k, v := range report.NumberOfValidationEntriesPerRule {
+		entriesPerRule[k] = int(v)
+	}
+	result := &ValidationResult{
+		Codespace:                        report.Codespace,
+		ValidationReportID:               report.ValidationReportID,
+		CreationDate:                     report.CreationDate,
+		ValidationReportEntries:          []ValidationReportEntry{},
+		NumberOfValidationEntriesPerRule: entriesPerRule,
+		FilesProcessed:                   filesProcessed,
+		spillThreshold:                   v.options.ResultSpillThreshold,
+	}
+	if result.spillThreshold <= 0 {
+		result.spillThreshold = DefaultResultSpillThreshold
+	}
+	v.applyResultOptions(result)
+
+	spill, err := types.NewSpill[ValidationReportEntry](v.options.ResultSpillDir)
+	if err != nil {
+		result.Error = newResultError(ErrorCodeValidationError, "%v", err)
+		return result
+	}
+	result.spilled = spill
+	result.spilledSeverities = make(map[types.Severity]int)
+
+	scorer := newQualityScorer(filesProcessed, v.config.Scoring)
+	var anonymizer *idAnonymizer
+	if v.options.AnonymizeIds {
+		anonymizer = newIdAnonymizer(v.options.AnonymizationKey)
+	}
+	var limiter *findingLimiter
+	if v.options.MaxFindingsPerRule > 0 {
+		limiter = newFindingLimiter(v.options.MaxFindingsPerRule)
+	}
+
+	// The same steps as for a report held in memory, one finding at a time
+	output := func(entry ValidationReportEntry) error {
+		if v.options.FindingTransform != nil {
+			entry = v.options.FindingTransform(entry)
+		}
+		return result.addSpilledEntry(entry)
+	}
+	process := func(entry ValidationReportEntry) error {
+		if anonymizer != nil {
+			anonymizer.anonymizeEntry(&entry)
+		}
+		if limiter != nil && !limiter.keep(entry) {
+			return nil
+		}
+		return output(entry)
+	}
+
+	err = report.ForEachEntry(func(reportEntry types.ValidationReportEntry) error {
+		entry := convertReportEntry(reportEntry)
+		scorer.add(entry)
+		return process(entry)
+	})
+	if err == nil && v.skippedRules != nil {
+		skipped := v.skippedRules.entries()
+		for _, entry := range skipped {
+			if err = process(entry); err != nil {
+				break
+			}
+		}
+		if len(skipped) > 0 {
+			entriesPerRule[skippedRuleName] += len(skipped)
+		}
+	}
+	if err == nil && limiter != nil {
+		for _, entry := range limiter.summaries() {
+			if err = output(entry); err != nil {
+				break
+			}
+		}
+	}
+	if err != nil {
+		result.Error = newResultError(ErrorCodeValidationError, "failed to spill findings: %v", err)
+	}
+
+	result.QualityScore = scorer.score()
+	if anonymizer != nil {
+		result.anonymizedIds = anonymizer.originals
+	}
+	result.ProcessingTime = time.Since(startTime)
+	return result
+}
+
+// addSpilledEntry adds an entry to a spilled result, moving the entries held in
+// memory to the spill file once there are more than the spill threshold
+func (r *ValidationResult) addSpilledEntry(entry ValidationReportEntry) error {
+	r.ValidationReportEntries = append(r.ValidationReportEntries, entry)
+	if len(r.ValidationReportEntries) <= r.spillThreshold {
+		return nil
+	}
+	if err := r.spilled.Append(r.ValidationReportEntries...); err != nil {
+		return err
+	}
+	for _, spilled := range r.ValidationReportEntries {
+		r.spilledSeverities[spilled.Severity]++
+	}
+	r.ValidationReportEntries = make([]ValidationReportEntry, 0, r.spillThreshold)
+	return nil
+}
+
+// SpilledEntryCount returns the number of findings of the result kept on disk
+// rather than in ValidationReportEntries, see ValidationOptions.WithResultSpillDir
+func (r *ValidationResult) SpilledEntryCount() int {
+	if r.spilled == nil {
+		return 0
+	}
+	return r.spilled.Len()
+}
+
+// ForEachEntry calls fn for every finding of the result, reading spilled findings
+// back from disk before those in ValidationReportEntries, and stops at the first
+// error
+func (r *ValidationResult) ForEachEntry(fn func(ValidationReportEntry) error) error {
+	if r.spilled != nil {
+		if err := r.spilled.ForEach(fn); err != nil {
+			return err
+		}
+	}
+	for _, entry := range r.ValidationReportEntries {
+		if err := fn(entry); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// allEntries returns every finding of the result, loading spilled findings into
+// memory
+func (r *ValidationResult) allEntries() ([]ValidationReportEntry, error) {
+	if r.spilled == nil {
+		return r.ValidationReportEntries, nil
+	}
+	entries := make([]ValidationReportEntry, 0, r.SpilledEntryCount()+len(r.ValidationReportEntries))
+	err := r.ForEachEntry(func(entry ValidationReportEntry) error {
+		entries = append(entries, entry)
+		return nil
+	})
+	return entries, err
+}
+
+// Close removes the spill file of a spilled result; its spilled findings are lost.
+// Results that were not spilled have nothing to close.
+func (r *ValidationResult) Close() error {
+	if r.spilled == nil {
+		return nil
+	}
+	err := r.spilled.Close()
+	r.spilled = nil
+	r.spilledSeverities = nil
+	return err
+}
+
+// WriteFlatJSON writes the result as ToFlatJSON does, streaming the findings of
+// spilled results from disk instead of holding them in memory
+func (r *ValidationResult) WriteFlatJSON(w io.Writer) error {
+	// Everything but the findings, which are written in place of the empty array
+	head := *r
+	head.ValidationReportEntries = []ValidationReportEntry{}
+	data, err := r.marshalJSON(&head)
+	if err != nil {
+		return err
+	}
+	marker := []byte(`"validationReportEntries":[]`)
+	indent, closing := "", "]"
+	if !r.compactJSON {
+		marker = []byte(`"validationReportEntries": []`)
+		indent, closing = "\n    ", "\n  ]"
+	}
+	i := bytes.Index(data, marker)
+	if i < 0 {
+		return fmt.Errorf("failed to write validation result entries")
+	}
+
+	// Up to and including the opening bracket of the findings
+	bw := &errWriter{w: w}
+	bw.write(data[:i+len(marker)-1])
+	first := true
+	err = r.ForEachEntry(func(entry ValidationReportEntry) error {
+		var encoded []byte
+		var err error
+		if r.compactJSON {
+			encoded, err = json.Marshal(entry)
+		} else {
+			encoded, err = json.MarshalIndent(entry, "    ", "  ")
+		}
+		if err != nil {
+			return err
+		}
+		if !first {
+			bw.write([]byte(","))
+		}
+		first = false
+		bw.write([]byte(indent))
+		bw.write(encoded)
+		return bw.err
+	})
+	if err != nil {
+		return err
+	}
+	if first {
+		closing = "]"
+	}
+	bw.write([]byte(closing))
+	bw.write(data[i+len(marker):])
+	return bw.err
+}
+
+// errWriter keeps the first error of a sequence of writes
+type errWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (e *errWriter) write(p []byte) {
+	if e.err == nil {
+		_, e.err = e.w.Write(p)
+	}
+}
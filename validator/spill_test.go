@@ -0,0 +1,153 @@
+package validator
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/theoremus-urban-solutions/netex-validator/testutil"
+)
+
+// marshalEntries returns the entries as sorted JSON strings, to compare findings
+// regardless of the order ZIP entries were validated in
+func marshalEntries(t *testing.T, entries []ValidationReportEntry) []string {
+	t.Helper()
+	encoded := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		data, err := json.Marshal(entry)
+		if err != nil {
+			t.Fatal(err)
+		}
+		encoded = append(encoded, string(data))
+	}
+	sort.Strings(encoded)
+	return encoded
+}
+
+func TestValidateZip_ResultSpill(t *testing.T) {
+	files := make(map[string]string)
+	for f := 0; f < 3; f++ {
+		var lines strings.Builder
+		for i := 0; i < 20; i++ {
+			fmt.Fprintf(&lines, "\t\t\t\t<Line id=\"TEST:Line:%d_%d\" version=\"1\"/>\n", f, i)
+		}
+		files[fmt.Sprintf("file%d.xml", f)] = netexDocument(`		<ServiceFrame id="TEST:ServiceFrame:` + fmt.Sprint(f) + `" version="1">
+			<lines>
+` + lines.String() + `			</lines>
+		</ServiceFrame>`)
+	}
+	tm := testutil.NewTestDataManager(t)
+	zipFile := tm.CreateTestZipFile(t, "dataset.zip", files)
+
+	options := func() *ValidationOptions {
+		return DefaultValidationOptions().WithCodespace(testutil.TestCodespace).WithSkipSchema(true)
+	}
+	inMemory, err := ValidateZip(zipFile, options())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	spillDir := t.TempDir()
+	spilled, err := ValidateZip(zipFile, options().WithResultSpillDir(spillDir).WithResultSpillThreshold(5))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if spilled.Error != nil {
+		t.Fatalf("Unexpected error: %v", spilled.Error)
+	}
+	if spilled.SpilledEntryCount() == 0 || len(spilled.ValidationReportEntries) > 5 {
+		t.Fatalf("Expected findings beyond the threshold to be spilled, got %d spilled and %d in memory",
+			spilled.SpilledEntryCount(), len(spilled.ValidationReportEntries))
+	}
+
+	// Every finding survives the spill, in the summary and in the streamed output
+	want := marshalEntries(t, inMemory.ValidationReportEntries)
+	if len(want) <= 5 {
+		t.Fatalf("Expected the dataset to produce more findings than the threshold, got %d", len(want))
+	}
+	all, err := spilled.allEntries()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := marshalEntries(t, all); strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("Expected the %d findings of the in-memory run, got %d", len(want), len(got))
+	}
+	if got, want := spilled.Summary().IssuesBySeverity, inMemory.Summary().IssuesBySeverity; fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("Expected summary %v, got %v", want, got)
+	}
+	if spilled.IsValid() != inMemory.IsValid() {
+		t.Errorf("Expected IsValid %v, got %v", inMemory.IsValid(), spilled.IsValid())
+	}
+	if spilled.QualityScore.Score != inMemory.QualityScore.Score {
+		t.Errorf("Expected quality score %v, got %v", inMemory.QualityScore.Score, spilled.QualityScore.Score)
+	}
+
+	var buf bytes.Buffer
+	if err := spilled.WriteFlatJSON(&buf); err != nil {
+		t.Fatal(err)
+	}
+	var decoded ValidationResult
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("Expected valid JSON, got %v:\n%s", err, buf.String())
+	}
+	if got := marshalEntries(t, decoded.ValidationReportEntries); strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("Expected the flat JSON to hold all %d findings, got %d", len(want), len(got))
+	}
+
+	// Output that needs every finding in memory is not available
+	if _, err := spilled.ToHTML(); !errors.Is(err, ErrSpilledResult) {
+		t.Errorf("Expected ErrSpilledResult from ToHTML, got %v", err)
+	}
+	if _, err := spilled.ToJSON(); !errors.Is(err, ErrSpilledResult) {
+		t.Errorf("Expected ErrSpilledResult from ToJSON, got %v", err)
+	}
+
+	if err := spilled.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if left, _ := os.ReadDir(spillDir); len(left) != 0 {
+		t.Errorf("Expected the spill files to be removed, found %d", len(left))
+	}
+}
+
+func TestValidationResult_WriteFlatJSONMatchesToFlatJSON(t *testing.T) {
+	result, err := ValidateContent([]byte(netexDocument(`		<ServiceFrame id="TEST:ServiceFrame:1" version="1">
+			<lines>
+				<Line id="TEST:Line:1" version="1"/>
+			</lines>
+		</ServiceFrame>`)), "test.xml", DefaultValidationOptions().WithCodespace(testutil.TestCodespace).WithSkipSchema(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, compact := range []bool{false, true} {
+		result.SetCompactJSON(compact)
+		want, err := result.ToFlatJSON()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var buf bytes.Buffer
+		if err := result.WriteFlatJSON(&buf); err != nil {
+			t.Fatal(err)
+		}
+		if buf.String() != string(want) {
+			t.Errorf("compact=%v: expected WriteFlatJSON to match ToFlatJSON:\n%s\ngot:\n%s", compact, want, buf.String())
+		}
+
+		result.ValidationReportEntries = []ValidationReportEntry{}
+	}
+}
+
+func TestValidationOptions_ResultSpillRequiresDirectory(t *testing.T) {
+	if _, err := NewWithOptions(DefaultValidationOptions().WithResultSpillDir("/nonexistent/spill")); err == nil {
+		t.Error("Expected an error for a missing spill directory")
+	}
+	if _, err := NewWithOptions(DefaultValidationOptions().WithResultSpillDir(t.TempDir()).WithDeterministic(true)); err == nil {
+		t.Error("Expected an error for spilling in deterministic mode")
+	}
+}
@@ -0,0 +1,139 @@
+package validator
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	// Pure-Go SQLite driver, registered as "sqlite"; keeps the build free of cgo
+	_ "modernc.org/sqlite"
+)
+
+// sqliteSchema creates the report tables. Each table carries the report id so
+// several reports can be written to the same database and queried together.
+const sqliteSchema = `
+CREATE TABLE IF NOT EXISTS reports (
+	report_id       TEXT PRIMARY KEY,
+	codespace       TEXT NOT NULL,
+	creation_date   TEXT NOT NULL,
+	files_processed INTEGER NOT NULL,
+	error           TEXT
+);
+CREATE TABLE IF NOT EXISTS files (
+	report_id TEXT NOT NULL REFERENCES reports(report_id),
+	name      TEXT NOT NULL,
+	findings  INTEGER NOT NULL,
+	PRIMARY KEY (report_id, name)
+);
+CREATE TABLE IF NOT EXISTS rules (
+	report_id TEXT NOT NULL REFERENCES reports(report_id),
+	name      TEXT NOT NULL,
+	findings  INTEGER NOT NULL,
+	PRIMARY KEY (report_id, name)
+);
+CREATE TABLE IF NOT EXISTS findings (
+	id          INTEGER PRIMARY KEY AUTOINCREMENT,
+	report_id   TEXT NOT NULL REFERENCES reports(report_id),
+	rule        TEXT NOT NULL,
+	severity    TEXT NOT NULL,
+	message     TEXT NOT NULL,
+	file_name   TEXT NOT NULL,
+	line_number INTEGER NOT NULL,
+	xpath       TEXT NOT NULL,
+	element_id  TEXT NOT NULL,
+	fingerprint TEXT NOT NULL,
+	snippet     TEXT NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_findings_report ON findings(report_id);
+CREATE INDEX IF NOT EXISTS idx_findings_rule ON findings(rule);
+CREATE INDEX IF NOT EXISTS idx_findings_severity ON findings(severity);
+`
+
+// ToSQLite writes the validation result to the SQLite database at path, creating
+// the database and its tables if needed. Rows of an earlier report with the same
+// ValidationReportID are replaced; other reports in the database are kept.
+func (r *ValidationResult) ToSQLite(path string) error {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return fmt.Errorf("failed to open SQLite database: %w", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	if _, err := db.Exec(sqliteSchema); err != nil {
+		return fmt.Errorf("failed to create SQLite tables: %w", err)
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to start SQLite transaction: %w", err)
+	}
+	if err := r.writeSQLite(tx); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit SQLite transaction: %w", err)
+	}
+	return nil
+}
+
+// writeSQLite replaces the rows of this report inside tx
+func (r *ValidationResult) writeSQLite(tx *sql.Tx) error {
+	for _, table := range []string{"findings", "files", "rules", "reports"} {
+		if _, err := tx.Exec("DELETE FROM "+table+" WHERE report_id = ?", r.ValidationReportID); err != nil {
+			return fmt.Errorf("failed to clear previous %s rows: %w", table, err)
+		}
+	}
+
+	var resultError sql.NullString
+	if r.Error != nil {
+		resultError = sql.NullString{String: r.Error.Message, Valid: true}
+	}
+	if _, err := tx.Exec(
+		"INSERT INTO reports (report_id, codespace, creation_date, files_processed, error) VALUES (?, ?, ?, ?, ?)",
+		r.ValidationReportID, r.Codespace, r.CreationDate.UTC().Format(time.RFC3339), r.FilesProcessed, resultError,
+	); err != nil {
+		return fmt.Errorf("failed to insert report: %w", err)
+	}
+
+	insertFinding, err := tx.Prepare(`INSERT INTO findings
+		(report_id, rule, severity, message, file_name, line_number, xpath, element_id, fingerprint, snippet)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`)
+	if err != nil {
+		return fmt.Errorf("failed to prepare finding insert: %w", err)
+	}
+	defer func() { _ = insertFinding.Close() }()
+
+	// Files without findings are known from the raw content kept for statistics
+	perFile := make(map[string]int, len(r.rawContent))
+	for name := range r.rawContent {
+		perFile[name] = 0
+	}
+	perRule := make(map[string]int)
+	err = r.ForEachEntry(func(entry ValidationReportEntry) error {
+		if _, err := insertFinding.Exec(
+			r.ValidationReportID, entry.Name, entry.Severity.String(), entry.Message, entry.FileName,
+			entry.Location.LineNumber, entry.Location.XPath, entry.Location.ElementID, entry.Fingerprint, entry.MatchedSnippet,
+		); err != nil {
+			return fmt.Errorf("failed to insert finding: %w", err)
+		}
+		perFile[entry.FileName]++
+		perRule[entry.Name]++
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for name, count := range perFile {
+		if _, err := tx.Exec("INSERT INTO files (report_id, name, findings) VALUES (?, ?, ?)", r.ValidationReportID, name, count); err != nil {
+			return fmt.Errorf("failed to insert file: %w", err)
+		}
+	}
+	for name, count := range perRule {
+		if _, err := tx.Exec("INSERT INTO rules (report_id, name, findings) VALUES (?, ?, ?)", r.ValidationReportID, name, count); err != nil {
+			return fmt.Errorf("failed to insert rule: %w", err)
+		}
+	}
+	return nil
+}
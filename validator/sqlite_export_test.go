@@ -0,0 +1,93 @@
+package validator
+
+import (
+	"database/sql"
+	"path/filepath"
+	"testing"
+
+	"github.com/theoremus-urban-solutions/netex-validator/testutil"
+	"github.com/theoremus-urban-solutions/netex-validator/types"
+)
+
+func TestValidationResult_ToSQLite(t *testing.T) {
+	content := `<?xml version="1.0" encoding="UTF-8"?>
+<PublicationDelivery xmlns="http://www.netex.org.uk/netex" version="1.15">
+	<PublicationTimestamp>2023-01-01T00:00:00</PublicationTimestamp>
+	<ParticipantRef>TEST</ParticipantRef>
+	<dataObjects>
+		<ServiceFrame id="TEST:ServiceFrame:1" version="1">
+			<lines>
+				<Line id="TEST:Line:1" version="1"/>
+				<Line id="TEST:Line:2" version="1"/>
+			</lines>
+		</ServiceFrame>
+	</dataObjects>
+</PublicationDelivery>`
+
+	options := DefaultValidationOptions().
+		WithCodespace(testutil.TestCodespace).
+		WithSkipSchema(true)
+	result, err := ValidateContent([]byte(content), "lines.xml", options)
+	if err != nil {
+		t.Fatalf("Validation failed: %v", err)
+	}
+	if len(result.ValidationReportEntries) == 0 {
+		t.Fatal("Expected findings for Lines without Name, TransportMode and references")
+	}
+
+	path := filepath.Join(t.TempDir(), "report.db")
+	if err := result.ToSQLite(path); err != nil {
+		t.Fatalf("ToSQLite() error = %v", err)
+	}
+	// Writing the same report again replaces its rows instead of duplicating them
+	if err := result.ToSQLite(path); err != nil {
+		t.Fatalf("ToSQLite() second write error = %v", err)
+	}
+
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	count := func(query string, args ...interface{}) int {
+		t.Helper()
+		var n int
+		if err := db.QueryRow(query, args...).Scan(&n); err != nil {
+			t.Fatalf("%s: %v", query, err)
+		}
+		return n
+	}
+
+	for _, table := range []string{"reports", "files", "rules", "findings"} {
+		if count("SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = ?", table) != 1 {
+			t.Errorf("Expected table %s to exist", table)
+		}
+	}
+	for _, index := range []string{"idx_findings_rule", "idx_findings_severity"} {
+		if count("SELECT COUNT(*) FROM sqlite_master WHERE type = 'index' AND name = ?", index) != 1 {
+			t.Errorf("Expected index %s to exist", index)
+		}
+	}
+
+	if got := count("SELECT COUNT(*) FROM reports"); got != 1 {
+		t.Errorf("Expected 1 report, got %d", got)
+	}
+	if got, want := count("SELECT COUNT(*) FROM findings"), len(result.ValidationReportEntries); got != want {
+		t.Errorf("Expected %d findings, got %d", want, got)
+	}
+	if got, want := count("SELECT COUNT(*) FROM rules"), len(result.NumberOfValidationEntriesPerRule); got != want {
+		t.Errorf("Expected %d rules, got %d", want, got)
+	}
+	for rule, want := range result.NumberOfValidationEntriesPerRule {
+		if got := count("SELECT findings FROM rules WHERE name = ?", rule); got != want {
+			t.Errorf("Expected %d findings for rule %q, got %d", want, rule, got)
+		}
+	}
+	if got, want := count("SELECT findings FROM files WHERE name = 'lines.xml'"), len(result.ValidationReportEntries); got != want {
+		t.Errorf("Expected %d findings for lines.xml, got %d", want, got)
+	}
+	if got, want := count("SELECT COUNT(*) FROM findings WHERE severity = 'ERROR'"), len(result.GetIssuesBySeverity()[types.ERROR]); got != want {
+		t.Errorf("Expected %d ERROR findings, got %d", want, got)
+	}
+}
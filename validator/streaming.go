@@ -0,0 +1,229 @@
+package validator
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/antchfx/xmlquery"
+	"github.com/theoremus-urban-solutions/netex-validator/types"
+	"github.com/theoremus-urban-solutions/netex-validator/validation/context"
+)
+
+// streamingNoteName is the name of the INFO finding listing what streaming mode skipped
+const streamingNoteName = "Streaming mode"
+
+// wholeDocumentAxes are XPath fragments that look beyond the matched element and its
+// descendants, or at its position among siblings
+var wholeDocumentAxes = []string{"..", "ancestor", "preceding", "following", "parent::", "position(", "last(", "id("}
+
+// isElementLocal reports whether a rule's XPath can be evaluated on one object at a
+// time: each of its branches selects elements other than frames anywhere with a
+// leading //, and its steps and predicates only look at the selected element, its
+// descendants and the names of its ancestors
+func isElementLocal(xpath string) bool {
+	for _, branch := range xpathBranches(xpath) {
+		rest, ok := strings.CutPrefix(branch, "//")
+		if !ok || strings.Contains(rest, "//") {
+			return false
+		}
+		for _, axis := range wholeDocumentAxes {
+			if strings.Contains(rest, axis) {
+				return false
+			}
+		}
+		// Frames are only streamed as empty ancestors of their objects
+		if strings.HasSuffix(selectedElement(branch), "Frame") {
+			return false
+		}
+	}
+	return true
+}
+
+// xpathBranches splits a union expression into its branches
+func xpathBranches(xpath string) []string {
+	var branches []string
+	depth, start := 0, 0
+	for i, r := range xpath {
+		switch r {
+		case '[', '(':
+			depth++
+		case ']', ')':
+			depth--
+		case '|':
+			if depth == 0 {
+				branches = append(branches, strings.TrimSpace(xpath[start:i]))
+				start = i + 1
+			}
+		}
+	}
+	return append(branches, strings.TrimSpace(xpath[start:]))
+}
+
+// selectedElement returns the name test of the last step of a location path
+func selectedElement(path string) string {
+	var b strings.Builder
+	depth := 0
+	for _, r := range path {
+		switch {
+		case r == '[':
+			depth++
+		case r == ']':
+			depth--
+		case depth == 0:
+			b.WriteRune(r)
+		}
+	}
+	steps := strings.Split(b.String(), "/")
+	return steps[len(steps)-1]
+}
+
+// streamingRuleValidator runs the element-local XPath rules on one streamed object.
+// Objects are small and numerous, so a rule only runs when an element it selects
+// occurs in the object.
+type streamingRuleValidator struct {
+	rules   []*SimpleXPathRule
+	targets [][]string // elements selected by each rule, nil when any element may match
+}
+
+func newStreamingRuleValidator(xrules []*SimpleXPathRule) *streamingRuleValidator {
+	v := &streamingRuleValidator{rules: xrules, targets: make([][]string, len(xrules))}
+	for i, xrule := range xrules {
+		var targets []string
+		for _, branch := range xpathBranches(xrule.rule.XPath) {
+			target := selectedElement(branch)
+			if target == "*" || strings.ContainsAny(target, ":()@ ") {
+				targets = nil
+				break
+			}
+			targets = append(targets, target)
+		}
+		v.targets[i] = targets
+	}
+	return v
+}
+
+// Validate runs the rules that may match an element of the object
+func (v *streamingRuleValidator) Validate(ctx context.XPathValidationContext) ([]types.ValidationIssue, error) {
+	present := make(map[string]bool)
+	for _, node := range xmlquery.Find(ctx.Document, "//*") {
+		present[node.Data] = true
+	}
+
+	var issues []types.ValidationIssue
+	for i, xrule := range v.rules {
+		if !v.mayMatch(i, present) {
+			continue
+		}
+		found, err := xrule.Validate(ctx)
+		if err != nil {
+			return nil, err
+		}
+		issues = append(issues, found...)
+	}
+	return issues, nil
+}
+
+// mayMatch reports whether rule i selects an element present in the object
+func (v *streamingRuleValidator) mayMatch(i int, present map[string]bool) bool {
+	if v.targets[i] == nil {
+		return true
+	}
+	for _, target := range v.targets[i] {
+		if present[target] {
+			return true
+		}
+	}
+	return false
+}
+
+// GetRules returns the rules evaluated in streaming mode
+func (v *streamingRuleValidator) GetRules() []types.ValidationRule {
+	rules := make([]types.ValidationRule, 0, len(v.rules))
+	for _, xrule := range v.rules {
+		rules = append(rules, xrule.GetRule())
+	}
+	return rules
+}
+
+// splitStreamingRules separates the XPath rules streaming mode evaluates from the
+// codes of those it skips
+func splitStreamingRules(xrules []*SimpleXPathRule) (local []*SimpleXPathRule, skipped []string) {
+	for _, xrule := range xrules {
+		if xrule.compiled != nil && isElementLocal(xrule.rule.XPath) {
+			local = append(local, xrule)
+			continue
+		}
+		skipped = append(skipped, xrule.rule.Code)
+	}
+	sort.Strings(skipped)
+	return local, skipped
+}
+
+// validateStream validates a document read from reader without holding it in memory.
+// Only the element-local XPath rules run, so the result carries an INFO finding
+// naming everything that was skipped. Results are not cached, as caching hashes the
+// whole content.
+func (v *NetexValidator) validateStream(reader io.Reader, filename string, startTime time.Time) (*ValidationResult, error) {
+	// Streamed files collect nothing for cross-file checks, but still count as
+	// files of an open dataset
+	v.stateMu.RLock()
+	defer v.stateMu.RUnlock()
+	if v.datasetOpen {
+		v.datasetFiles.Add(1)
+	}
+
+	report, err := v.runner.ValidateStream(filename, v.codespace, reader, v.streamingValidators)
+	if err != nil {
+		return &ValidationResult{
+			Error:        newResultError(runErrorCode(err), "validation failed: %v", err),
+			CreationDate: time.Now(),
+		}, nil
+	}
+	report.AddValidationReportEntry(v.streamingNote(filename))
+
+	result := v.createValidationResultFromReport(report, filename, startTime, 1)
+	return result, nil
+}
+
+// validateFileStream validates the file at path in streaming mode
+func (v *NetexValidator) validateFileStream(path, filename string, startTime time.Time) (*ValidationResult, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return &ValidationResult{
+			Error:        newResultError(ErrorCodeReadError, "failed to read file: %v", err),
+			CreationDate: time.Now(),
+		}, nil
+	}
+	defer func() { _ = file.Close() }()
+
+	result, err := v.validateStream(bufio.NewReader(file), filename, startTime)
+	if err != nil {
+		return nil, err
+	}
+	if !result.deterministic {
+		result.ProcessingTime = time.Since(startTime)
+	}
+	return result, nil
+}
+
+// streamingNote returns the INFO finding listing what streaming mode did not check
+func (v *NetexValidator) streamingNote(filename string) types.ValidationReportEntry {
+	message := fmt.Sprintf("Validated in streaming mode with %d element-local rules; schema validation, ID and reference validation, business validators and cross-file checks were skipped",
+		v.streamingRuleCount)
+	if len(v.streamingSkipped) > 0 {
+		message += fmt.Sprintf(", as were %d rules that need more than one element: %s",
+			len(v.streamingSkipped), strings.Join(v.streamingSkipped, ", "))
+	}
+	return types.ValidationReportEntry{
+		Name:     streamingNoteName,
+		Message:  message,
+		Severity: types.INFO,
+		FileName: filename,
+		Location: types.DataLocation{FileName: filename},
+	}
+}
@@ -0,0 +1,200 @@
+package validator
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/theoremus-urban-solutions/netex-validator/testutil"
+	"github.com/theoremus-urban-solutions/netex-validator/types"
+)
+
+// writeStopRegister writes a SiteFrame with count StopPlaces, each with two quays.
+// Every 250th StopPlace has an empty Name and every 125th has a quay without one.
+func writeStopRegister(t *testing.T, path string, count int) {
+	t.Helper()
+	file, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("Failed to create %s: %v", path, err)
+	}
+	defer func() { _ = file.Close() }()
+
+	w := bufio.NewWriter(file)
+	fmt.Fprint(w, `<?xml version="1.0" encoding="UTF-8"?>
+<PublicationDelivery xmlns="http://www.netex.org.uk/netex" version="1.15">
+	<PublicationTimestamp>2024-01-01T00:00:00</PublicationTimestamp>
+	<ParticipantRef>TEST</ParticipantRef>
+	<dataObjects>
+		<SiteFrame id="TEST:SiteFrame:1" version="1">
+			<stopPlaces>
+`)
+	for i := 1; i <= count; i++ {
+		name := fmt.Sprintf("Stop %d", i)
+		if i%250 == 0 {
+			name = " "
+		}
+		quayName := "<Name>Platform B</Name>"
+		if i%125 == 0 {
+			quayName = ""
+		}
+		fmt.Fprintf(w, `				<StopPlace id="TEST:StopPlace:%d" version="1">
+					<Name>%s</Name>
+					<quays>
+						<Quay id="TEST:Quay:%d-A" version="1"><Name>Platform A</Name></Quay>
+						<Quay id="TEST:Quay:%d-B" version="1">%s</Quay>
+					</quays>
+				</StopPlace>
+`, i, name, i, i, quayName)
+	}
+	fmt.Fprint(w, `			</stopPlaces>
+		</SiteFrame>
+	</dataObjects>
+</PublicationDelivery>`)
+	if err := w.Flush(); err != nil {
+		t.Fatalf("Failed to write %s: %v", path, err)
+	}
+}
+
+func TestValidateFile_StreamingMode(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "stops.xml")
+	writeStopRegister(t, path, 5000)
+
+	options := DefaultValidationOptions().
+		WithCodespace(testutil.TestCodespace).
+		WithStreamingMode(true)
+	result, err := ValidateFile(path, options)
+	if err != nil {
+		t.Fatalf("ValidateFile() error = %v", err)
+	}
+	if result.Error != nil {
+		t.Fatalf("Unexpected result error: %v", result.Error)
+	}
+
+	stops := entriesNamed(result, "StopPlace missing Name")
+	if len(stops) != 20 {
+		t.Fatalf("Expected 20 StopPlaces without a Name, got %d", len(stops))
+	}
+	first := stops[0]
+	if first.Location.ElementID != "TEST:StopPlace:250" || first.FileName != "stops.xml" || first.Severity != types.ERROR {
+		t.Errorf("Expected ERROR for TEST:StopPlace:250 in stops.xml, got %+v", first)
+	}
+	if want := "/PublicationDelivery[1]/dataObjects[1]/SiteFrame[1]/stopPlaces[1]/StopPlace[250]"; first.Location.XPath != want {
+		t.Errorf("Expected XPath %s, got %s", want, first.Location.XPath)
+	}
+
+	quays := entriesNamed(result, "Quay missing Name")
+	if len(quays) != 40 {
+		t.Fatalf("Expected 40 quays without a Name, got %d", len(quays))
+	}
+	if want := "/PublicationDelivery[1]/dataObjects[1]/SiteFrame[1]/stopPlaces[1]/StopPlace[125]/quays[1]/Quay[2]"; quays[0].Location.XPath != want {
+		t.Errorf("Expected XPath %s, got %s", want, quays[0].Location.XPath)
+	}
+
+	notes := entriesNamed(result, streamingNoteName)
+	if len(notes) != 1 || notes[0].Severity != types.INFO {
+		t.Fatalf("Expected one INFO streaming note, got %+v", notes)
+	}
+	for _, want := range []string{"schema validation", "ID and reference validation", "ROUTE_DUPLICATE_ORDER"} {
+		if !strings.Contains(notes[0].Message, want) {
+			t.Errorf("Expected note to mention %q, got %q", want, notes[0].Message)
+		}
+	}
+}
+
+func TestValidateContent_StreamingModeMatchesFullParse(t *testing.T) {
+	content := netexDocument(`		<ServiceFrame id="TEST:ServiceFrame:1" version="1">
+			<lines>
+				<Line id="TEST:Line:1" version="1">
+					<Name>Line 1</Name>
+					<TransportMode>hovercraft</TransportMode>
+				</Line>
+				<Line id="TEST:Line:2" version="1">
+					<Name>Line 2</Name>
+					<TransportMode>bus</TransportMode>
+					<OperatorRef ref="TEST:Operator:1"/>
+				</Line>
+			</lines>
+			<scheduledStopPoints>
+				<ScheduledStopPoint id="TEST:ScheduledStopPoint:1" version="1"/>
+			</scheduledStopPoints>
+		</ServiceFrame>`)
+
+	findings := func(streaming bool) []string {
+		options := DefaultValidationOptions().
+			WithCodespace(testutil.TestCodespace).
+			WithSkipSchema(true).
+			WithStreamingMode(streaming)
+		result, err := ValidateContent([]byte(content), "lines.xml", options)
+		if err != nil {
+			t.Fatalf("ValidateContent() error = %v", err)
+		}
+		var found []string
+		for _, entry := range result.ValidationReportEntries {
+			if entry.Name != streamingNoteName {
+				found = append(found, entry.Name+" @ "+entry.Location.XPath)
+			}
+		}
+		sort.Strings(found)
+		return found
+	}
+
+	full := make(map[string]bool)
+	for _, finding := range findings(false) {
+		full[finding] = true
+	}
+	streamed := findings(true)
+	for _, finding := range streamed {
+		if !full[finding] {
+			t.Errorf("Streaming finding %q is not reported by a full parse", finding)
+		}
+	}
+	for _, want := range []string{
+		"Invalid transport mode on Line @ /PublicationDelivery[1]/dataObjects[1]/ServiceFrame[1]/lines[1]/Line[1]/TransportMode[1]",
+		"Line missing OperatorRef @ /PublicationDelivery[1]/dataObjects[1]/ServiceFrame[1]/lines[1]/Line[1]",
+	} {
+		if !slices.Contains(streamed, want) {
+			t.Errorf("Expected streaming finding %q, got %v", want, streamed)
+		}
+	}
+}
+
+func TestValidateContent_StreamingModeMalformed(t *testing.T) {
+	options := DefaultValidationOptions().
+		WithCodespace(testutil.TestCodespace).
+		WithStreamingMode(true)
+	result, err := ValidateContent([]byte(`<PublicationDelivery><dataObjects></PublicationDelivery>`), "broken.xml", options)
+	if err != nil {
+		t.Fatalf("ValidateContent() error = %v", err)
+	}
+	if result.Error == nil || result.Error.Code != ErrorCodeParseError {
+		t.Errorf("Expected a parse error, got %+v", result.Error)
+	}
+}
+
+func TestIsElementLocal(t *testing.T) {
+	tests := []struct {
+		xpath string
+		want  bool
+	}{
+		{"//stopPlaces/StopPlace[not(Name) or normalize-space(Name) = '']", true},
+		{"//lines/*[self::Line or self::FlexibleLine]/TransportMode[not(text() = 'bus')]", true},
+		{"//StopPointInJourneyPattern[not(@order)]", true},
+		{"//lines/Line[TransportMode = 'unknown'] | //vehicleJourneys/ServiceJourney[TransportMode = 'unknown']", true},
+		{"/PublicationDelivery[not(PublicationTimestamp)]", false},
+		{"//CompositeFrame[not(ServiceFrame)]", false},
+		{"//Quay[not(ancestor::StopPlace)]", false},
+		{"//lines/Line[TransportMode = 'unknown'] | //CompositeFrame[not(frames)]", false},
+		{"//ServiceJourney[@id=//DatedServiceJourney/ServiceJourneyRef/@ref]", false},
+		{"//PointInSequence[@order = preceding-sibling::PointInSequence/@order]", false},
+	}
+	for _, tt := range tests {
+		if got := isElementLocal(tt.xpath); got != tt.want {
+			t.Errorf("isElementLocal(%q) = %v, want %v", tt.xpath, got, tt.want)
+		}
+	}
+}
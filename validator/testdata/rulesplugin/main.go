@@ -0,0 +1,72 @@
+// Package main is a rule plugin used by the plugin loading tests. It flags every
+// Line whose PublicCode is "X", per file and once per dataset.
+package main
+
+import (
+	"github.com/antchfx/xmlquery"
+	"github.com/theoremus-urban-solutions/netex-validator/interfaces"
+	"github.com/theoremus-urban-solutions/netex-validator/types"
+	"github.com/theoremus-urban-solutions/netex-validator/validation/context"
+)
+
+var lineRule = types.ValidationRule{
+	Code:     "PLUGIN_LINE_X",
+	Name:     "Plugin Line X",
+	Message:  "Line PublicCode X is reserved",
+	Severity: types.WARNING,
+}
+
+var datasetRule = types.ValidationRule{
+	Code:     "PLUGIN_DATASET_LINE_X",
+	Name:     "Plugin dataset Line X",
+	Message:  "Dataset declares Lines with PublicCode X",
+	Severity: types.INFO,
+}
+
+type lineValidator struct{}
+
+func (lineValidator) Validate(ctx context.XPathValidationContext) ([]types.ValidationIssue, error) {
+	var issues []types.ValidationIssue
+	for _, node := range xmlquery.Find(ctx.Document, "//lines/Line[PublicCode = 'X']") {
+		issues = append(issues, types.ValidationIssue{
+			Rule:     lineRule,
+			Location: types.DataLocation{FileName: ctx.GetFileName(), ElementID: node.SelectAttr("id")},
+			Message:  lineRule.Message,
+		})
+	}
+	return issues, nil
+}
+
+func (lineValidator) GetRules() []types.ValidationRule { return []types.ValidationRule{lineRule} }
+
+type datasetValidator struct{ count int }
+
+func (v *datasetValidator) Collect(ctx context.XPathValidationContext) error {
+	v.count += len(xmlquery.Find(ctx.Document, "//lines/Line[PublicCode = 'X']"))
+	return nil
+}
+
+func (v *datasetValidator) Validate(interfaces.IdRepository) ([]types.ValidationIssue, error) {
+	if v.count == 0 {
+		return nil, nil
+	}
+	return []types.ValidationIssue{{Rule: datasetRule, Message: datasetRule.Message}}, nil
+}
+
+func (v *datasetValidator) GetRules() []types.ValidationRule {
+	return []types.ValidationRule{datasetRule}
+}
+
+func (v *datasetValidator) Reset() { v.count = 0 }
+
+// NetexValidators returns the validators run on every file
+func NetexValidators() []interfaces.XPathValidator {
+	return []interfaces.XPathValidator{lineValidator{}}
+}
+
+// NetexDatasetValidators returns the validators run once per dataset
+func NetexDatasetValidators() []interfaces.DatasetValidator {
+	return []interfaces.DatasetValidator{&datasetValidator{}}
+}
+
+func main() {}
@@ -0,0 +1,163 @@
+package validator
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/theoremus-urban-solutions/netex-validator/utils"
+	xsdpkg "github.com/theoremus-urban-solutions/netex-validator/validation/schema"
+)
+
+// zipMagic starts every ZIP archive that has at least one entry
+var zipMagic = []byte("PK\x03\x04")
+
+// ValidateURL downloads a NetEX file or ZIP dataset and validates it.
+//
+// The download uses the HTTP client of schema downloads, including its proxy
+// settings, and is retried like them, SchemaDownloadRetries times with the same
+// backoff. It is bounded by the URLTimeoutSeconds and MaxURLBytes options. A
+// download that fails is reported in the result's Error with ErrorCodeDownloadError.
+//
+// Example:
+//
+//	options := netexvalidator.DefaultValidationOptions().WithCodespace("NO")
+//	result, err := netexvalidator.ValidateURL("https://example.com/netex/latest.zip", options)
+func ValidateURL(rawURL string, options *ValidationOptions) (*ValidationResult, error) {
+	validator, err := NewWithOptions(options)
+	if err != nil {
+		return nil, err
+	}
+	return validator.ValidateURL(rawURL)
+}
+
+// ValidateURL downloads a NetEX file or ZIP dataset and validates it using this
+// validator instance. The content is treated as a ZIP dataset when the response
+// says so, the URL path ends in .zip or the content starts like a ZIP archive.
+func (v *NetexValidator) ValidateURL(rawURL string) (*ValidationResult, error) {
+	startTime := time.Now()
+
+	parsed, err := url.Parse(rawURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return &ValidationResult{
+			Error:        newResultError(ErrorCodeConfigError, "invalid dataset URL %q: only http and https URLs are supported", rawURL),
+			CreationDate: time.Now(),
+		}, nil
+	}
+
+	content, contentType, err := v.download(parsed.String())
+	if err != nil {
+		return &ValidationResult{
+			Error:        newResultError(ErrorCodeDownloadError, "failed to download %s: %v", rawURL, err),
+			CreationDate: time.Now(),
+		}, nil
+	}
+
+	name := path.Base(parsed.Path)
+	if name == "." || name == "/" {
+		name = parsed.Host
+	}
+	isZip := isZipContentType(contentType) || strings.EqualFold(path.Ext(name), ".zip") || bytes.HasPrefix(content, zipMagic)
+	if !isZip {
+		if !strings.EqualFold(path.Ext(name), ".xml") {
+			name += ".xml"
+		}
+		result, err := v.ValidateContent(content, name)
+		if err != nil {
+			return nil, err
+		}
+		if !result.deterministic {
+			result.ProcessingTime = time.Since(startTime)
+		}
+		result.FilesProcessed = 1
+		return result, nil
+	}
+
+	// The ZIP reader needs a file; keep the URL's name so the report names the dataset
+	if !strings.EqualFold(path.Ext(name), ".zip") {
+		name += ".zip"
+	}
+	dir, err := os.MkdirTemp("", "netex-url-*")
+	if err != nil {
+		return &ValidationResult{
+			Error:        newResultError(ErrorCodeReadError, "failed to create temporary directory: %v", err),
+			CreationDate: time.Now(),
+		}, nil
+	}
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	zipPath := filepath.Join(dir, filepath.Base(name))
+	if err := os.WriteFile(zipPath, content, 0o600); err != nil {
+		return &ValidationResult{
+			Error:        newResultError(ErrorCodeReadError, "failed to store downloaded dataset: %v", err),
+			CreationDate: time.Now(),
+		}, nil
+	}
+	return v.ValidateZip(zipPath)
+}
+
+// download fetches rawURL within the configured time and size limits and returns
+// the body with its media type
+func (v *NetexValidator) download(rawURL string) ([]byte, string, error) {
+	timeout := time.Duration(v.options.URLTimeoutSeconds) * time.Second
+	if timeout <= 0 {
+		timeout = 120 * time.Second
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, "", err
+	}
+	req.Header.Set("User-Agent", "NetEX-Validator-Go/1.0")
+	req.Header.Set("Accept", "application/zip, application/xml, text/xml, */*")
+
+	opts := utils.DefaultHTTPClientOptions()
+	opts.Timeout = timeout
+	opts.MaxRetries = v.options.SchemaDownloadRetries
+	opts.RetryBackoff = xsdpkg.DefaultXSDValidationOptions().DownloadRetryDelay
+	resp, err := utils.NewOptimizedHTTPClient(opts).Do(req)
+	if err != nil {
+		return nil, "", err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	limit := v.options.MaxURLBytes
+	if limit > 0 && resp.ContentLength > limit {
+		return nil, "", fmt.Errorf("dataset is %d bytes, more than the limit of %d bytes", resp.ContentLength, limit)
+	}
+
+	body := io.Reader(resp.Body)
+	if limit > 0 {
+		body = io.LimitReader(resp.Body, limit+1)
+	}
+	content, err := io.ReadAll(body)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to read response body: %w", err)
+	}
+	if limit > 0 && int64(len(content)) > limit {
+		return nil, "", fmt.Errorf("dataset is larger than the limit of %d bytes", limit)
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	return content, mediaType, nil
+}
+
+// isZipContentType reports whether a media type announces a ZIP archive
+func isZipContentType(mediaType string) bool {
+	switch mediaType {
+	case "application/zip", "application/x-zip-compressed", "application/x-zip":
+		return true
+	}
+	return false
+}
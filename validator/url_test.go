@@ -0,0 +1,153 @@
+package validator
+
+import (
+	"archive/zip"
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync/atomic"
+	"testing"
+
+	"github.com/theoremus-urban-solutions/netex-validator/testutil"
+)
+
+func TestValidateURL(t *testing.T) {
+	xmlContent, err := os.ReadFile("../testdata/valid_minimal.xml")
+	if err != nil {
+		t.Fatalf("failed to read test data: %v", err)
+	}
+
+	var zipContent bytes.Buffer
+	zw := zip.NewWriter(&zipContent)
+	w, err := zw.Create("line.xml")
+	if err != nil {
+		t.Fatalf("failed to create zip entry: %v", err)
+	}
+	if _, err := w.Write(xmlContent); err != nil {
+		t.Fatalf("failed to write zip entry: %v", err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatalf("failed to close zip: %v", err)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/feeds/line.xml", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/xml")
+		_, _ = w.Write(xmlContent)
+	})
+	// No extension and a generic content type: the ZIP is recognized by its content
+	mux.HandleFunc("/feeds/latest", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/octet-stream")
+		_, _ = w.Write(zipContent.Bytes())
+	})
+	// Unavailable on every other request
+	var flakyRequests atomic.Int32
+	mux.HandleFunc("/feeds/flaky.xml", func(w http.ResponseWriter, r *http.Request) {
+		if flakyRequests.Add(1)%2 == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/xml")
+		_, _ = w.Write(xmlContent)
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	options := func() *ValidationOptions {
+		return DefaultValidationOptions().
+			WithCodespace(testutil.TestCodespace).
+			WithSkipSchema(true)
+	}
+
+	t.Run("XML file", func(t *testing.T) {
+		result, err := ValidateURL(server.URL+"/feeds/line.xml", options())
+		if err != nil {
+			t.Fatalf("ValidateURL() error = %v", err)
+		}
+		if result.Error != nil {
+			t.Fatalf("Unexpected result error: %v", result.Error)
+		}
+		if result.FilesProcessed != 1 || len(result.ValidationReportEntries) == 0 {
+			t.Errorf("Expected findings for one file, got %d files and %d findings", result.FilesProcessed, len(result.ValidationReportEntries))
+		}
+		for _, entry := range result.ValidationReportEntries {
+			if entry.FileName != "line.xml" {
+				t.Errorf("Expected findings in line.xml, got %q", entry.FileName)
+				break
+			}
+		}
+	})
+
+	t.Run("ZIP dataset", func(t *testing.T) {
+		result, err := ValidateURL(server.URL+"/feeds/latest", options())
+		if err != nil {
+			t.Fatalf("ValidateURL() error = %v", err)
+		}
+		if result.Error != nil {
+			t.Fatalf("Unexpected result error: %v", result.Error)
+		}
+		if len(result.ValidationReportEntries) == 0 {
+			t.Fatal("Expected findings from the dataset")
+		}
+		if result.ValidationReportEntries[0].FileName != "line.xml" {
+			t.Errorf("Expected findings in the ZIP entry line.xml, got %q", result.ValidationReportEntries[0].FileName)
+		}
+	})
+
+	t.Run("size limit", func(t *testing.T) {
+		result, err := ValidateURL(server.URL+"/feeds/line.xml", options().WithMaxURLBytes(16))
+		if err != nil {
+			t.Fatalf("ValidateURL() error = %v", err)
+		}
+		if result.Error == nil || result.Error.Code != ErrorCodeDownloadError {
+			t.Errorf("Expected a %s error, got %v", ErrorCodeDownloadError, result.Error)
+		}
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		result, err := ValidateURL(server.URL+"/feeds/missing.xml", options())
+		if err != nil {
+			t.Fatalf("ValidateURL() error = %v", err)
+		}
+		if result.Error == nil || result.Error.Code != ErrorCodeDownloadError {
+			t.Errorf("Expected a %s error, got %v", ErrorCodeDownloadError, result.Error)
+		}
+	})
+
+	t.Run("retries", func(t *testing.T) {
+		flakyRequests.Store(0)
+		result, err := ValidateURL(server.URL+"/feeds/flaky.xml", options())
+		if err != nil {
+			t.Fatalf("ValidateURL() error = %v", err)
+		}
+		if result.Error == nil || result.Error.Code != ErrorCodeDownloadError {
+			t.Errorf("Expected a %s error without retries, got %v", ErrorCodeDownloadError, result.Error)
+		}
+		if got := flakyRequests.Load(); got != 1 {
+			t.Errorf("Expected 1 request without retries, got %d", got)
+		}
+
+		flakyRequests.Store(0)
+		result, err = ValidateURL(server.URL+"/feeds/flaky.xml", options().WithSchemaDownloadRetries(1))
+		if err != nil {
+			t.Fatalf("ValidateURL() error = %v", err)
+		}
+		if result.Error != nil {
+			t.Errorf("Expected the retried download to succeed, got %v", result.Error)
+		}
+		if got := flakyRequests.Load(); got != 2 {
+			t.Errorf("Expected 2 requests with one retry, got %d", got)
+		}
+	})
+
+	t.Run("unsupported scheme", func(t *testing.T) {
+		result, err := ValidateURL("ftp://example.com/line.xml", options())
+		if err != nil {
+			t.Fatalf("ValidateURL() error = %v", err)
+		}
+		if result.Error == nil || result.Error.Code != ErrorCodeConfigError {
+			t.Errorf("Expected a %s error, got %v", ErrorCodeConfigError, result.Error)
+		}
+	})
+}
@@ -1,8 +1,12 @@
 package validator
 
 import (
+	"os"
+	"path/filepath"
+	"sort"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/theoremus-urban-solutions/netex-validator/config"
 	"github.com/theoremus-urban-solutions/netex-validator/rules"
@@ -486,3 +490,1075 @@ func BenchmarkXPathRules_AllRules(b *testing.B) {
 		_, _ = validator.ValidateContent(content, "benchmark.xml")
 	}
 }
+
+func TestXPathRules_InterchangeWithoutTransferTime(t *testing.T) {
+	xmlContent := `<?xml version="1.0" encoding="UTF-8"?>
+<PublicationDelivery xmlns="http://www.netex.org.uk/netex" version="1.15">
+	<PublicationTimestamp>2023-01-01T00:00:00</PublicationTimestamp>
+	<ParticipantRef>TEST</ParticipantRef>
+	<dataObjects>
+		<TimetableFrame id="TEST:TimetableFrame:1" version="1">
+			<journeyInterchanges>
+				<ServiceJourneyInterchange id="TEST:ServiceJourneyInterchange:None" version="1"/>
+				<ServiceJourneyInterchange id="TEST:ServiceJourneyInterchange:Standard" version="1">
+					<StandardTransferTime>PT5M</StandardTransferTime>
+				</ServiceJourneyInterchange>
+				<ServiceJourneyInterchange id="TEST:ServiceJourneyInterchange:Minimum" version="1">
+					<MinimumTransferTime>PT2M</MinimumTransferTime>
+				</ServiceJourneyInterchange>
+				<ServiceJourneyInterchange id="TEST:ServiceJourneyInterchange:Maximum" version="1">
+					<MaximumTransferTime>PT15M</MaximumTransferTime>
+				</ServiceJourneyInterchange>
+			</journeyInterchanges>
+		</TimetableFrame>
+	</dataObjects>
+</PublicationDelivery>`
+
+	options := DefaultValidationOptions().
+		WithCodespace(testutil.TestCodespace).
+		WithSkipSchema(true)
+	result, err := ValidateContent([]byte(xmlContent), "interchanges.xml", options)
+	if err != nil {
+		t.Fatalf("Validation failed: %v", err)
+	}
+
+	entries := entriesNamed(result, "ServiceJourneyInterchange missing transfer time")
+	if len(entries) != 1 {
+		t.Fatalf("Expected 1 interchange without transfer time, got %d: %+v", len(entries), entries)
+	}
+	if entries[0].RuleCode != "INTERCHANGE_8" || entries[0].Severity != types.WARNING {
+		t.Errorf("Expected an INTERCHANGE_8 warning, got %s (%v)", entries[0].RuleCode, entries[0].Severity)
+	}
+	if entries[0].Location.ElementID != "TEST:ServiceJourneyInterchange:None" {
+		t.Errorf("Expected TEST:ServiceJourneyInterchange:None to be reported, got %s", entries[0].Location.ElementID)
+	}
+}
+
+func TestBusinessRules_InterchangeTransferTime(t *testing.T) {
+	xmlContent := `<?xml version="1.0" encoding="UTF-8"?>
+<PublicationDelivery xmlns="http://www.netex.org.uk/netex" version="1.15">
+	<PublicationTimestamp>2023-01-01T00:00:00</PublicationTimestamp>
+	<ParticipantRef>TEST</ParticipantRef>
+	<dataObjects>
+		<ServiceFrame id="TEST:ServiceFrame:1" version="1">
+			<interchanges>
+				<ServiceJourneyInterchange id="TEST:ServiceJourneyInterchange:1" version="1">
+					<StandardTransferTime>PT40M</StandardTransferTime>
+					<MinimumTransferTime>PT30M</MinimumTransferTime>
+					<MaximumTransferTime>PT20M</MaximumTransferTime>
+				</ServiceJourneyInterchange>
+			</interchanges>
+		</ServiceFrame>
+	</dataObjects>
+</PublicationDelivery>`
+
+	countByName := func(result *ValidationResult, name string) int {
+		count := 0
+		for _, entry := range result.ValidationReportEntries {
+			if entry.Name == name {
+				count++
+			}
+		}
+		return count
+	}
+
+	t.Run("Configurable threshold", func(t *testing.T) {
+		options := DefaultValidationOptions().
+			WithCodespace(testutil.TestCodespace).
+			WithSkipSchema(true).
+			WithMaxTransferTime(30 * time.Minute)
+
+		result, err := ValidateContent([]byte(xmlContent), "interchanges.xml", options)
+		if err != nil {
+			t.Fatalf("Validation failed: %v", err)
+		}
+
+		if countByName(result, "Implausible interchange transfer time") != 1 {
+			t.Error("Expected StandardTransferTime above 30 minutes to be reported")
+		}
+		if countByName(result, "Inconsistent interchange transfer time bounds") != 1 {
+			t.Error("Expected MinimumTransferTime > MaximumTransferTime to be reported")
+		}
+	})
+
+	t.Run("Default threshold and rule overrides", func(t *testing.T) {
+		options := DefaultValidationOptions().
+			WithCodespace(testutil.TestCodespace).
+			WithSkipSchema(true).
+			WithRuleOverride("INTERCHANGE_7", false)
+
+		result, err := ValidateContent([]byte(xmlContent), "interchanges.xml", options)
+		if err != nil {
+			t.Fatalf("Validation failed: %v", err)
+		}
+
+		if countByName(result, "Implausible interchange transfer time") != 0 {
+			t.Error("Expected 40 minutes to be within the default threshold")
+		}
+		if countByName(result, "Inconsistent interchange transfer time bounds") != 0 {
+			t.Error("Expected disabled INTERCHANGE_7 to be suppressed")
+		}
+	})
+}
+
+func TestXPathRules_StopPlaceCentroidLocation(t *testing.T) {
+	xmlContent := `<?xml version="1.0" encoding="UTF-8"?>
+<PublicationDelivery xmlns="http://www.netex.org.uk/netex" version="1.15">
+	<PublicationTimestamp>2023-01-01T00:00:00</PublicationTimestamp>
+	<ParticipantRef>TEST</ParticipantRef>
+	<dataObjects>
+		<SiteFrame id="TEST:SiteFrame:1" version="1">
+			<stopPlaces>
+				<StopPlace id="TEST:StopPlace:Complete" version="1">
+					<Name>Complete</Name>
+					<Centroid><Location><Longitude>10.75</Longitude><Latitude>59.91</Latitude></Location></Centroid>
+					<quays>
+						<Quay id="TEST:Quay:Complete" version="1">
+							<Name>Complete quay</Name>
+							<Centroid><Location><Longitude>10.75</Longitude><Latitude>59.91</Latitude></Location></Centroid>
+						</Quay>
+						<Quay id="TEST:Quay:EmptyLatitude" version="1">
+							<Name>Empty latitude</Name>
+							<Centroid><Location><Longitude>10.75</Longitude><Latitude></Latitude></Location></Centroid>
+						</Quay>
+					</quays>
+				</StopPlace>
+				<StopPlace id="TEST:StopPlace:HalfPopulated" version="1">
+					<Name>Half populated</Name>
+					<Centroid><Location><Longitude>10.75</Longitude></Location></Centroid>
+					<quays>
+						<Quay id="TEST:Quay:NotNumeric" version="1">
+							<Name>Not numeric</Name>
+							<Centroid><Location><Longitude>east</Longitude><Latitude>59.91</Latitude></Location></Centroid>
+						</Quay>
+					</quays>
+				</StopPlace>
+				<StopPlace id="TEST:StopPlace:NoCentroid" version="1">
+					<Name>No centroid</Name>
+				</StopPlace>
+			</stopPlaces>
+		</SiteFrame>
+	</dataObjects>
+</PublicationDelivery>`
+
+	options := DefaultValidationOptions().
+		WithCodespace(testutil.TestCodespace).
+		WithSkipSchema(true)
+
+	result, err := ValidateContent([]byte(xmlContent), "stops.xml", options)
+	if err != nil {
+		t.Fatalf("Validation failed: %v", err)
+	}
+
+	reported := make(map[string][]string)
+	for _, entry := range result.ValidationReportEntries {
+		if entry.Name == "StopPlace Centroid incomplete" || entry.Name == "Quay Centroid incomplete" {
+			if entry.Severity != types.ERROR {
+				t.Errorf("Expected ERROR severity for %s, got %v", entry.Name, entry.Severity)
+			}
+			reported[entry.Name] = append(reported[entry.Name], entry.Location.ElementID)
+		}
+	}
+
+	stopPlaces := reported["StopPlace Centroid incomplete"]
+	if len(stopPlaces) != 1 || stopPlaces[0] != "TEST:StopPlace:HalfPopulated" {
+		t.Errorf("Expected only TEST:StopPlace:HalfPopulated to be reported, got %v", stopPlaces)
+	}
+
+	quays := reported["Quay Centroid incomplete"]
+	sort.Strings(quays)
+	if len(quays) != 2 || quays[0] != "TEST:Quay:EmptyLatitude" || quays[1] != "TEST:Quay:NotNumeric" {
+		t.Errorf("Expected the empty and non-numeric quays to be reported, got %v", quays)
+	}
+}
+
+func TestXPathRules_ZonesWithoutMembers(t *testing.T) {
+	xmlContent := `<?xml version="1.0" encoding="UTF-8"?>
+<PublicationDelivery xmlns="http://www.netex.org.uk/netex" version="1.15">
+	<PublicationTimestamp>2023-01-01T00:00:00</PublicationTimestamp>
+	<ParticipantRef>TEST</ParticipantRef>
+	<dataObjects>
+		<SiteFrame id="TEST:SiteFrame:1" version="1">
+			<tariffZones>
+				<TariffZone id="TEST:TariffZone:Populated" version="1">
+					<Name>Populated</Name>
+					<members>
+						<ScheduledStopPointRef ref="TEST:ScheduledStopPoint:1"/>
+					</members>
+				</TariffZone>
+				<TariffZone id="TEST:TariffZone:EmptyMembers" version="1">
+					<Name>Empty members</Name>
+					<members/>
+				</TariffZone>
+			</tariffZones>
+		</SiteFrame>
+		<FareFrame id="TEST:FareFrame:1" version="1">
+			<fareZones>
+				<FareZone id="TEST:FareZone:Populated" version="1">
+					<Name>Populated</Name>
+					<members>
+						<StopPlaceRef ref="TEST:StopPlace:1"/>
+					</members>
+				</FareZone>
+				<FareZone id="TEST:FareZone:NoMembers" version="1">
+					<Name>No members</Name>
+				</FareZone>
+			</fareZones>
+		</FareFrame>
+	</dataObjects>
+</PublicationDelivery>`
+
+	options := DefaultValidationOptions().
+		WithCodespace(testutil.TestCodespace).
+		WithSkipSchema(true)
+
+	result, err := ValidateContent([]byte(xmlContent), "zones.xml", options)
+	if err != nil {
+		t.Fatalf("Validation failed: %v", err)
+	}
+
+	reported := make(map[string][]string)
+	for _, entry := range result.ValidationReportEntries {
+		if entry.Name == "FareZone without members" || entry.Name == "TariffZone without members" {
+			if entry.Severity != types.WARNING {
+				t.Errorf("Expected WARNING severity for %s, got %v", entry.Name, entry.Severity)
+			}
+			reported[entry.Name] = append(reported[entry.Name], entry.Location.ElementID)
+		}
+	}
+
+	if fareZones := reported["FareZone without members"]; len(fareZones) != 1 || fareZones[0] != "TEST:FareZone:NoMembers" {
+		t.Errorf("Expected only TEST:FareZone:NoMembers to be reported, got %v", fareZones)
+	}
+	if tariffZones := reported["TariffZone without members"]; len(tariffZones) != 1 || tariffZones[0] != "TEST:TariffZone:EmptyMembers" {
+		t.Errorf("Expected only TEST:TariffZone:EmptyMembers to be reported, got %v", tariffZones)
+	}
+}
+
+func TestXPathRules_OperatorLegalDetails(t *testing.T) {
+	xmlContent := `<?xml version="1.0" encoding="UTF-8"?>
+<PublicationDelivery xmlns="http://www.netex.org.uk/netex" version="1.15">
+	<PublicationTimestamp>2023-01-01T00:00:00</PublicationTimestamp>
+	<ParticipantRef>TEST</ParticipantRef>
+	<dataObjects>
+		<ResourceFrame id="TEST:ResourceFrame:1" version="1">
+			<organisations>
+				<Operator id="TEST:Operator:Complete" version="1">
+					<CompanyNumber>987654321</CompanyNumber>
+					<Name>Complete</Name>
+					<ContactDetails>
+						<Url>https://example.com</Url>
+					</ContactDetails>
+				</Operator>
+				<Operator id="TEST:Operator:EmailOnly" version="1">
+					<CompanyNumber>123456789</CompanyNumber>
+					<Name>Email only</Name>
+					<ContactDetails>
+						<Email>info@example.com</Email>
+					</ContactDetails>
+				</Operator>
+				<Operator id="TEST:Operator:Unregistered" version="1">
+					<Name>Unregistered</Name>
+					<ContactDetails>
+						<Phone>+47 12345678</Phone>
+					</ContactDetails>
+				</Operator>
+			</organisations>
+		</ResourceFrame>
+	</dataObjects>
+</PublicationDelivery>`
+
+	options := DefaultValidationOptions().
+		WithCodespace(testutil.TestCodespace).
+		WithSkipSchema(true)
+
+	result, err := ValidateContent([]byte(xmlContent), "operators.xml", options)
+	if err != nil {
+		t.Fatalf("Validation failed: %v", err)
+	}
+
+	reported := make(map[string][]string)
+	for _, entry := range result.ValidationReportEntries {
+		if entry.Name == "Operator missing CompanyNumber" || entry.Name == "Operator missing phone or url" {
+			if entry.Severity != types.WARNING {
+				t.Errorf("Expected WARNING severity for %s, got %v", entry.Name, entry.Severity)
+			}
+			reported[entry.Name] = append(reported[entry.Name], entry.Location.ElementID)
+		}
+	}
+
+	if operators := reported["Operator missing CompanyNumber"]; len(operators) != 1 || operators[0] != "TEST:Operator:Unregistered" {
+		t.Errorf("Expected only TEST:Operator:Unregistered to be reported, got %v", operators)
+	}
+	if operators := reported["Operator missing phone or url"]; len(operators) != 1 || operators[0] != "TEST:Operator:EmailOnly" {
+		t.Errorf("Expected only TEST:Operator:EmailOnly to be reported, got %v", operators)
+	}
+}
+
+func TestXPathRules_OperatorLegalDetailsCategoryDisabled(t *testing.T) {
+	configFile := filepath.Join(t.TempDir(), "config.yaml")
+	configYAML := `rules:
+  categories:
+    network:
+      enabled: false
+`
+	if err := os.WriteFile(configFile, []byte(configYAML), 0o600); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	xmlContent := []byte(netexDocument(`		<ResourceFrame id="TEST:ResourceFrame:1" version="1">
+			<organisations>
+				<Operator id="TEST:Operator:1" version="1">
+					<Name>Unregistered</Name>
+				</Operator>
+			</organisations>
+		</ResourceFrame>`))
+
+	operatorFindings := func(configFile string) int {
+		options := DefaultValidationOptions().
+			WithCodespace(testutil.TestCodespace).
+			WithSkipSchema(true).
+			WithConfigFile(configFile)
+		result, err := ValidateContent(xmlContent, "operators.xml", options)
+		if err != nil {
+			t.Fatalf("Validation failed: %v", err)
+		}
+		count := 0
+		for _, entry := range result.ValidationReportEntries {
+			if entry.RuleCode == "OPERATOR_3" || entry.RuleCode == "OPERATOR_4" {
+				count++
+			}
+		}
+		return count
+	}
+
+	if count := operatorFindings(""); count != 2 {
+		t.Fatalf("Expected OPERATOR_3 and OPERATOR_4 with the default configuration, got %d findings", count)
+	}
+	if count := operatorFindings(configFile); count != 0 {
+		t.Errorf("Expected no OPERATOR_3 or OPERATOR_4 with the network category disabled, got %d findings", count)
+	}
+}
+
+func TestXPathRules_EmptyServiceCalendar(t *testing.T) {
+	xmlContent := `<?xml version="1.0" encoding="UTF-8"?>
+<PublicationDelivery xmlns="http://www.netex.org.uk/netex" version="1.15">
+	<PublicationTimestamp>2023-01-01T00:00:00</PublicationTimestamp>
+	<ParticipantRef>TEST</ParticipantRef>
+	<dataObjects>
+		<ServiceCalendarFrame id="TEST:ServiceCalendarFrame:1" version="1">
+			<ServiceCalendar id="TEST:ServiceCalendar:DayTypesOnly" version="1">
+				<dayTypes>
+					<DayType id="TEST:DayType:1" version="1"/>
+				</dayTypes>
+			</ServiceCalendar>
+		</ServiceCalendarFrame>
+		<ServiceCalendarFrame id="TEST:ServiceCalendarFrame:2" version="1">
+			<ServiceCalendar id="TEST:ServiceCalendar:Empty" version="1">
+				<dayTypes/>
+			</ServiceCalendar>
+		</ServiceCalendarFrame>
+	</dataObjects>
+</PublicationDelivery>`
+
+	options := DefaultValidationOptions().
+		WithCodespace(testutil.TestCodespace).
+		WithSkipSchema(true)
+
+	result, err := ValidateContent([]byte(xmlContent), "calendar.xml", options)
+	if err != nil {
+		t.Fatalf("Validation failed: %v", err)
+	}
+
+	reported := make(map[string][]string)
+	for _, entry := range result.ValidationReportEntries {
+		switch entry.Name {
+		case "ServiceCalendar empty":
+			if entry.Severity != types.ERROR {
+				t.Errorf("Expected ERROR severity for %s, got %v", entry.Name, entry.Severity)
+			}
+			fallthrough
+		case "ServiceCalendar missing OperatingPeriod":
+			reported[entry.Name] = append(reported[entry.Name], entry.Location.ElementID)
+		}
+	}
+
+	if calendars := reported["ServiceCalendar empty"]; len(calendars) != 1 || calendars[0] != "TEST:ServiceCalendar:Empty" {
+		t.Errorf("Expected only TEST:ServiceCalendar:Empty to be reported as empty, got %v", calendars)
+	}
+	if calendars := reported["ServiceCalendar missing OperatingPeriod"]; len(calendars) != 2 {
+		t.Errorf("Expected both calendars to miss OperatingPeriod, got %v", calendars)
+	}
+}
+
+func TestXPathRules_UnknownTransportMode(t *testing.T) {
+	xmlContent := `<?xml version="1.0" encoding="UTF-8"?>
+<PublicationDelivery xmlns="http://www.netex.org.uk/netex" version="1.15">
+	<PublicationTimestamp>2023-01-01T00:00:00</PublicationTimestamp>
+	<ParticipantRef>TEST</ParticipantRef>
+	<dataObjects>
+		<ServiceFrame id="TEST:ServiceFrame:1" version="1">
+			<lines>
+				<Line id="TEST:Line:Bus" version="1">
+					<Name>Bus</Name>
+					<PublicCode>1</PublicCode>
+					<TransportMode>bus</TransportMode>
+				</Line>
+				<Line id="TEST:Line:Unknown" version="1">
+					<Name>Unknown</Name>
+					<PublicCode>2</PublicCode>
+					<TransportMode>unknown</TransportMode>
+				</Line>
+			</lines>
+		</ServiceFrame>
+		<TimetableFrame id="TEST:TimetableFrame:1" version="1">
+			<vehicleJourneys>
+				<ServiceJourney id="TEST:ServiceJourney:Unknown" version="1">
+					<TransportMode>unknown</TransportMode>
+				</ServiceJourney>
+			</vehicleJourneys>
+		</TimetableFrame>
+	</dataObjects>
+</PublicationDelivery>`
+
+	validate := func(flag bool) []string {
+		options := DefaultValidationOptions().
+			WithCodespace(testutil.TestCodespace).
+			WithSkipSchema(true).
+			WithFlagUnknownModes(flag)
+
+		result, err := ValidateContent([]byte(xmlContent), "modes.xml", options)
+		if err != nil {
+			t.Fatalf("Validation failed: %v", err)
+		}
+
+		var reported []string
+		for _, entry := range result.ValidationReportEntries {
+			if entry.Name == "TransportMode unknown" {
+				if entry.Severity != types.WARNING {
+					t.Errorf("Expected WARNING severity for %s, got %v", entry.Name, entry.Severity)
+				}
+				reported = append(reported, entry.Location.ElementID)
+			}
+		}
+		sort.Strings(reported)
+		return reported
+	}
+
+	if reported := validate(false); len(reported) != 0 {
+		t.Errorf("Expected no findings unless enabled, got %v", reported)
+	}
+	reported := validate(true)
+	if len(reported) != 2 || reported[0] != "TEST:Line:Unknown" || reported[1] != "TEST:ServiceJourney:Unknown" {
+		t.Errorf("Expected TEST:Line:Unknown and TEST:ServiceJourney:Unknown, got %v", reported)
+	}
+}
+
+func TestXPathRules_LineWithoutOperatorOrAuthority(t *testing.T) {
+	xmlContent := `<?xml version="1.0" encoding="UTF-8"?>
+<PublicationDelivery xmlns="http://www.netex.org.uk/netex" version="1.15">
+	<PublicationTimestamp>2023-01-01T00:00:00</PublicationTimestamp>
+	<ParticipantRef>TEST</ParticipantRef>
+	<dataObjects>
+		<ServiceFrame id="TEST:ServiceFrame:1" version="1">
+			<lines>
+				<Line id="TEST:Line:Operated" version="1">
+					<TransportMode>bus</TransportMode>
+					<OperatorRef ref="TEST:Operator:1" version="1"/>
+				</Line>
+				<Line id="TEST:Line:Authorised" version="1">
+					<TransportMode>bus</TransportMode>
+					<AuthorityRef ref="TEST:Authority:1" version="1"/>
+				</Line>
+				<Line id="TEST:Line:Unattributed" version="1">
+					<TransportMode>bus</TransportMode>
+				</Line>
+			</lines>
+		</ServiceFrame>
+		<ServiceFrame id="TEST:ServiceFrame:2" version="1" responsibilitySetRef="TEST:ResponsibilitySet:1">
+			<lines>
+				<Line id="TEST:Line:Inherited" version="1">
+					<TransportMode>bus</TransportMode>
+				</Line>
+			</lines>
+		</ServiceFrame>
+	</dataObjects>
+</PublicationDelivery>`
+
+	options := DefaultValidationOptions().
+		WithCodespace(testutil.TestCodespace).
+		WithSkipSchema(true)
+
+	result, err := ValidateContent([]byte(xmlContent), "lines.xml", options)
+	if err != nil {
+		t.Fatalf("Validation failed: %v", err)
+	}
+
+	var reported []string
+	for _, entry := range result.ValidationReportEntries {
+		if entry.Name == "Line missing OperatorRef and AuthorityRef" {
+			if entry.Severity != types.ERROR {
+				t.Errorf("Expected ERROR severity for %s, got %v", entry.Name, entry.Severity)
+			}
+			reported = append(reported, entry.Location.ElementID)
+		}
+	}
+
+	if len(reported) != 1 || reported[0] != "TEST:Line:Unattributed" {
+		t.Errorf("Expected only TEST:Line:Unattributed to be reported, got %v", reported)
+	}
+}
+
+func TestXPathRules_EmptyGroups(t *testing.T) {
+	xmlContent := `<?xml version="1.0" encoding="UTF-8"?>
+<PublicationDelivery xmlns="http://www.netex.org.uk/netex" version="1.15">
+	<PublicationTimestamp>2023-01-01T00:00:00</PublicationTimestamp>
+	<ParticipantRef>TEST</ParticipantRef>
+	<dataObjects>
+		<ServiceFrame id="TEST:ServiceFrame:1" version="1">
+			<Network id="TEST:Network:1" version="1">
+				<Name>Network</Name>
+				<groupsOfLines>
+					<GroupOfLines id="TEST:GroupOfLines:Populated" version="1">
+						<Name>Populated</Name>
+						<members>
+							<LineRef ref="TEST:Line:1"/>
+						</members>
+					</GroupOfLines>
+					<GroupOfLines id="TEST:GroupOfLines:Flexible" version="1">
+						<Name>Flexible</Name>
+						<members>
+							<FlexibleLineRef ref="TEST:FlexibleLine:1"/>
+						</members>
+					</GroupOfLines>
+					<GroupOfLines id="TEST:GroupOfLines:EmptyMembers" version="1">
+						<Name>Empty members</Name>
+						<members/>
+					</GroupOfLines>
+					<GroupOfLines id="TEST:GroupOfLines:NoMembers" version="1">
+						<Name>No members</Name>
+					</GroupOfLines>
+				</groupsOfLines>
+			</Network>
+			<groupsOfServices>
+				<GroupOfServices id="TEST:GroupOfServices:Populated" version="1">
+					<Name>Populated</Name>
+					<members>
+						<GroupOfServicesMember>
+							<ServiceJourneyRef ref="TEST:ServiceJourney:1"/>
+						</GroupOfServicesMember>
+					</members>
+				</GroupOfServices>
+				<GroupOfServices id="TEST:GroupOfServices:Empty" version="1">
+					<Name>Empty</Name>
+					<members/>
+				</GroupOfServices>
+			</groupsOfServices>
+		</ServiceFrame>
+	</dataObjects>
+</PublicationDelivery>`
+
+	options := DefaultValidationOptions().
+		WithCodespace(testutil.TestCodespace).
+		WithSkipSchema(true)
+
+	result, err := ValidateContent([]byte(xmlContent), "groups.xml", options)
+	if err != nil {
+		t.Fatalf("Validation failed: %v", err)
+	}
+
+	reported := make(map[string][]string)
+	for _, entry := range result.ValidationReportEntries {
+		switch entry.Name {
+		case "GroupOfLines without members", "GroupOfServices without members":
+			if entry.Severity != types.WARNING {
+				t.Errorf("Expected WARNING severity for %s, got %v", entry.Name, entry.Severity)
+			}
+			reported[entry.Name] = append(reported[entry.Name], entry.Location.ElementID)
+		}
+	}
+
+	groupsOfLines := reported["GroupOfLines without members"]
+	sort.Strings(groupsOfLines)
+	if len(groupsOfLines) != 2 || groupsOfLines[0] != "TEST:GroupOfLines:EmptyMembers" || groupsOfLines[1] != "TEST:GroupOfLines:NoMembers" {
+		t.Errorf("Expected the two GroupOfLines without members, got %v", groupsOfLines)
+	}
+	if groups := reported["GroupOfServices without members"]; len(groups) != 1 || groups[0] != "TEST:GroupOfServices:Empty" {
+		t.Errorf("Expected only TEST:GroupOfServices:Empty, got %v", groups)
+	}
+}
+
+func TestXPathRules_QuayOutsideStopPlace(t *testing.T) {
+	xmlContent := `<?xml version="1.0" encoding="UTF-8"?>
+<PublicationDelivery xmlns="http://www.netex.org.uk/netex" version="1.15">
+	<PublicationTimestamp>2023-01-01T00:00:00</PublicationTimestamp>
+	<ParticipantRef>TEST</ParticipantRef>
+	<dataObjects>
+		<SiteFrame id="TEST:SiteFrame:1" version="1">
+			<stopPlaces>
+				<StopPlace id="TEST:StopPlace:1" version="1">
+					<Name>Central</Name>
+					<quays>
+						<Quay id="TEST:Quay:Enclosed" version="1">
+							<Name>Platform A</Name>
+						</Quay>
+					</quays>
+				</StopPlace>
+			</stopPlaces>
+			<quays>
+				<Quay id="TEST:Quay:Orphan" version="1">
+					<Name>Platform B</Name>
+				</Quay>
+			</quays>
+		</SiteFrame>
+	</dataObjects>
+</PublicationDelivery>`
+
+	options := DefaultValidationOptions().
+		WithCodespace(testutil.TestCodespace).
+		WithSkipSchema(true)
+
+	result, err := ValidateContent([]byte(xmlContent), "quays.xml", options)
+	if err != nil {
+		t.Fatalf("Validation failed: %v", err)
+	}
+
+	var reported []string
+	for _, entry := range result.ValidationReportEntries {
+		if entry.Name == "Quay outside StopPlace" {
+			if entry.Severity != types.ERROR {
+				t.Errorf("Expected ERROR severity, got %v", entry.Severity)
+			}
+			reported = append(reported, entry.Location.ElementID)
+		}
+	}
+	if len(reported) != 1 || reported[0] != "TEST:Quay:Orphan" {
+		t.Errorf("Expected only TEST:Quay:Orphan to be reported, got %v", reported)
+	}
+}
+
+func TestXPathRules_StopPlaceWithoutQuays(t *testing.T) {
+	xmlContent := `<?xml version="1.0" encoding="UTF-8"?>
+<PublicationDelivery xmlns="http://www.netex.org.uk/netex" version="1.15">
+	<PublicationTimestamp>2023-01-01T00:00:00</PublicationTimestamp>
+	<ParticipantRef>TEST</ParticipantRef>
+	<dataObjects>
+		<SiteFrame id="TEST:SiteFrame:1" version="1">
+			<stopPlaces>
+				<StopPlace id="TEST:StopPlace:WithQuay" version="1">
+					<Name>Central</Name>
+					<quays>
+						<Quay id="TEST:Quay:1" version="1">
+							<Name>Platform A</Name>
+						</Quay>
+					</quays>
+				</StopPlace>
+				<StopPlace id="TEST:StopPlace:EmptyQuays" version="1">
+					<Name>Harbour</Name>
+					<quays/>
+				</StopPlace>
+				<StopPlace id="TEST:StopPlace:NoQuays" version="1">
+					<Name>Market</Name>
+				</StopPlace>
+			</stopPlaces>
+		</SiteFrame>
+	</dataObjects>
+</PublicationDelivery>`
+
+	options := DefaultValidationOptions().
+		WithCodespace(testutil.TestCodespace).
+		WithSkipSchema(true)
+
+	result, err := ValidateContent([]byte(xmlContent), "stops.xml", options)
+	if err != nil {
+		t.Fatalf("Validation failed: %v", err)
+	}
+
+	var reported []string
+	for _, entry := range result.ValidationReportEntries {
+		if entry.Name == "StopPlace without Quays" {
+			if entry.Severity != types.WARNING {
+				t.Errorf("Expected WARNING severity, got %v", entry.Severity)
+			}
+			reported = append(reported, entry.Location.ElementID)
+		}
+	}
+	sort.Strings(reported)
+	if strings.Join(reported, ",") != "TEST:StopPlace:EmptyQuays,TEST:StopPlace:NoQuays" {
+		t.Errorf("Expected only the StopPlaces without Quays to be reported, got %v", reported)
+	}
+}
+
+func TestXPathRules_ReportSkippedRules(t *testing.T) {
+	configFile := filepath.Join(t.TempDir(), "config.yaml")
+	configYAML := `rules:
+  custom:
+    - code: CUSTOM_CURRENT
+      name: Line referencing itself
+      message: Line references itself
+      severity: WARNING
+      xpath: "//Line[@id = current()/@id]"
+      enabled: true
+    - code: CUSTOM_BROKEN
+      name: Broken expression
+      message: Never evaluated
+      severity: WARNING
+      xpath: "//Line[Name"
+      enabled: true
+`
+	if err := os.WriteFile(configFile, []byte(configYAML), 0o600); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	xmlContent := `<?xml version="1.0" encoding="UTF-8"?>
+<PublicationDelivery xmlns="http://www.netex.org.uk/netex" version="1.15">
+	<PublicationTimestamp>2023-01-01T00:00:00</PublicationTimestamp>
+	<ParticipantRef>TEST</ParticipantRef>
+	<dataObjects>
+		<ServiceFrame id="TEST:ServiceFrame:1" version="1">
+			<lines>
+				<Line id="TEST:Line:1" version="1">
+					<Name>Line 1</Name>
+				</Line>
+			</lines>
+		</ServiceFrame>
+	</dataObjects>
+</PublicationDelivery>`
+
+	validate := func(report bool) []ValidationReportEntry {
+		options := DefaultValidationOptions().
+			WithCodespace(testutil.TestCodespace).
+			WithSkipSchema(true).
+			WithConfigFile(configFile).
+			WithReportSkippedRules(report)
+		result, err := ValidateContent([]byte(xmlContent), "line.xml", options)
+		if err != nil {
+			t.Fatalf("Validation failed: %v", err)
+		}
+		skipped := entriesNamed(result, "XPath rule skipped")
+		if len(skipped) != result.NumberOfValidationEntriesPerRule["XPath rule skipped"] {
+			t.Errorf("Expected the per-rule count to match %d skipped rule findings, got %d", len(skipped), result.NumberOfValidationEntriesPerRule["XPath rule skipped"])
+		}
+		return skipped
+	}
+
+	if skipped := validate(false); len(skipped) != 0 {
+		t.Errorf("Expected no skipped rule findings by default, got %+v", skipped)
+	}
+
+	// Built-in rules the XPath engine cannot evaluate are listed as well
+	var skipped []ValidationReportEntry
+	for _, entry := range validate(true) {
+		if strings.Contains(entry.Message, "CUSTOM_") {
+			skipped = append(skipped, entry)
+		}
+	}
+	if len(skipped) != 2 {
+		t.Fatalf("Expected 2 skipped rules, got %d: %+v", len(skipped), skipped)
+	}
+	expected := []string{
+		"Rule CUSTOM_BROKEN (Broken expression) was not evaluated: evaluating its XPath failed",
+		"Rule CUSTOM_CURRENT (Line referencing itself) was not evaluated: its XPath uses a function the XPath engine does not support",
+	}
+	for i, want := range expected {
+		if skipped[i].Severity != types.INFO {
+			t.Errorf("Expected INFO severity, got %v", skipped[i].Severity)
+		}
+		if !strings.HasPrefix(skipped[i].Message, want) {
+			t.Errorf("Expected message starting with %q, got %q", want, skipped[i].Message)
+		}
+	}
+}
+
+func TestXPathRules_PassingTimeWithoutStopPoint(t *testing.T) {
+	xmlContent := `<?xml version="1.0" encoding="UTF-8"?>
+<PublicationDelivery xmlns="http://www.netex.org.uk/netex" version="1.15">
+	<PublicationTimestamp>2023-01-01T00:00:00</PublicationTimestamp>
+	<ParticipantRef>TEST</ParticipantRef>
+	<dataObjects>
+		<TimetableFrame id="TEST:TimetableFrame:1" version="1">
+			<vehicleJourneys>
+				<ServiceJourney id="TEST:ServiceJourney:Placed" version="1">
+					<passingTimes>
+						<TimetabledPassingTime id="TEST:TimetabledPassingTime:1" version="1">
+							<StopPointInJourneyPatternRef ref="TEST:StopPointInJourneyPattern:1"/>
+							<DepartureTime>09:30:00</DepartureTime>
+						</TimetabledPassingTime>
+					</passingTimes>
+				</ServiceJourney>
+				<ServiceJourney id="TEST:ServiceJourney:Unplaced" version="1">
+					<passingTimes>
+						<TimetabledPassingTime id="TEST:TimetabledPassingTime:2" version="1">
+							<StopPointInJourneyPatternRef ref="TEST:StopPointInJourneyPattern:1"/>
+							<DepartureTime>10:30:00</DepartureTime>
+						</TimetabledPassingTime>
+						<TimetabledPassingTime id="TEST:TimetabledPassingTime:3" version="1">
+							<ArrivalTime>10:45:00</ArrivalTime>
+						</TimetabledPassingTime>
+					</passingTimes>
+				</ServiceJourney>
+			</vehicleJourneys>
+		</TimetableFrame>
+	</dataObjects>
+</PublicationDelivery>`
+
+	options := DefaultValidationOptions().
+		WithCodespace(testutil.TestCodespace).
+		WithSkipSchema(true)
+
+	result, err := ValidateContent([]byte(xmlContent), "journeys.xml", options)
+	if err != nil {
+		t.Fatalf("Validation failed: %v", err)
+	}
+
+	var reported []ValidationReportEntry
+	for _, entry := range result.ValidationReportEntries {
+		if entry.Name == "ServiceJourney TimetabledPassingTime missing StopPointInJourneyPatternRef" {
+			reported = append(reported, entry)
+		}
+	}
+	if len(reported) != 1 {
+		t.Fatalf("Expected 1 passing time to be reported, got %+v", reported)
+	}
+
+	entry := reported[0]
+	if entry.Severity != types.ERROR || entry.Location.ElementID != "TEST:TimetabledPassingTime:3" {
+		t.Errorf("Expected ERROR for TEST:TimetabledPassingTime:3, got %v for %s", entry.Severity, entry.Location.ElementID)
+	}
+	if want := "/PublicationDelivery[1]/dataObjects[1]/TimetableFrame[1]/vehicleJourneys[1]/ServiceJourney[2]/passingTimes[1]/TimetabledPassingTime[2]"; entry.Location.XPath != want {
+		t.Errorf("Expected XPath %s, got %s", want, entry.Location.XPath)
+	}
+}
+
+func TestXPathRules_FrameOutsideCompositeFrame(t *testing.T) {
+	xmlContent := `<?xml version="1.0" encoding="UTF-8"?>
+<PublicationDelivery xmlns="http://www.netex.org.uk/netex" version="1.15">
+	<PublicationTimestamp>2023-01-01T00:00:00</PublicationTimestamp>
+	<ParticipantRef>TEST</ParticipantRef>
+	<dataObjects>
+		<CompositeFrame id="TEST:CompositeFrame:1" version="1">
+			<frames>
+				<ServiceFrame id="TEST:ServiceFrame:Wrapped" version="1"/>
+			</frames>
+		</CompositeFrame>
+		<ResourceFrame id="TEST:ResourceFrame:Standalone" version="1"/>
+		<TimetableFrame id="TEST:TimetableFrame:Standalone" version="1"/>
+		<GeneralFrame id="TEST:GeneralFrame:1" version="1"/>
+	</dataObjects>
+</PublicationDelivery>`
+
+	validate := func(profile string) []string {
+		options := DefaultValidationOptions().
+			WithCodespace(testutil.TestCodespace).
+			WithSkipSchema(true).
+			WithProfile(profile)
+
+		result, err := ValidateContent([]byte(xmlContent), "frames.xml", options)
+		if err != nil {
+			t.Fatalf("Validation failed: %v", err)
+		}
+
+		var reported []string
+		for _, entry := range result.ValidationReportEntries {
+			if entry.Name == "Frame outside CompositeFrame" {
+				if entry.Severity != types.WARNING {
+					t.Errorf("Expected WARNING severity for %s, got %v", entry.Name, entry.Severity)
+				}
+				reported = append(reported, entry.Location.ElementID)
+			}
+		}
+		sort.Strings(reported)
+		return reported
+	}
+
+	for _, profile := range []string{"", ProfileEU} {
+		reported := validate(profile)
+		if len(reported) != 2 || reported[0] != "TEST:ResourceFrame:Standalone" || reported[1] != "TEST:TimetableFrame:Standalone" {
+			t.Errorf("Profile %q: expected the standalone ResourceFrame and TimetableFrame, got %v", profile, reported)
+		}
+	}
+	if reported := validate(ProfileFrench); len(reported) != 0 {
+		t.Errorf("Expected no findings for the French profile, got %v", reported)
+	}
+}
+
+func TestXPathRules_FrameOutsideCompositeFrameDetectedProfile(t *testing.T) {
+	standalone := `<ResourceFrame id="TEST:ResourceFrame:Standalone" version="1"/>`
+	nordic := `<ServiceFrame id="TEST:ServiceFrame:Standalone" version="1">
+			<stopAssignments>
+				<PassengerStopAssignment id="TEST:PassengerStopAssignment:1" version="1" order="1">
+					<ScheduledStopPointRef ref="TEST:ScheduledStopPoint:1"/>
+					<QuayRef ref="NSR:Quay:1"/>
+				</PassengerStopAssignment>
+			</stopAssignments>
+		</ServiceFrame>`
+
+	options := DefaultValidationOptions().
+		WithCodespace(testutil.TestCodespace).
+		WithSkipSchema(true)
+	result, err := ValidateContent([]byte(netexDocument(nordic)), "nordic.xml", options)
+	if err != nil {
+		t.Fatalf("Validation failed: %v", err)
+	}
+	if entries := entriesNamed(result, "Frame outside CompositeFrame"); len(entries) != 0 {
+		t.Errorf("Expected no findings for a file detected as Nordic, got %+v", entries)
+	}
+
+	// Files of a dataset take the profile detected for the whole dataset
+	result = validateDataset(t, map[string]string{
+		"_common.xml": netexDocument(""),
+		"line.xml":    netexDocument(standalone),
+	})
+	if entries := entriesNamed(result, "Frame outside CompositeFrame"); len(entries) != 0 {
+		t.Errorf("Expected no findings for a dataset detected as Nordic, got %+v", entries)
+	}
+	result = validateDataset(t, map[string]string{"line.xml": netexDocument(standalone)})
+	if entries := entriesNamed(result, "Frame outside CompositeFrame"); len(entries) != 1 {
+		t.Errorf("Expected the standalone frame of an EU dataset to be reported, got %+v", entries)
+	}
+}
+
+func TestXPathRules_EmptyDataObjects(t *testing.T) {
+	document := func(dataObjects string) []byte {
+		return []byte(`<?xml version="1.0" encoding="UTF-8"?>
+<PublicationDelivery xmlns="http://www.netex.org.uk/netex" version="1.15">
+	<PublicationTimestamp>2023-01-01T00:00:00</PublicationTimestamp>
+	<ParticipantRef>TEST</ParticipantRef>
+	` + dataObjects + `
+</PublicationDelivery>`)
+	}
+
+	tests := []struct {
+		name        string
+		dataObjects string
+		want        int
+	}{
+		{"empty", "<dataObjects/>", 1},
+		{"only a comment", "<dataObjects><!-- nothing exported --></dataObjects>", 1},
+		{"populated", `<dataObjects><CompositeFrame id="TEST:CompositeFrame:1" version="1"/></dataObjects>`, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := DefaultValidationOptions().
+				WithCodespace(testutil.TestCodespace).
+				WithSkipSchema(true)
+			result, err := ValidateContent(document(tt.dataObjects), "export.xml", options)
+			if err != nil {
+				t.Fatalf("Validation failed: %v", err)
+			}
+
+			entries := entriesNamed(result, "Empty dataObjects")
+			if len(entries) != tt.want {
+				t.Fatalf("Expected %d findings, got %d: %+v", tt.want, len(entries), entries)
+			}
+			for _, entry := range entries {
+				if entry.RuleCode != "FRAME_EMPTY_DATAOBJECTS" || entry.Severity != types.ERROR {
+					t.Errorf("Expected a FRAME_EMPTY_DATAOBJECTS error, got %s (%v)", entry.RuleCode, entry.Severity)
+				}
+				if entry.FileName != "export.xml" {
+					t.Errorf("Expected the finding in export.xml, got %s", entry.FileName)
+				}
+			}
+		})
+	}
+}
+
+func TestXPathRules_DisabledCategory(t *testing.T) {
+	configFile := filepath.Join(t.TempDir(), "config.yaml")
+	configYAML := `rules:
+  categories:
+    route:
+      enabled: false
+`
+	if err := os.WriteFile(configFile, []byte(configYAML), 0o600); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	// The Route misses its Name (ROUTE_2) and DirectionType (ROUTE_7)
+	xmlContent := []byte(`<?xml version="1.0" encoding="UTF-8"?>
+<PublicationDelivery xmlns="http://www.netex.org.uk/netex" version="1.15">
+	<PublicationTimestamp>2023-01-01T00:00:00</PublicationTimestamp>
+	<ParticipantRef>TEST</ParticipantRef>
+	<dataObjects>
+		<ServiceFrame id="TEST:ServiceFrame:1" version="1">
+			<routes>
+				<Route id="TEST:Route:1" version="1">
+					<LineRef ref="TEST:Line:1" version="1"/>
+				</Route>
+			</routes>
+		</ServiceFrame>
+	</dataObjects>
+</PublicationDelivery>`)
+
+	routeFindings := func(configFile string) []string {
+		options := DefaultValidationOptions().
+			WithCodespace(testutil.TestCodespace).
+			WithSkipSchema(true).
+			WithConfigFile(configFile)
+		result, err := ValidateContent(xmlContent, "route.xml", options)
+		if err != nil {
+			t.Fatalf("Validation failed: %v", err)
+		}
+		var codes []string
+		for _, entry := range result.ValidationReportEntries {
+			if entry.RuleCode == "ROUTE_2" || entry.RuleCode == "ROUTE_7" {
+				codes = append(codes, entry.RuleCode)
+			}
+		}
+		return codes
+	}
+
+	if codes := routeFindings(""); len(codes) != 2 {
+		t.Fatalf("Expected ROUTE_2 and ROUTE_7 with the default configuration, got %v", codes)
+	}
+	if codes := routeFindings(configFile); len(codes) != 0 {
+		t.Errorf("Expected no route rules with the route category disabled, got %v", codes)
+	}
+}
+
+func TestBusinessRules_DisabledCategory(t *testing.T) {
+	configFile := filepath.Join(t.TempDir(), "config.yaml")
+	configYAML := `rules:
+  categories:
+    interchange:
+      enabled: false
+`
+	if err := os.WriteFile(configFile, []byte(configYAML), 0o600); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	// The transfer time bounds are inverted (INTERCHANGE_7, a business rule)
+	xmlContent := []byte(netexDocument(`		<TimetableFrame id="TEST:TimetableFrame:1" version="1">
+			<journeyInterchanges>
+				<ServiceJourneyInterchange id="TEST:ServiceJourneyInterchange:1" version="1">
+					<MinimumTransferTime>PT10M</MinimumTransferTime>
+					<MaximumTransferTime>PT5M</MaximumTransferTime>
+				</ServiceJourneyInterchange>
+			</journeyInterchanges>
+		</TimetableFrame>`))
+
+	boundsFindings := func(configFile string) int {
+		options := DefaultValidationOptions().
+			WithCodespace(testutil.TestCodespace).
+			WithSkipSchema(true).
+			WithConfigFile(configFile)
+		result, err := ValidateContent(xmlContent, "interchange.xml", options)
+		if err != nil {
+			t.Fatalf("Validation failed: %v", err)
+		}
+		count := 0
+		for _, entry := range result.ValidationReportEntries {
+			if entry.RuleCode == "INTERCHANGE_7" {
+				count++
+			}
+		}
+		return count
+	}
+
+	if count := boundsFindings(""); count != 1 {
+		t.Fatalf("Expected INTERCHANGE_7 with the default configuration, got %d findings", count)
+	}
+	if count := boundsFindings(configFile); count != 0 {
+		t.Errorf("Expected no INTERCHANGE_7 with the interchange category disabled, got %d findings", count)
+	}
+}